@@ -10,21 +10,189 @@ GRANT ALL PRIVILEGES ON DATABASE auth TO app;
 CREATE TABLE users (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     name VARCHAR(255) NOT NULL,
-    email VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
     password VARCHAR(255) NOT NULL,
+    email_verified_at TIMESTAMP DEFAULT NULL,
+    password_reset_required BOOLEAN NOT NULL DEFAULT FALSE,
+    disabled_at TIMESTAMP DEFAULT NULL,
+    is_admin BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP DEFAULT current_timestamp,
     updated_at TIMESTAMP DEFAULT current_timestamp
 );
 
+-- email_verification_tokens テーブル
+-- トークンはハッシュのみを保存する
+CREATE TABLE email_verification_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX email_verification_tokens_user_id_idx ON email_verification_tokens (user_id, created_at);
+
+-- password_reset_tokens テーブル
+-- トークンはハッシュのみを保存する
+CREATE TABLE password_reset_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX password_reset_tokens_user_id_idx ON password_reset_tokens (user_id, created_at);
+
+-- user_totp_credentials テーブル
+-- シークレットはアプリケーションの鍵で暗号化して保存する
+CREATE TABLE user_totp_credentials (
+    user_id UUID PRIMARY KEY,
+    secret TEXT NOT NULL,
+    confirmed_at TIMESTAMP DEFAULT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    updated_at TIMESTAMP DEFAULT current_timestamp,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- user_recovery_codes テーブル
+-- コードはハッシュのみを保存する
+CREATE TABLE user_recovery_codes (
+    user_id UUID NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    PRIMARY KEY (user_id, code_hash),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- user_passkeys テーブル
+-- sign_count は複製された認証器を検出するために、認証のたびに更新する
+CREATE TABLE user_passkeys (
+    credential_id BYTEA PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    public_key BYTEA NOT NULL,
+    attestation_type VARCHAR(32) NOT NULL,
+    aaguid BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    transports TEXT NOT NULL DEFAULT '',
+    backup_eligible BOOLEAN NOT NULL DEFAULT FALSE,
+    backup_state BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX user_passkeys_user_id_idx ON user_passkeys (user_id);
+
+-- user_federated_identities テーブル
+-- 上流の OpenID プロバイダの利用者をプロバイダ名と sub で識別する
+CREATE TABLE user_federated_identities (
+    provider VARCHAR(64) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    last_used_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    PRIMARY KEY (provider, subject),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX user_federated_identities_user_id_idx ON user_federated_identities (user_id);
+
+-- saml_connections テーブル
+-- SAML でサインインする IdP ごとの接続。name は SP のメタデータと ACS のURLに使う
+CREATE TABLE saml_connections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(64) NOT NULL UNIQUE,
+    display_name VARCHAR(255) NOT NULL,
+    idp_metadata TEXT NOT NULL,
+    email_attribute VARCHAR(255) NOT NULL DEFAULT '',
+    name_attribute VARCHAR(255) NOT NULL DEFAULT '',
+    link_by_email BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    updated_at TIMESTAMP DEFAULT current_timestamp
+);
+
+-- login_lockout_events テーブル
+-- 失敗回数はkvsで数え、ロックと管理者による解除のみを記録する
+CREATE TABLE login_lockout_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(16) NOT NULL,
+    subject_type VARCHAR(16) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    locked_until TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+CREATE INDEX login_lockout_events_created_at_idx ON login_lockout_events (created_at);
+
 -- oauth2_clients テーブル
+-- secret_hash が NULL のクライアントは公開クライアント。有効期間の 0 は設定値を使う
 CREATE TABLE oauth2_clients (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     name VARCHAR(255) NOT NULL,
-    redirect_uris VARCHAR(255) NOT NULL,
+    first_party BOOLEAN NOT NULL DEFAULT FALSE,
+    secret_hash VARCHAR(255) DEFAULT NULL,
+    grant_types VARCHAR(255) NOT NULL DEFAULT 'authorization_code refresh_token',
+    access_token_expires_min INTEGER NOT NULL DEFAULT 0,
+    refresh_token_expires_day INTEGER NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    updated_at TIMESTAMP DEFAULT current_timestamp
+);
+
+-- oauth2_client_redirect_uris テーブル
+-- 登録時に正規化した URI を1行ずつ保持する
+CREATE TABLE oauth2_client_redirect_uris (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    client_id UUID NOT NULL,
+    redirect_uri VARCHAR(2048) NOT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    FOREIGN KEY (client_id) REFERENCES oauth2_clients (id) ON DELETE CASCADE,
+    UNIQUE (client_id, redirect_uri)
+);
+
+-- oauth2_scopes テーブル
+CREATE TABLE oauth2_scopes (
+    name VARCHAR(255) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    requires_consent BOOLEAN NOT NULL DEFAULT TRUE,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP DEFAULT current_timestamp,
     updated_at TIMESTAMP DEFAULT current_timestamp
 );
 
+-- oauth2_client_scopes テーブル
+-- クライアントごとに要求を許可するスコープ
+CREATE TABLE oauth2_client_scopes (
+    client_id UUID NOT NULL,
+    scope_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    PRIMARY KEY (client_id, scope_name),
+    FOREIGN KEY (client_id) REFERENCES oauth2_clients (id) ON DELETE CASCADE,
+    FOREIGN KEY (scope_name) REFERENCES oauth2_scopes (name) ON DELETE CASCADE
+);
+
+-- oauth2_consents テーブル
+-- ユーザーがクライアントに許可したスコープ
+CREATE TABLE oauth2_consents (
+    user_id UUID NOT NULL,
+    client_id UUID NOT NULL,
+    scope VARCHAR(1024) NOT NULL,
+    expires_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    updated_at TIMESTAMP DEFAULT current_timestamp,
+    PRIMARY KEY (user_id, client_id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (client_id) REFERENCES oauth2_clients (id) ON DELETE CASCADE
+);
+
 -- oauth2_codes テーブル
 CREATE TABLE oauth2_codes (
     code VARCHAR(255) PRIMARY KEY,
@@ -46,19 +214,25 @@ CREATE TABLE oauth2_tokens (
     client_id UUID NOT NULL,
     user_id UUID NOT NULL,
     scope VARCHAR(255) NOT NULL,
+    authorization_code VARCHAR(255) DEFAULT NULL,
     expires_at TIMESTAMP,
     revoked_at TIMESTAMP DEFAULT NULL,
     created_at TIMESTAMP DEFAULT current_timestamp,
     updated_at TIMESTAMP DEFAULT current_timestamp,
     FOREIGN KEY (client_id) REFERENCES oauth2_clients (id),
-    FOREIGN KEY (user_id) REFERENCES users (id)
+    FOREIGN KEY (user_id) REFERENCES users (id),
+    FOREIGN KEY (authorization_code) REFERENCES oauth2_codes (code)
 );
 
+-- 認可コードの再利用時に派生トークンを一括失効するためのインデックス
+CREATE INDEX oauth2_tokens_authorization_code_idx ON oauth2_tokens (authorization_code);
+
 -- oauth2_refresh_tokens テーブル
 CREATE TABLE oauth2_refresh_tokens (
     refresh_token VARCHAR(255) PRIMARY KEY,
     access_token VARCHAR(512) NOT NULL,
     expires_at TIMESTAMP,
+    revoked_at TIMESTAMP DEFAULT NULL,
     created_at TIMESTAMP DEFAULT current_timestamp,
     updated_at TIMESTAMP DEFAULT current_timestamp,
     FOREIGN KEY (access_token) REFERENCES oauth2_tokens (access_token)
@@ -5,19 +5,34 @@ import (
 	"log"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-webauthn/webauthn/webauthn"
 
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/breached"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/kvs"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/ldap"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/mail"
 	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/accesstoken"
 	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/sso"
 	"github.com/sntkn/go-oauth2/oauth2/internal/interface/handler"
+	"github.com/sntkn/go-oauth2/oauth2/internal/interface/middleware"
 	"github.com/sntkn/go-oauth2/oauth2/internal/interface/presenter/bindings"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/oidc"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/ratelimit"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/saml"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/secretbox"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/valkey"
 )
 
@@ -51,6 +66,11 @@ func main() {
 	defer db.Close()
 
 	r := gin.Default()
+	// 接続元ごとの制限とロックを偽装された X-Forwarded-For で回避されないよう、指定したプロキシのヘッダーだけを信頼する
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		logger.Error("Trusted Proxies Error", "message:", err)
+		return
+	}
 	r.LoadHTMLGlob("templates/*")
 
 	if err := bindings.Setup(); err != nil {
@@ -60,21 +80,233 @@ func main() {
 
 	r.Use(ErrorLoggerMiddleware(logger))
 
+	sameSite, err := session.ParseSameSite(cfg.SessionCookieSameSite)
+	if err != nil {
+		logger.Error("Session Error", "message:", err)
+		return
+	}
+
+	sessionManager, err := session.NewSessionManager(valkeyCli, cfg.SessionExpires, session.CookieOptions{
+		Domain:   cfg.SessionCookieDomain,
+		Secure:   cfg.SessionCookieSecure,
+		SameSite: sameSite,
+		Prefix:   cfg.SessionCookiePrefix,
+	})
+	if err != nil {
+		logger.Error("Session Error", "message:", err)
+		return
+	}
+
+	mailSender, err := mail.NewSender(cfg.MailSender, cfg.MailDir, cfg.MailFrom, logger)
+	if err != nil {
+		logger.Error("Mail Error", "message:", err)
+		return
+	}
+
+	secretBox, err := secretbox.New(cfg.MFAEncryptionKey)
+	if err != nil {
+		logger.Error("MFA Error", "message:", err)
+		return
+	}
+
+	// パスキーの操作を受け付けるオリジン。指定がなければ自身のURLのみ
+	passkeyOrigins := cfg.PasskeyRPOrigins
+	if len(passkeyOrigins) == 0 {
+		passkeyOrigins = []string{cfg.BaseURL}
+	}
+	wa, err := webauthn.New(&webauthn.Config{
+		RPID:          cfg.PasskeyRPID,
+		RPDisplayName: cfg.PasskeyRPName,
+		RPOrigins:     passkeyOrigins,
+	})
+	if err != nil {
+		logger.Error("Passkey Error", "message:", err)
+		return
+	}
+
+	hasher, err := domain.NewPasswordHasher(domain.PasswordHasherParams{
+		Algorithm:         cfg.PasswordHashAlgorithm,
+		Argon2Memory:      uint32(cfg.PasswordArgon2Memory),
+		Argon2Time:        uint32(cfg.PasswordArgon2Time),
+		Argon2Parallelism: uint8(cfg.PasswordArgon2Parallelism),
+		BcryptCost:        cfg.PasswordBcryptCost,
+	})
+	if err != nil {
+		logger.Error("Password Hasher Error", "message:", err)
+		return
+	}
+
+	breachedPasswords, err := breached.NewList(cfg.PasswordBreachedList, cfg.PasswordBreachedPath)
+	if err != nil {
+		logger.Error("Breached Password List Error", "message:", err)
+		return
+	}
+	passwordPolicy := domain.NewPasswordPolicy(domain.PasswordPolicyParams{
+		MinLength:         cfg.PasswordMinLength,
+		BreachedPasswords: breachedPasswords,
+	})
+
+	// 上流の OpenID プロバイダ。リダイレクトURIはプロバイダごとに分ける
+	federationProviders := make([]usecase.FederationProvider, 0, len(cfg.FederationProviders))
+	for _, p := range cfg.FederationProviders {
+		federationProviders = append(federationProviders, usecase.FederationProvider{
+			Name:        p.Name,
+			DisplayName: p.DisplayName,
+			LinkByEmail: p.LinkByEmail,
+			Client: oidc.NewClient(oidc.Config{
+				Issuer:       p.Issuer,
+				ClientID:     p.ClientID,
+				ClientSecret: p.ClientSecret,
+				RedirectURL:  cfg.BaseURL + "/client/signin/federation/" + url.PathEscape(p.Name) + "/callback",
+				Scopes:       p.Scopes,
+			}),
+		})
+	}
+
+	// ローカルのパスワードを持たない利用者を LDAP で認証する
+	var directory *usecase.DirectoryVerifierParams
+	if cfg.LDAPURL != "" {
+		directory = &usecase.DirectoryVerifierParams{
+			Name: "ldap",
+			Directory: ldap.NewDirectory(ldap.Config{
+				URL:                cfg.LDAPURL,
+				StartTLS:           cfg.LDAPStartTLS,
+				BindDN:             cfg.LDAPBindDN,
+				BindPassword:       cfg.LDAPBindPassword,
+				BaseDN:             cfg.LDAPBaseDN,
+				UserFilter:         cfg.LDAPUserFilter,
+				IDAttribute:        cfg.LDAPIDAttribute,
+				NameAttribute:      cfg.LDAPNameAttribute,
+				EmailAttribute:     cfg.LDAPEmailAttribute,
+				GroupBaseDN:        cfg.LDAPGroupBaseDN,
+				GroupFilter:        cfg.LDAPGroupFilter,
+				GroupNameAttribute: cfg.LDAPGroupNameAttribute,
+				Timeout:            time.Duration(cfg.LDAPTimeout) * time.Second,
+			}),
+			RequiredGroups: cfg.LDAPRequiredGroups,
+			LinkByEmail:    cfg.LDAPLinkByEmail,
+		}
+	}
+
+	// SAML の接続は DB に登録する。SP の鍵は全ての接続で共有する
+	samlParams := usecase.SAMLServiceProviderParams{BaseURL: cfg.BaseURL}
+	if cfg.SAMLSPPrivateKey != "" {
+		samlParams.Key, samlParams.Certificate, err = saml.LoadKeyPair(cfg.SAMLSPPrivateKey, cfg.SAMLSPCertificate)
+		if err != nil {
+			logger.Error("SAML Error", "message:", err)
+			return
+		}
+	}
+
 	opt := handler.HandlerOption{
-		DB:      db,
-		Session: session.NewSessionManager(valkeyCli, cfg.SessionExpires),
-		Config:  cfg,
+		DB:                  db,
+		Session:             sessionManager,
+		Config:              cfg,
+		MailSender:          mailSender,
+		SSORevoker:          sso.NewRevoker(valkeyCli, cfg.SessionExpires),
+		SecretBox:           secretBox,
+		WebAuthn:            wa,
+		LoginAttempts:       kvs.NewLoginAttemptStore(valkeyCli),
+		PasswordHasher:      hasher,
+		PasswordPolicy:      passwordPolicy,
+		FederationProviders: federationProviders,
+		Directory:           directory,
+		SAML:                samlParams,
+		SAMLLogins:          kvs.NewSAMLLoginStore(valkeyCli),
+		Logger:              logger,
 	}
 
+	// ブラウザのフォームから状態を変更するリクエストはCSRFトークンを検証する
+	csrf := middleware.CSRFMiddleware(opt.Session)
+
+	// リクエスト数の制限。回数は valkey で数えるため、全てのサーバーで共有される
+	limiter := ratelimit.New(valkeyCli)
+	window := time.Duration(cfg.RateLimitWindow) * time.Second
+	limitByIP := func(name string, limit int) gin.HandlerFunc {
+		return middleware.RateLimitMiddleware(limiter, middleware.RateLimitRule{
+			Name: name + ":ip", Limit: limit, Window: window, Key: middleware.RateLimitByIP,
+		})
+	}
+	signinLimit := limitByIP("signin", cfg.RateLimitSigninPerIP)
+	tokenLimit := middleware.RateLimitMiddleware(limiter,
+		middleware.RateLimitRule{Name: "token:client", Limit: cfg.RateLimitTokenPerClient, Window: window, Key: middleware.RateLimitByClientID},
+		middleware.RateLimitRule{Name: "token:ip", Limit: cfg.RateLimitTokenPerIP, Window: window, Key: middleware.RateLimitByIP},
+	)
+
 	ah := handler.NewAuthenticationHandler(opt)
 	r.GET("/client/sign-entry", ah.Entry)
 	r.GET("/client/signin", ah.Signin)
-	r.POST("/client/signin", ah.PostSignin)
+	r.POST("/client/signin", signinLimit, csrf, ah.PostSignin)
+	r.GET("/client/signin/mfa", ah.SigninMFA)
+	r.POST("/client/signin/mfa", signinLimit, csrf, ah.PostSigninMFA)
+	r.POST("/client/signin/passkey/begin", signinLimit, csrf, ah.BeginPasskeySignin)
+	r.POST("/client/signin/passkey/finish", signinLimit, csrf, ah.FinishPasskeySignin)
+	r.GET("/client/signin/federation/:provider", signinLimit, ah.BeginFederatedSignin)
+	r.GET("/client/signin/federation/:provider/callback", signinLimit, ah.FederatedSigninCallback)
+	r.GET("/client/signin/saml/:connection", signinLimit, ah.BeginSAMLSignin)
+	r.GET("/client/signin/saml/:connection/finish", signinLimit, ah.FinishSAMLSignin)
+	// IdP からの POST はセッションを持たないため CSRF トークンを検証しない。応答の署名と InResponseTo で照合する
+	r.GET("/saml/:connection/metadata", ah.SAMLMetadata)
+	r.POST("/saml/:connection/acs", signinLimit, ah.SAMLAssertionConsumer)
+	r.GET("/client/signup", ah.Signup)
+	r.POST("/client/signup", limitByIP("signup", cfg.RateLimitSignupPerIP), csrf, ah.PostSignup)
+
+	prh := handler.NewPasswordResetHandler(opt)
+	r.GET("/client/forgot-password", prh.ForgotPassword)
+	r.POST("/client/forgot-password", limitByIP("forgot-password", cfg.RateLimitSignupPerIP), csrf, prh.PostForgotPassword)
+	r.GET("/client/reset-password", prh.ResetPassword)
+	r.POST("/client/reset-password", limitByIP("reset-password", cfg.RateLimitSignupPerIP), csrf, prh.PostResetPassword)
+
+	evh := handler.NewEmailVerificationHandler(opt)
+	r.GET("/client/verify-email", evh.VerifyEmail)
+	r.POST("/client/verify-email", limitByIP("verify-email", cfg.RateLimitSignupPerIP), csrf, evh.ResendVerificationEmail)
+	r.GET("/client/verify-email/confirm", evh.ConfirmEmail)
 
 	arh := handler.NewAuthorizationHandler(opt)
 	r.GET("/oauth2/consent", arh.Consent)
-	r.POST("/oauth2/consent", arh.PostConsent)
-	r.POST("/oauth2/token", arh.Token)
+	r.POST("/oauth2/consent", csrf, arh.PostConsent)
+	r.POST("/oauth2/token", tokenLimit, arh.Token)
+
+	ach := handler.NewAccountHandler(opt)
+	r.GET("/account/apps", ach.Apps)
+	r.POST("/account/apps/:client_id/revoke", csrf, ach.RevokeApp)
+	r.GET("/account/mfa", ach.MFA)
+	r.POST("/account/mfa", csrf, ach.ConfirmMFA)
+	r.POST("/account/mfa/recovery-codes", csrf, ach.RegenerateRecoveryCodes)
+	r.POST("/account/mfa/disable", csrf, ach.DisableMFA)
+	r.GET("/account/passkeys", ach.Passkeys)
+	r.POST("/account/passkeys/register/begin", csrf, ach.BeginPasskeyRegistration)
+	r.POST("/account/passkeys/register/finish", csrf, ach.FinishPasskeyRegistration)
+	r.POST("/account/passkeys/:credential_id/delete", csrf, ach.DeletePasskey)
+
+	// 管理用のAPIは admin スコープのアクセストークンで呼び出す。
+	// 失効させたトークンと無効にしたユーザーを拒否するため、署名に加えて保存された記録も確かめる
+	adh := handler.NewAdminHandler(opt)
+	admin := r.Group("/admin",
+		middleware.AuthMiddleware(cfg, accesstoken.NewTokenService()),
+		adh.Authorize,
+		middleware.RequireScope("admin"),
+		middleware.RateLimitMiddleware(limiter, middleware.RateLimitRule{
+			Name: "admin:user", Limit: cfg.RateLimitAdminPerUser, Window: window, Key: middleware.RateLimitByUser,
+		}),
+	)
+	admin.GET("/lockouts", adh.LockoutEvents)
+	admin.POST("/lockouts/unlock", adh.Unlock)
+	admin.GET("/clients", adh.Clients)
+	admin.POST("/clients", adh.CreateClient)
+	admin.GET("/clients/:client_id", adh.Client)
+	admin.PATCH("/clients/:client_id", adh.UpdateClient)
+	admin.DELETE("/clients/:client_id", adh.DeleteClient)
+	admin.POST("/clients/:client_id/secret", adh.RotateClientSecret)
+	admin.POST("/clients/:client_id/disable", adh.DisableClient)
+	admin.GET("/users", adh.Users)
+	admin.POST("/users", adh.CreateUser)
+	admin.GET("/users/:user_id", adh.User)
+	admin.PATCH("/users/:user_id", adh.UpdateUser)
+	admin.DELETE("/users/:user_id", adh.DeleteUser)
+	admin.POST("/users/:user_id/password-reset", adh.ForcePasswordReset)
+	admin.POST("/users/:user_id/disable", adh.DisableUser)
+	admin.POST("/users/:user_id/enable", adh.EnableUser)
 
 	// サーバーの設定
 	srv := &http.Server{
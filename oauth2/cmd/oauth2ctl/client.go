@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/interface/handler"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+)
+
+const defaultListLimit = 50
+
+func (a *app) clientUsecase() (usecase.IClientAdminUsecase, error) {
+	db, err := a.openDB()
+	if err != nil {
+		return nil, err
+	}
+	return usecase.NewClientAdminUsecase(
+		repository.NewClientRepository(db),
+		repository.NewScopeRepository(db),
+		repository.NewTokenRepository(db),
+		repository.NewRefreshTokenRepository(db),
+	), nil
+}
+
+// clientFields は1件のクライアントの表を作る。JSON は管理用APIの応答と同じ形にする
+func clientFields(c handler.ClientResponse) table {
+	t := fields(
+		"ID", c.ID.String(),
+		"NAME", c.Name,
+		"REDIRECT URIS", formatList(c.RedirectURIs),
+		"SCOPES", formatList(c.Scopes),
+		"GRANT TYPES", formatList(c.GrantTypes),
+		"FIRST PARTY", formatBool(c.FirstParty),
+		"CONFIDENTIAL", formatBool(c.Confidential),
+		"ACCESS TOKEN EXPIRES MIN", strconv.Itoa(c.AccessTokenExpiresMin),
+		"REFRESH TOKEN EXPIRES DAY", strconv.Itoa(c.RefreshTokenExpiresDay),
+		"DISABLED AT", formatTimePtr(c.DisabledAt),
+		"CREATED AT", formatTime(c.CreatedAt),
+		"UPDATED AT", formatTime(c.UpdatedAt),
+	)
+	if c.ClientSecret != "" {
+		t.rows = append(t.rows, []string{"CLIENT SECRET", c.ClientSecret})
+	}
+	return t
+}
+
+func clientCreate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "client create")
+	name := fs.String("name", "", "client name (required)")
+	redirectURIs := fs.String("redirect-uris", "", "comma separated redirect URIs (required)")
+	scopes := fs.String("scopes", "", "comma separated scopes the client may request")
+	grantTypes := fs.String("grant-types", "", "comma separated grant types (default: authorization_code,refresh_token)")
+	firstParty := fs.Bool("first-party", false, "skip the consent screen")
+	public := fs.Bool("public", false, "register a public client without a secret")
+	accessMin := fs.Int("access-token-expires-min", 0, "access token lifetime in minutes (0: server default)")
+	refreshDay := fs.Int("refresh-token-expires-day", 0, "refresh token lifetime in days (0: server default)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	uc, err := a.clientUsecase()
+	if err != nil {
+		return err
+	}
+	client, secret, err := uc.CreateClient(ctx, usecase.CreateClientParams{
+		Name:                   *name,
+		RedirectURIs:           splitList(*redirectURIs),
+		AllowedScopes:          splitList(*scopes),
+		GrantTypes:             splitList(*grantTypes),
+		FirstParty:             *firstParty,
+		Public:                 *public,
+		AccessTokenExpiresMin:  *accessMin,
+		RefreshTokenExpiresDay: *refreshDay,
+	})
+	if err != nil {
+		return err
+	}
+
+	// シークレットは保存されないため、この出力でだけ確認できる
+	res := handler.NewClientResponse(client)
+	res.ClientSecret = secret
+	return a.out.print(res, clientFields(res))
+}
+
+func clientList(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "client list")
+	offset := fs.Int("offset", 0, "number of clients to skip")
+	limit := fs.Int("limit", defaultListLimit, "maximum number of clients")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	uc, err := a.clientUsecase()
+	if err != nil {
+		return err
+	}
+	clients, more, err := uc.ListClients(ctx, *offset, *limit)
+	if err != nil {
+		return err
+	}
+
+	res := make([]handler.ClientResponse, 0, len(clients))
+	t := table{header: []string{"ID", "NAME", "CONFIDENTIAL", "FIRST PARTY", "SCOPES", "GRANT TYPES", "DISABLED AT"}}
+	for _, client := range clients {
+		c := handler.NewClientResponse(client)
+		res = append(res, c)
+		t.rows = append(t.rows, []string{
+			c.ID.String(), c.Name, formatBool(c.Confidential), formatBool(c.FirstParty),
+			formatList(c.Scopes), formatList(c.GrantTypes), formatTimePtr(c.DisabledAt),
+		})
+	}
+	body := map[string]any{"clients": res}
+	if more {
+		body["next_offset"] = *offset + len(clients)
+		a.printNextOffset(*offset + len(clients))
+	}
+	return a.out.print(body, t)
+}
+
+func clientGet(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "client get")
+	clientID, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	uc, err := a.clientUsecase()
+	if err != nil {
+		return err
+	}
+	client, err := uc.GetClient(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	res := handler.NewClientResponse(client)
+	return a.out.print(res, clientFields(res))
+}
+
+func clientUpdate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "client update")
+	name := fs.String("name", "", "client name")
+	redirectURIs := fs.String("redirect-uris", "", "comma separated redirect URIs")
+	scopes := fs.String("scopes", "", "comma separated scopes the client may request")
+	grantTypes := fs.String("grant-types", "", "comma separated grant types")
+	accessMin := fs.Int("access-token-expires-min", 0, "access token lifetime in minutes (0: server default)")
+	refreshDay := fs.Int("refresh-token-expires-day", 0, "refresh token lifetime in days (0: server default)")
+	clientID, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	// 指定されなかったフラグの項目は変更しない
+	var p usecase.UpdateClientParams
+	if isFlagSet(fs, "name") {
+		p.Name = name
+	}
+	if isFlagSet(fs, "redirect-uris") {
+		p.RedirectURIs = splitList(*redirectURIs)
+	}
+	if isFlagSet(fs, "scopes") {
+		p.AllowedScopes = splitList(*scopes)
+	}
+	if isFlagSet(fs, "grant-types") {
+		p.GrantTypes = splitList(*grantTypes)
+	}
+	if isFlagSet(fs, "access-token-expires-min") {
+		p.AccessTokenExpiresMin = accessMin
+	}
+	if isFlagSet(fs, "refresh-token-expires-day") {
+		p.RefreshTokenExpiresDay = refreshDay
+	}
+
+	uc, err := a.clientUsecase()
+	if err != nil {
+		return err
+	}
+	client, err := uc.UpdateClient(ctx, clientID, p)
+	if err != nil {
+		return err
+	}
+	res := handler.NewClientResponse(client)
+	return a.out.print(res, clientFields(res))
+}
+
+func clientRotateSecret(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "client rotate-secret")
+	clientID, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	uc, err := a.clientUsecase()
+	if err != nil {
+		return err
+	}
+	secret, err := uc.RotateClientSecret(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	return a.out.print(
+		map[string]string{"client_id": clientID.String(), "client_secret": secret},
+		fields("CLIENT ID", clientID.String(), "CLIENT SECRET", secret),
+	)
+}
+
+// clientDisable はクライアントを無効にし、発行済みのトークンを失効させる
+func clientDisable(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "client disable")
+	clientID, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	uc, err := a.clientUsecase()
+	if err != nil {
+		return err
+	}
+	if err := uc.DisableClient(ctx, clientID); err != nil {
+		return err
+	}
+	return clientGet(ctx, a, []string{clientID.String()})
+}
+
+func clientDelete(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "client delete")
+	clientID, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	uc, err := a.clientUsecase()
+	if err != nil {
+		return err
+	}
+	if err := uc.DeleteClient(ctx, clientID); err != nil {
+		return err
+	}
+	return a.out.print(
+		map[string]string{"client_id": clientID.String(), "status": "deleted"},
+		fields("CLIENT ID", clientID.String(), "STATUS", "deleted"),
+	)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// MFA_ENCRYPTION_KEY には AES-256 の鍵を指定する
+const mfaEncryptionKeyBytes = 32
+
+// 署名鍵を読み込む環境変数の名前
+const (
+	envPrivateKey = "PRIVATE_KEY"
+	envPublicKey  = "PUBLIC_KEY"
+)
+
+type keyPair struct {
+	PublicKey  string `json:"public_key"`
+	PrivateKey string `json:"private_key"`
+}
+
+// generateKeyPair はアクセストークンに署名する Ed25519 の鍵を Base64 で返す
+func generateKeyPair() (keyPair, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return keyPair{}, err
+	}
+	return keyPair{
+		PublicKey:  base64.StdEncoding.EncodeToString(publicKey),
+		PrivateKey: base64.StdEncoding.EncodeToString(privateKey),
+	}, nil
+}
+
+// keyGenerate は署名鍵と MFA の暗号化鍵を作って表示する。新しく環境を用意する時に使う
+func keyGenerate(_ context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "key generate")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	pair, err := generateKeyPair()
+	if err != nil {
+		return err
+	}
+	mfaKey := make([]byte, mfaEncryptionKeyBytes)
+	if _, err := rand.Read(mfaKey); err != nil {
+		return err
+	}
+	mfaKeyBase64 := base64.StdEncoding.EncodeToString(mfaKey)
+
+	return a.out.print(
+		map[string]string{
+			"public_key":         pair.PublicKey,
+			"private_key":        pair.PrivateKey,
+			"mfa_encryption_key": mfaKeyBase64,
+		},
+		fields(
+			envPublicKey, pair.PublicKey,
+			envPrivateKey, pair.PrivateKey,
+			"MFA_ENCRYPTION_KEY", mfaKeyBase64,
+		),
+	)
+}
+
+// keyRotate は新しい署名鍵を作り、env ファイルの PRIVATE_KEY と PUBLIC_KEY を置き換える。
+// 検証に使う公開鍵は1つのため、サーバーを再起動すると以前の鍵で署名したアクセストークンは使えなくなる
+func keyRotate(_ context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "key rotate")
+	envFile := fs.String("env-file", "", "env file to update (required)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *envFile == "" {
+		return errFlagsRequired(fs, "env-file")
+	}
+
+	info, err := os.Stat(*envFile)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(*envFile)
+	if err != nil {
+		return err
+	}
+
+	pair, err := generateKeyPair()
+	if err != nil {
+		return err
+	}
+	updated := replaceEnv(string(content), [][2]string{
+		{envPrivateKey, pair.PrivateKey},
+		{envPublicKey, pair.PublicKey},
+	})
+	if err := os.WriteFile(*envFile, []byte(updated), info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stderr, "updated %s: restart the server to sign with the new key; access tokens signed with the previous key will be rejected\n", *envFile)
+	return a.out.print(
+		map[string]string{"env_file": *envFile, "public_key": pair.PublicKey},
+		fields("ENV FILE", *envFile, envPublicKey, pair.PublicKey),
+	)
+}
+
+// replaceEnv は env ファイルの内容の指定された変数の値を置き換える。ない変数は末尾に追加し、他の行は変えない
+func replaceEnv(content string, values [][2]string) string {
+	lines := strings.Split(content, "\n")
+	replaced := make(map[string]bool, len(values))
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		prefix := ""
+		if rest, ok := strings.CutPrefix(trimmed, "export "); ok {
+			prefix, trimmed = "export ", rest
+		}
+		for _, kv := range values {
+			if strings.HasPrefix(trimmed, kv[0]+"=") {
+				lines[i] = fmt.Sprintf("%s%s=%q", prefix, kv[0], kv[1])
+				replaced[kv[0]] = true
+			}
+		}
+	}
+
+	updated := strings.Join(lines, "\n")
+	for _, kv := range values {
+		if replaced[kv[0]] {
+			continue
+		}
+		if updated != "" && !strings.HasSuffix(updated, "\n") {
+			updated += "\n"
+		}
+		updated += fmt.Sprintf("%s=%q\n", kv[0], kv[1])
+	}
+	return updated
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceEnv(t *testing.T) {
+	values := [][2]string{{"PRIVATE_KEY", "new-private"}, {"PUBLIC_KEY", "new+public="}}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "replace existing",
+			content: "# keys\nPRIVATE_KEY=old\nexport PUBLIC_KEY=\"old\"\nDBHost=db\n",
+			want:    "# keys\nPRIVATE_KEY=\"new-private\"\nexport PUBLIC_KEY=\"new+public=\"\nDBHost=db\n",
+		},
+		{
+			name:    "append missing",
+			content: "DBHost=db",
+			want:    "DBHost=db\nPRIVATE_KEY=\"new-private\"\nPUBLIC_KEY=\"new+public=\"\n",
+		},
+		{
+			name:    "empty file",
+			content: "",
+			want:    "PRIVATE_KEY=\"new-private\"\nPUBLIC_KEY=\"new+public=\"\n",
+		},
+		{
+			// 名前が前方一致するだけの変数は置き換えない
+			name:    "similar name",
+			content: "PRIVATE_KEY_OLD=x\n",
+			want:    "PRIVATE_KEY_OLD=x\nPRIVATE_KEY=\"new-private\"\nPUBLIC_KEY=\"new+public=\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, replaceEnv(tt.content, values))
+		})
+	}
+}
+
+func TestKeyRotate(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("PRIVATE_KEY=old\nPUBLIC_KEY=old\n"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, run(context.Background(), []string{"-o", "json", "key", "rotate", "-env-file", envFile}, &stdout, &stderr))
+
+	var res map[string]string
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &res))
+	publicKey, err := base64.StdEncoding.DecodeString(res["public_key"])
+	require.NoError(t, err)
+	assert.Len(t, publicKey, 32)
+
+	content, err := os.ReadFile(envFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "PUBLIC_KEY=\""+res["public_key"]+"\"")
+	assert.NotContains(t, string(content), "=old")
+
+	info, err := os.Stat(envFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
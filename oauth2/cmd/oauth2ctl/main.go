@@ -0,0 +1,250 @@
+// oauth2ctl は DB に直接接続してクライアント、ユーザー、署名鍵、トークンを管理する運用向けのコマンド
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/valkey"
+)
+
+const usage = `Usage: oauth2ctl [-o table|json] [-kvs addr] <command> <subcommand> [flags]
+
+Commands:
+  client  create | list | get | update | rotate-secret | disable | delete
+  user    create | list | get | update | disable | enable | delete
+  key     generate | rotate
+  token   mint | decode | introspect | revoke
+
+Run "oauth2ctl <command> <subcommand> -h" for the flags of each subcommand.
+Database settings are read from the same environment variables as the server.
+
+Global flags:
+`
+
+var errUsage = errors.New("invalid arguments")
+
+type command func(ctx context.Context, a *app, args []string) error
+
+var commands = map[string]map[string]command{
+	"client": {
+		"create":        clientCreate,
+		"list":          clientList,
+		"get":           clientGet,
+		"update":        clientUpdate,
+		"rotate-secret": clientRotateSecret,
+		"disable":       clientDisable,
+		"delete":        clientDelete,
+	},
+	"user": {
+		"create":  userCreate,
+		"list":    userList,
+		"get":     userGet,
+		"update":  userUpdate,
+		"disable": userDisable,
+		"enable":  userEnable,
+		"delete":  userDelete,
+	},
+	"key": {
+		"generate": keyGenerate,
+		"rotate":   keyRotate,
+	},
+	"token": {
+		"mint":       tokenMint,
+		"decode":     tokenDecode,
+		"introspect": tokenIntrospect,
+		"revoke":     tokenRevoke,
+	},
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errUsage) && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "oauth2ctl:", errorMessage(err))
+		}
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("oauth2ctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("o", formatTable, "output format: table or json")
+	// セッションの失効は valkey に記録するため、ユーザーの無効化と削除でのみ接続する
+	kvsAddr := fs.String("kvs", "kvs:6379", "valkey address used to revoke SSO sessions")
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	out, err := newOutput(stdout, *format)
+	if err != nil {
+		return err
+	}
+
+	rest := fs.Args()
+	if len(rest) < 2 {
+		fs.Usage()
+		return errUsage
+	}
+	cmd, ok := commands[rest[0]][rest[1]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command: %s\n\n", strings.Join(rest[:2], " "))
+		fs.Usage()
+		return errUsage
+	}
+
+	a := &app{out: out, stderr: stderr, kvsAddr: *kvsAddr}
+	defer a.close()
+	return cmd(ctx, a, rest[2:])
+}
+
+// app は設定と接続を必要になった時に作る。鍵の生成やトークンのデコードは DB がなくても使える
+type app struct {
+	out     *output
+	stderr  io.Writer
+	kvsAddr string
+	cfg     *config.Config
+	db      *sqlx.DB
+	kvs     *valkey.Client
+}
+
+func (a *app) config() (*config.Config, error) {
+	if a.cfg != nil {
+		return a.cfg, nil
+	}
+	cfg, err := config.GetEnv()
+	if err != nil {
+		return nil, err
+	}
+	a.cfg = cfg
+	return cfg, nil
+}
+
+func (a *app) openDB() (*sqlx.DB, error) {
+	if a.db != nil {
+		return a.db, nil
+	}
+	cfg, err := a.config()
+	if err != nil {
+		return nil, err
+	}
+	db, err := repository.NewDB(cfg.DBHost, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBPort)
+	if err != nil {
+		return nil, err
+	}
+	a.db = db
+	return db, nil
+}
+
+func (a *app) openKVS(ctx context.Context) (*valkey.Client, error) {
+	if a.kvs != nil {
+		return a.kvs, nil
+	}
+	cli, err := valkey.NewClient(ctx, valkey.Options{Addr: []string{a.kvsAddr}})
+	if err != nil {
+		return nil, err
+	}
+	a.kvs = cli
+	return cli, nil
+}
+
+func (a *app) close() {
+	if a.db != nil {
+		a.db.Close()
+	}
+}
+
+// newFlagSet はサブコマンドのフラグを作る。エラーは呼び出し元で表示する
+func newFlagSet(a *app, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("oauth2ctl "+name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// parseArgs はフラグと位置引数を混ぜて指定できるように、位置引数を取り除きながら最後まで解析する
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseID は位置引数で1つだけ指定された ID を解析する
+func parseID(fs *flag.FlagSet, args []string) (uuid.UUID, error) {
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(positional) != 1 {
+		return uuid.Nil, errors.Errorf("%s: exactly one ID is required", fs.Name())
+	}
+	id, err := uuid.Parse(positional[0])
+	if err != nil {
+		return uuid.Nil, errors.Errorf("invalid ID %q: %w", positional[0], err)
+	}
+	return id, nil
+}
+
+func errFlagsRequired(fs *flag.FlagSet, names ...string) error {
+	return errors.Errorf("%s: -%s are required", fs.Name(), strings.Join(names, ", -"))
+}
+
+// isFlagSet は指定されたフラグかを判定する。更新で省略した項目を変えないために使う
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// splitList はカンマ区切りの値を分割する。更新で空の一覧を指定できるように、空の場合も nil を返さない
+func splitList(s string) []string {
+	list := []string{}
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// errorMessage はユースケースのエラーからステータスコードを除いたメッセージを返す
+func errorMessage(err error) string {
+	var usecaseErr *errors.UsecaseError
+	if errors.As(err, &usecaseErr) {
+		return usecaseErr.Message
+	}
+	return err.Error()
+}
+
+// printNextOffset は続きがあることを標準エラーに出す。表の出力をそのまま処理できるように標準出力には出さない
+func (a *app) printNextOffset(next int) {
+	if a.out.format == formatTable {
+		fmt.Fprintf(a.stderr, "more results: use -offset %d\n", next)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	err := run(context.Background(), nil, &stdout, &stderr)
+	assert.ErrorIs(t, err, errUsage)
+	assert.Contains(t, stderr.String(), "Usage: oauth2ctl")
+
+	err = run(context.Background(), []string{"client", "unknown"}, &stdout, &stderr)
+	assert.ErrorIs(t, err, errUsage)
+	assert.Contains(t, stderr.String(), "unknown command: client unknown")
+
+	err = run(context.Background(), []string{"-o", "yaml", "key", "generate"}, &stdout, &stderr)
+	require.Error(t, err)
+	assert.Empty(t, stdout.String())
+}
+
+func TestRun_Output(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, run(context.Background(), []string{"key", "generate"}, &stdout, &stderr))
+	assert.Regexp(t, `(?m)^PUBLIC_KEY\s+\S+$`, stdout.String())
+	assert.Regexp(t, `(?m)^MFA_ENCRYPTION_KEY\s+\S+$`, stdout.String())
+
+	stdout.Reset()
+	require.NoError(t, run(context.Background(), []string{"-o", "json", "key", "generate"}, &stdout, &stderr))
+	var res map[string]string
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &res))
+	assert.NotEmpty(t, res["public_key"])
+	assert.NotEmpty(t, res["private_key"])
+	assert.NotEmpty(t, res["mfa_encryption_key"])
+}
+
+func TestParseArgs(t *testing.T) {
+	// 位置引数の後に指定したフラグも解析する
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	name := fs.String("name", "", "")
+	positional, err := parseArgs(fs, []string{"4f1c1a36-7a43-4a4e-9d51-0f3e4b6d9a10", "-name", "app"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"4f1c1a36-7a43-4a4e-9d51-0f3e4b6d9a10"}, positional)
+	assert.Equal(t, "app", *name)
+	assert.True(t, isFlagSet(fs, "name"))
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	_, err = parseID(fs, []string{"not-a-uuid"})
+	require.Error(t, err)
+	_, err = parseID(fs, nil)
+	require.Error(t, err)
+}
+
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"read", "write"}, splitList(" read, write,,"))
+	// 更新で一覧を空にできるように nil を返さない
+	assert.Equal(t, []string{}, splitList(""))
+}
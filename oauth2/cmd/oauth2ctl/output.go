@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// output は結果を人が読む表か、スクリプトで扱う JSON で書き出す
+type output struct {
+	w      io.Writer
+	format string
+}
+
+func newOutput(w io.Writer, format string) (*output, error) {
+	if format != formatTable && format != formatJSON {
+		return nil, errors.Errorf("unknown output format %q: use table or json", format)
+	}
+	return &output{w: w, format: format}, nil
+}
+
+// table は表で書き出す内容。header が nil の場合は見出しを出さない
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// fields は1件の項目を「名前 値」の行で並べた表を作る
+func fields(pairs ...string) table {
+	t := table{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		t.rows = append(t.rows, []string{pairs[i], pairs[i+1]})
+	}
+	return t
+}
+
+// print は JSON の場合は v を、表の場合は t を書き出す
+func (o *output) print(v any, t table) error {
+	if o.format == formatJSON {
+		enc := json.NewEncoder(o.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(o.w, 0, 0, 2, ' ', 0)
+	if t.header != nil {
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	}
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.RFC3339)
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return formatTime(*t)
+}
+
+func formatList(list []string) string {
+	if len(list) == 0 {
+		return "-"
+	}
+	return strings.Join(list, ",")
+}
+
+func formatBool(b bool) string {
+	return strconv.FormatBool(b)
+}
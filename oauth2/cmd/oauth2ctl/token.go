@@ -0,0 +1,360 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/domain/domainservice"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/accesstoken"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+type mintedToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       int64     `json:"expiry"`
+	UserID       uuid.UUID `json:"user_id"`
+	ClientID     uuid.UUID `json:"client_id"`
+	Scope        string    `json:"scope"`
+}
+
+// tokenMint は認可の手続きを経ずにアクセストークンを発行する。動作確認や障害対応のために使う。
+// 発行したトークンはトークンエンドポイントで発行したものと同じように保存し、失効させられる
+func tokenMint(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "token mint")
+	userFlag := fs.String("user", "", "user ID (required)")
+	clientFlag := fs.String("client", "", "client ID (required)")
+	scopeFlag := fs.String("scope", "", "space separated scopes; must be allowed for the client")
+	expiresMin := fs.Int("expires-min", 0, "access token lifetime in minutes (0: the client's lifetime)")
+	withRefresh := fs.Bool("refresh", false, "also issue a refresh token")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	userID, clientID, err := parseUserClientFlags(fs, *userFlag, *clientFlag)
+	if err != nil {
+		return err
+	}
+	if userID == uuid.Nil || clientID == uuid.Nil {
+		return errFlagsRequired(fs, "user", "client")
+	}
+	scope, err := domain.ParseScope(*scopeFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := a.config()
+	if err != nil {
+		return err
+	}
+	if cfg.PrivateKey == "" {
+		return errors.New(envPrivateKey + " is not set")
+	}
+
+	clientUC, err := a.clientUsecase()
+	if err != nil {
+		return err
+	}
+	client, err := clientUC.GetClient(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	if client.IsDisabled() {
+		return errors.New("client is disabled")
+	}
+	if !scope.IsSubsetOf(client.GetAllowedScopes()) {
+		return errors.Errorf("scope is not allowed for the client: %s", scope.Difference(client.GetAllowedScopes()))
+	}
+
+	userUC, err := a.userUsecase()
+	if err != nil {
+		return err
+	}
+	user, err := userUC.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsDisabled() {
+		return errors.New("user is disabled")
+	}
+
+	db, err := a.openDB()
+	if err != nil {
+		return err
+	}
+	tokenService := domainservice.NewTokenService(repository.NewTokenRepository(db), repository.NewRefreshTokenRepository(db), cfg)
+	if *expiresMin == 0 {
+		*expiresMin = client.GetAccessTokenExpiresMin()
+	}
+	atoken, err := tokenService.StoreNewToken(ctx, clientID, userID, scope.String(), "", *expiresMin)
+	if err != nil {
+		return err
+	}
+	res := mintedToken{
+		AccessToken: atoken.GetAccessToken(),
+		Expiry:      atoken.Expiry(),
+		UserID:      userID,
+		ClientID:    clientID,
+		Scope:       scope.String(),
+	}
+	if *withRefresh {
+		rtoken, err := tokenService.StoreNewRefreshToken(ctx, atoken.GetAccessToken(), client.GetRefreshTokenExpiresDay())
+		if err != nil {
+			return err
+		}
+		res.RefreshToken = rtoken.GetRefreshToken()
+	}
+
+	t := fields(
+		"ACCESS TOKEN", res.AccessToken,
+		"EXPIRES AT", formatTime(atoken.GetExpiresAt()),
+		"USER ID", userID.String(),
+		"CLIENT ID", clientID.String(),
+		"SCOPE", formatList(scope.Names()),
+	)
+	if res.RefreshToken != "" {
+		t.rows = append(t.rows, []string{"REFRESH TOKEN", res.RefreshToken})
+	}
+	return a.out.print(res, t)
+}
+
+type decodedToken struct {
+	Header map[string]any `json:"header"`
+	Claims jwt.MapClaims  `json:"claims"`
+}
+
+// tokenDecode は署名を検証せずに JWT のヘッダーとクレームを表示する。他の環境で発行されたトークンも読める
+func tokenDecode(_ context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "token decode")
+	tokenStr, err := parseToken(fs, args)
+	if err != nil {
+		return err
+	}
+
+	claims := jwt.MapClaims{}
+	token, _, err := new(jwt.Parser).ParseUnverified(tokenStr, claims)
+	if err != nil {
+		return err
+	}
+
+	t := table{}
+	for _, name := range sortedKeys(token.Header) {
+		t.rows = append(t.rows, []string{"header." + name, fmt.Sprint(token.Header[name])})
+	}
+	for _, name := range sortedKeys(claims) {
+		value := fmt.Sprint(claims[name])
+		// 日時のクレームは読める形を添える
+		if sec, ok := claims[name].(float64); ok && (name == "exp" || name == "iat" || name == "nbf") {
+			value = fmt.Sprintf("%.0f (%s)", sec, formatTime(time.Unix(int64(sec), 0)))
+		}
+		t.rows = append(t.rows, []string{name, value})
+	}
+	return a.out.print(decodedToken{Header: token.Header, Claims: claims}, t)
+}
+
+type introspection struct {
+	Active    bool       `json:"active"`
+	Reason    string     `json:"reason,omitempty"`
+	UserID    string     `json:"user_id,omitempty"`
+	ClientID  string     `json:"client_id,omitempty"`
+	Scope     string     `json:"scope"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	IssuedAt  *time.Time `json:"issued_at,omitempty"`
+	Stored    bool       `json:"stored"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// tokenIntrospect は PUBLIC_KEY で署名と有効期限を検証し、DB に保存されていて失効していないかを確かめる
+func tokenIntrospect(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "token introspect")
+	tokenStr, err := parseToken(fs, args)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := a.config()
+	if err != nil {
+		return err
+	}
+	if cfg.PublicKey == "" {
+		return errors.New(envPublicKey + " is not set")
+	}
+
+	// 無効なトークンでも内容を表示するため、クレームは検証と別に読む
+	claims := &accesstoken.CustomClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(tokenStr, claims); err != nil {
+		return err
+	}
+	res := introspection{
+		UserID:    claims.UserID,
+		ClientID:  claims.ClientID,
+		Scope:     claims.Scope,
+		ExpiresAt: unixTime(claims.StandardClaims.ExpiresAt),
+		IssuedAt:  unixTime(claims.IssuedAt),
+	}
+	if _, err := accesstoken.NewTokenService().Parse(tokenStr, cfg.PublicKey); err != nil {
+		var vErr *jwt.ValidationError
+		if errors.As(err, &vErr) && vErr.Errors == jwt.ValidationErrorExpired {
+			res.Reason = "expired"
+		} else {
+			res.Reason = "invalid signature: " + err.Error()
+		}
+	}
+
+	db, err := a.openDB()
+	if err != nil {
+		return err
+	}
+	stored, err := repository.NewTokenRepository(db).FindToken(ctx, tokenStr)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		res.Stored = true
+		res.RevokedAt = stored.GetRevokedAt()
+		if stored.IsRevoked() && res.Reason == "" {
+			res.Reason = "revoked"
+		}
+	} else if res.Reason == "" {
+		res.Reason = "not issued by this server"
+	}
+	res.Active = res.Reason == ""
+
+	reason := res.Reason
+	if reason == "" {
+		reason = "-"
+	}
+	return a.out.print(res, fields(
+		"ACTIVE", formatBool(res.Active),
+		"REASON", reason,
+		"USER ID", res.UserID,
+		"CLIENT ID", res.ClientID,
+		"SCOPE", formatList(strings.Fields(res.Scope)),
+		"EXPIRES AT", formatTimePtr(res.ExpiresAt),
+		"ISSUED AT", formatTimePtr(res.IssuedAt),
+		"STORED", formatBool(res.Stored),
+		"REVOKED AT", formatTimePtr(res.RevokedAt),
+	))
+}
+
+// tokenRevoke はユーザーかクライアント、または両方の組み合わせに発行したアクセストークンとリフレッシュトークンを失効させる
+func tokenRevoke(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "token revoke")
+	userFlag := fs.String("user", "", "revoke tokens issued to this user")
+	clientFlag := fs.String("client", "", "revoke tokens issued to this client")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	userID, clientID, err := parseUserClientFlags(fs, *userFlag, *clientFlag)
+	if err != nil {
+		return err
+	}
+	if userID == uuid.Nil && clientID == uuid.Nil {
+		return errors.Errorf("%s: -user or -client is required", fs.Name())
+	}
+
+	db, err := a.openDB()
+	if err != nil {
+		return err
+	}
+	tokenRepo := repository.NewTokenRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+
+	// リフレッシュトークンから新しいアクセストークンを発行されないように、リフレッシュトークンを先に失効させる
+	switch {
+	case userID != uuid.Nil && clientID != uuid.Nil:
+		if err := refreshTokenRepo.RevokeRefreshTokensByUserAndClient(ctx, userID, clientID); err != nil {
+			return err
+		}
+		err = tokenRepo.RevokeTokensByUserAndClient(ctx, userID, clientID)
+	case userID != uuid.Nil:
+		if err := refreshTokenRepo.RevokeRefreshTokensByUserID(ctx, userID); err != nil {
+			return err
+		}
+		err = tokenRepo.RevokeTokensByUserID(ctx, userID)
+	default:
+		if err := refreshTokenRepo.RevokeRefreshTokensByClientID(ctx, clientID); err != nil {
+			return err
+		}
+		err = tokenRepo.RevokeTokensByClientID(ctx, clientID)
+	}
+	if err != nil {
+		return err
+	}
+
+	res := map[string]string{"status": "revoked"}
+	t := table{}
+	if userID != uuid.Nil {
+		res["user_id"] = userID.String()
+		t.rows = append(t.rows, []string{"USER ID", userID.String()})
+	}
+	if clientID != uuid.Nil {
+		res["client_id"] = clientID.String()
+		t.rows = append(t.rows, []string{"CLIENT ID", clientID.String()})
+	}
+	t.rows = append(t.rows, []string{"STATUS", "revoked"})
+	return a.out.print(res, t)
+}
+
+// parseUserClientFlags は -user と -client の ID を解析する。指定されなかった ID は uuid.Nil になる
+func parseUserClientFlags(fs *flag.FlagSet, user, client string) (uuid.UUID, uuid.UUID, error) {
+	var userID, clientID uuid.UUID
+	var err error
+	if user != "" {
+		if userID, err = uuid.Parse(user); err != nil {
+			return uuid.Nil, uuid.Nil, errors.Errorf("%s: invalid -user %q: %w", fs.Name(), user, err)
+		}
+	}
+	if client != "" {
+		if clientID, err = uuid.Parse(client); err != nil {
+			return uuid.Nil, uuid.Nil, errors.Errorf("%s: invalid -client %q: %w", fs.Name(), client, err)
+		}
+	}
+	return userID, clientID, nil
+}
+
+// parseToken は位置引数のトークンを返す。"-" の場合はシェルの履歴に残さないように標準入力から読む
+func parseToken(fs *flag.FlagSet, args []string) (string, error) {
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return "", err
+	}
+	if len(positional) != 1 {
+		return "", errors.Errorf("%s: exactly one token (or - for stdin) is required", fs.Name())
+	}
+	if positional[0] != "-" {
+		return positional[0], nil
+	}
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func unixTime(sec int64) *time.Time {
+	if sec == 0 {
+		return nil
+	}
+	t := time.Unix(sec, 0)
+	return &t
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
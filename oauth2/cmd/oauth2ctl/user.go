@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/breached"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/sso"
+	"github.com/sntkn/go-oauth2/oauth2/internal/interface/handler"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+)
+
+// sessionRevoker は SSO セッションを失効させる必要が出た時に valkey へ接続する。
+// 作成や一覧では valkey がなくても動くようにする
+type sessionRevoker struct {
+	a *app
+}
+
+var _ domain.SessionRevoker = (*sessionRevoker)(nil)
+
+func (r *sessionRevoker) revoker(ctx context.Context) (*sso.Revoker, error) {
+	cfg, err := r.a.config()
+	if err != nil {
+		return nil, err
+	}
+	cli, err := r.a.openKVS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sso.NewRevoker(cli, cfg.SessionExpires), nil
+}
+
+func (r *sessionRevoker) RevokeUserSessions(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	revoker, err := r.revoker(ctx)
+	if err != nil {
+		return err
+	}
+	return revoker.RevokeUserSessions(ctx, userID, at)
+}
+
+func (a *app) userUsecase() (usecase.IUserAdminUsecase, error) {
+	cfg, err := a.config()
+	if err != nil {
+		return nil, err
+	}
+	db, err := a.openDB()
+	if err != nil {
+		return nil, err
+	}
+	hasher, err := domain.NewPasswordHasher(domain.PasswordHasherParams{
+		Algorithm:         cfg.PasswordHashAlgorithm,
+		Argon2Memory:      uint32(cfg.PasswordArgon2Memory),
+		Argon2Time:        uint32(cfg.PasswordArgon2Time),
+		Argon2Parallelism: uint8(cfg.PasswordArgon2Parallelism),
+		BcryptCost:        cfg.PasswordBcryptCost,
+	})
+	if err != nil {
+		return nil, err
+	}
+	breachedPasswords, err := breached.NewList(cfg.PasswordBreachedList, cfg.PasswordBreachedPath)
+	if err != nil {
+		return nil, err
+	}
+	policy := domain.NewPasswordPolicy(domain.PasswordPolicyParams{
+		MinLength:         cfg.PasswordMinLength,
+		BreachedPasswords: breachedPasswords,
+	})
+
+	return usecase.NewUserAdminUsecase(
+		repository.NewUserRepository(db),
+		repository.NewTokenRepository(db),
+		repository.NewRefreshTokenRepository(db),
+		&sessionRevoker{a: a},
+		hasher,
+		policy,
+	), nil
+}
+
+func userFields(u handler.UserResponse) table {
+	return fields(
+		"ID", u.ID.String(),
+		"NAME", u.Name,
+		"EMAIL", u.Email,
+		"EMAIL VERIFIED AT", formatTimePtr(u.EmailVerifiedAt),
+		"HAS PASSWORD", formatBool(u.HasPassword),
+		"PASSWORD RESET REQUIRED", formatBool(u.PasswordResetRequired),
+		"DISABLED AT", formatTimePtr(u.DisabledAt),
+		"ADMIN", formatBool(u.IsAdmin),
+		"CREATED AT", formatTime(u.CreatedAt),
+		"UPDATED AT", formatTime(u.UpdatedAt),
+	)
+}
+
+func userCreate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "user create")
+	name := fs.String("name", "", "user name (required)")
+	email := fs.String("email", "", "email address (required)")
+	password := fs.String("password", "", "initial password (omit to create a user without a password)")
+	emailVerified := fs.Bool("email-verified", false, "mark the email address as verified")
+	isAdmin := fs.Bool("admin", false, "allow the user to call the admin API with an admin scoped token")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *name == "" || *email == "" {
+		return errFlagsRequired(fs, "name", "email")
+	}
+
+	uc, err := a.userUsecase()
+	if err != nil {
+		return err
+	}
+	user, err := uc.CreateUser(ctx, usecase.CreateUserParams{
+		Name:          *name,
+		Email:         *email,
+		Password:      *password,
+		EmailVerified: *emailVerified,
+		IsAdmin:       *isAdmin,
+	})
+	if err != nil {
+		return err
+	}
+	res := handler.NewUserResponse(user)
+	return a.out.print(res, userFields(res))
+}
+
+func userList(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "user list")
+	query := fs.String("q", "", "only users whose email address or name contains this text")
+	offset := fs.Int("offset", 0, "number of users to skip")
+	limit := fs.Int("limit", defaultListLimit, "maximum number of users")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	uc, err := a.userUsecase()
+	if err != nil {
+		return err
+	}
+	users, more, err := uc.SearchUsers(ctx, *query, *offset, *limit)
+	if err != nil {
+		return err
+	}
+
+	res := make([]handler.UserResponse, 0, len(users))
+	t := table{header: []string{"ID", "NAME", "EMAIL", "VERIFIED", "HAS PASSWORD", "ADMIN", "DISABLED AT"}}
+	for _, user := range users {
+		u := handler.NewUserResponse(user)
+		res = append(res, u)
+		t.rows = append(t.rows, []string{
+			u.ID.String(), u.Name, u.Email, formatBool(u.EmailVerifiedAt != nil),
+			formatBool(u.HasPassword), formatBool(u.IsAdmin), formatTimePtr(u.DisabledAt),
+		})
+	}
+	body := map[string]any{"users": res}
+	if more {
+		body["next_offset"] = *offset + len(users)
+		a.printNextOffset(*offset + len(users))
+	}
+	return a.out.print(body, t)
+}
+
+func userGet(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "user get")
+	userID, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	uc, err := a.userUsecase()
+	if err != nil {
+		return err
+	}
+	user, err := uc.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	res := handler.NewUserResponse(user)
+	return a.out.print(res, userFields(res))
+}
+
+func userUpdate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "user update")
+	name := fs.String("name", "", "user name")
+	email := fs.String("email", "", "email address")
+	isAdmin := fs.Bool("admin", false, "allow the user to call the admin API (-admin=false to revoke)")
+	userID, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	var p usecase.UpdateUserParams
+	if isFlagSet(fs, "name") {
+		p.Name = name
+	}
+	if isFlagSet(fs, "email") {
+		p.Email = email
+	}
+	if isFlagSet(fs, "admin") {
+		p.IsAdmin = isAdmin
+	}
+
+	uc, err := a.userUsecase()
+	if err != nil {
+		return err
+	}
+	user, err := uc.UpdateUser(ctx, userID, p)
+	if err != nil {
+		return err
+	}
+	res := handler.NewUserResponse(user)
+	return a.out.print(res, userFields(res))
+}
+
+// userDisable はユーザーを無効にし、発行済みのトークンとSSOセッションを失効させる
+func userDisable(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "user disable")
+	userID, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	uc, err := a.userUsecase()
+	if err != nil {
+		return err
+	}
+	if err := uc.DisableUser(ctx, userID); err != nil {
+		return err
+	}
+	return userGet(ctx, a, []string{userID.String()})
+}
+
+func userEnable(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "user enable")
+	userID, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	uc, err := a.userUsecase()
+	if err != nil {
+		return err
+	}
+	if err := uc.EnableUser(ctx, userID); err != nil {
+		return err
+	}
+	return userGet(ctx, a, []string{userID.String()})
+}
+
+func userDelete(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet(a, "user delete")
+	userID, err := parseID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	uc, err := a.userUsecase()
+	if err != nil {
+		return err
+	}
+	if err := uc.DeleteUser(ctx, userID); err != nil {
+		return err
+	}
+	return a.out.print(
+		map[string]string{"user_id": userID.String(), "status": "deleted"},
+		fields("USER ID", userID.String(), "STATUS", "deleted"),
+	)
+}
@@ -3,9 +3,11 @@ package domain
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/str"
 )
 
@@ -29,6 +31,7 @@ type AuthorizationCodeParams[T uuidLike] struct {
 	Scope       string
 	RedirectURI string
 	ExpiresAt   time.Time
+	RevokedAt   *time.Time
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -50,6 +53,7 @@ func NewAuthorizationCode[T uuidLike](p AuthorizationCodeParams[T]) (Authorizati
 		scope:       p.Scope,
 		redirectURI: p.RedirectURI,
 		expiresAt:   p.ExpiresAt,
+		revokedAt:   p.RevokedAt,
 		createdAt:   p.CreatedAt,
 		updatedAt:   p.UpdatedAt,
 	}, nil
@@ -64,8 +68,11 @@ type AuthorizationCode interface {
 	GetScope() string
 	GetRedirectURI() string
 	GetExpiresAt() time.Time
-	GenerateRedirectURIWithCode() string
+	GenerateRedirectURIWithCode(state string) (string, error)
 	IsExpired(t time.Time) bool
+	IsRevoked() bool
+	IsIssuedTo(clientID uuid.UUID) bool
+	IsRedirectURIMatch(redirectURI string) bool
 }
 
 //go:generate go run github.com/matryer/moq -out authorization_code_repository_mock.go . AuthorizationCodeRepository
@@ -73,7 +80,8 @@ type AuthorizationCodeRepository interface {
 	FindAuthorizationCode(ctx context.Context, code string) (AuthorizationCode, error)
 	StoreAuthorizationCode(ctx context.Context, p StoreAuthorizationCodeParams) (string, error)
 	FindValidAuthorizationCode(ctx context.Context, code string, expiresAt time.Time) (AuthorizationCode, error)
-	RevokeCode(ctx context.Context, code string) error
+	// RedeemCode は未使用のコードを使用済みにする。既に使用済みの場合は false を返す
+	RedeemCode(ctx context.Context, code string) (bool, error)
 }
 
 type authorizationCode struct {
@@ -84,6 +92,7 @@ type authorizationCode struct {
 	// Scopes      []string
 	redirectURI string
 	expiresAt   time.Time
+	revokedAt   *time.Time
 	createdAt   time.Time
 	updatedAt   time.Time
 }
@@ -116,14 +125,38 @@ func (a *authorizationCode) GetExpiresAt() time.Time {
 	return a.expiresAt
 }
 
-func (a *authorizationCode) GenerateRedirectURIWithCode() string {
-	return fmt.Sprintf("%s?code=%s", a.redirectURI, a.code)
+// GenerateRedirectURIWithCode は登録されたリダイレクトURIのクエリを残したまま code と state を加える (RFC 6749 4.1.2)
+func (a *authorizationCode) GenerateRedirectURIWithCode(state string) (string, error) {
+	u, err := url.Parse(a.redirectURI)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	q := u.Query()
+	q.Set("code", a.code)
+	if state != "" {
+		q.Set("state", state)
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
 }
 
 func (a *authorizationCode) IsExpired(t time.Time) bool {
 	return t.After(a.expiresAt)
 }
 
+func (a *authorizationCode) IsRevoked() bool {
+	return a.revokedAt != nil
+}
+
+func (a *authorizationCode) IsIssuedTo(clientID uuid.UUID) bool {
+	return a.clientID == clientID
+}
+
+// IsRedirectURIMatch は認可リクエスト時の redirect_uri と完全一致するかを判定する (RFC 6749 4.1.3)
+func (a *authorizationCode) IsRedirectURIMatch(redirectURI string) bool {
+	return a.redirectURI == redirectURI
+}
+
 func GenerateCode() (string, error) {
 	randomStringLen := 32
 	return str.GenerateRandomString(randomStringLen)
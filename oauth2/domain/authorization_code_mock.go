@@ -19,7 +19,7 @@ var _ AuthorizationCode = &AuthorizationCodeMock{}
 //
 //		// make and configure a mocked AuthorizationCode
 //		mockedAuthorizationCode := &AuthorizationCodeMock{
-//			GenerateRedirectURIWithCodeFunc: func() string {
+//			GenerateRedirectURIWithCodeFunc: func(state string) (string, error) {
 //				panic("mock out the GenerateRedirectURIWithCode method")
 //			},
 //			GetClientIDFunc: func() uuid.UUID {
@@ -43,9 +43,18 @@ var _ AuthorizationCode = &AuthorizationCodeMock{}
 //			IsExpiredFunc: func(t time.Time) bool {
 //				panic("mock out the IsExpired method")
 //			},
+//			IsIssuedToFunc: func(clientID uuid.UUID) bool {
+//				panic("mock out the IsIssuedTo method")
+//			},
 //			IsNotFoundFunc: func() bool {
 //				panic("mock out the IsNotFound method")
 //			},
+//			IsRedirectURIMatchFunc: func(redirectURI string) bool {
+//				panic("mock out the IsRedirectURIMatch method")
+//			},
+//			IsRevokedFunc: func() bool {
+//				panic("mock out the IsRevoked method")
+//			},
 //		}
 //
 //		// use mockedAuthorizationCode in code that requires AuthorizationCode
@@ -54,7 +63,7 @@ var _ AuthorizationCode = &AuthorizationCodeMock{}
 //	}
 type AuthorizationCodeMock struct {
 	// GenerateRedirectURIWithCodeFunc mocks the GenerateRedirectURIWithCode method.
-	GenerateRedirectURIWithCodeFunc func() string
+	GenerateRedirectURIWithCodeFunc func(state string) (string, error)
 
 	// GetClientIDFunc mocks the GetClientID method.
 	GetClientIDFunc func() uuid.UUID
@@ -77,13 +86,24 @@ type AuthorizationCodeMock struct {
 	// IsExpiredFunc mocks the IsExpired method.
 	IsExpiredFunc func(t time.Time) bool
 
+	// IsIssuedToFunc mocks the IsIssuedTo method.
+	IsIssuedToFunc func(clientID uuid.UUID) bool
+
 	// IsNotFoundFunc mocks the IsNotFound method.
 	IsNotFoundFunc func() bool
 
+	// IsRedirectURIMatchFunc mocks the IsRedirectURIMatch method.
+	IsRedirectURIMatchFunc func(redirectURI string) bool
+
+	// IsRevokedFunc mocks the IsRevoked method.
+	IsRevokedFunc func() bool
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// GenerateRedirectURIWithCode holds details about calls to the GenerateRedirectURIWithCode method.
 		GenerateRedirectURIWithCode []struct {
+			// State is the state argument value.
+			State string
 		}
 		// GetClientID holds details about calls to the GetClientID method.
 		GetClientID []struct {
@@ -108,9 +128,22 @@ type AuthorizationCodeMock struct {
 			// T is the t argument value.
 			T time.Time
 		}
+		// IsIssuedTo holds details about calls to the IsIssuedTo method.
+		IsIssuedTo []struct {
+			// ClientID is the clientID argument value.
+			ClientID uuid.UUID
+		}
 		// IsNotFound holds details about calls to the IsNotFound method.
 		IsNotFound []struct {
 		}
+		// IsRedirectURIMatch holds details about calls to the IsRedirectURIMatch method.
+		IsRedirectURIMatch []struct {
+			// RedirectURI is the redirectURI argument value.
+			RedirectURI string
+		}
+		// IsRevoked holds details about calls to the IsRevoked method.
+		IsRevoked []struct {
+		}
 	}
 	lockGenerateRedirectURIWithCode sync.RWMutex
 	lockGetClientID                 sync.RWMutex
@@ -120,20 +153,26 @@ type AuthorizationCodeMock struct {
 	lockGetScope                    sync.RWMutex
 	lockGetUserID                   sync.RWMutex
 	lockIsExpired                   sync.RWMutex
+	lockIsIssuedTo                  sync.RWMutex
 	lockIsNotFound                  sync.RWMutex
+	lockIsRedirectURIMatch          sync.RWMutex
+	lockIsRevoked                   sync.RWMutex
 }
 
 // GenerateRedirectURIWithCode calls GenerateRedirectURIWithCodeFunc.
-func (mock *AuthorizationCodeMock) GenerateRedirectURIWithCode() string {
+func (mock *AuthorizationCodeMock) GenerateRedirectURIWithCode(state string) (string, error) {
 	if mock.GenerateRedirectURIWithCodeFunc == nil {
 		panic("AuthorizationCodeMock.GenerateRedirectURIWithCodeFunc: method is nil but AuthorizationCode.GenerateRedirectURIWithCode was just called")
 	}
 	callInfo := struct {
-	}{}
+		State string
+	}{
+		State: state,
+	}
 	mock.lockGenerateRedirectURIWithCode.Lock()
 	mock.calls.GenerateRedirectURIWithCode = append(mock.calls.GenerateRedirectURIWithCode, callInfo)
 	mock.lockGenerateRedirectURIWithCode.Unlock()
-	return mock.GenerateRedirectURIWithCodeFunc()
+	return mock.GenerateRedirectURIWithCodeFunc(state)
 }
 
 // GenerateRedirectURIWithCodeCalls gets all the calls that were made to GenerateRedirectURIWithCode.
@@ -141,8 +180,10 @@ func (mock *AuthorizationCodeMock) GenerateRedirectURIWithCode() string {
 //
 //	len(mockedAuthorizationCode.GenerateRedirectURIWithCodeCalls())
 func (mock *AuthorizationCodeMock) GenerateRedirectURIWithCodeCalls() []struct {
+	State string
 } {
 	var calls []struct {
+		State string
 	}
 	mock.lockGenerateRedirectURIWithCode.RLock()
 	calls = mock.calls.GenerateRedirectURIWithCode
@@ -344,6 +385,38 @@ func (mock *AuthorizationCodeMock) IsExpiredCalls() []struct {
 	return calls
 }
 
+// IsIssuedTo calls IsIssuedToFunc.
+func (mock *AuthorizationCodeMock) IsIssuedTo(clientID uuid.UUID) bool {
+	if mock.IsIssuedToFunc == nil {
+		panic("AuthorizationCodeMock.IsIssuedToFunc: method is nil but AuthorizationCode.IsIssuedTo was just called")
+	}
+	callInfo := struct {
+		ClientID uuid.UUID
+	}{
+		ClientID: clientID,
+	}
+	mock.lockIsIssuedTo.Lock()
+	mock.calls.IsIssuedTo = append(mock.calls.IsIssuedTo, callInfo)
+	mock.lockIsIssuedTo.Unlock()
+	return mock.IsIssuedToFunc(clientID)
+}
+
+// IsIssuedToCalls gets all the calls that were made to IsIssuedTo.
+// Check the length with:
+//
+//	len(mockedAuthorizationCode.IsIssuedToCalls())
+func (mock *AuthorizationCodeMock) IsIssuedToCalls() []struct {
+	ClientID uuid.UUID
+} {
+	var calls []struct {
+		ClientID uuid.UUID
+	}
+	mock.lockIsIssuedTo.RLock()
+	calls = mock.calls.IsIssuedTo
+	mock.lockIsIssuedTo.RUnlock()
+	return calls
+}
+
 // IsNotFound calls IsNotFoundFunc.
 func (mock *AuthorizationCodeMock) IsNotFound() bool {
 	if mock.IsNotFoundFunc == nil {
@@ -370,3 +443,62 @@ func (mock *AuthorizationCodeMock) IsNotFoundCalls() []struct {
 	mock.lockIsNotFound.RUnlock()
 	return calls
 }
+
+// IsRedirectURIMatch calls IsRedirectURIMatchFunc.
+func (mock *AuthorizationCodeMock) IsRedirectURIMatch(redirectURI string) bool {
+	if mock.IsRedirectURIMatchFunc == nil {
+		panic("AuthorizationCodeMock.IsRedirectURIMatchFunc: method is nil but AuthorizationCode.IsRedirectURIMatch was just called")
+	}
+	callInfo := struct {
+		RedirectURI string
+	}{
+		RedirectURI: redirectURI,
+	}
+	mock.lockIsRedirectURIMatch.Lock()
+	mock.calls.IsRedirectURIMatch = append(mock.calls.IsRedirectURIMatch, callInfo)
+	mock.lockIsRedirectURIMatch.Unlock()
+	return mock.IsRedirectURIMatchFunc(redirectURI)
+}
+
+// IsRedirectURIMatchCalls gets all the calls that were made to IsRedirectURIMatch.
+// Check the length with:
+//
+//	len(mockedAuthorizationCode.IsRedirectURIMatchCalls())
+func (mock *AuthorizationCodeMock) IsRedirectURIMatchCalls() []struct {
+	RedirectURI string
+} {
+	var calls []struct {
+		RedirectURI string
+	}
+	mock.lockIsRedirectURIMatch.RLock()
+	calls = mock.calls.IsRedirectURIMatch
+	mock.lockIsRedirectURIMatch.RUnlock()
+	return calls
+}
+
+// IsRevoked calls IsRevokedFunc.
+func (mock *AuthorizationCodeMock) IsRevoked() bool {
+	if mock.IsRevokedFunc == nil {
+		panic("AuthorizationCodeMock.IsRevokedFunc: method is nil but AuthorizationCode.IsRevoked was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsRevoked.Lock()
+	mock.calls.IsRevoked = append(mock.calls.IsRevoked, callInfo)
+	mock.lockIsRevoked.Unlock()
+	return mock.IsRevokedFunc()
+}
+
+// IsRevokedCalls gets all the calls that were made to IsRevoked.
+// Check the length with:
+//
+//	len(mockedAuthorizationCode.IsRevokedCalls())
+func (mock *AuthorizationCodeMock) IsRevokedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsRevoked.RLock()
+	calls = mock.calls.IsRevoked
+	mock.lockIsRevoked.RUnlock()
+	return calls
+}
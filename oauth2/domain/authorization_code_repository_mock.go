@@ -25,8 +25,8 @@ var _ AuthorizationCodeRepository = &AuthorizationCodeRepositoryMock{}
 //			FindValidAuthorizationCodeFunc: func(ctx context.Context, code string, expiresAt time.Time) (AuthorizationCode, error) {
 //				panic("mock out the FindValidAuthorizationCode method")
 //			},
-//			RevokeCodeFunc: func(ctx context.Context, code string) error {
-//				panic("mock out the RevokeCode method")
+//			RedeemCodeFunc: func(ctx context.Context, code string) (bool, error) {
+//				panic("mock out the RedeemCode method")
 //			},
 //			StoreAuthorizationCodeFunc: func(ctx context.Context, p StoreAuthorizationCodeParams) (string, error) {
 //				panic("mock out the StoreAuthorizationCode method")
@@ -44,8 +44,8 @@ type AuthorizationCodeRepositoryMock struct {
 	// FindValidAuthorizationCodeFunc mocks the FindValidAuthorizationCode method.
 	FindValidAuthorizationCodeFunc func(ctx context.Context, code string, expiresAt time.Time) (AuthorizationCode, error)
 
-	// RevokeCodeFunc mocks the RevokeCode method.
-	RevokeCodeFunc func(ctx context.Context, code string) error
+	// RedeemCodeFunc mocks the RedeemCode method.
+	RedeemCodeFunc func(ctx context.Context, code string) (bool, error)
 
 	// StoreAuthorizationCodeFunc mocks the StoreAuthorizationCode method.
 	StoreAuthorizationCodeFunc func(ctx context.Context, p StoreAuthorizationCodeParams) (string, error)
@@ -68,8 +68,8 @@ type AuthorizationCodeRepositoryMock struct {
 			// ExpiresAt is the expiresAt argument value.
 			ExpiresAt time.Time
 		}
-		// RevokeCode holds details about calls to the RevokeCode method.
-		RevokeCode []struct {
+		// RedeemCode holds details about calls to the RedeemCode method.
+		RedeemCode []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Code is the code argument value.
@@ -85,7 +85,7 @@ type AuthorizationCodeRepositoryMock struct {
 	}
 	lockFindAuthorizationCode      sync.RWMutex
 	lockFindValidAuthorizationCode sync.RWMutex
-	lockRevokeCode                 sync.RWMutex
+	lockRedeemCode                 sync.RWMutex
 	lockStoreAuthorizationCode     sync.RWMutex
 }
 
@@ -165,10 +165,10 @@ func (mock *AuthorizationCodeRepositoryMock) FindValidAuthorizationCodeCalls() [
 	return calls
 }
 
-// RevokeCode calls RevokeCodeFunc.
-func (mock *AuthorizationCodeRepositoryMock) RevokeCode(ctx context.Context, code string) error {
-	if mock.RevokeCodeFunc == nil {
-		panic("AuthorizationCodeRepositoryMock.RevokeCodeFunc: method is nil but AuthorizationCodeRepository.RevokeCode was just called")
+// RedeemCode calls RedeemCodeFunc.
+func (mock *AuthorizationCodeRepositoryMock) RedeemCode(ctx context.Context, code string) (bool, error) {
+	if mock.RedeemCodeFunc == nil {
+		panic("AuthorizationCodeRepositoryMock.RedeemCodeFunc: method is nil but AuthorizationCodeRepository.RedeemCode was just called")
 	}
 	callInfo := struct {
 		Ctx  context.Context
@@ -177,17 +177,17 @@ func (mock *AuthorizationCodeRepositoryMock) RevokeCode(ctx context.Context, cod
 		Ctx:  ctx,
 		Code: code,
 	}
-	mock.lockRevokeCode.Lock()
-	mock.calls.RevokeCode = append(mock.calls.RevokeCode, callInfo)
-	mock.lockRevokeCode.Unlock()
-	return mock.RevokeCodeFunc(ctx, code)
+	mock.lockRedeemCode.Lock()
+	mock.calls.RedeemCode = append(mock.calls.RedeemCode, callInfo)
+	mock.lockRedeemCode.Unlock()
+	return mock.RedeemCodeFunc(ctx, code)
 }
 
-// RevokeCodeCalls gets all the calls that were made to RevokeCode.
+// RedeemCodeCalls gets all the calls that were made to RedeemCode.
 // Check the length with:
 //
-//	len(mockedAuthorizationCodeRepository.RevokeCodeCalls())
-func (mock *AuthorizationCodeRepositoryMock) RevokeCodeCalls() []struct {
+//	len(mockedAuthorizationCodeRepository.RedeemCodeCalls())
+func (mock *AuthorizationCodeRepositoryMock) RedeemCodeCalls() []struct {
 	Ctx  context.Context
 	Code string
 } {
@@ -195,9 +195,9 @@ func (mock *AuthorizationCodeRepositoryMock) RevokeCodeCalls() []struct {
 		Ctx  context.Context
 		Code string
 	}
-	mock.lockRevokeCode.RLock()
-	calls = mock.calls.RevokeCode
-	mock.lockRevokeCode.RUnlock()
+	mock.lockRedeemCode.RLock()
+	calls = mock.calls.RedeemCode
+	mock.lockRedeemCode.RUnlock()
 	return calls
 }
 
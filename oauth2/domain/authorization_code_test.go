@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateRedirectURIWithCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		redirectURI string
+		state       string
+		want        string
+	}{
+		{name: "code and state", redirectURI: "https://example.com/callback", state: "xyz", want: "https://example.com/callback?code=abc&state=xyz"},
+		{name: "without state", redirectURI: "https://example.com/callback", want: "https://example.com/callback?code=abc"},
+		// 登録されたURIのクエリは残す
+		{name: "registered query", redirectURI: "https://example.com/callback?lang=ja", state: "xyz", want: "https://example.com/callback?code=abc&lang=ja&state=xyz"},
+		{name: "escaped state", redirectURI: "com.example.app:/oauth2redirect", state: "a b&c", want: "com.example.app:/oauth2redirect?code=abc&state=a+b%26c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := NewAuthorizationCode(AuthorizationCodeParams[uuid.UUID]{
+				Code:        "abc",
+				ClientID:    uuid.New(),
+				UserID:      uuid.New(),
+				RedirectURI: tt.redirectURI,
+			})
+			require.NoError(t, err)
+
+			got, err := code.GenerateRedirectURIWithCode(tt.state)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
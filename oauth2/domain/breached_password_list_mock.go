@@ -0,0 +1,74 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"sync"
+)
+
+// Ensure, that BreachedPasswordListMock does implement BreachedPasswordList.
+// If this is not the case, regenerate this file with moq.
+var _ BreachedPasswordList = &BreachedPasswordListMock{}
+
+// BreachedPasswordListMock is a mock implementation of BreachedPasswordList.
+//
+//	func TestSomethingThatUsesBreachedPasswordList(t *testing.T) {
+//
+//		// make and configure a mocked BreachedPasswordList
+//		mockedBreachedPasswordList := &BreachedPasswordListMock{
+//			ContainsFunc: func(password string) (bool, error) {
+//				panic("mock out the Contains method")
+//			},
+//		}
+//
+//		// use mockedBreachedPasswordList in code that requires BreachedPasswordList
+//		// and then make assertions.
+//
+//	}
+type BreachedPasswordListMock struct {
+	// ContainsFunc mocks the Contains method.
+	ContainsFunc func(password string) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Contains holds details about calls to the Contains method.
+		Contains []struct {
+			// Password is the password argument value.
+			Password string
+		}
+	}
+	lockContains sync.RWMutex
+}
+
+// Contains calls ContainsFunc.
+func (mock *BreachedPasswordListMock) Contains(password string) (bool, error) {
+	if mock.ContainsFunc == nil {
+		panic("BreachedPasswordListMock.ContainsFunc: method is nil but BreachedPasswordList.Contains was just called")
+	}
+	callInfo := struct {
+		Password string
+	}{
+		Password: password,
+	}
+	mock.lockContains.Lock()
+	mock.calls.Contains = append(mock.calls.Contains, callInfo)
+	mock.lockContains.Unlock()
+	return mock.ContainsFunc(password)
+}
+
+// ContainsCalls gets all the calls that were made to Contains.
+// Check the length with:
+//
+//	len(mockedBreachedPasswordList.ContainsCalls())
+func (mock *BreachedPasswordListMock) ContainsCalls() []struct {
+	Password string
+} {
+	var calls []struct {
+		Password string
+	}
+	mock.lockContains.RLock()
+	calls = mock.calls.Contains
+	mock.lockContains.RUnlock()
+	return calls
+}
@@ -2,53 +2,337 @@ package domain
 
 import (
 	"context"
+	"crypto/subtle"
 	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
 )
 
+// クライアントが使える grant_type
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+)
+
+const (
+	maxClientNameLen = 255
+	// MaxAccessTokenExpiresMin と MaxRefreshTokenExpiresDay はクライアントごとに指定できる有効期間の上限
+	MaxAccessTokenExpiresMin  = 24 * 60
+	MaxRefreshTokenExpiresDay = 365
+)
+
+var (
+	ErrClientNameRequired         = errors.New("client name is required")
+	ErrClientNameTooLong          = errors.New("client name is too long")
+	ErrClientGrantTypeUnsupported = errors.New("grant type is not supported")
+	ErrClientGrantTypeRequired    = errors.New("grant types must include authorization_code")
+	ErrClientRedirectURIRequired  = errors.New("at least one redirect uri is required")
+	ErrClientTokenLifetimeInvalid = errors.New("token lifetime is out of range")
+)
+
+// DefaultGrantTypes は grant_type を指定せずに登録したクライアントが使える grant_type
+var DefaultGrantTypes = []string{GrantTypeAuthorizationCode, GrantTypeRefreshToken}
+
 type ClientParams struct {
-	ID           uuid.UUID
-	Name         string
-	RedirectURIs []string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID            uuid.UUID
+	Name          string
+	RedirectURIs  []string
+	AllowedScopes []string
+	// GrantTypes が空の場合は DefaultGrantTypes
+	GrantTypes []string
+	FirstParty bool
+	// SecretHash が空のクライアントは公開クライアントとして扱い、シークレットを確認しない
+	SecretHash string
+	// AccessTokenExpiresMin と RefreshTokenExpiresDay が 0 の場合は設定値を使う
+	AccessTokenExpiresMin  int
+	RefreshTokenExpiresDay int
+	DisabledAt             *time.Time
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
 }
 
 func NewClient(p ClientParams) Client {
+	// 登録済みの URI は登録時に NewRedirectURI で正規化済み
+	redirectURIs := make([]RedirectURI, 0, len(p.RedirectURIs))
+	for _, u := range p.RedirectURIs {
+		redirectURIs = append(redirectURIs, RedirectURI(u))
+	}
+	grantTypes := p.GrantTypes
+	if len(grantTypes) == 0 {
+		grantTypes = DefaultGrantTypes
+	}
 	return &client{
-		ID:           p.ID,
-		Name:         p.Name,
-		RedirectURIs: p.RedirectURIs,
-		CreatedAt:    p.CreatedAt,
-		UpdatedAt:    p.UpdatedAt,
+		ID:                     p.ID,
+		Name:                   p.Name,
+		RedirectURIs:           redirectURIs,
+		AllowedScopes:          NewScopeSet(p.AllowedScopes...),
+		GrantTypes:             slices.Clone(grantTypes),
+		FirstParty:             p.FirstParty,
+		SecretHash:             p.SecretHash,
+		AccessTokenExpiresMin:  p.AccessTokenExpiresMin,
+		RefreshTokenExpiresDay: p.RefreshTokenExpiresDay,
+		DisabledAt:             p.DisabledAt,
+		CreatedAt:              p.CreatedAt,
+		UpdatedAt:              p.UpdatedAt,
+	}
+}
+
+// RegisterClient は新しいクライアントを作る。confidential が true の場合はシークレットを発行し、
+// 戻り値で一度だけ返す。保存されるのはそのハッシュのみ
+func RegisterClient(p ClientParams, confidential bool) (Client, string, error) {
+	now := time.Now()
+	p.ID = uuid.New()
+	p.SecretHash = ""
+	p.DisabledAt = nil
+	p.CreatedAt = now
+	p.UpdatedAt = now
+	c := NewClient(p)
+	if err := c.SetRedirectURIs(p.RedirectURIs); err != nil {
+		return nil, "", err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, "", err
+	}
+	if !confidential {
+		return c, "", nil
+	}
+	secret, err := c.RotateSecret()
+	if err != nil {
+		return nil, "", err
 	}
+	return c, secret, nil
 }
 
 //go:generate go run github.com/matryer/moq -out client_mock.go . Client
 type Client interface {
 	IsNotFound() bool
+	GetID() uuid.UUID
+	GetName() string
+	GetRedirectURIs() []RedirectURI
 	IsRedirectURIMatch(redirectURI string) bool
+	GetAllowedScopes() ScopeSet
+	IsScopeAllowed(scopes ScopeSet) bool
+	GetGrantTypes() []string
+	IsGrantTypeAllowed(grantType string) bool
+	IsFirstParty() bool
+	// IsConfidential はシークレットを持つクライアントかを判定する
+	IsConfidential() bool
+	GetSecretHash() string
+	// VerifySecret はトークンエンドポイントで提示されたシークレットを確認する。公開クライアントは常に true
+	VerifySecret(secret string) bool
+	GetAccessTokenExpiresMin() int
+	GetRefreshTokenExpiresDay() int
+	IsDisabled() bool
+	GetDisabledAt() *time.Time
+	GetCreatedAt() time.Time
+	GetUpdatedAt() time.Time
+
+	SetName(name string)
+	// SetRedirectURIs は URI を検証して正規化した値に置き換える
+	SetRedirectURIs(uris []string) error
+	SetAllowedScopes(scopes []string)
+	SetGrantTypes(grantTypes []string) error
+	SetTokenLifetimes(accessTokenExpiresMin, refreshTokenExpiresDay int) error
+	// RotateSecret は新しいシークレットを発行して返す。以前のシークレットは使えなくなる
+	RotateSecret() (string, error)
+	Disable(now time.Time)
+	// Validate は項目をまたいだ整合性を確認する。変更した後、保存する前に呼ぶ
+	Validate() error
 }
 
 //go:generate go run github.com/matryer/moq -out client_repository_mock.go . ClientRepository
 type ClientRepository interface {
 	FindClientByClientID(ctx context.Context, clientID uuid.UUID) (Client, error)
+	// FindClients は作成順に offset 件目から limit 件を返す
+	FindClients(ctx context.Context, offset, limit int) ([]Client, error)
+	AddRedirectURI(ctx context.Context, clientID uuid.UUID, redirectURI RedirectURI) error
+	CreateClient(ctx context.Context, c Client) error
+	// UpdateClient はリダイレクトURIと許可するスコープも含めて置き換える
+	UpdateClient(ctx context.Context, c Client) error
+	// DeleteClient はクライアントが発行した認可コードとトークンもまとめて削除する
+	DeleteClient(ctx context.Context, clientID uuid.UUID) error
 }
 
 type client struct {
-	ID           uuid.UUID
-	Name         string
-	RedirectURIs []string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID                     uuid.UUID
+	Name                   string
+	RedirectURIs           []RedirectURI
+	AllowedScopes          ScopeSet
+	GrantTypes             []string
+	FirstParty             bool
+	SecretHash             string
+	AccessTokenExpiresMin  int
+	RefreshTokenExpiresDay int
+	DisabledAt             *time.Time
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
 }
 
 func (c *client) IsNotFound() bool {
 	return c.ID == uuid.Nil
 }
 
+func (c *client) GetID() uuid.UUID {
+	return c.ID
+}
+
+func (c *client) GetName() string {
+	return c.Name
+}
+
+func (c *client) GetRedirectURIs() []RedirectURI {
+	return c.RedirectURIs
+}
+
 func (c *client) IsRedirectURIMatch(redirectURI string) bool {
-	return slices.Contains(c.RedirectURIs, redirectURI)
+	for _, u := range c.RedirectURIs {
+		if u.Match(redirectURI) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *client) GetAllowedScopes() ScopeSet {
+	return c.AllowedScopes
+}
+
+func (c *client) IsScopeAllowed(scopes ScopeSet) bool {
+	return scopes.IsSubsetOf(c.AllowedScopes)
+}
+
+func (c *client) GetGrantTypes() []string {
+	return c.GrantTypes
+}
+
+func (c *client) IsGrantTypeAllowed(grantType string) bool {
+	return slices.Contains(c.GrantTypes, grantType)
+}
+
+// IsFirstParty は自社のクライアントかを判定する。自社のクライアントには同意画面を表示しない
+func (c *client) IsFirstParty() bool {
+	return c.FirstParty
+}
+
+func (c *client) IsConfidential() bool {
+	return c.SecretHash != ""
+}
+
+func (c *client) GetSecretHash() string {
+	return c.SecretHash
+}
+
+func (c *client) VerifySecret(secret string) bool {
+	if !c.IsConfidential() {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(HashOpaqueToken(secret)), []byte(c.SecretHash)) == 1
+}
+
+func (c *client) GetAccessTokenExpiresMin() int {
+	return c.AccessTokenExpiresMin
+}
+
+func (c *client) GetRefreshTokenExpiresDay() int {
+	return c.RefreshTokenExpiresDay
+}
+
+func (c *client) IsDisabled() bool {
+	return c.DisabledAt != nil
+}
+
+func (c *client) GetDisabledAt() *time.Time {
+	return c.DisabledAt
+}
+
+func (c *client) GetCreatedAt() time.Time {
+	return c.CreatedAt
+}
+
+func (c *client) GetUpdatedAt() time.Time {
+	return c.UpdatedAt
+}
+
+func (c *client) SetName(name string) {
+	c.Name = name
+}
+
+func (c *client) SetRedirectURIs(uris []string) error {
+	redirectURIs := make([]RedirectURI, 0, len(uris))
+	for _, raw := range uris {
+		u, err := NewRedirectURI(raw)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(redirectURIs, u) {
+			redirectURIs = append(redirectURIs, u)
+		}
+	}
+	c.RedirectURIs = redirectURIs
+	return nil
+}
+
+func (c *client) SetAllowedScopes(scopes []string) {
+	c.AllowedScopes = NewScopeSet(scopes...)
+}
+
+func (c *client) SetGrantTypes(grantTypes []string) error {
+	normalized := make([]string, 0, len(grantTypes))
+	for _, gt := range grantTypes {
+		if gt != GrantTypeAuthorizationCode && gt != GrantTypeRefreshToken {
+			return ErrClientGrantTypeUnsupported
+		}
+		if !slices.Contains(normalized, gt) {
+			normalized = append(normalized, gt)
+		}
+	}
+	c.GrantTypes = normalized
+	return nil
+}
+
+func (c *client) SetTokenLifetimes(accessTokenExpiresMin, refreshTokenExpiresDay int) error {
+	if accessTokenExpiresMin < 0 || accessTokenExpiresMin > MaxAccessTokenExpiresMin ||
+		refreshTokenExpiresDay < 0 || refreshTokenExpiresDay > MaxRefreshTokenExpiresDay {
+		return ErrClientTokenLifetimeInvalid
+	}
+	c.AccessTokenExpiresMin = accessTokenExpiresMin
+	c.RefreshTokenExpiresDay = refreshTokenExpiresDay
+	return nil
+}
+
+func (c *client) RotateSecret() (string, error) {
+	secret, hash, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	c.SecretHash = hash
+	return secret, nil
+}
+
+func (c *client) Disable(now time.Time) {
+	if c.DisabledAt == nil {
+		c.DisabledAt = &now
+	}
+}
+
+func (c *client) Validate() error {
+	if c.Name == "" {
+		return ErrClientNameRequired
+	}
+	if len(c.Name) > maxClientNameLen {
+		return ErrClientNameTooLong
+	}
+	if err := c.SetGrantTypes(c.GrantTypes); err != nil {
+		return err
+	}
+	// 発行できるトークンは全て認可コードから始まる
+	if !c.IsGrantTypeAllowed(GrantTypeAuthorizationCode) {
+		return ErrClientGrantTypeRequired
+	}
+	if len(c.RedirectURIs) == 0 {
+		return ErrClientRedirectURIRequired
+	}
+	return c.SetTokenLifetimes(c.AccessTokenExpiresMin, c.RefreshTokenExpiresDay)
 }
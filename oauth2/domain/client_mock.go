@@ -4,7 +4,9 @@
 package domain
 
 import (
+	"github.com/google/uuid"
 	"sync"
+	"time"
 )
 
 // Ensure, that ClientMock does implement Client.
@@ -17,12 +19,87 @@ var _ Client = &ClientMock{}
 //
 //		// make and configure a mocked Client
 //		mockedClient := &ClientMock{
+//			DisableFunc: func(now time.Time)  {
+//				panic("mock out the Disable method")
+//			},
+//			GetAccessTokenExpiresMinFunc: func() int {
+//				panic("mock out the GetAccessTokenExpiresMin method")
+//			},
+//			GetAllowedScopesFunc: func() ScopeSet {
+//				panic("mock out the GetAllowedScopes method")
+//			},
+//			GetCreatedAtFunc: func() time.Time {
+//				panic("mock out the GetCreatedAt method")
+//			},
+//			GetDisabledAtFunc: func() *time.Time {
+//				panic("mock out the GetDisabledAt method")
+//			},
+//			GetGrantTypesFunc: func() []string {
+//				panic("mock out the GetGrantTypes method")
+//			},
+//			GetIDFunc: func() uuid.UUID {
+//				panic("mock out the GetID method")
+//			},
+//			GetNameFunc: func() string {
+//				panic("mock out the GetName method")
+//			},
+//			GetRedirectURIsFunc: func() []RedirectURI {
+//				panic("mock out the GetRedirectURIs method")
+//			},
+//			GetRefreshTokenExpiresDayFunc: func() int {
+//				panic("mock out the GetRefreshTokenExpiresDay method")
+//			},
+//			GetSecretHashFunc: func() string {
+//				panic("mock out the GetSecretHash method")
+//			},
+//			GetUpdatedAtFunc: func() time.Time {
+//				panic("mock out the GetUpdatedAt method")
+//			},
+//			IsConfidentialFunc: func() bool {
+//				panic("mock out the IsConfidential method")
+//			},
+//			IsDisabledFunc: func() bool {
+//				panic("mock out the IsDisabled method")
+//			},
+//			IsFirstPartyFunc: func() bool {
+//				panic("mock out the IsFirstParty method")
+//			},
+//			IsGrantTypeAllowedFunc: func(grantType string) bool {
+//				panic("mock out the IsGrantTypeAllowed method")
+//			},
 //			IsNotFoundFunc: func() bool {
 //				panic("mock out the IsNotFound method")
 //			},
 //			IsRedirectURIMatchFunc: func(redirectURI string) bool {
 //				panic("mock out the IsRedirectURIMatch method")
 //			},
+//			IsScopeAllowedFunc: func(scopes ScopeSet) bool {
+//				panic("mock out the IsScopeAllowed method")
+//			},
+//			RotateSecretFunc: func() (string, error) {
+//				panic("mock out the RotateSecret method")
+//			},
+//			SetAllowedScopesFunc: func(scopes []string)  {
+//				panic("mock out the SetAllowedScopes method")
+//			},
+//			SetGrantTypesFunc: func(grantTypes []string) error {
+//				panic("mock out the SetGrantTypes method")
+//			},
+//			SetNameFunc: func(name string)  {
+//				panic("mock out the SetName method")
+//			},
+//			SetRedirectURIsFunc: func(uris []string) error {
+//				panic("mock out the SetRedirectURIs method")
+//			},
+//			SetTokenLifetimesFunc: func(accessTokenExpiresMin int, refreshTokenExpiresDay int) error {
+//				panic("mock out the SetTokenLifetimes method")
+//			},
+//			ValidateFunc: func() error {
+//				panic("mock out the Validate method")
+//			},
+//			VerifySecretFunc: func(secret string) bool {
+//				panic("mock out the VerifySecret method")
+//			},
 //		}
 //
 //		// use mockedClient in code that requires Client
@@ -30,14 +107,141 @@ var _ Client = &ClientMock{}
 //
 //	}
 type ClientMock struct {
+	// DisableFunc mocks the Disable method.
+	DisableFunc func(now time.Time)
+
+	// GetAccessTokenExpiresMinFunc mocks the GetAccessTokenExpiresMin method.
+	GetAccessTokenExpiresMinFunc func() int
+
+	// GetAllowedScopesFunc mocks the GetAllowedScopes method.
+	GetAllowedScopesFunc func() ScopeSet
+
+	// GetCreatedAtFunc mocks the GetCreatedAt method.
+	GetCreatedAtFunc func() time.Time
+
+	// GetDisabledAtFunc mocks the GetDisabledAt method.
+	GetDisabledAtFunc func() *time.Time
+
+	// GetGrantTypesFunc mocks the GetGrantTypes method.
+	GetGrantTypesFunc func() []string
+
+	// GetIDFunc mocks the GetID method.
+	GetIDFunc func() uuid.UUID
+
+	// GetNameFunc mocks the GetName method.
+	GetNameFunc func() string
+
+	// GetRedirectURIsFunc mocks the GetRedirectURIs method.
+	GetRedirectURIsFunc func() []RedirectURI
+
+	// GetRefreshTokenExpiresDayFunc mocks the GetRefreshTokenExpiresDay method.
+	GetRefreshTokenExpiresDayFunc func() int
+
+	// GetSecretHashFunc mocks the GetSecretHash method.
+	GetSecretHashFunc func() string
+
+	// GetUpdatedAtFunc mocks the GetUpdatedAt method.
+	GetUpdatedAtFunc func() time.Time
+
+	// IsConfidentialFunc mocks the IsConfidential method.
+	IsConfidentialFunc func() bool
+
+	// IsDisabledFunc mocks the IsDisabled method.
+	IsDisabledFunc func() bool
+
+	// IsFirstPartyFunc mocks the IsFirstParty method.
+	IsFirstPartyFunc func() bool
+
+	// IsGrantTypeAllowedFunc mocks the IsGrantTypeAllowed method.
+	IsGrantTypeAllowedFunc func(grantType string) bool
+
 	// IsNotFoundFunc mocks the IsNotFound method.
 	IsNotFoundFunc func() bool
 
 	// IsRedirectURIMatchFunc mocks the IsRedirectURIMatch method.
 	IsRedirectURIMatchFunc func(redirectURI string) bool
 
+	// IsScopeAllowedFunc mocks the IsScopeAllowed method.
+	IsScopeAllowedFunc func(scopes ScopeSet) bool
+
+	// RotateSecretFunc mocks the RotateSecret method.
+	RotateSecretFunc func() (string, error)
+
+	// SetAllowedScopesFunc mocks the SetAllowedScopes method.
+	SetAllowedScopesFunc func(scopes []string)
+
+	// SetGrantTypesFunc mocks the SetGrantTypes method.
+	SetGrantTypesFunc func(grantTypes []string) error
+
+	// SetNameFunc mocks the SetName method.
+	SetNameFunc func(name string)
+
+	// SetRedirectURIsFunc mocks the SetRedirectURIs method.
+	SetRedirectURIsFunc func(uris []string) error
+
+	// SetTokenLifetimesFunc mocks the SetTokenLifetimes method.
+	SetTokenLifetimesFunc func(accessTokenExpiresMin int, refreshTokenExpiresDay int) error
+
+	// ValidateFunc mocks the Validate method.
+	ValidateFunc func() error
+
+	// VerifySecretFunc mocks the VerifySecret method.
+	VerifySecretFunc func(secret string) bool
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// Disable holds details about calls to the Disable method.
+		Disable []struct {
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// GetAccessTokenExpiresMin holds details about calls to the GetAccessTokenExpiresMin method.
+		GetAccessTokenExpiresMin []struct {
+		}
+		// GetAllowedScopes holds details about calls to the GetAllowedScopes method.
+		GetAllowedScopes []struct {
+		}
+		// GetCreatedAt holds details about calls to the GetCreatedAt method.
+		GetCreatedAt []struct {
+		}
+		// GetDisabledAt holds details about calls to the GetDisabledAt method.
+		GetDisabledAt []struct {
+		}
+		// GetGrantTypes holds details about calls to the GetGrantTypes method.
+		GetGrantTypes []struct {
+		}
+		// GetID holds details about calls to the GetID method.
+		GetID []struct {
+		}
+		// GetName holds details about calls to the GetName method.
+		GetName []struct {
+		}
+		// GetRedirectURIs holds details about calls to the GetRedirectURIs method.
+		GetRedirectURIs []struct {
+		}
+		// GetRefreshTokenExpiresDay holds details about calls to the GetRefreshTokenExpiresDay method.
+		GetRefreshTokenExpiresDay []struct {
+		}
+		// GetSecretHash holds details about calls to the GetSecretHash method.
+		GetSecretHash []struct {
+		}
+		// GetUpdatedAt holds details about calls to the GetUpdatedAt method.
+		GetUpdatedAt []struct {
+		}
+		// IsConfidential holds details about calls to the IsConfidential method.
+		IsConfidential []struct {
+		}
+		// IsDisabled holds details about calls to the IsDisabled method.
+		IsDisabled []struct {
+		}
+		// IsFirstParty holds details about calls to the IsFirstParty method.
+		IsFirstParty []struct {
+		}
+		// IsGrantTypeAllowed holds details about calls to the IsGrantTypeAllowed method.
+		IsGrantTypeAllowed []struct {
+			// GrantType is the grantType argument value.
+			GrantType string
+		}
 		// IsNotFound holds details about calls to the IsNotFound method.
 		IsNotFound []struct {
 		}
@@ -46,9 +250,519 @@ type ClientMock struct {
 			// RedirectURI is the redirectURI argument value.
 			RedirectURI string
 		}
+		// IsScopeAllowed holds details about calls to the IsScopeAllowed method.
+		IsScopeAllowed []struct {
+			// Scopes is the scopes argument value.
+			Scopes ScopeSet
+		}
+		// RotateSecret holds details about calls to the RotateSecret method.
+		RotateSecret []struct {
+		}
+		// SetAllowedScopes holds details about calls to the SetAllowedScopes method.
+		SetAllowedScopes []struct {
+			// Scopes is the scopes argument value.
+			Scopes []string
+		}
+		// SetGrantTypes holds details about calls to the SetGrantTypes method.
+		SetGrantTypes []struct {
+			// GrantTypes is the grantTypes argument value.
+			GrantTypes []string
+		}
+		// SetName holds details about calls to the SetName method.
+		SetName []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// SetRedirectURIs holds details about calls to the SetRedirectURIs method.
+		SetRedirectURIs []struct {
+			// Uris is the uris argument value.
+			Uris []string
+		}
+		// SetTokenLifetimes holds details about calls to the SetTokenLifetimes method.
+		SetTokenLifetimes []struct {
+			// AccessTokenExpiresMin is the accessTokenExpiresMin argument value.
+			AccessTokenExpiresMin int
+			// RefreshTokenExpiresDay is the refreshTokenExpiresDay argument value.
+			RefreshTokenExpiresDay int
+		}
+		// Validate holds details about calls to the Validate method.
+		Validate []struct {
+		}
+		// VerifySecret holds details about calls to the VerifySecret method.
+		VerifySecret []struct {
+			// Secret is the secret argument value.
+			Secret string
+		}
 	}
-	lockIsNotFound         sync.RWMutex
-	lockIsRedirectURIMatch sync.RWMutex
+	lockDisable                   sync.RWMutex
+	lockGetAccessTokenExpiresMin  sync.RWMutex
+	lockGetAllowedScopes          sync.RWMutex
+	lockGetCreatedAt              sync.RWMutex
+	lockGetDisabledAt             sync.RWMutex
+	lockGetGrantTypes             sync.RWMutex
+	lockGetID                     sync.RWMutex
+	lockGetName                   sync.RWMutex
+	lockGetRedirectURIs           sync.RWMutex
+	lockGetRefreshTokenExpiresDay sync.RWMutex
+	lockGetSecretHash             sync.RWMutex
+	lockGetUpdatedAt              sync.RWMutex
+	lockIsConfidential            sync.RWMutex
+	lockIsDisabled                sync.RWMutex
+	lockIsFirstParty              sync.RWMutex
+	lockIsGrantTypeAllowed        sync.RWMutex
+	lockIsNotFound                sync.RWMutex
+	lockIsRedirectURIMatch        sync.RWMutex
+	lockIsScopeAllowed            sync.RWMutex
+	lockRotateSecret              sync.RWMutex
+	lockSetAllowedScopes          sync.RWMutex
+	lockSetGrantTypes             sync.RWMutex
+	lockSetName                   sync.RWMutex
+	lockSetRedirectURIs           sync.RWMutex
+	lockSetTokenLifetimes         sync.RWMutex
+	lockValidate                  sync.RWMutex
+	lockVerifySecret              sync.RWMutex
+}
+
+// Disable calls DisableFunc.
+func (mock *ClientMock) Disable(now time.Time) {
+	if mock.DisableFunc == nil {
+		panic("ClientMock.DisableFunc: method is nil but Client.Disable was just called")
+	}
+	callInfo := struct {
+		Now time.Time
+	}{
+		Now: now,
+	}
+	mock.lockDisable.Lock()
+	mock.calls.Disable = append(mock.calls.Disable, callInfo)
+	mock.lockDisable.Unlock()
+	mock.DisableFunc(now)
+}
+
+// DisableCalls gets all the calls that were made to Disable.
+// Check the length with:
+//
+//	len(mockedClient.DisableCalls())
+func (mock *ClientMock) DisableCalls() []struct {
+	Now time.Time
+} {
+	var calls []struct {
+		Now time.Time
+	}
+	mock.lockDisable.RLock()
+	calls = mock.calls.Disable
+	mock.lockDisable.RUnlock()
+	return calls
+}
+
+// GetAccessTokenExpiresMin calls GetAccessTokenExpiresMinFunc.
+func (mock *ClientMock) GetAccessTokenExpiresMin() int {
+	if mock.GetAccessTokenExpiresMinFunc == nil {
+		panic("ClientMock.GetAccessTokenExpiresMinFunc: method is nil but Client.GetAccessTokenExpiresMin was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetAccessTokenExpiresMin.Lock()
+	mock.calls.GetAccessTokenExpiresMin = append(mock.calls.GetAccessTokenExpiresMin, callInfo)
+	mock.lockGetAccessTokenExpiresMin.Unlock()
+	return mock.GetAccessTokenExpiresMinFunc()
+}
+
+// GetAccessTokenExpiresMinCalls gets all the calls that were made to GetAccessTokenExpiresMin.
+// Check the length with:
+//
+//	len(mockedClient.GetAccessTokenExpiresMinCalls())
+func (mock *ClientMock) GetAccessTokenExpiresMinCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetAccessTokenExpiresMin.RLock()
+	calls = mock.calls.GetAccessTokenExpiresMin
+	mock.lockGetAccessTokenExpiresMin.RUnlock()
+	return calls
+}
+
+// GetAllowedScopes calls GetAllowedScopesFunc.
+func (mock *ClientMock) GetAllowedScopes() ScopeSet {
+	if mock.GetAllowedScopesFunc == nil {
+		panic("ClientMock.GetAllowedScopesFunc: method is nil but Client.GetAllowedScopes was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetAllowedScopes.Lock()
+	mock.calls.GetAllowedScopes = append(mock.calls.GetAllowedScopes, callInfo)
+	mock.lockGetAllowedScopes.Unlock()
+	return mock.GetAllowedScopesFunc()
+}
+
+// GetAllowedScopesCalls gets all the calls that were made to GetAllowedScopes.
+// Check the length with:
+//
+//	len(mockedClient.GetAllowedScopesCalls())
+func (mock *ClientMock) GetAllowedScopesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetAllowedScopes.RLock()
+	calls = mock.calls.GetAllowedScopes
+	mock.lockGetAllowedScopes.RUnlock()
+	return calls
+}
+
+// GetCreatedAt calls GetCreatedAtFunc.
+func (mock *ClientMock) GetCreatedAt() time.Time {
+	if mock.GetCreatedAtFunc == nil {
+		panic("ClientMock.GetCreatedAtFunc: method is nil but Client.GetCreatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCreatedAt.Lock()
+	mock.calls.GetCreatedAt = append(mock.calls.GetCreatedAt, callInfo)
+	mock.lockGetCreatedAt.Unlock()
+	return mock.GetCreatedAtFunc()
+}
+
+// GetCreatedAtCalls gets all the calls that were made to GetCreatedAt.
+// Check the length with:
+//
+//	len(mockedClient.GetCreatedAtCalls())
+func (mock *ClientMock) GetCreatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCreatedAt.RLock()
+	calls = mock.calls.GetCreatedAt
+	mock.lockGetCreatedAt.RUnlock()
+	return calls
+}
+
+// GetDisabledAt calls GetDisabledAtFunc.
+func (mock *ClientMock) GetDisabledAt() *time.Time {
+	if mock.GetDisabledAtFunc == nil {
+		panic("ClientMock.GetDisabledAtFunc: method is nil but Client.GetDisabledAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetDisabledAt.Lock()
+	mock.calls.GetDisabledAt = append(mock.calls.GetDisabledAt, callInfo)
+	mock.lockGetDisabledAt.Unlock()
+	return mock.GetDisabledAtFunc()
+}
+
+// GetDisabledAtCalls gets all the calls that were made to GetDisabledAt.
+// Check the length with:
+//
+//	len(mockedClient.GetDisabledAtCalls())
+func (mock *ClientMock) GetDisabledAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetDisabledAt.RLock()
+	calls = mock.calls.GetDisabledAt
+	mock.lockGetDisabledAt.RUnlock()
+	return calls
+}
+
+// GetGrantTypes calls GetGrantTypesFunc.
+func (mock *ClientMock) GetGrantTypes() []string {
+	if mock.GetGrantTypesFunc == nil {
+		panic("ClientMock.GetGrantTypesFunc: method is nil but Client.GetGrantTypes was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetGrantTypes.Lock()
+	mock.calls.GetGrantTypes = append(mock.calls.GetGrantTypes, callInfo)
+	mock.lockGetGrantTypes.Unlock()
+	return mock.GetGrantTypesFunc()
+}
+
+// GetGrantTypesCalls gets all the calls that were made to GetGrantTypes.
+// Check the length with:
+//
+//	len(mockedClient.GetGrantTypesCalls())
+func (mock *ClientMock) GetGrantTypesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetGrantTypes.RLock()
+	calls = mock.calls.GetGrantTypes
+	mock.lockGetGrantTypes.RUnlock()
+	return calls
+}
+
+// GetID calls GetIDFunc.
+func (mock *ClientMock) GetID() uuid.UUID {
+	if mock.GetIDFunc == nil {
+		panic("ClientMock.GetIDFunc: method is nil but Client.GetID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetID.Lock()
+	mock.calls.GetID = append(mock.calls.GetID, callInfo)
+	mock.lockGetID.Unlock()
+	return mock.GetIDFunc()
+}
+
+// GetIDCalls gets all the calls that were made to GetID.
+// Check the length with:
+//
+//	len(mockedClient.GetIDCalls())
+func (mock *ClientMock) GetIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetID.RLock()
+	calls = mock.calls.GetID
+	mock.lockGetID.RUnlock()
+	return calls
+}
+
+// GetName calls GetNameFunc.
+func (mock *ClientMock) GetName() string {
+	if mock.GetNameFunc == nil {
+		panic("ClientMock.GetNameFunc: method is nil but Client.GetName was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetName.Lock()
+	mock.calls.GetName = append(mock.calls.GetName, callInfo)
+	mock.lockGetName.Unlock()
+	return mock.GetNameFunc()
+}
+
+// GetNameCalls gets all the calls that were made to GetName.
+// Check the length with:
+//
+//	len(mockedClient.GetNameCalls())
+func (mock *ClientMock) GetNameCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetName.RLock()
+	calls = mock.calls.GetName
+	mock.lockGetName.RUnlock()
+	return calls
+}
+
+// GetRedirectURIs calls GetRedirectURIsFunc.
+func (mock *ClientMock) GetRedirectURIs() []RedirectURI {
+	if mock.GetRedirectURIsFunc == nil {
+		panic("ClientMock.GetRedirectURIsFunc: method is nil but Client.GetRedirectURIs was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetRedirectURIs.Lock()
+	mock.calls.GetRedirectURIs = append(mock.calls.GetRedirectURIs, callInfo)
+	mock.lockGetRedirectURIs.Unlock()
+	return mock.GetRedirectURIsFunc()
+}
+
+// GetRedirectURIsCalls gets all the calls that were made to GetRedirectURIs.
+// Check the length with:
+//
+//	len(mockedClient.GetRedirectURIsCalls())
+func (mock *ClientMock) GetRedirectURIsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetRedirectURIs.RLock()
+	calls = mock.calls.GetRedirectURIs
+	mock.lockGetRedirectURIs.RUnlock()
+	return calls
+}
+
+// GetRefreshTokenExpiresDay calls GetRefreshTokenExpiresDayFunc.
+func (mock *ClientMock) GetRefreshTokenExpiresDay() int {
+	if mock.GetRefreshTokenExpiresDayFunc == nil {
+		panic("ClientMock.GetRefreshTokenExpiresDayFunc: method is nil but Client.GetRefreshTokenExpiresDay was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetRefreshTokenExpiresDay.Lock()
+	mock.calls.GetRefreshTokenExpiresDay = append(mock.calls.GetRefreshTokenExpiresDay, callInfo)
+	mock.lockGetRefreshTokenExpiresDay.Unlock()
+	return mock.GetRefreshTokenExpiresDayFunc()
+}
+
+// GetRefreshTokenExpiresDayCalls gets all the calls that were made to GetRefreshTokenExpiresDay.
+// Check the length with:
+//
+//	len(mockedClient.GetRefreshTokenExpiresDayCalls())
+func (mock *ClientMock) GetRefreshTokenExpiresDayCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetRefreshTokenExpiresDay.RLock()
+	calls = mock.calls.GetRefreshTokenExpiresDay
+	mock.lockGetRefreshTokenExpiresDay.RUnlock()
+	return calls
+}
+
+// GetSecretHash calls GetSecretHashFunc.
+func (mock *ClientMock) GetSecretHash() string {
+	if mock.GetSecretHashFunc == nil {
+		panic("ClientMock.GetSecretHashFunc: method is nil but Client.GetSecretHash was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetSecretHash.Lock()
+	mock.calls.GetSecretHash = append(mock.calls.GetSecretHash, callInfo)
+	mock.lockGetSecretHash.Unlock()
+	return mock.GetSecretHashFunc()
+}
+
+// GetSecretHashCalls gets all the calls that were made to GetSecretHash.
+// Check the length with:
+//
+//	len(mockedClient.GetSecretHashCalls())
+func (mock *ClientMock) GetSecretHashCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetSecretHash.RLock()
+	calls = mock.calls.GetSecretHash
+	mock.lockGetSecretHash.RUnlock()
+	return calls
+}
+
+// GetUpdatedAt calls GetUpdatedAtFunc.
+func (mock *ClientMock) GetUpdatedAt() time.Time {
+	if mock.GetUpdatedAtFunc == nil {
+		panic("ClientMock.GetUpdatedAtFunc: method is nil but Client.GetUpdatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetUpdatedAt.Lock()
+	mock.calls.GetUpdatedAt = append(mock.calls.GetUpdatedAt, callInfo)
+	mock.lockGetUpdatedAt.Unlock()
+	return mock.GetUpdatedAtFunc()
+}
+
+// GetUpdatedAtCalls gets all the calls that were made to GetUpdatedAt.
+// Check the length with:
+//
+//	len(mockedClient.GetUpdatedAtCalls())
+func (mock *ClientMock) GetUpdatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetUpdatedAt.RLock()
+	calls = mock.calls.GetUpdatedAt
+	mock.lockGetUpdatedAt.RUnlock()
+	return calls
+}
+
+// IsConfidential calls IsConfidentialFunc.
+func (mock *ClientMock) IsConfidential() bool {
+	if mock.IsConfidentialFunc == nil {
+		panic("ClientMock.IsConfidentialFunc: method is nil but Client.IsConfidential was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsConfidential.Lock()
+	mock.calls.IsConfidential = append(mock.calls.IsConfidential, callInfo)
+	mock.lockIsConfidential.Unlock()
+	return mock.IsConfidentialFunc()
+}
+
+// IsConfidentialCalls gets all the calls that were made to IsConfidential.
+// Check the length with:
+//
+//	len(mockedClient.IsConfidentialCalls())
+func (mock *ClientMock) IsConfidentialCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsConfidential.RLock()
+	calls = mock.calls.IsConfidential
+	mock.lockIsConfidential.RUnlock()
+	return calls
+}
+
+// IsDisabled calls IsDisabledFunc.
+func (mock *ClientMock) IsDisabled() bool {
+	if mock.IsDisabledFunc == nil {
+		panic("ClientMock.IsDisabledFunc: method is nil but Client.IsDisabled was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsDisabled.Lock()
+	mock.calls.IsDisabled = append(mock.calls.IsDisabled, callInfo)
+	mock.lockIsDisabled.Unlock()
+	return mock.IsDisabledFunc()
+}
+
+// IsDisabledCalls gets all the calls that were made to IsDisabled.
+// Check the length with:
+//
+//	len(mockedClient.IsDisabledCalls())
+func (mock *ClientMock) IsDisabledCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsDisabled.RLock()
+	calls = mock.calls.IsDisabled
+	mock.lockIsDisabled.RUnlock()
+	return calls
+}
+
+// IsFirstParty calls IsFirstPartyFunc.
+func (mock *ClientMock) IsFirstParty() bool {
+	if mock.IsFirstPartyFunc == nil {
+		panic("ClientMock.IsFirstPartyFunc: method is nil but Client.IsFirstParty was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsFirstParty.Lock()
+	mock.calls.IsFirstParty = append(mock.calls.IsFirstParty, callInfo)
+	mock.lockIsFirstParty.Unlock()
+	return mock.IsFirstPartyFunc()
+}
+
+// IsFirstPartyCalls gets all the calls that were made to IsFirstParty.
+// Check the length with:
+//
+//	len(mockedClient.IsFirstPartyCalls())
+func (mock *ClientMock) IsFirstPartyCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsFirstParty.RLock()
+	calls = mock.calls.IsFirstParty
+	mock.lockIsFirstParty.RUnlock()
+	return calls
+}
+
+// IsGrantTypeAllowed calls IsGrantTypeAllowedFunc.
+func (mock *ClientMock) IsGrantTypeAllowed(grantType string) bool {
+	if mock.IsGrantTypeAllowedFunc == nil {
+		panic("ClientMock.IsGrantTypeAllowedFunc: method is nil but Client.IsGrantTypeAllowed was just called")
+	}
+	callInfo := struct {
+		GrantType string
+	}{
+		GrantType: grantType,
+	}
+	mock.lockIsGrantTypeAllowed.Lock()
+	mock.calls.IsGrantTypeAllowed = append(mock.calls.IsGrantTypeAllowed, callInfo)
+	mock.lockIsGrantTypeAllowed.Unlock()
+	return mock.IsGrantTypeAllowedFunc(grantType)
+}
+
+// IsGrantTypeAllowedCalls gets all the calls that were made to IsGrantTypeAllowed.
+// Check the length with:
+//
+//	len(mockedClient.IsGrantTypeAllowedCalls())
+func (mock *ClientMock) IsGrantTypeAllowedCalls() []struct {
+	GrantType string
+} {
+	var calls []struct {
+		GrantType string
+	}
+	mock.lockIsGrantTypeAllowed.RLock()
+	calls = mock.calls.IsGrantTypeAllowed
+	mock.lockIsGrantTypeAllowed.RUnlock()
+	return calls
 }
 
 // IsNotFound calls IsNotFoundFunc.
@@ -109,3 +823,285 @@ func (mock *ClientMock) IsRedirectURIMatchCalls() []struct {
 	mock.lockIsRedirectURIMatch.RUnlock()
 	return calls
 }
+
+// IsScopeAllowed calls IsScopeAllowedFunc.
+func (mock *ClientMock) IsScopeAllowed(scopes ScopeSet) bool {
+	if mock.IsScopeAllowedFunc == nil {
+		panic("ClientMock.IsScopeAllowedFunc: method is nil but Client.IsScopeAllowed was just called")
+	}
+	callInfo := struct {
+		Scopes ScopeSet
+	}{
+		Scopes: scopes,
+	}
+	mock.lockIsScopeAllowed.Lock()
+	mock.calls.IsScopeAllowed = append(mock.calls.IsScopeAllowed, callInfo)
+	mock.lockIsScopeAllowed.Unlock()
+	return mock.IsScopeAllowedFunc(scopes)
+}
+
+// IsScopeAllowedCalls gets all the calls that were made to IsScopeAllowed.
+// Check the length with:
+//
+//	len(mockedClient.IsScopeAllowedCalls())
+func (mock *ClientMock) IsScopeAllowedCalls() []struct {
+	Scopes ScopeSet
+} {
+	var calls []struct {
+		Scopes ScopeSet
+	}
+	mock.lockIsScopeAllowed.RLock()
+	calls = mock.calls.IsScopeAllowed
+	mock.lockIsScopeAllowed.RUnlock()
+	return calls
+}
+
+// RotateSecret calls RotateSecretFunc.
+func (mock *ClientMock) RotateSecret() (string, error) {
+	if mock.RotateSecretFunc == nil {
+		panic("ClientMock.RotateSecretFunc: method is nil but Client.RotateSecret was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockRotateSecret.Lock()
+	mock.calls.RotateSecret = append(mock.calls.RotateSecret, callInfo)
+	mock.lockRotateSecret.Unlock()
+	return mock.RotateSecretFunc()
+}
+
+// RotateSecretCalls gets all the calls that were made to RotateSecret.
+// Check the length with:
+//
+//	len(mockedClient.RotateSecretCalls())
+func (mock *ClientMock) RotateSecretCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockRotateSecret.RLock()
+	calls = mock.calls.RotateSecret
+	mock.lockRotateSecret.RUnlock()
+	return calls
+}
+
+// SetAllowedScopes calls SetAllowedScopesFunc.
+func (mock *ClientMock) SetAllowedScopes(scopes []string) {
+	if mock.SetAllowedScopesFunc == nil {
+		panic("ClientMock.SetAllowedScopesFunc: method is nil but Client.SetAllowedScopes was just called")
+	}
+	callInfo := struct {
+		Scopes []string
+	}{
+		Scopes: scopes,
+	}
+	mock.lockSetAllowedScopes.Lock()
+	mock.calls.SetAllowedScopes = append(mock.calls.SetAllowedScopes, callInfo)
+	mock.lockSetAllowedScopes.Unlock()
+	mock.SetAllowedScopesFunc(scopes)
+}
+
+// SetAllowedScopesCalls gets all the calls that were made to SetAllowedScopes.
+// Check the length with:
+//
+//	len(mockedClient.SetAllowedScopesCalls())
+func (mock *ClientMock) SetAllowedScopesCalls() []struct {
+	Scopes []string
+} {
+	var calls []struct {
+		Scopes []string
+	}
+	mock.lockSetAllowedScopes.RLock()
+	calls = mock.calls.SetAllowedScopes
+	mock.lockSetAllowedScopes.RUnlock()
+	return calls
+}
+
+// SetGrantTypes calls SetGrantTypesFunc.
+func (mock *ClientMock) SetGrantTypes(grantTypes []string) error {
+	if mock.SetGrantTypesFunc == nil {
+		panic("ClientMock.SetGrantTypesFunc: method is nil but Client.SetGrantTypes was just called")
+	}
+	callInfo := struct {
+		GrantTypes []string
+	}{
+		GrantTypes: grantTypes,
+	}
+	mock.lockSetGrantTypes.Lock()
+	mock.calls.SetGrantTypes = append(mock.calls.SetGrantTypes, callInfo)
+	mock.lockSetGrantTypes.Unlock()
+	return mock.SetGrantTypesFunc(grantTypes)
+}
+
+// SetGrantTypesCalls gets all the calls that were made to SetGrantTypes.
+// Check the length with:
+//
+//	len(mockedClient.SetGrantTypesCalls())
+func (mock *ClientMock) SetGrantTypesCalls() []struct {
+	GrantTypes []string
+} {
+	var calls []struct {
+		GrantTypes []string
+	}
+	mock.lockSetGrantTypes.RLock()
+	calls = mock.calls.SetGrantTypes
+	mock.lockSetGrantTypes.RUnlock()
+	return calls
+}
+
+// SetName calls SetNameFunc.
+func (mock *ClientMock) SetName(name string) {
+	if mock.SetNameFunc == nil {
+		panic("ClientMock.SetNameFunc: method is nil but Client.SetName was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockSetName.Lock()
+	mock.calls.SetName = append(mock.calls.SetName, callInfo)
+	mock.lockSetName.Unlock()
+	mock.SetNameFunc(name)
+}
+
+// SetNameCalls gets all the calls that were made to SetName.
+// Check the length with:
+//
+//	len(mockedClient.SetNameCalls())
+func (mock *ClientMock) SetNameCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockSetName.RLock()
+	calls = mock.calls.SetName
+	mock.lockSetName.RUnlock()
+	return calls
+}
+
+// SetRedirectURIs calls SetRedirectURIsFunc.
+func (mock *ClientMock) SetRedirectURIs(uris []string) error {
+	if mock.SetRedirectURIsFunc == nil {
+		panic("ClientMock.SetRedirectURIsFunc: method is nil but Client.SetRedirectURIs was just called")
+	}
+	callInfo := struct {
+		Uris []string
+	}{
+		Uris: uris,
+	}
+	mock.lockSetRedirectURIs.Lock()
+	mock.calls.SetRedirectURIs = append(mock.calls.SetRedirectURIs, callInfo)
+	mock.lockSetRedirectURIs.Unlock()
+	return mock.SetRedirectURIsFunc(uris)
+}
+
+// SetRedirectURIsCalls gets all the calls that were made to SetRedirectURIs.
+// Check the length with:
+//
+//	len(mockedClient.SetRedirectURIsCalls())
+func (mock *ClientMock) SetRedirectURIsCalls() []struct {
+	Uris []string
+} {
+	var calls []struct {
+		Uris []string
+	}
+	mock.lockSetRedirectURIs.RLock()
+	calls = mock.calls.SetRedirectURIs
+	mock.lockSetRedirectURIs.RUnlock()
+	return calls
+}
+
+// SetTokenLifetimes calls SetTokenLifetimesFunc.
+func (mock *ClientMock) SetTokenLifetimes(accessTokenExpiresMin int, refreshTokenExpiresDay int) error {
+	if mock.SetTokenLifetimesFunc == nil {
+		panic("ClientMock.SetTokenLifetimesFunc: method is nil but Client.SetTokenLifetimes was just called")
+	}
+	callInfo := struct {
+		AccessTokenExpiresMin  int
+		RefreshTokenExpiresDay int
+	}{
+		AccessTokenExpiresMin:  accessTokenExpiresMin,
+		RefreshTokenExpiresDay: refreshTokenExpiresDay,
+	}
+	mock.lockSetTokenLifetimes.Lock()
+	mock.calls.SetTokenLifetimes = append(mock.calls.SetTokenLifetimes, callInfo)
+	mock.lockSetTokenLifetimes.Unlock()
+	return mock.SetTokenLifetimesFunc(accessTokenExpiresMin, refreshTokenExpiresDay)
+}
+
+// SetTokenLifetimesCalls gets all the calls that were made to SetTokenLifetimes.
+// Check the length with:
+//
+//	len(mockedClient.SetTokenLifetimesCalls())
+func (mock *ClientMock) SetTokenLifetimesCalls() []struct {
+	AccessTokenExpiresMin  int
+	RefreshTokenExpiresDay int
+} {
+	var calls []struct {
+		AccessTokenExpiresMin  int
+		RefreshTokenExpiresDay int
+	}
+	mock.lockSetTokenLifetimes.RLock()
+	calls = mock.calls.SetTokenLifetimes
+	mock.lockSetTokenLifetimes.RUnlock()
+	return calls
+}
+
+// Validate calls ValidateFunc.
+func (mock *ClientMock) Validate() error {
+	if mock.ValidateFunc == nil {
+		panic("ClientMock.ValidateFunc: method is nil but Client.Validate was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockValidate.Lock()
+	mock.calls.Validate = append(mock.calls.Validate, callInfo)
+	mock.lockValidate.Unlock()
+	return mock.ValidateFunc()
+}
+
+// ValidateCalls gets all the calls that were made to Validate.
+// Check the length with:
+//
+//	len(mockedClient.ValidateCalls())
+func (mock *ClientMock) ValidateCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockValidate.RLock()
+	calls = mock.calls.Validate
+	mock.lockValidate.RUnlock()
+	return calls
+}
+
+// VerifySecret calls VerifySecretFunc.
+func (mock *ClientMock) VerifySecret(secret string) bool {
+	if mock.VerifySecretFunc == nil {
+		panic("ClientMock.VerifySecretFunc: method is nil but Client.VerifySecret was just called")
+	}
+	callInfo := struct {
+		Secret string
+	}{
+		Secret: secret,
+	}
+	mock.lockVerifySecret.Lock()
+	mock.calls.VerifySecret = append(mock.calls.VerifySecret, callInfo)
+	mock.lockVerifySecret.Unlock()
+	return mock.VerifySecretFunc(secret)
+}
+
+// VerifySecretCalls gets all the calls that were made to VerifySecret.
+// Check the length with:
+//
+//	len(mockedClient.VerifySecretCalls())
+func (mock *ClientMock) VerifySecretCalls() []struct {
+	Secret string
+} {
+	var calls []struct {
+		Secret string
+	}
+	mock.lockVerifySecret.RLock()
+	calls = mock.calls.VerifySecret
+	mock.lockVerifySecret.RUnlock()
+	return calls
+}
@@ -19,9 +19,24 @@ var _ ClientRepository = &ClientRepositoryMock{}
 //
 //		// make and configure a mocked ClientRepository
 //		mockedClientRepository := &ClientRepositoryMock{
+//			AddRedirectURIFunc: func(ctx context.Context, clientID uuid.UUID, redirectURI RedirectURI) error {
+//				panic("mock out the AddRedirectURI method")
+//			},
+//			CreateClientFunc: func(ctx context.Context, c Client) error {
+//				panic("mock out the CreateClient method")
+//			},
+//			DeleteClientFunc: func(ctx context.Context, clientID uuid.UUID) error {
+//				panic("mock out the DeleteClient method")
+//			},
 //			FindClientByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) (Client, error) {
 //				panic("mock out the FindClientByClientID method")
 //			},
+//			FindClientsFunc: func(ctx context.Context, offset int, limit int) ([]Client, error) {
+//				panic("mock out the FindClients method")
+//			},
+//			UpdateClientFunc: func(ctx context.Context, c Client) error {
+//				panic("mock out the UpdateClient method")
+//			},
 //		}
 //
 //		// use mockedClientRepository in code that requires ClientRepository
@@ -29,11 +44,49 @@ var _ ClientRepository = &ClientRepositoryMock{}
 //
 //	}
 type ClientRepositoryMock struct {
+	// AddRedirectURIFunc mocks the AddRedirectURI method.
+	AddRedirectURIFunc func(ctx context.Context, clientID uuid.UUID, redirectURI RedirectURI) error
+
+	// CreateClientFunc mocks the CreateClient method.
+	CreateClientFunc func(ctx context.Context, c Client) error
+
+	// DeleteClientFunc mocks the DeleteClient method.
+	DeleteClientFunc func(ctx context.Context, clientID uuid.UUID) error
+
 	// FindClientByClientIDFunc mocks the FindClientByClientID method.
 	FindClientByClientIDFunc func(ctx context.Context, clientID uuid.UUID) (Client, error)
 
+	// FindClientsFunc mocks the FindClients method.
+	FindClientsFunc func(ctx context.Context, offset int, limit int) ([]Client, error)
+
+	// UpdateClientFunc mocks the UpdateClient method.
+	UpdateClientFunc func(ctx context.Context, c Client) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddRedirectURI holds details about calls to the AddRedirectURI method.
+		AddRedirectURI []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ClientID is the clientID argument value.
+			ClientID uuid.UUID
+			// RedirectURI is the redirectURI argument value.
+			RedirectURI RedirectURI
+		}
+		// CreateClient holds details about calls to the CreateClient method.
+		CreateClient []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// C is the c argument value.
+			C Client
+		}
+		// DeleteClient holds details about calls to the DeleteClient method.
+		DeleteClient []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ClientID is the clientID argument value.
+			ClientID uuid.UUID
+		}
 		// FindClientByClientID holds details about calls to the FindClientByClientID method.
 		FindClientByClientID []struct {
 			// Ctx is the ctx argument value.
@@ -41,8 +94,141 @@ type ClientRepositoryMock struct {
 			// ClientID is the clientID argument value.
 			ClientID uuid.UUID
 		}
+		// FindClients holds details about calls to the FindClients method.
+		FindClients []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// UpdateClient holds details about calls to the UpdateClient method.
+		UpdateClient []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// C is the c argument value.
+			C Client
+		}
 	}
+	lockAddRedirectURI       sync.RWMutex
+	lockCreateClient         sync.RWMutex
+	lockDeleteClient         sync.RWMutex
 	lockFindClientByClientID sync.RWMutex
+	lockFindClients          sync.RWMutex
+	lockUpdateClient         sync.RWMutex
+}
+
+// AddRedirectURI calls AddRedirectURIFunc.
+func (mock *ClientRepositoryMock) AddRedirectURI(ctx context.Context, clientID uuid.UUID, redirectURI RedirectURI) error {
+	if mock.AddRedirectURIFunc == nil {
+		panic("ClientRepositoryMock.AddRedirectURIFunc: method is nil but ClientRepository.AddRedirectURI was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ClientID    uuid.UUID
+		RedirectURI RedirectURI
+	}{
+		Ctx:         ctx,
+		ClientID:    clientID,
+		RedirectURI: redirectURI,
+	}
+	mock.lockAddRedirectURI.Lock()
+	mock.calls.AddRedirectURI = append(mock.calls.AddRedirectURI, callInfo)
+	mock.lockAddRedirectURI.Unlock()
+	return mock.AddRedirectURIFunc(ctx, clientID, redirectURI)
+}
+
+// AddRedirectURICalls gets all the calls that were made to AddRedirectURI.
+// Check the length with:
+//
+//	len(mockedClientRepository.AddRedirectURICalls())
+func (mock *ClientRepositoryMock) AddRedirectURICalls() []struct {
+	Ctx         context.Context
+	ClientID    uuid.UUID
+	RedirectURI RedirectURI
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ClientID    uuid.UUID
+		RedirectURI RedirectURI
+	}
+	mock.lockAddRedirectURI.RLock()
+	calls = mock.calls.AddRedirectURI
+	mock.lockAddRedirectURI.RUnlock()
+	return calls
+}
+
+// CreateClient calls CreateClientFunc.
+func (mock *ClientRepositoryMock) CreateClient(ctx context.Context, c Client) error {
+	if mock.CreateClientFunc == nil {
+		panic("ClientRepositoryMock.CreateClientFunc: method is nil but ClientRepository.CreateClient was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		C   Client
+	}{
+		Ctx: ctx,
+		C:   c,
+	}
+	mock.lockCreateClient.Lock()
+	mock.calls.CreateClient = append(mock.calls.CreateClient, callInfo)
+	mock.lockCreateClient.Unlock()
+	return mock.CreateClientFunc(ctx, c)
+}
+
+// CreateClientCalls gets all the calls that were made to CreateClient.
+// Check the length with:
+//
+//	len(mockedClientRepository.CreateClientCalls())
+func (mock *ClientRepositoryMock) CreateClientCalls() []struct {
+	Ctx context.Context
+	C   Client
+} {
+	var calls []struct {
+		Ctx context.Context
+		C   Client
+	}
+	mock.lockCreateClient.RLock()
+	calls = mock.calls.CreateClient
+	mock.lockCreateClient.RUnlock()
+	return calls
+}
+
+// DeleteClient calls DeleteClientFunc.
+func (mock *ClientRepositoryMock) DeleteClient(ctx context.Context, clientID uuid.UUID) error {
+	if mock.DeleteClientFunc == nil {
+		panic("ClientRepositoryMock.DeleteClientFunc: method is nil but ClientRepository.DeleteClient was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ClientID uuid.UUID
+	}{
+		Ctx:      ctx,
+		ClientID: clientID,
+	}
+	mock.lockDeleteClient.Lock()
+	mock.calls.DeleteClient = append(mock.calls.DeleteClient, callInfo)
+	mock.lockDeleteClient.Unlock()
+	return mock.DeleteClientFunc(ctx, clientID)
+}
+
+// DeleteClientCalls gets all the calls that were made to DeleteClient.
+// Check the length with:
+//
+//	len(mockedClientRepository.DeleteClientCalls())
+func (mock *ClientRepositoryMock) DeleteClientCalls() []struct {
+	Ctx      context.Context
+	ClientID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ClientID uuid.UUID
+	}
+	mock.lockDeleteClient.RLock()
+	calls = mock.calls.DeleteClient
+	mock.lockDeleteClient.RUnlock()
+	return calls
 }
 
 // FindClientByClientID calls FindClientByClientIDFunc.
@@ -80,3 +266,79 @@ func (mock *ClientRepositoryMock) FindClientByClientIDCalls() []struct {
 	mock.lockFindClientByClientID.RUnlock()
 	return calls
 }
+
+// FindClients calls FindClientsFunc.
+func (mock *ClientRepositoryMock) FindClients(ctx context.Context, offset int, limit int) ([]Client, error) {
+	if mock.FindClientsFunc == nil {
+		panic("ClientRepositoryMock.FindClientsFunc: method is nil but ClientRepository.FindClients was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockFindClients.Lock()
+	mock.calls.FindClients = append(mock.calls.FindClients, callInfo)
+	mock.lockFindClients.Unlock()
+	return mock.FindClientsFunc(ctx, offset, limit)
+}
+
+// FindClientsCalls gets all the calls that were made to FindClients.
+// Check the length with:
+//
+//	len(mockedClientRepository.FindClientsCalls())
+func (mock *ClientRepositoryMock) FindClientsCalls() []struct {
+	Ctx    context.Context
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}
+	mock.lockFindClients.RLock()
+	calls = mock.calls.FindClients
+	mock.lockFindClients.RUnlock()
+	return calls
+}
+
+// UpdateClient calls UpdateClientFunc.
+func (mock *ClientRepositoryMock) UpdateClient(ctx context.Context, c Client) error {
+	if mock.UpdateClientFunc == nil {
+		panic("ClientRepositoryMock.UpdateClientFunc: method is nil but ClientRepository.UpdateClient was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		C   Client
+	}{
+		Ctx: ctx,
+		C:   c,
+	}
+	mock.lockUpdateClient.Lock()
+	mock.calls.UpdateClient = append(mock.calls.UpdateClient, callInfo)
+	mock.lockUpdateClient.Unlock()
+	return mock.UpdateClientFunc(ctx, c)
+}
+
+// UpdateClientCalls gets all the calls that were made to UpdateClient.
+// Check the length with:
+//
+//	len(mockedClientRepository.UpdateClientCalls())
+func (mock *ClientRepositoryMock) UpdateClientCalls() []struct {
+	Ctx context.Context
+	C   Client
+} {
+	var calls []struct {
+		Ctx context.Context
+		C   Client
+	}
+	mock.lockUpdateClient.RLock()
+	calls = mock.calls.UpdateClient
+	mock.lockUpdateClient.RUnlock()
+	return calls
+}
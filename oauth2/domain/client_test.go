@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterClient(t *testing.T) {
+	p := ClientParams{
+		Name:         "app",
+		RedirectURIs: []string{"HTTPS://Example.COM/callback", "https://example.com/callback"},
+	}
+
+	c, secret, err := RegisterClient(p, true)
+	require.NoError(t, err)
+	assert.False(t, c.IsNotFound())
+	assert.Equal(t, []RedirectURI{"https://example.com/callback"}, c.GetRedirectURIs())
+	assert.Equal(t, DefaultGrantTypes, c.GetGrantTypes())
+	assert.True(t, c.IsConfidential())
+	assert.True(t, c.VerifySecret(secret))
+	assert.False(t, c.VerifySecret(""))
+	assert.False(t, c.VerifySecret(secret+"x"))
+
+	// 公開クライアントはシークレットを確認しない
+	c, secret, err = RegisterClient(p, false)
+	require.NoError(t, err)
+	assert.Empty(t, secret)
+	assert.False(t, c.IsConfidential())
+	assert.True(t, c.VerifySecret(""))
+}
+
+func TestClient_RotateSecret(t *testing.T) {
+	c, old, err := RegisterClient(ClientParams{Name: "app", RedirectURIs: []string{"https://example.com/callback"}}, true)
+	require.NoError(t, err)
+
+	secret, err := c.RotateSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, old, secret)
+	assert.True(t, c.VerifySecret(secret))
+	assert.False(t, c.VerifySecret(old))
+}
+
+func TestClient_Validate(t *testing.T) {
+	valid := func() Client {
+		return NewClient(ClientParams{Name: "app", RedirectURIs: []string{"https://example.com/callback"}})
+	}
+	long := make([]byte, maxClientNameLen+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c Client) error
+		wantErr error
+	}{
+		{name: "valid", modify: func(c Client) error { return nil }},
+		{name: "no name", modify: func(c Client) error { c.SetName(""); return nil }, wantErr: ErrClientNameRequired},
+		{name: "long name", modify: func(c Client) error { c.SetName(string(long)); return nil }, wantErr: ErrClientNameTooLong},
+		{name: "no redirect uri", modify: func(c Client) error { return c.SetRedirectURIs(nil) }, wantErr: ErrClientRedirectURIRequired},
+		{name: "authorization code only", modify: func(c Client) error { return c.SetGrantTypes([]string{GrantTypeAuthorizationCode}) }},
+		{name: "refresh token only", modify: func(c Client) error { return c.SetGrantTypes([]string{GrantTypeRefreshToken}) }, wantErr: ErrClientGrantTypeRequired},
+		{name: "unsupported grant type", modify: func(c Client) error { return c.SetGrantTypes([]string{"password"}) }, wantErr: ErrClientGrantTypeUnsupported},
+		{name: "max lifetimes", modify: func(c Client) error { return c.SetTokenLifetimes(MaxAccessTokenExpiresMin, MaxRefreshTokenExpiresDay) }},
+		{name: "negative lifetime", modify: func(c Client) error { return c.SetTokenLifetimes(-1, 0) }, wantErr: ErrClientTokenLifetimeInvalid},
+		{name: "too long lifetime", modify: func(c Client) error { return c.SetTokenLifetimes(0, MaxRefreshTokenExpiresDay+1) }, wantErr: ErrClientTokenLifetimeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			err := tt.modify(c)
+			if err == nil {
+				err = c.Validate()
+			}
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_Disable(t *testing.T) {
+	c := NewClient(ClientParams{Name: "app"})
+	assert.False(t, c.IsDisabled())
+
+	first := time.Now()
+	c.Disable(first)
+	c.Disable(first.Add(time.Hour))
+	assert.True(t, c.IsDisabled())
+	assert.Equal(t, first, *c.GetDisabledAt())
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ConsentParams struct {
+	UserID    uuid.UUID
+	ClientID  uuid.UUID
+	Scope     string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewConsent(p ConsentParams) (Consent, error) {
+	scopes, err := ParseScope(p.Scope)
+	if err != nil {
+		return nil, err
+	}
+	return &consent{
+		userID:    p.UserID,
+		clientID:  p.ClientID,
+		scopes:    scopes,
+		expiresAt: p.ExpiresAt,
+		createdAt: p.CreatedAt,
+		updatedAt: p.UpdatedAt,
+	}, nil
+}
+
+//go:generate go run github.com/matryer/moq -out consent_mock.go . Consent
+type Consent interface {
+	GetUserID() uuid.UUID
+	GetClientID() uuid.UUID
+	GetScopes() ScopeSet
+	GetExpiresAt() *time.Time
+	GetCreatedAt() time.Time
+	GetUpdatedAt() time.Time
+	IsExpired(now time.Time) bool
+	Covers(scopes ScopeSet) bool
+	Grant(scopes ScopeSet)
+	SetNewExpiry(additionalDays int)
+}
+
+//go:generate go run github.com/matryer/moq -out consent_repository_mock.go . ConsentRepository
+type ConsentRepository interface {
+	FindConsent(ctx context.Context, userID, clientID uuid.UUID) (Consent, error)
+	FindConsentsByUserID(ctx context.Context, userID uuid.UUID) ([]Consent, error)
+	StoreConsent(ctx context.Context, c Consent) error
+	DeleteConsent(ctx context.Context, userID, clientID uuid.UUID) error
+}
+
+// consent はユーザーがクライアントに許可したスコープの記録
+type consent struct {
+	userID    uuid.UUID
+	clientID  uuid.UUID
+	scopes    ScopeSet
+	expiresAt *time.Time
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func (c *consent) GetUserID() uuid.UUID {
+	return c.userID
+}
+
+func (c *consent) GetClientID() uuid.UUID {
+	return c.clientID
+}
+
+func (c *consent) GetScopes() ScopeSet {
+	return c.scopes
+}
+
+func (c *consent) GetExpiresAt() *time.Time {
+	return c.expiresAt
+}
+
+func (c *consent) GetCreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *consent) GetUpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// IsExpired は同意の有効期限が切れているかを判定する。期限がない場合は失効しない
+func (c *consent) IsExpired(now time.Time) bool {
+	return c.expiresAt != nil && now.After(*c.expiresAt)
+}
+
+// Covers は要求されたスコープが全て同意済みかを判定する
+func (c *consent) Covers(scopes ScopeSet) bool {
+	return scopes.IsSubsetOf(c.scopes)
+}
+
+// Grant は同意済みのスコープに追加する
+func (c *consent) Grant(scopes ScopeSet) {
+	c.scopes = c.scopes.Union(scopes)
+}
+
+// SetNewExpiry は有効期限を更新する。0 以下を指定した場合は期限なしにする
+func (c *consent) SetNewExpiry(additionalDays int) {
+	if additionalDays <= 0 {
+		c.expiresAt = nil
+		return
+	}
+	t := time.Now().Add(time.Duration(additionalDays) * day)
+	c.expiresAt = &t
+}
@@ -0,0 +1,430 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Ensure, that ConsentMock does implement Consent.
+// If this is not the case, regenerate this file with moq.
+var _ Consent = &ConsentMock{}
+
+// ConsentMock is a mock implementation of Consent.
+//
+//	func TestSomethingThatUsesConsent(t *testing.T) {
+//
+//		// make and configure a mocked Consent
+//		mockedConsent := &ConsentMock{
+//			CoversFunc: func(scopes ScopeSet) bool {
+//				panic("mock out the Covers method")
+//			},
+//			GetClientIDFunc: func() uuid.UUID {
+//				panic("mock out the GetClientID method")
+//			},
+//			GetCreatedAtFunc: func() time.Time {
+//				panic("mock out the GetCreatedAt method")
+//			},
+//			GetExpiresAtFunc: func() *time.Time {
+//				panic("mock out the GetExpiresAt method")
+//			},
+//			GetScopesFunc: func() ScopeSet {
+//				panic("mock out the GetScopes method")
+//			},
+//			GetUpdatedAtFunc: func() time.Time {
+//				panic("mock out the GetUpdatedAt method")
+//			},
+//			GetUserIDFunc: func() uuid.UUID {
+//				panic("mock out the GetUserID method")
+//			},
+//			GrantFunc: func(scopes ScopeSet)  {
+//				panic("mock out the Grant method")
+//			},
+//			IsExpiredFunc: func(now time.Time) bool {
+//				panic("mock out the IsExpired method")
+//			},
+//			SetNewExpiryFunc: func(additionalDays int)  {
+//				panic("mock out the SetNewExpiry method")
+//			},
+//		}
+//
+//		// use mockedConsent in code that requires Consent
+//		// and then make assertions.
+//
+//	}
+type ConsentMock struct {
+	// CoversFunc mocks the Covers method.
+	CoversFunc func(scopes ScopeSet) bool
+
+	// GetClientIDFunc mocks the GetClientID method.
+	GetClientIDFunc func() uuid.UUID
+
+	// GetCreatedAtFunc mocks the GetCreatedAt method.
+	GetCreatedAtFunc func() time.Time
+
+	// GetExpiresAtFunc mocks the GetExpiresAt method.
+	GetExpiresAtFunc func() *time.Time
+
+	// GetScopesFunc mocks the GetScopes method.
+	GetScopesFunc func() ScopeSet
+
+	// GetUpdatedAtFunc mocks the GetUpdatedAt method.
+	GetUpdatedAtFunc func() time.Time
+
+	// GetUserIDFunc mocks the GetUserID method.
+	GetUserIDFunc func() uuid.UUID
+
+	// GrantFunc mocks the Grant method.
+	GrantFunc func(scopes ScopeSet)
+
+	// IsExpiredFunc mocks the IsExpired method.
+	IsExpiredFunc func(now time.Time) bool
+
+	// SetNewExpiryFunc mocks the SetNewExpiry method.
+	SetNewExpiryFunc func(additionalDays int)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Covers holds details about calls to the Covers method.
+		Covers []struct {
+			// Scopes is the scopes argument value.
+			Scopes ScopeSet
+		}
+		// GetClientID holds details about calls to the GetClientID method.
+		GetClientID []struct {
+		}
+		// GetCreatedAt holds details about calls to the GetCreatedAt method.
+		GetCreatedAt []struct {
+		}
+		// GetExpiresAt holds details about calls to the GetExpiresAt method.
+		GetExpiresAt []struct {
+		}
+		// GetScopes holds details about calls to the GetScopes method.
+		GetScopes []struct {
+		}
+		// GetUpdatedAt holds details about calls to the GetUpdatedAt method.
+		GetUpdatedAt []struct {
+		}
+		// GetUserID holds details about calls to the GetUserID method.
+		GetUserID []struct {
+		}
+		// Grant holds details about calls to the Grant method.
+		Grant []struct {
+			// Scopes is the scopes argument value.
+			Scopes ScopeSet
+		}
+		// IsExpired holds details about calls to the IsExpired method.
+		IsExpired []struct {
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// SetNewExpiry holds details about calls to the SetNewExpiry method.
+		SetNewExpiry []struct {
+			// AdditionalDays is the additionalDays argument value.
+			AdditionalDays int
+		}
+	}
+	lockCovers       sync.RWMutex
+	lockGetClientID  sync.RWMutex
+	lockGetCreatedAt sync.RWMutex
+	lockGetExpiresAt sync.RWMutex
+	lockGetScopes    sync.RWMutex
+	lockGetUpdatedAt sync.RWMutex
+	lockGetUserID    sync.RWMutex
+	lockGrant        sync.RWMutex
+	lockIsExpired    sync.RWMutex
+	lockSetNewExpiry sync.RWMutex
+}
+
+// Covers calls CoversFunc.
+func (mock *ConsentMock) Covers(scopes ScopeSet) bool {
+	if mock.CoversFunc == nil {
+		panic("ConsentMock.CoversFunc: method is nil but Consent.Covers was just called")
+	}
+	callInfo := struct {
+		Scopes ScopeSet
+	}{
+		Scopes: scopes,
+	}
+	mock.lockCovers.Lock()
+	mock.calls.Covers = append(mock.calls.Covers, callInfo)
+	mock.lockCovers.Unlock()
+	return mock.CoversFunc(scopes)
+}
+
+// CoversCalls gets all the calls that were made to Covers.
+// Check the length with:
+//
+//	len(mockedConsent.CoversCalls())
+func (mock *ConsentMock) CoversCalls() []struct {
+	Scopes ScopeSet
+} {
+	var calls []struct {
+		Scopes ScopeSet
+	}
+	mock.lockCovers.RLock()
+	calls = mock.calls.Covers
+	mock.lockCovers.RUnlock()
+	return calls
+}
+
+// GetClientID calls GetClientIDFunc.
+func (mock *ConsentMock) GetClientID() uuid.UUID {
+	if mock.GetClientIDFunc == nil {
+		panic("ConsentMock.GetClientIDFunc: method is nil but Consent.GetClientID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetClientID.Lock()
+	mock.calls.GetClientID = append(mock.calls.GetClientID, callInfo)
+	mock.lockGetClientID.Unlock()
+	return mock.GetClientIDFunc()
+}
+
+// GetClientIDCalls gets all the calls that were made to GetClientID.
+// Check the length with:
+//
+//	len(mockedConsent.GetClientIDCalls())
+func (mock *ConsentMock) GetClientIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetClientID.RLock()
+	calls = mock.calls.GetClientID
+	mock.lockGetClientID.RUnlock()
+	return calls
+}
+
+// GetCreatedAt calls GetCreatedAtFunc.
+func (mock *ConsentMock) GetCreatedAt() time.Time {
+	if mock.GetCreatedAtFunc == nil {
+		panic("ConsentMock.GetCreatedAtFunc: method is nil but Consent.GetCreatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCreatedAt.Lock()
+	mock.calls.GetCreatedAt = append(mock.calls.GetCreatedAt, callInfo)
+	mock.lockGetCreatedAt.Unlock()
+	return mock.GetCreatedAtFunc()
+}
+
+// GetCreatedAtCalls gets all the calls that were made to GetCreatedAt.
+// Check the length with:
+//
+//	len(mockedConsent.GetCreatedAtCalls())
+func (mock *ConsentMock) GetCreatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCreatedAt.RLock()
+	calls = mock.calls.GetCreatedAt
+	mock.lockGetCreatedAt.RUnlock()
+	return calls
+}
+
+// GetExpiresAt calls GetExpiresAtFunc.
+func (mock *ConsentMock) GetExpiresAt() *time.Time {
+	if mock.GetExpiresAtFunc == nil {
+		panic("ConsentMock.GetExpiresAtFunc: method is nil but Consent.GetExpiresAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetExpiresAt.Lock()
+	mock.calls.GetExpiresAt = append(mock.calls.GetExpiresAt, callInfo)
+	mock.lockGetExpiresAt.Unlock()
+	return mock.GetExpiresAtFunc()
+}
+
+// GetExpiresAtCalls gets all the calls that were made to GetExpiresAt.
+// Check the length with:
+//
+//	len(mockedConsent.GetExpiresAtCalls())
+func (mock *ConsentMock) GetExpiresAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetExpiresAt.RLock()
+	calls = mock.calls.GetExpiresAt
+	mock.lockGetExpiresAt.RUnlock()
+	return calls
+}
+
+// GetScopes calls GetScopesFunc.
+func (mock *ConsentMock) GetScopes() ScopeSet {
+	if mock.GetScopesFunc == nil {
+		panic("ConsentMock.GetScopesFunc: method is nil but Consent.GetScopes was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetScopes.Lock()
+	mock.calls.GetScopes = append(mock.calls.GetScopes, callInfo)
+	mock.lockGetScopes.Unlock()
+	return mock.GetScopesFunc()
+}
+
+// GetScopesCalls gets all the calls that were made to GetScopes.
+// Check the length with:
+//
+//	len(mockedConsent.GetScopesCalls())
+func (mock *ConsentMock) GetScopesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetScopes.RLock()
+	calls = mock.calls.GetScopes
+	mock.lockGetScopes.RUnlock()
+	return calls
+}
+
+// GetUpdatedAt calls GetUpdatedAtFunc.
+func (mock *ConsentMock) GetUpdatedAt() time.Time {
+	if mock.GetUpdatedAtFunc == nil {
+		panic("ConsentMock.GetUpdatedAtFunc: method is nil but Consent.GetUpdatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetUpdatedAt.Lock()
+	mock.calls.GetUpdatedAt = append(mock.calls.GetUpdatedAt, callInfo)
+	mock.lockGetUpdatedAt.Unlock()
+	return mock.GetUpdatedAtFunc()
+}
+
+// GetUpdatedAtCalls gets all the calls that were made to GetUpdatedAt.
+// Check the length with:
+//
+//	len(mockedConsent.GetUpdatedAtCalls())
+func (mock *ConsentMock) GetUpdatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetUpdatedAt.RLock()
+	calls = mock.calls.GetUpdatedAt
+	mock.lockGetUpdatedAt.RUnlock()
+	return calls
+}
+
+// GetUserID calls GetUserIDFunc.
+func (mock *ConsentMock) GetUserID() uuid.UUID {
+	if mock.GetUserIDFunc == nil {
+		panic("ConsentMock.GetUserIDFunc: method is nil but Consent.GetUserID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetUserID.Lock()
+	mock.calls.GetUserID = append(mock.calls.GetUserID, callInfo)
+	mock.lockGetUserID.Unlock()
+	return mock.GetUserIDFunc()
+}
+
+// GetUserIDCalls gets all the calls that were made to GetUserID.
+// Check the length with:
+//
+//	len(mockedConsent.GetUserIDCalls())
+func (mock *ConsentMock) GetUserIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetUserID.RLock()
+	calls = mock.calls.GetUserID
+	mock.lockGetUserID.RUnlock()
+	return calls
+}
+
+// Grant calls GrantFunc.
+func (mock *ConsentMock) Grant(scopes ScopeSet) {
+	if mock.GrantFunc == nil {
+		panic("ConsentMock.GrantFunc: method is nil but Consent.Grant was just called")
+	}
+	callInfo := struct {
+		Scopes ScopeSet
+	}{
+		Scopes: scopes,
+	}
+	mock.lockGrant.Lock()
+	mock.calls.Grant = append(mock.calls.Grant, callInfo)
+	mock.lockGrant.Unlock()
+	mock.GrantFunc(scopes)
+}
+
+// GrantCalls gets all the calls that were made to Grant.
+// Check the length with:
+//
+//	len(mockedConsent.GrantCalls())
+func (mock *ConsentMock) GrantCalls() []struct {
+	Scopes ScopeSet
+} {
+	var calls []struct {
+		Scopes ScopeSet
+	}
+	mock.lockGrant.RLock()
+	calls = mock.calls.Grant
+	mock.lockGrant.RUnlock()
+	return calls
+}
+
+// IsExpired calls IsExpiredFunc.
+func (mock *ConsentMock) IsExpired(now time.Time) bool {
+	if mock.IsExpiredFunc == nil {
+		panic("ConsentMock.IsExpiredFunc: method is nil but Consent.IsExpired was just called")
+	}
+	callInfo := struct {
+		Now time.Time
+	}{
+		Now: now,
+	}
+	mock.lockIsExpired.Lock()
+	mock.calls.IsExpired = append(mock.calls.IsExpired, callInfo)
+	mock.lockIsExpired.Unlock()
+	return mock.IsExpiredFunc(now)
+}
+
+// IsExpiredCalls gets all the calls that were made to IsExpired.
+// Check the length with:
+//
+//	len(mockedConsent.IsExpiredCalls())
+func (mock *ConsentMock) IsExpiredCalls() []struct {
+	Now time.Time
+} {
+	var calls []struct {
+		Now time.Time
+	}
+	mock.lockIsExpired.RLock()
+	calls = mock.calls.IsExpired
+	mock.lockIsExpired.RUnlock()
+	return calls
+}
+
+// SetNewExpiry calls SetNewExpiryFunc.
+func (mock *ConsentMock) SetNewExpiry(additionalDays int) {
+	if mock.SetNewExpiryFunc == nil {
+		panic("ConsentMock.SetNewExpiryFunc: method is nil but Consent.SetNewExpiry was just called")
+	}
+	callInfo := struct {
+		AdditionalDays int
+	}{
+		AdditionalDays: additionalDays,
+	}
+	mock.lockSetNewExpiry.Lock()
+	mock.calls.SetNewExpiry = append(mock.calls.SetNewExpiry, callInfo)
+	mock.lockSetNewExpiry.Unlock()
+	mock.SetNewExpiryFunc(additionalDays)
+}
+
+// SetNewExpiryCalls gets all the calls that were made to SetNewExpiry.
+// Check the length with:
+//
+//	len(mockedConsent.SetNewExpiryCalls())
+func (mock *ConsentMock) SetNewExpiryCalls() []struct {
+	AdditionalDays int
+} {
+	var calls []struct {
+		AdditionalDays int
+	}
+	mock.lockSetNewExpiry.RLock()
+	calls = mock.calls.SetNewExpiry
+	mock.lockSetNewExpiry.RUnlock()
+	return calls
+}
@@ -0,0 +1,244 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+)
+
+// Ensure, that ConsentRepositoryMock does implement ConsentRepository.
+// If this is not the case, regenerate this file with moq.
+var _ ConsentRepository = &ConsentRepositoryMock{}
+
+// ConsentRepositoryMock is a mock implementation of ConsentRepository.
+//
+//	func TestSomethingThatUsesConsentRepository(t *testing.T) {
+//
+//		// make and configure a mocked ConsentRepository
+//		mockedConsentRepository := &ConsentRepositoryMock{
+//			DeleteConsentFunc: func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error {
+//				panic("mock out the DeleteConsent method")
+//			},
+//			FindConsentFunc: func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) (Consent, error) {
+//				panic("mock out the FindConsent method")
+//			},
+//			FindConsentsByUserIDFunc: func(ctx context.Context, userID uuid.UUID) ([]Consent, error) {
+//				panic("mock out the FindConsentsByUserID method")
+//			},
+//			StoreConsentFunc: func(ctx context.Context, c Consent) error {
+//				panic("mock out the StoreConsent method")
+//			},
+//		}
+//
+//		// use mockedConsentRepository in code that requires ConsentRepository
+//		// and then make assertions.
+//
+//	}
+type ConsentRepositoryMock struct {
+	// DeleteConsentFunc mocks the DeleteConsent method.
+	DeleteConsentFunc func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error
+
+	// FindConsentFunc mocks the FindConsent method.
+	FindConsentFunc func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) (Consent, error)
+
+	// FindConsentsByUserIDFunc mocks the FindConsentsByUserID method.
+	FindConsentsByUserIDFunc func(ctx context.Context, userID uuid.UUID) ([]Consent, error)
+
+	// StoreConsentFunc mocks the StoreConsent method.
+	StoreConsentFunc func(ctx context.Context, c Consent) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteConsent holds details about calls to the DeleteConsent method.
+		DeleteConsent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ClientID is the clientID argument value.
+			ClientID uuid.UUID
+		}
+		// FindConsent holds details about calls to the FindConsent method.
+		FindConsent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ClientID is the clientID argument value.
+			ClientID uuid.UUID
+		}
+		// FindConsentsByUserID holds details about calls to the FindConsentsByUserID method.
+		FindConsentsByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// StoreConsent holds details about calls to the StoreConsent method.
+		StoreConsent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// C is the c argument value.
+			C Consent
+		}
+	}
+	lockDeleteConsent        sync.RWMutex
+	lockFindConsent          sync.RWMutex
+	lockFindConsentsByUserID sync.RWMutex
+	lockStoreConsent         sync.RWMutex
+}
+
+// DeleteConsent calls DeleteConsentFunc.
+func (mock *ConsentRepositoryMock) DeleteConsent(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error {
+	if mock.DeleteConsentFunc == nil {
+		panic("ConsentRepositoryMock.DeleteConsentFunc: method is nil but ConsentRepository.DeleteConsent was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		ClientID: clientID,
+	}
+	mock.lockDeleteConsent.Lock()
+	mock.calls.DeleteConsent = append(mock.calls.DeleteConsent, callInfo)
+	mock.lockDeleteConsent.Unlock()
+	return mock.DeleteConsentFunc(ctx, userID, clientID)
+}
+
+// DeleteConsentCalls gets all the calls that were made to DeleteConsent.
+// Check the length with:
+//
+//	len(mockedConsentRepository.DeleteConsentCalls())
+func (mock *ConsentRepositoryMock) DeleteConsentCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	ClientID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+	}
+	mock.lockDeleteConsent.RLock()
+	calls = mock.calls.DeleteConsent
+	mock.lockDeleteConsent.RUnlock()
+	return calls
+}
+
+// FindConsent calls FindConsentFunc.
+func (mock *ConsentRepositoryMock) FindConsent(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) (Consent, error) {
+	if mock.FindConsentFunc == nil {
+		panic("ConsentRepositoryMock.FindConsentFunc: method is nil but ConsentRepository.FindConsent was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		ClientID: clientID,
+	}
+	mock.lockFindConsent.Lock()
+	mock.calls.FindConsent = append(mock.calls.FindConsent, callInfo)
+	mock.lockFindConsent.Unlock()
+	return mock.FindConsentFunc(ctx, userID, clientID)
+}
+
+// FindConsentCalls gets all the calls that were made to FindConsent.
+// Check the length with:
+//
+//	len(mockedConsentRepository.FindConsentCalls())
+func (mock *ConsentRepositoryMock) FindConsentCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	ClientID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+	}
+	mock.lockFindConsent.RLock()
+	calls = mock.calls.FindConsent
+	mock.lockFindConsent.RUnlock()
+	return calls
+}
+
+// FindConsentsByUserID calls FindConsentsByUserIDFunc.
+func (mock *ConsentRepositoryMock) FindConsentsByUserID(ctx context.Context, userID uuid.UUID) ([]Consent, error) {
+	if mock.FindConsentsByUserIDFunc == nil {
+		panic("ConsentRepositoryMock.FindConsentsByUserIDFunc: method is nil but ConsentRepository.FindConsentsByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockFindConsentsByUserID.Lock()
+	mock.calls.FindConsentsByUserID = append(mock.calls.FindConsentsByUserID, callInfo)
+	mock.lockFindConsentsByUserID.Unlock()
+	return mock.FindConsentsByUserIDFunc(ctx, userID)
+}
+
+// FindConsentsByUserIDCalls gets all the calls that were made to FindConsentsByUserID.
+// Check the length with:
+//
+//	len(mockedConsentRepository.FindConsentsByUserIDCalls())
+func (mock *ConsentRepositoryMock) FindConsentsByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockFindConsentsByUserID.RLock()
+	calls = mock.calls.FindConsentsByUserID
+	mock.lockFindConsentsByUserID.RUnlock()
+	return calls
+}
+
+// StoreConsent calls StoreConsentFunc.
+func (mock *ConsentRepositoryMock) StoreConsent(ctx context.Context, c Consent) error {
+	if mock.StoreConsentFunc == nil {
+		panic("ConsentRepositoryMock.StoreConsentFunc: method is nil but ConsentRepository.StoreConsent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		C   Consent
+	}{
+		Ctx: ctx,
+		C:   c,
+	}
+	mock.lockStoreConsent.Lock()
+	mock.calls.StoreConsent = append(mock.calls.StoreConsent, callInfo)
+	mock.lockStoreConsent.Unlock()
+	return mock.StoreConsentFunc(ctx, c)
+}
+
+// StoreConsentCalls gets all the calls that were made to StoreConsent.
+// Check the length with:
+//
+//	len(mockedConsentRepository.StoreConsentCalls())
+func (mock *ConsentRepositoryMock) StoreConsentCalls() []struct {
+	Ctx context.Context
+	C   Consent
+} {
+	var calls []struct {
+		Ctx context.Context
+		C   Consent
+	}
+	mock.lockStoreConsent.RLock()
+	calls = mock.calls.StoreConsent
+	mock.lockStoreConsent.RUnlock()
+	return calls
+}
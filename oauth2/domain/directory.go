@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+var (
+	// ErrDirectoryUserNotFound はディレクトリに該当する利用者がいない
+	ErrDirectoryUserNotFound = errors.New("directory user not found")
+	// ErrDirectoryInvalidCredentials はディレクトリがパスワードを受け付けなかった
+	ErrDirectoryInvalidCredentials = errors.New("directory rejected the credentials")
+)
+
+// DirectoryEntry はディレクトリで認証できた利用者
+type DirectoryEntry struct {
+	// ID は利用者を識別する変わらない値。名前の変更で変わり得る DN は使わない設定にできる
+	ID     string
+	DN     string
+	Name   string
+	Email  string
+	Groups []string
+}
+
+// IsMemberOfAny は groups のいずれかに所属しているかを判定する。groups が空の場合は true を返す
+func (e DirectoryEntry) IsMemberOfAny(groups []string) bool {
+	if len(groups) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(e.Groups, func(g string) bool {
+		return slices.ContainsFunc(groups, func(want string) bool {
+			return strings.EqualFold(g, want)
+		})
+	})
+}
+
+// Directory は LDAP などの外部のディレクトリ
+//
+//go:generate go run github.com/matryer/moq -out directory_mock.go . Directory
+type Directory interface {
+	// Authenticate は login で利用者を検索し、password で認証する。
+	// 利用者がいない場合は ErrDirectoryUserNotFound、パスワードが誤っている場合は ErrDirectoryInvalidCredentials を返す
+	Authenticate(ctx context.Context, login, password string) (*DirectoryEntry, error)
+}
@@ -0,0 +1,87 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that DirectoryMock does implement Directory.
+// If this is not the case, regenerate this file with moq.
+var _ Directory = &DirectoryMock{}
+
+// DirectoryMock is a mock implementation of Directory.
+//
+//	func TestSomethingThatUsesDirectory(t *testing.T) {
+//
+//		// make and configure a mocked Directory
+//		mockedDirectory := &DirectoryMock{
+//			AuthenticateFunc: func(ctx context.Context, login string, password string) (*DirectoryEntry, error) {
+//				panic("mock out the Authenticate method")
+//			},
+//		}
+//
+//		// use mockedDirectory in code that requires Directory
+//		// and then make assertions.
+//
+//	}
+type DirectoryMock struct {
+	// AuthenticateFunc mocks the Authenticate method.
+	AuthenticateFunc func(ctx context.Context, login string, password string) (*DirectoryEntry, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Authenticate holds details about calls to the Authenticate method.
+		Authenticate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Password is the password argument value.
+			Password string
+		}
+	}
+	lockAuthenticate sync.RWMutex
+}
+
+// Authenticate calls AuthenticateFunc.
+func (mock *DirectoryMock) Authenticate(ctx context.Context, login string, password string) (*DirectoryEntry, error) {
+	if mock.AuthenticateFunc == nil {
+		panic("DirectoryMock.AuthenticateFunc: method is nil but Directory.Authenticate was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Login    string
+		Password string
+	}{
+		Ctx:      ctx,
+		Login:    login,
+		Password: password,
+	}
+	mock.lockAuthenticate.Lock()
+	mock.calls.Authenticate = append(mock.calls.Authenticate, callInfo)
+	mock.lockAuthenticate.Unlock()
+	return mock.AuthenticateFunc(ctx, login, password)
+}
+
+// AuthenticateCalls gets all the calls that were made to Authenticate.
+// Check the length with:
+//
+//	len(mockedDirectory.AuthenticateCalls())
+func (mock *DirectoryMock) AuthenticateCalls() []struct {
+	Ctx      context.Context
+	Login    string
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Login    string
+		Password string
+	}
+	mock.lockAuthenticate.RLock()
+	calls = mock.calls.Authenticate
+	mock.lockAuthenticate.RUnlock()
+	return calls
+}
@@ -0,0 +1,68 @@
+package domainservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+)
+
+//go:generate go run github.com/matryer/moq -out consent_service_mock.go . ConsentService
+type ConsentService interface {
+	FindConsent(ctx context.Context, userID, clientID uuid.UUID) (domain.Consent, error)
+	GrantConsent(ctx context.Context, userID, clientID uuid.UUID, scopes domain.ScopeSet) (domain.Consent, error)
+}
+
+func NewConsentService(
+	consentRepo domain.ConsentRepository,
+	config *config.Config,
+) *consentService {
+	return &consentService{
+		consentRepo: consentRepo,
+		config:      config,
+	}
+}
+
+type consentService struct {
+	consentRepo domain.ConsentRepository
+	config      *config.Config
+}
+
+// FindConsent は有効な同意を返す。同意がない、または期限切れの場合は nil を返す
+func (s *consentService) FindConsent(ctx context.Context, userID, clientID uuid.UUID) (domain.Consent, error) {
+	c, err := s.consentRepo.FindConsent(ctx, userID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || c.IsExpired(time.Now()) {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// GrantConsent は許可されたスコープを既存の同意に追加して保存し、有効期限を延長する
+func (s *consentService) GrantConsent(ctx context.Context, userID, clientID uuid.UUID, scopes domain.ScopeSet) (domain.Consent, error) {
+	c, err := s.FindConsent(ctx, userID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		c, err = domain.NewConsent(domain.ConsentParams{
+			UserID:   userID,
+			ClientID: clientID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.Grant(scopes)
+	c.SetNewExpiry(s.config.ConsentExpiresDay)
+
+	if err := s.consentRepo.StoreConsent(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
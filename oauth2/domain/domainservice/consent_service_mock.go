@@ -0,0 +1,151 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domainservice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"sync"
+)
+
+// Ensure, that ConsentServiceMock does implement ConsentService.
+// If this is not the case, regenerate this file with moq.
+var _ ConsentService = &ConsentServiceMock{}
+
+// ConsentServiceMock is a mock implementation of ConsentService.
+//
+//	func TestSomethingThatUsesConsentService(t *testing.T) {
+//
+//		// make and configure a mocked ConsentService
+//		mockedConsentService := &ConsentServiceMock{
+//			FindConsentFunc: func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) (domain.Consent, error) {
+//				panic("mock out the FindConsent method")
+//			},
+//			GrantConsentFunc: func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID, scopes domain.ScopeSet) (domain.Consent, error) {
+//				panic("mock out the GrantConsent method")
+//			},
+//		}
+//
+//		// use mockedConsentService in code that requires ConsentService
+//		// and then make assertions.
+//
+//	}
+type ConsentServiceMock struct {
+	// FindConsentFunc mocks the FindConsent method.
+	FindConsentFunc func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) (domain.Consent, error)
+
+	// GrantConsentFunc mocks the GrantConsent method.
+	GrantConsentFunc func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID, scopes domain.ScopeSet) (domain.Consent, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// FindConsent holds details about calls to the FindConsent method.
+		FindConsent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ClientID is the clientID argument value.
+			ClientID uuid.UUID
+		}
+		// GrantConsent holds details about calls to the GrantConsent method.
+		GrantConsent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ClientID is the clientID argument value.
+			ClientID uuid.UUID
+			// Scopes is the scopes argument value.
+			Scopes domain.ScopeSet
+		}
+	}
+	lockFindConsent  sync.RWMutex
+	lockGrantConsent sync.RWMutex
+}
+
+// FindConsent calls FindConsentFunc.
+func (mock *ConsentServiceMock) FindConsent(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) (domain.Consent, error) {
+	if mock.FindConsentFunc == nil {
+		panic("ConsentServiceMock.FindConsentFunc: method is nil but ConsentService.FindConsent was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		ClientID: clientID,
+	}
+	mock.lockFindConsent.Lock()
+	mock.calls.FindConsent = append(mock.calls.FindConsent, callInfo)
+	mock.lockFindConsent.Unlock()
+	return mock.FindConsentFunc(ctx, userID, clientID)
+}
+
+// FindConsentCalls gets all the calls that were made to FindConsent.
+// Check the length with:
+//
+//	len(mockedConsentService.FindConsentCalls())
+func (mock *ConsentServiceMock) FindConsentCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	ClientID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+	}
+	mock.lockFindConsent.RLock()
+	calls = mock.calls.FindConsent
+	mock.lockFindConsent.RUnlock()
+	return calls
+}
+
+// GrantConsent calls GrantConsentFunc.
+func (mock *ConsentServiceMock) GrantConsent(ctx context.Context, userID uuid.UUID, clientID uuid.UUID, scopes domain.ScopeSet) (domain.Consent, error) {
+	if mock.GrantConsentFunc == nil {
+		panic("ConsentServiceMock.GrantConsentFunc: method is nil but ConsentService.GrantConsent was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+		Scopes   domain.ScopeSet
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		ClientID: clientID,
+		Scopes:   scopes,
+	}
+	mock.lockGrantConsent.Lock()
+	mock.calls.GrantConsent = append(mock.calls.GrantConsent, callInfo)
+	mock.lockGrantConsent.Unlock()
+	return mock.GrantConsentFunc(ctx, userID, clientID, scopes)
+}
+
+// GrantConsentCalls gets all the calls that were made to GrantConsent.
+// Check the length with:
+//
+//	len(mockedConsentService.GrantConsentCalls())
+func (mock *ConsentServiceMock) GrantConsentCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	ClientID uuid.UUID
+	Scopes   domain.ScopeSet
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+		Scopes   domain.ScopeSet
+	}
+	mock.lockGrantConsent.RLock()
+	calls = mock.calls.GrantConsent
+	mock.lockGrantConsent.RUnlock()
+	return calls
+}
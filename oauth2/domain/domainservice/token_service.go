@@ -12,13 +12,15 @@ import (
 
 //go:generate go run github.com/matryer/moq -out token_service_mock.go . TokenService
 type TokenService interface {
-	StoreNewToken(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error)
-	StoreNewRefreshToken(ctx context.Context, accessToken string) (domain.RefreshToken, error)
+	// StoreNewToken と StoreNewRefreshToken の有効期間に 0 を指定した場合は設定値を使う
+	StoreNewToken(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error)
+	StoreNewRefreshToken(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error)
 	FindToken(ctx context.Context, accessToken string) (domain.Token, error)
 	RevokeToken(ctx context.Context, accessToken string) error
 	FindRefreshToken(ctx context.Context, refreshToken string) (domain.RefreshToken, error)
 	RevokeRefreshToken(ctx context.Context, refreshToken string) error
 	FindTokenByRefreshToken(ctx context.Context, refreshToken string, now time.Time) (domain.Token, error)
+	RevokeTokensByAuthorizationCode(ctx context.Context, code string) error
 }
 
 func NewTokenService(
@@ -39,13 +41,20 @@ type tokenService struct {
 	config           *config.Config
 }
 
-func (s *tokenService) StoreNewToken(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error) {
+func (s *tokenService) StoreNewToken(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
 	atoken := domain.NewToken(domain.TokenParams{
-		ClientID: clientID,
-		UserID:   UserID,
-		Scope:    scope,
+		ClientID:          clientID,
+		UserID:            UserID,
+		Scope:             scope,
+		AuthorizationCode: authorizationCode,
 	})
 
+	// JWT の exp に入れるため、署名する前に有効期限を決める
+	if expiresMin == 0 {
+		expiresMin = s.config.AuthTokenExpiresMin
+	}
+	atoken.SetNewExpiry(expiresMin)
+
 	var at domain.AccessToken
 	token, err := at.Generate(atoken, s.config.PrivateKey)
 	if err != nil {
@@ -56,8 +65,6 @@ func (s *tokenService) StoreNewToken(ctx context.Context, clientID, UserID uuid.
 		return nil, err
 	}
 
-	atoken.SetNewExpiry(s.config.AuthTokenExpiresMin)
-
 	if err := s.tokenRepo.StoreToken(ctx, atoken); err != nil {
 		return nil, err
 	}
@@ -65,7 +72,7 @@ func (s *tokenService) StoreNewToken(ctx context.Context, clientID, UserID uuid.
 	return atoken, nil
 }
 
-func (s *tokenService) StoreNewRefreshToken(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+func (s *tokenService) StoreNewRefreshToken(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 	var rt domain.RefreshTokenString
 	refreshToken, err := rt.Generate()
 	if err != nil {
@@ -77,7 +84,10 @@ func (s *tokenService) StoreNewRefreshToken(ctx context.Context, accessToken str
 		RefreshToken: refreshToken,
 	})
 
-	rtoken.SetNewExpiry(s.config.AuthRefreshTokenExpiresDay)
+	if expiresDay == 0 {
+		expiresDay = s.config.AuthRefreshTokenExpiresDay
+	}
+	rtoken.SetNewExpiry(expiresDay)
 
 	if err := s.refreshTokenRepo.StoreRefreshToken(ctx, rtoken); err != nil {
 		return nil, err
@@ -101,6 +111,14 @@ func (s *tokenService) RevokeRefreshToken(ctx context.Context, refreshToken stri
 	return s.refreshTokenRepo.RevokeRefreshToken(ctx, refreshToken)
 }
 
+// RevokeTokensByAuthorizationCode は認可コードから発行された全てのトークンを失効させる
+func (s *tokenService) RevokeTokensByAuthorizationCode(ctx context.Context, code string) error {
+	if err := s.refreshTokenRepo.RevokeRefreshTokensByAuthorizationCode(ctx, code); err != nil {
+		return err
+	}
+	return s.tokenRepo.RevokeTokensByAuthorizationCode(ctx, code)
+}
+
 func (s *tokenService) FindTokenByRefreshToken(ctx context.Context, refreshToken string, now time.Time) (domain.Token, error) {
 	rt, err := s.refreshTokenRepo.FindRefreshToken(ctx, refreshToken)
 	if err != nil {
@@ -36,10 +36,13 @@ var _ TokenService = &TokenServiceMock{}
 //			RevokeTokenFunc: func(ctx context.Context, accessToken string) error {
 //				panic("mock out the RevokeToken method")
 //			},
-//			StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+//			RevokeTokensByAuthorizationCodeFunc: func(ctx context.Context, code string) error {
+//				panic("mock out the RevokeTokensByAuthorizationCode method")
+//			},
+//			StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 //				panic("mock out the StoreNewRefreshToken method")
 //			},
-//			StoreNewTokenFunc: func(ctx context.Context, clientID uuid.UUID, UserID uuid.UUID, scope string) (domain.Token, error) {
+//			StoreNewTokenFunc: func(ctx context.Context, clientID uuid.UUID, UserID uuid.UUID, scope string, authorizationCode string, expiresMin int) (domain.Token, error) {
 //				panic("mock out the StoreNewToken method")
 //			},
 //		}
@@ -64,11 +67,14 @@ type TokenServiceMock struct {
 	// RevokeTokenFunc mocks the RevokeToken method.
 	RevokeTokenFunc func(ctx context.Context, accessToken string) error
 
+	// RevokeTokensByAuthorizationCodeFunc mocks the RevokeTokensByAuthorizationCode method.
+	RevokeTokensByAuthorizationCodeFunc func(ctx context.Context, code string) error
+
 	// StoreNewRefreshTokenFunc mocks the StoreNewRefreshToken method.
-	StoreNewRefreshTokenFunc func(ctx context.Context, accessToken string) (domain.RefreshToken, error)
+	StoreNewRefreshTokenFunc func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error)
 
 	// StoreNewTokenFunc mocks the StoreNewToken method.
-	StoreNewTokenFunc func(ctx context.Context, clientID uuid.UUID, UserID uuid.UUID, scope string) (domain.Token, error)
+	StoreNewTokenFunc func(ctx context.Context, clientID uuid.UUID, UserID uuid.UUID, scope string, authorizationCode string, expiresMin int) (domain.Token, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -109,12 +115,21 @@ type TokenServiceMock struct {
 			// AccessToken is the accessToken argument value.
 			AccessToken string
 		}
+		// RevokeTokensByAuthorizationCode holds details about calls to the RevokeTokensByAuthorizationCode method.
+		RevokeTokensByAuthorizationCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+		}
 		// StoreNewRefreshToken holds details about calls to the StoreNewRefreshToken method.
 		StoreNewRefreshToken []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// AccessToken is the accessToken argument value.
 			AccessToken string
+			// ExpiresDay is the expiresDay argument value.
+			ExpiresDay int
 		}
 		// StoreNewToken holds details about calls to the StoreNewToken method.
 		StoreNewToken []struct {
@@ -126,15 +141,20 @@ type TokenServiceMock struct {
 			UserID uuid.UUID
 			// Scope is the scope argument value.
 			Scope string
+			// AuthorizationCode is the authorizationCode argument value.
+			AuthorizationCode string
+			// ExpiresMin is the expiresMin argument value.
+			ExpiresMin int
 		}
 	}
-	lockFindRefreshToken        sync.RWMutex
-	lockFindToken               sync.RWMutex
-	lockFindTokenByRefreshToken sync.RWMutex
-	lockRevokeRefreshToken      sync.RWMutex
-	lockRevokeToken             sync.RWMutex
-	lockStoreNewRefreshToken    sync.RWMutex
-	lockStoreNewToken           sync.RWMutex
+	lockFindRefreshToken                sync.RWMutex
+	lockFindToken                       sync.RWMutex
+	lockFindTokenByRefreshToken         sync.RWMutex
+	lockRevokeRefreshToken              sync.RWMutex
+	lockRevokeToken                     sync.RWMutex
+	lockRevokeTokensByAuthorizationCode sync.RWMutex
+	lockStoreNewRefreshToken            sync.RWMutex
+	lockStoreNewToken                   sync.RWMutex
 }
 
 // FindRefreshToken calls FindRefreshTokenFunc.
@@ -321,22 +341,60 @@ func (mock *TokenServiceMock) RevokeTokenCalls() []struct {
 	return calls
 }
 
+// RevokeTokensByAuthorizationCode calls RevokeTokensByAuthorizationCodeFunc.
+func (mock *TokenServiceMock) RevokeTokensByAuthorizationCode(ctx context.Context, code string) error {
+	if mock.RevokeTokensByAuthorizationCodeFunc == nil {
+		panic("TokenServiceMock.RevokeTokensByAuthorizationCodeFunc: method is nil but TokenService.RevokeTokensByAuthorizationCode was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+	}{
+		Ctx:  ctx,
+		Code: code,
+	}
+	mock.lockRevokeTokensByAuthorizationCode.Lock()
+	mock.calls.RevokeTokensByAuthorizationCode = append(mock.calls.RevokeTokensByAuthorizationCode, callInfo)
+	mock.lockRevokeTokensByAuthorizationCode.Unlock()
+	return mock.RevokeTokensByAuthorizationCodeFunc(ctx, code)
+}
+
+// RevokeTokensByAuthorizationCodeCalls gets all the calls that were made to RevokeTokensByAuthorizationCode.
+// Check the length with:
+//
+//	len(mockedTokenService.RevokeTokensByAuthorizationCodeCalls())
+func (mock *TokenServiceMock) RevokeTokensByAuthorizationCodeCalls() []struct {
+	Ctx  context.Context
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+	}
+	mock.lockRevokeTokensByAuthorizationCode.RLock()
+	calls = mock.calls.RevokeTokensByAuthorizationCode
+	mock.lockRevokeTokensByAuthorizationCode.RUnlock()
+	return calls
+}
+
 // StoreNewRefreshToken calls StoreNewRefreshTokenFunc.
-func (mock *TokenServiceMock) StoreNewRefreshToken(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+func (mock *TokenServiceMock) StoreNewRefreshToken(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 	if mock.StoreNewRefreshTokenFunc == nil {
 		panic("TokenServiceMock.StoreNewRefreshTokenFunc: method is nil but TokenService.StoreNewRefreshToken was just called")
 	}
 	callInfo := struct {
 		Ctx         context.Context
 		AccessToken string
+		ExpiresDay  int
 	}{
 		Ctx:         ctx,
 		AccessToken: accessToken,
+		ExpiresDay:  expiresDay,
 	}
 	mock.lockStoreNewRefreshToken.Lock()
 	mock.calls.StoreNewRefreshToken = append(mock.calls.StoreNewRefreshToken, callInfo)
 	mock.lockStoreNewRefreshToken.Unlock()
-	return mock.StoreNewRefreshTokenFunc(ctx, accessToken)
+	return mock.StoreNewRefreshTokenFunc(ctx, accessToken, expiresDay)
 }
 
 // StoreNewRefreshTokenCalls gets all the calls that were made to StoreNewRefreshToken.
@@ -346,10 +404,12 @@ func (mock *TokenServiceMock) StoreNewRefreshToken(ctx context.Context, accessTo
 func (mock *TokenServiceMock) StoreNewRefreshTokenCalls() []struct {
 	Ctx         context.Context
 	AccessToken string
+	ExpiresDay  int
 } {
 	var calls []struct {
 		Ctx         context.Context
 		AccessToken string
+		ExpiresDay  int
 	}
 	mock.lockStoreNewRefreshToken.RLock()
 	calls = mock.calls.StoreNewRefreshToken
@@ -358,25 +418,29 @@ func (mock *TokenServiceMock) StoreNewRefreshTokenCalls() []struct {
 }
 
 // StoreNewToken calls StoreNewTokenFunc.
-func (mock *TokenServiceMock) StoreNewToken(ctx context.Context, clientID uuid.UUID, UserID uuid.UUID, scope string) (domain.Token, error) {
+func (mock *TokenServiceMock) StoreNewToken(ctx context.Context, clientID uuid.UUID, UserID uuid.UUID, scope string, authorizationCode string, expiresMin int) (domain.Token, error) {
 	if mock.StoreNewTokenFunc == nil {
 		panic("TokenServiceMock.StoreNewTokenFunc: method is nil but TokenService.StoreNewToken was just called")
 	}
 	callInfo := struct {
-		Ctx      context.Context
-		ClientID uuid.UUID
-		UserID   uuid.UUID
-		Scope    string
+		Ctx               context.Context
+		ClientID          uuid.UUID
+		UserID            uuid.UUID
+		Scope             string
+		AuthorizationCode string
+		ExpiresMin        int
 	}{
-		Ctx:      ctx,
-		ClientID: clientID,
-		UserID:   UserID,
-		Scope:    scope,
+		Ctx:               ctx,
+		ClientID:          clientID,
+		UserID:            UserID,
+		Scope:             scope,
+		AuthorizationCode: authorizationCode,
+		ExpiresMin:        expiresMin,
 	}
 	mock.lockStoreNewToken.Lock()
 	mock.calls.StoreNewToken = append(mock.calls.StoreNewToken, callInfo)
 	mock.lockStoreNewToken.Unlock()
-	return mock.StoreNewTokenFunc(ctx, clientID, UserID, scope)
+	return mock.StoreNewTokenFunc(ctx, clientID, UserID, scope, authorizationCode, expiresMin)
 }
 
 // StoreNewTokenCalls gets all the calls that were made to StoreNewToken.
@@ -384,16 +448,20 @@ func (mock *TokenServiceMock) StoreNewToken(ctx context.Context, clientID uuid.U
 //
 //	len(mockedTokenService.StoreNewTokenCalls())
 func (mock *TokenServiceMock) StoreNewTokenCalls() []struct {
-	Ctx      context.Context
-	ClientID uuid.UUID
-	UserID   uuid.UUID
-	Scope    string
+	Ctx               context.Context
+	ClientID          uuid.UUID
+	UserID            uuid.UUID
+	Scope             string
+	AuthorizationCode string
+	ExpiresMin        int
 } {
 	var calls []struct {
-		Ctx      context.Context
-		ClientID uuid.UUID
-		UserID   uuid.UUID
-		Scope    string
+		Ctx               context.Context
+		ClientID          uuid.UUID
+		UserID            uuid.UUID
+		Scope             string
+		AuthorizationCode string
+		ExpiresMin        int
 	}
 	mock.lockStoreNewToken.RLock()
 	calls = mock.calls.StoreNewToken
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmailVerificationTokenParams struct {
+	TokenHash string
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func NewEmailVerificationToken(p EmailVerificationTokenParams) EmailVerificationToken {
+	return &emailVerificationToken{
+		tokenHash: p.TokenHash,
+		userID:    p.UserID,
+		expiresAt: p.ExpiresAt,
+		usedAt:    p.UsedAt,
+		createdAt: p.CreatedAt,
+	}
+}
+
+// IssueEmailVerificationToken は新しい確認用トークンを発行する。
+// 戻り値の文字列はメールで送るトークンで、保存されるのはそのハッシュのみ
+func IssueEmailVerificationToken(userID uuid.UUID, expires time.Duration) (EmailVerificationToken, string, error) {
+	token, hash, err := GenerateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	return NewEmailVerificationToken(EmailVerificationTokenParams{
+		TokenHash: hash,
+		UserID:    userID,
+		ExpiresAt: now.Add(expires),
+		CreatedAt: now,
+	}), token, nil
+}
+
+//go:generate go run github.com/matryer/moq -out email_verification_token_mock.go . EmailVerificationToken
+type EmailVerificationToken interface {
+	GetTokenHash() string
+	GetUserID() uuid.UUID
+	GetExpiresAt() time.Time
+	GetCreatedAt() time.Time
+	IsExpired(now time.Time) bool
+	IsUsed() bool
+}
+
+//go:generate go run github.com/matryer/moq -out email_verification_token_repository_mock.go . EmailVerificationTokenRepository
+type EmailVerificationTokenRepository interface {
+	StoreEmailVerificationToken(ctx context.Context, t EmailVerificationToken) error
+	FindEmailVerificationToken(ctx context.Context, tokenHash string) (EmailVerificationToken, error)
+	// ConsumeEmailVerificationToken は未使用のトークンを使用済みにする。既に使用済みの場合は false を返す
+	ConsumeEmailVerificationToken(ctx context.Context, tokenHash string) (bool, error)
+	CountEmailVerificationTokensSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+}
+
+type emailVerificationToken struct {
+	tokenHash string
+	userID    uuid.UUID
+	expiresAt time.Time
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+func (t *emailVerificationToken) GetTokenHash() string {
+	return t.tokenHash
+}
+
+func (t *emailVerificationToken) GetUserID() uuid.UUID {
+	return t.userID
+}
+
+func (t *emailVerificationToken) GetExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *emailVerificationToken) GetCreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *emailVerificationToken) IsExpired(now time.Time) bool {
+	return now.After(t.expiresAt)
+}
+
+func (t *emailVerificationToken) IsUsed() bool {
+	return t.usedAt != nil
+}
+
+// EmailVerificationPolicy はメールアドレスを確認していないユーザーに対する制限
+type EmailVerificationPolicy struct {
+	// RequireForSignin が true の場合は確認するまでサインインさせない
+	RequireForSignin bool
+	// RequiredScopes は確認するまで許可しないスコープ
+	RequiredScopes ScopeSet
+}
+
+func (p EmailVerificationPolicy) BlocksSignin(u User) bool {
+	return p.RequireForSignin && !u.IsEmailVerified()
+}
+
+// BlockedScopes は要求されたスコープのうち、確認していないために許可できないものを返す
+func (p EmailVerificationPolicy) BlockedScopes(u User, requested ScopeSet) ScopeSet {
+	if u.IsEmailVerified() {
+		return ScopeSet{}
+	}
+	return requested.Intersect(p.RequiredScopes)
+}
@@ -0,0 +1,239 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Ensure, that EmailVerificationTokenRepositoryMock does implement EmailVerificationTokenRepository.
+// If this is not the case, regenerate this file with moq.
+var _ EmailVerificationTokenRepository = &EmailVerificationTokenRepositoryMock{}
+
+// EmailVerificationTokenRepositoryMock is a mock implementation of EmailVerificationTokenRepository.
+//
+//	func TestSomethingThatUsesEmailVerificationTokenRepository(t *testing.T) {
+//
+//		// make and configure a mocked EmailVerificationTokenRepository
+//		mockedEmailVerificationTokenRepository := &EmailVerificationTokenRepositoryMock{
+//			ConsumeEmailVerificationTokenFunc: func(ctx context.Context, tokenHash string) (bool, error) {
+//				panic("mock out the ConsumeEmailVerificationToken method")
+//			},
+//			CountEmailVerificationTokensSinceFunc: func(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+//				panic("mock out the CountEmailVerificationTokensSince method")
+//			},
+//			FindEmailVerificationTokenFunc: func(ctx context.Context, tokenHash string) (EmailVerificationToken, error) {
+//				panic("mock out the FindEmailVerificationToken method")
+//			},
+//			StoreEmailVerificationTokenFunc: func(ctx context.Context, t EmailVerificationToken) error {
+//				panic("mock out the StoreEmailVerificationToken method")
+//			},
+//		}
+//
+//		// use mockedEmailVerificationTokenRepository in code that requires EmailVerificationTokenRepository
+//		// and then make assertions.
+//
+//	}
+type EmailVerificationTokenRepositoryMock struct {
+	// ConsumeEmailVerificationTokenFunc mocks the ConsumeEmailVerificationToken method.
+	ConsumeEmailVerificationTokenFunc func(ctx context.Context, tokenHash string) (bool, error)
+
+	// CountEmailVerificationTokensSinceFunc mocks the CountEmailVerificationTokensSince method.
+	CountEmailVerificationTokensSinceFunc func(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+
+	// FindEmailVerificationTokenFunc mocks the FindEmailVerificationToken method.
+	FindEmailVerificationTokenFunc func(ctx context.Context, tokenHash string) (EmailVerificationToken, error)
+
+	// StoreEmailVerificationTokenFunc mocks the StoreEmailVerificationToken method.
+	StoreEmailVerificationTokenFunc func(ctx context.Context, t EmailVerificationToken) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ConsumeEmailVerificationToken holds details about calls to the ConsumeEmailVerificationToken method.
+		ConsumeEmailVerificationToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TokenHash is the tokenHash argument value.
+			TokenHash string
+		}
+		// CountEmailVerificationTokensSince holds details about calls to the CountEmailVerificationTokensSince method.
+		CountEmailVerificationTokensSince []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Since is the since argument value.
+			Since time.Time
+		}
+		// FindEmailVerificationToken holds details about calls to the FindEmailVerificationToken method.
+		FindEmailVerificationToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TokenHash is the tokenHash argument value.
+			TokenHash string
+		}
+		// StoreEmailVerificationToken holds details about calls to the StoreEmailVerificationToken method.
+		StoreEmailVerificationToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// T is the t argument value.
+			T EmailVerificationToken
+		}
+	}
+	lockConsumeEmailVerificationToken     sync.RWMutex
+	lockCountEmailVerificationTokensSince sync.RWMutex
+	lockFindEmailVerificationToken        sync.RWMutex
+	lockStoreEmailVerificationToken       sync.RWMutex
+}
+
+// ConsumeEmailVerificationToken calls ConsumeEmailVerificationTokenFunc.
+func (mock *EmailVerificationTokenRepositoryMock) ConsumeEmailVerificationToken(ctx context.Context, tokenHash string) (bool, error) {
+	if mock.ConsumeEmailVerificationTokenFunc == nil {
+		panic("EmailVerificationTokenRepositoryMock.ConsumeEmailVerificationTokenFunc: method is nil but EmailVerificationTokenRepository.ConsumeEmailVerificationToken was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		TokenHash string
+	}{
+		Ctx:       ctx,
+		TokenHash: tokenHash,
+	}
+	mock.lockConsumeEmailVerificationToken.Lock()
+	mock.calls.ConsumeEmailVerificationToken = append(mock.calls.ConsumeEmailVerificationToken, callInfo)
+	mock.lockConsumeEmailVerificationToken.Unlock()
+	return mock.ConsumeEmailVerificationTokenFunc(ctx, tokenHash)
+}
+
+// ConsumeEmailVerificationTokenCalls gets all the calls that were made to ConsumeEmailVerificationToken.
+// Check the length with:
+//
+//	len(mockedEmailVerificationTokenRepository.ConsumeEmailVerificationTokenCalls())
+func (mock *EmailVerificationTokenRepositoryMock) ConsumeEmailVerificationTokenCalls() []struct {
+	Ctx       context.Context
+	TokenHash string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		TokenHash string
+	}
+	mock.lockConsumeEmailVerificationToken.RLock()
+	calls = mock.calls.ConsumeEmailVerificationToken
+	mock.lockConsumeEmailVerificationToken.RUnlock()
+	return calls
+}
+
+// CountEmailVerificationTokensSince calls CountEmailVerificationTokensSinceFunc.
+func (mock *EmailVerificationTokenRepositoryMock) CountEmailVerificationTokensSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	if mock.CountEmailVerificationTokensSinceFunc == nil {
+		panic("EmailVerificationTokenRepositoryMock.CountEmailVerificationTokensSinceFunc: method is nil but EmailVerificationTokenRepository.CountEmailVerificationTokensSince was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Since:  since,
+	}
+	mock.lockCountEmailVerificationTokensSince.Lock()
+	mock.calls.CountEmailVerificationTokensSince = append(mock.calls.CountEmailVerificationTokensSince, callInfo)
+	mock.lockCountEmailVerificationTokensSince.Unlock()
+	return mock.CountEmailVerificationTokensSinceFunc(ctx, userID, since)
+}
+
+// CountEmailVerificationTokensSinceCalls gets all the calls that were made to CountEmailVerificationTokensSince.
+// Check the length with:
+//
+//	len(mockedEmailVerificationTokenRepository.CountEmailVerificationTokensSinceCalls())
+func (mock *EmailVerificationTokenRepositoryMock) CountEmailVerificationTokensSinceCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Since  time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+	}
+	mock.lockCountEmailVerificationTokensSince.RLock()
+	calls = mock.calls.CountEmailVerificationTokensSince
+	mock.lockCountEmailVerificationTokensSince.RUnlock()
+	return calls
+}
+
+// FindEmailVerificationToken calls FindEmailVerificationTokenFunc.
+func (mock *EmailVerificationTokenRepositoryMock) FindEmailVerificationToken(ctx context.Context, tokenHash string) (EmailVerificationToken, error) {
+	if mock.FindEmailVerificationTokenFunc == nil {
+		panic("EmailVerificationTokenRepositoryMock.FindEmailVerificationTokenFunc: method is nil but EmailVerificationTokenRepository.FindEmailVerificationToken was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		TokenHash string
+	}{
+		Ctx:       ctx,
+		TokenHash: tokenHash,
+	}
+	mock.lockFindEmailVerificationToken.Lock()
+	mock.calls.FindEmailVerificationToken = append(mock.calls.FindEmailVerificationToken, callInfo)
+	mock.lockFindEmailVerificationToken.Unlock()
+	return mock.FindEmailVerificationTokenFunc(ctx, tokenHash)
+}
+
+// FindEmailVerificationTokenCalls gets all the calls that were made to FindEmailVerificationToken.
+// Check the length with:
+//
+//	len(mockedEmailVerificationTokenRepository.FindEmailVerificationTokenCalls())
+func (mock *EmailVerificationTokenRepositoryMock) FindEmailVerificationTokenCalls() []struct {
+	Ctx       context.Context
+	TokenHash string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		TokenHash string
+	}
+	mock.lockFindEmailVerificationToken.RLock()
+	calls = mock.calls.FindEmailVerificationToken
+	mock.lockFindEmailVerificationToken.RUnlock()
+	return calls
+}
+
+// StoreEmailVerificationToken calls StoreEmailVerificationTokenFunc.
+func (mock *EmailVerificationTokenRepositoryMock) StoreEmailVerificationToken(ctx context.Context, t EmailVerificationToken) error {
+	if mock.StoreEmailVerificationTokenFunc == nil {
+		panic("EmailVerificationTokenRepositoryMock.StoreEmailVerificationTokenFunc: method is nil but EmailVerificationTokenRepository.StoreEmailVerificationToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		T   EmailVerificationToken
+	}{
+		Ctx: ctx,
+		T:   t,
+	}
+	mock.lockStoreEmailVerificationToken.Lock()
+	mock.calls.StoreEmailVerificationToken = append(mock.calls.StoreEmailVerificationToken, callInfo)
+	mock.lockStoreEmailVerificationToken.Unlock()
+	return mock.StoreEmailVerificationTokenFunc(ctx, t)
+}
+
+// StoreEmailVerificationTokenCalls gets all the calls that were made to StoreEmailVerificationToken.
+// Check the length with:
+//
+//	len(mockedEmailVerificationTokenRepository.StoreEmailVerificationTokenCalls())
+func (mock *EmailVerificationTokenRepositoryMock) StoreEmailVerificationTokenCalls() []struct {
+	Ctx context.Context
+	T   EmailVerificationToken
+} {
+	var calls []struct {
+		Ctx context.Context
+		T   EmailVerificationToken
+	}
+	mock.lockStoreEmailVerificationToken.RLock()
+	calls = mock.calls.StoreEmailVerificationToken
+	mock.lockStoreEmailVerificationToken.RUnlock()
+	return calls
+}
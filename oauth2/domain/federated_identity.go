@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FederatedIdentityParams struct {
+	Provider   string
+	Subject    string
+	UserID     uuid.UUID
+	Email      string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+func NewFederatedIdentity(p FederatedIdentityParams) FederatedIdentity {
+	return &federatedIdentity{
+		provider:   p.Provider,
+		subject:    p.Subject,
+		userID:     p.UserID,
+		email:      p.Email,
+		createdAt:  p.CreatedAt,
+		lastUsedAt: p.LastUsedAt,
+	}
+}
+
+// FederatedIdentity は上流のプロバイダの利用者 (iss ごとの sub) とローカルのユーザーの紐づけ。
+// メールアドレスは変わり得るため、照合には Subject を使う
+//
+//go:generate go run github.com/matryer/moq -out federated_identity_mock.go . FederatedIdentity
+type FederatedIdentity interface {
+	GetProvider() string
+	GetSubject() string
+	GetUserID() uuid.UUID
+	// GetEmail は最後にサインインしたときにプロバイダが返したメールアドレス
+	GetEmail() string
+	GetCreatedAt() time.Time
+	GetLastUsedAt() *time.Time
+}
+
+//go:generate go run github.com/matryer/moq -out federated_identity_repository_mock.go . FederatedIdentityRepository
+type FederatedIdentityRepository interface {
+	// FindFederatedIdentity は紐づけがない場合に nil を返す
+	FindFederatedIdentity(ctx context.Context, provider, subject string) (FederatedIdentity, error)
+	StoreFederatedIdentity(ctx context.Context, fi FederatedIdentity) error
+	TouchFederatedIdentity(ctx context.Context, provider, subject, email string, usedAt time.Time) error
+}
+
+type federatedIdentity struct {
+	provider   string
+	subject    string
+	userID     uuid.UUID
+	email      string
+	createdAt  time.Time
+	lastUsedAt *time.Time
+}
+
+func (f *federatedIdentity) GetProvider() string {
+	return f.provider
+}
+
+func (f *federatedIdentity) GetSubject() string {
+	return f.subject
+}
+
+func (f *federatedIdentity) GetUserID() uuid.UUID {
+	return f.userID
+}
+
+func (f *federatedIdentity) GetEmail() string {
+	return f.email
+}
+
+func (f *federatedIdentity) GetCreatedAt() time.Time {
+	return f.createdAt
+}
+
+func (f *federatedIdentity) GetLastUsedAt() *time.Time {
+	return f.lastUsedAt
+}
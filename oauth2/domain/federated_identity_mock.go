@@ -0,0 +1,254 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Ensure, that FederatedIdentityMock does implement FederatedIdentity.
+// If this is not the case, regenerate this file with moq.
+var _ FederatedIdentity = &FederatedIdentityMock{}
+
+// FederatedIdentityMock is a mock implementation of FederatedIdentity.
+//
+//	func TestSomethingThatUsesFederatedIdentity(t *testing.T) {
+//
+//		// make and configure a mocked FederatedIdentity
+//		mockedFederatedIdentity := &FederatedIdentityMock{
+//			GetCreatedAtFunc: func() time.Time {
+//				panic("mock out the GetCreatedAt method")
+//			},
+//			GetEmailFunc: func() string {
+//				panic("mock out the GetEmail method")
+//			},
+//			GetLastUsedAtFunc: func() *time.Time {
+//				panic("mock out the GetLastUsedAt method")
+//			},
+//			GetProviderFunc: func() string {
+//				panic("mock out the GetProvider method")
+//			},
+//			GetSubjectFunc: func() string {
+//				panic("mock out the GetSubject method")
+//			},
+//			GetUserIDFunc: func() uuid.UUID {
+//				panic("mock out the GetUserID method")
+//			},
+//		}
+//
+//		// use mockedFederatedIdentity in code that requires FederatedIdentity
+//		// and then make assertions.
+//
+//	}
+type FederatedIdentityMock struct {
+	// GetCreatedAtFunc mocks the GetCreatedAt method.
+	GetCreatedAtFunc func() time.Time
+
+	// GetEmailFunc mocks the GetEmail method.
+	GetEmailFunc func() string
+
+	// GetLastUsedAtFunc mocks the GetLastUsedAt method.
+	GetLastUsedAtFunc func() *time.Time
+
+	// GetProviderFunc mocks the GetProvider method.
+	GetProviderFunc func() string
+
+	// GetSubjectFunc mocks the GetSubject method.
+	GetSubjectFunc func() string
+
+	// GetUserIDFunc mocks the GetUserID method.
+	GetUserIDFunc func() uuid.UUID
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetCreatedAt holds details about calls to the GetCreatedAt method.
+		GetCreatedAt []struct {
+		}
+		// GetEmail holds details about calls to the GetEmail method.
+		GetEmail []struct {
+		}
+		// GetLastUsedAt holds details about calls to the GetLastUsedAt method.
+		GetLastUsedAt []struct {
+		}
+		// GetProvider holds details about calls to the GetProvider method.
+		GetProvider []struct {
+		}
+		// GetSubject holds details about calls to the GetSubject method.
+		GetSubject []struct {
+		}
+		// GetUserID holds details about calls to the GetUserID method.
+		GetUserID []struct {
+		}
+	}
+	lockGetCreatedAt  sync.RWMutex
+	lockGetEmail      sync.RWMutex
+	lockGetLastUsedAt sync.RWMutex
+	lockGetProvider   sync.RWMutex
+	lockGetSubject    sync.RWMutex
+	lockGetUserID     sync.RWMutex
+}
+
+// GetCreatedAt calls GetCreatedAtFunc.
+func (mock *FederatedIdentityMock) GetCreatedAt() time.Time {
+	if mock.GetCreatedAtFunc == nil {
+		panic("FederatedIdentityMock.GetCreatedAtFunc: method is nil but FederatedIdentity.GetCreatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCreatedAt.Lock()
+	mock.calls.GetCreatedAt = append(mock.calls.GetCreatedAt, callInfo)
+	mock.lockGetCreatedAt.Unlock()
+	return mock.GetCreatedAtFunc()
+}
+
+// GetCreatedAtCalls gets all the calls that were made to GetCreatedAt.
+// Check the length with:
+//
+//	len(mockedFederatedIdentity.GetCreatedAtCalls())
+func (mock *FederatedIdentityMock) GetCreatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCreatedAt.RLock()
+	calls = mock.calls.GetCreatedAt
+	mock.lockGetCreatedAt.RUnlock()
+	return calls
+}
+
+// GetEmail calls GetEmailFunc.
+func (mock *FederatedIdentityMock) GetEmail() string {
+	if mock.GetEmailFunc == nil {
+		panic("FederatedIdentityMock.GetEmailFunc: method is nil but FederatedIdentity.GetEmail was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetEmail.Lock()
+	mock.calls.GetEmail = append(mock.calls.GetEmail, callInfo)
+	mock.lockGetEmail.Unlock()
+	return mock.GetEmailFunc()
+}
+
+// GetEmailCalls gets all the calls that were made to GetEmail.
+// Check the length with:
+//
+//	len(mockedFederatedIdentity.GetEmailCalls())
+func (mock *FederatedIdentityMock) GetEmailCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetEmail.RLock()
+	calls = mock.calls.GetEmail
+	mock.lockGetEmail.RUnlock()
+	return calls
+}
+
+// GetLastUsedAt calls GetLastUsedAtFunc.
+func (mock *FederatedIdentityMock) GetLastUsedAt() *time.Time {
+	if mock.GetLastUsedAtFunc == nil {
+		panic("FederatedIdentityMock.GetLastUsedAtFunc: method is nil but FederatedIdentity.GetLastUsedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetLastUsedAt.Lock()
+	mock.calls.GetLastUsedAt = append(mock.calls.GetLastUsedAt, callInfo)
+	mock.lockGetLastUsedAt.Unlock()
+	return mock.GetLastUsedAtFunc()
+}
+
+// GetLastUsedAtCalls gets all the calls that were made to GetLastUsedAt.
+// Check the length with:
+//
+//	len(mockedFederatedIdentity.GetLastUsedAtCalls())
+func (mock *FederatedIdentityMock) GetLastUsedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetLastUsedAt.RLock()
+	calls = mock.calls.GetLastUsedAt
+	mock.lockGetLastUsedAt.RUnlock()
+	return calls
+}
+
+// GetProvider calls GetProviderFunc.
+func (mock *FederatedIdentityMock) GetProvider() string {
+	if mock.GetProviderFunc == nil {
+		panic("FederatedIdentityMock.GetProviderFunc: method is nil but FederatedIdentity.GetProvider was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetProvider.Lock()
+	mock.calls.GetProvider = append(mock.calls.GetProvider, callInfo)
+	mock.lockGetProvider.Unlock()
+	return mock.GetProviderFunc()
+}
+
+// GetProviderCalls gets all the calls that were made to GetProvider.
+// Check the length with:
+//
+//	len(mockedFederatedIdentity.GetProviderCalls())
+func (mock *FederatedIdentityMock) GetProviderCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetProvider.RLock()
+	calls = mock.calls.GetProvider
+	mock.lockGetProvider.RUnlock()
+	return calls
+}
+
+// GetSubject calls GetSubjectFunc.
+func (mock *FederatedIdentityMock) GetSubject() string {
+	if mock.GetSubjectFunc == nil {
+		panic("FederatedIdentityMock.GetSubjectFunc: method is nil but FederatedIdentity.GetSubject was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetSubject.Lock()
+	mock.calls.GetSubject = append(mock.calls.GetSubject, callInfo)
+	mock.lockGetSubject.Unlock()
+	return mock.GetSubjectFunc()
+}
+
+// GetSubjectCalls gets all the calls that were made to GetSubject.
+// Check the length with:
+//
+//	len(mockedFederatedIdentity.GetSubjectCalls())
+func (mock *FederatedIdentityMock) GetSubjectCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetSubject.RLock()
+	calls = mock.calls.GetSubject
+	mock.lockGetSubject.RUnlock()
+	return calls
+}
+
+// GetUserID calls GetUserIDFunc.
+func (mock *FederatedIdentityMock) GetUserID() uuid.UUID {
+	if mock.GetUserIDFunc == nil {
+		panic("FederatedIdentityMock.GetUserIDFunc: method is nil but FederatedIdentity.GetUserID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetUserID.Lock()
+	mock.calls.GetUserID = append(mock.calls.GetUserID, callInfo)
+	mock.lockGetUserID.Unlock()
+	return mock.GetUserIDFunc()
+}
+
+// GetUserIDCalls gets all the calls that were made to GetUserID.
+// Check the length with:
+//
+//	len(mockedFederatedIdentity.GetUserIDCalls())
+func (mock *FederatedIdentityMock) GetUserIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetUserID.RLock()
+	calls = mock.calls.GetUserID
+	mock.lockGetUserID.RUnlock()
+	return calls
+}
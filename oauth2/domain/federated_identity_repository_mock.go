@@ -0,0 +1,206 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Ensure, that FederatedIdentityRepositoryMock does implement FederatedIdentityRepository.
+// If this is not the case, regenerate this file with moq.
+var _ FederatedIdentityRepository = &FederatedIdentityRepositoryMock{}
+
+// FederatedIdentityRepositoryMock is a mock implementation of FederatedIdentityRepository.
+//
+//	func TestSomethingThatUsesFederatedIdentityRepository(t *testing.T) {
+//
+//		// make and configure a mocked FederatedIdentityRepository
+//		mockedFederatedIdentityRepository := &FederatedIdentityRepositoryMock{
+//			FindFederatedIdentityFunc: func(ctx context.Context, provider string, subject string) (FederatedIdentity, error) {
+//				panic("mock out the FindFederatedIdentity method")
+//			},
+//			StoreFederatedIdentityFunc: func(ctx context.Context, fi FederatedIdentity) error {
+//				panic("mock out the StoreFederatedIdentity method")
+//			},
+//			TouchFederatedIdentityFunc: func(ctx context.Context, provider string, subject string, email string, usedAt time.Time) error {
+//				panic("mock out the TouchFederatedIdentity method")
+//			},
+//		}
+//
+//		// use mockedFederatedIdentityRepository in code that requires FederatedIdentityRepository
+//		// and then make assertions.
+//
+//	}
+type FederatedIdentityRepositoryMock struct {
+	// FindFederatedIdentityFunc mocks the FindFederatedIdentity method.
+	FindFederatedIdentityFunc func(ctx context.Context, provider string, subject string) (FederatedIdentity, error)
+
+	// StoreFederatedIdentityFunc mocks the StoreFederatedIdentity method.
+	StoreFederatedIdentityFunc func(ctx context.Context, fi FederatedIdentity) error
+
+	// TouchFederatedIdentityFunc mocks the TouchFederatedIdentity method.
+	TouchFederatedIdentityFunc func(ctx context.Context, provider string, subject string, email string, usedAt time.Time) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// FindFederatedIdentity holds details about calls to the FindFederatedIdentity method.
+		FindFederatedIdentity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// Subject is the subject argument value.
+			Subject string
+		}
+		// StoreFederatedIdentity holds details about calls to the StoreFederatedIdentity method.
+		StoreFederatedIdentity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Fi is the fi argument value.
+			Fi FederatedIdentity
+		}
+		// TouchFederatedIdentity holds details about calls to the TouchFederatedIdentity method.
+		TouchFederatedIdentity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// Subject is the subject argument value.
+			Subject string
+			// Email is the email argument value.
+			Email string
+			// UsedAt is the usedAt argument value.
+			UsedAt time.Time
+		}
+	}
+	lockFindFederatedIdentity  sync.RWMutex
+	lockStoreFederatedIdentity sync.RWMutex
+	lockTouchFederatedIdentity sync.RWMutex
+}
+
+// FindFederatedIdentity calls FindFederatedIdentityFunc.
+func (mock *FederatedIdentityRepositoryMock) FindFederatedIdentity(ctx context.Context, provider string, subject string) (FederatedIdentity, error) {
+	if mock.FindFederatedIdentityFunc == nil {
+		panic("FederatedIdentityRepositoryMock.FindFederatedIdentityFunc: method is nil but FederatedIdentityRepository.FindFederatedIdentity was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Provider string
+		Subject  string
+	}{
+		Ctx:      ctx,
+		Provider: provider,
+		Subject:  subject,
+	}
+	mock.lockFindFederatedIdentity.Lock()
+	mock.calls.FindFederatedIdentity = append(mock.calls.FindFederatedIdentity, callInfo)
+	mock.lockFindFederatedIdentity.Unlock()
+	return mock.FindFederatedIdentityFunc(ctx, provider, subject)
+}
+
+// FindFederatedIdentityCalls gets all the calls that were made to FindFederatedIdentity.
+// Check the length with:
+//
+//	len(mockedFederatedIdentityRepository.FindFederatedIdentityCalls())
+func (mock *FederatedIdentityRepositoryMock) FindFederatedIdentityCalls() []struct {
+	Ctx      context.Context
+	Provider string
+	Subject  string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Provider string
+		Subject  string
+	}
+	mock.lockFindFederatedIdentity.RLock()
+	calls = mock.calls.FindFederatedIdentity
+	mock.lockFindFederatedIdentity.RUnlock()
+	return calls
+}
+
+// StoreFederatedIdentity calls StoreFederatedIdentityFunc.
+func (mock *FederatedIdentityRepositoryMock) StoreFederatedIdentity(ctx context.Context, fi FederatedIdentity) error {
+	if mock.StoreFederatedIdentityFunc == nil {
+		panic("FederatedIdentityRepositoryMock.StoreFederatedIdentityFunc: method is nil but FederatedIdentityRepository.StoreFederatedIdentity was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Fi  FederatedIdentity
+	}{
+		Ctx: ctx,
+		Fi:  fi,
+	}
+	mock.lockStoreFederatedIdentity.Lock()
+	mock.calls.StoreFederatedIdentity = append(mock.calls.StoreFederatedIdentity, callInfo)
+	mock.lockStoreFederatedIdentity.Unlock()
+	return mock.StoreFederatedIdentityFunc(ctx, fi)
+}
+
+// StoreFederatedIdentityCalls gets all the calls that were made to StoreFederatedIdentity.
+// Check the length with:
+//
+//	len(mockedFederatedIdentityRepository.StoreFederatedIdentityCalls())
+func (mock *FederatedIdentityRepositoryMock) StoreFederatedIdentityCalls() []struct {
+	Ctx context.Context
+	Fi  FederatedIdentity
+} {
+	var calls []struct {
+		Ctx context.Context
+		Fi  FederatedIdentity
+	}
+	mock.lockStoreFederatedIdentity.RLock()
+	calls = mock.calls.StoreFederatedIdentity
+	mock.lockStoreFederatedIdentity.RUnlock()
+	return calls
+}
+
+// TouchFederatedIdentity calls TouchFederatedIdentityFunc.
+func (mock *FederatedIdentityRepositoryMock) TouchFederatedIdentity(ctx context.Context, provider string, subject string, email string, usedAt time.Time) error {
+	if mock.TouchFederatedIdentityFunc == nil {
+		panic("FederatedIdentityRepositoryMock.TouchFederatedIdentityFunc: method is nil but FederatedIdentityRepository.TouchFederatedIdentity was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Provider string
+		Subject  string
+		Email    string
+		UsedAt   time.Time
+	}{
+		Ctx:      ctx,
+		Provider: provider,
+		Subject:  subject,
+		Email:    email,
+		UsedAt:   usedAt,
+	}
+	mock.lockTouchFederatedIdentity.Lock()
+	mock.calls.TouchFederatedIdentity = append(mock.calls.TouchFederatedIdentity, callInfo)
+	mock.lockTouchFederatedIdentity.Unlock()
+	return mock.TouchFederatedIdentityFunc(ctx, provider, subject, email, usedAt)
+}
+
+// TouchFederatedIdentityCalls gets all the calls that were made to TouchFederatedIdentity.
+// Check the length with:
+//
+//	len(mockedFederatedIdentityRepository.TouchFederatedIdentityCalls())
+func (mock *FederatedIdentityRepositoryMock) TouchFederatedIdentityCalls() []struct {
+	Ctx      context.Context
+	Provider string
+	Subject  string
+	Email    string
+	UsedAt   time.Time
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Provider string
+		Subject  string
+		Email    string
+		UsedAt   time.Time
+	}
+	mock.lockTouchFederatedIdentity.RLock()
+	calls = mock.calls.TouchFederatedIdentity
+	mock.lockTouchFederatedIdentity.RUnlock()
+	return calls
+}
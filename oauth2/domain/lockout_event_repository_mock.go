@@ -0,0 +1,131 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that LockoutEventRepositoryMock does implement LockoutEventRepository.
+// If this is not the case, regenerate this file with moq.
+var _ LockoutEventRepository = &LockoutEventRepositoryMock{}
+
+// LockoutEventRepositoryMock is a mock implementation of LockoutEventRepository.
+//
+//	func TestSomethingThatUsesLockoutEventRepository(t *testing.T) {
+//
+//		// make and configure a mocked LockoutEventRepository
+//		mockedLockoutEventRepository := &LockoutEventRepositoryMock{
+//			FindRecentLockoutEventsFunc: func(ctx context.Context, limit int) ([]LockoutEvent, error) {
+//				panic("mock out the FindRecentLockoutEvents method")
+//			},
+//			StoreLockoutEventFunc: func(ctx context.Context, e LockoutEvent) error {
+//				panic("mock out the StoreLockoutEvent method")
+//			},
+//		}
+//
+//		// use mockedLockoutEventRepository in code that requires LockoutEventRepository
+//		// and then make assertions.
+//
+//	}
+type LockoutEventRepositoryMock struct {
+	// FindRecentLockoutEventsFunc mocks the FindRecentLockoutEvents method.
+	FindRecentLockoutEventsFunc func(ctx context.Context, limit int) ([]LockoutEvent, error)
+
+	// StoreLockoutEventFunc mocks the StoreLockoutEvent method.
+	StoreLockoutEventFunc func(ctx context.Context, e LockoutEvent) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// FindRecentLockoutEvents holds details about calls to the FindRecentLockoutEvents method.
+		FindRecentLockoutEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// StoreLockoutEvent holds details about calls to the StoreLockoutEvent method.
+		StoreLockoutEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// E is the e argument value.
+			E LockoutEvent
+		}
+	}
+	lockFindRecentLockoutEvents sync.RWMutex
+	lockStoreLockoutEvent       sync.RWMutex
+}
+
+// FindRecentLockoutEvents calls FindRecentLockoutEventsFunc.
+func (mock *LockoutEventRepositoryMock) FindRecentLockoutEvents(ctx context.Context, limit int) ([]LockoutEvent, error) {
+	if mock.FindRecentLockoutEventsFunc == nil {
+		panic("LockoutEventRepositoryMock.FindRecentLockoutEventsFunc: method is nil but LockoutEventRepository.FindRecentLockoutEvents was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockFindRecentLockoutEvents.Lock()
+	mock.calls.FindRecentLockoutEvents = append(mock.calls.FindRecentLockoutEvents, callInfo)
+	mock.lockFindRecentLockoutEvents.Unlock()
+	return mock.FindRecentLockoutEventsFunc(ctx, limit)
+}
+
+// FindRecentLockoutEventsCalls gets all the calls that were made to FindRecentLockoutEvents.
+// Check the length with:
+//
+//	len(mockedLockoutEventRepository.FindRecentLockoutEventsCalls())
+func (mock *LockoutEventRepositoryMock) FindRecentLockoutEventsCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockFindRecentLockoutEvents.RLock()
+	calls = mock.calls.FindRecentLockoutEvents
+	mock.lockFindRecentLockoutEvents.RUnlock()
+	return calls
+}
+
+// StoreLockoutEvent calls StoreLockoutEventFunc.
+func (mock *LockoutEventRepositoryMock) StoreLockoutEvent(ctx context.Context, e LockoutEvent) error {
+	if mock.StoreLockoutEventFunc == nil {
+		panic("LockoutEventRepositoryMock.StoreLockoutEventFunc: method is nil but LockoutEventRepository.StoreLockoutEvent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		E   LockoutEvent
+	}{
+		Ctx: ctx,
+		E:   e,
+	}
+	mock.lockStoreLockoutEvent.Lock()
+	mock.calls.StoreLockoutEvent = append(mock.calls.StoreLockoutEvent, callInfo)
+	mock.lockStoreLockoutEvent.Unlock()
+	return mock.StoreLockoutEventFunc(ctx, e)
+}
+
+// StoreLockoutEventCalls gets all the calls that were made to StoreLockoutEvent.
+// Check the length with:
+//
+//	len(mockedLockoutEventRepository.StoreLockoutEventCalls())
+func (mock *LockoutEventRepositoryMock) StoreLockoutEventCalls() []struct {
+	Ctx context.Context
+	E   LockoutEvent
+} {
+	var calls []struct {
+		Ctx context.Context
+		E   LockoutEvent
+	}
+	mock.lockStoreLockoutEvent.RLock()
+	calls = mock.calls.StoreLockoutEvent
+	mock.lockStoreLockoutEvent.RUnlock()
+	return calls
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// サインインの失敗を数える対象
+const (
+	LockoutSubjectAccount = "account"
+	LockoutSubjectIP      = "ip"
+)
+
+const (
+	LockoutEventLocked   = "locked"
+	LockoutEventUnlocked = "unlocked"
+)
+
+// LoginAttemptStore はサインインの失敗回数と一時的な制限を保持する。
+// 複数のサーバーで同じ回数を数えられるよう、共有のストアに置く
+//
+//go:generate go run github.com/matryer/moq -out login_attempt_store_mock.go . LoginAttemptStore
+type LoginAttemptStore interface {
+	// IncrFailures は失敗回数を加算して返す。回数は最初の失敗から window の間だけ保持する
+	IncrFailures(ctx context.Context, key string, window time.Duration) (int64, error)
+	ResetFailures(ctx context.Context, key string) error
+	// Block は until までサインインを受け付けないようにする
+	Block(ctx context.Context, key string, until time.Time) error
+	// BlockedUntil は制限が解除される時刻を返す。制限されていない場合はゼロ値を返す
+	BlockedUntil(ctx context.Context, key string) (time.Time, error)
+	Unblock(ctx context.Context, key string) error
+}
+
+// LockoutEvent はアカウントや接続元をロックした、または管理者が解除した記録
+type LockoutEvent struct {
+	ID          uuid.UUID
+	Kind        string
+	SubjectType string
+	// Subject はロックしたメールアドレスまたはIPアドレス
+	Subject     string
+	IP          string
+	LockedUntil *time.Time
+	CreatedAt   time.Time
+}
+
+//go:generate go run github.com/matryer/moq -out lockout_event_repository_mock.go . LockoutEventRepository
+type LockoutEventRepository interface {
+	StoreLockoutEvent(ctx context.Context, e LockoutEvent) error
+	// FindRecentLockoutEvents は新しいものから limit 件を返す
+	FindRecentLockoutEvents(ctx context.Context, limit int) ([]LockoutEvent, error)
+}
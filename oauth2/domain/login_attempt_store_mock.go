@@ -0,0 +1,294 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Ensure, that LoginAttemptStoreMock does implement LoginAttemptStore.
+// If this is not the case, regenerate this file with moq.
+var _ LoginAttemptStore = &LoginAttemptStoreMock{}
+
+// LoginAttemptStoreMock is a mock implementation of LoginAttemptStore.
+//
+//	func TestSomethingThatUsesLoginAttemptStore(t *testing.T) {
+//
+//		// make and configure a mocked LoginAttemptStore
+//		mockedLoginAttemptStore := &LoginAttemptStoreMock{
+//			BlockFunc: func(ctx context.Context, key string, until time.Time) error {
+//				panic("mock out the Block method")
+//			},
+//			BlockedUntilFunc: func(ctx context.Context, key string) (time.Time, error) {
+//				panic("mock out the BlockedUntil method")
+//			},
+//			IncrFailuresFunc: func(ctx context.Context, key string, window time.Duration) (int64, error) {
+//				panic("mock out the IncrFailures method")
+//			},
+//			ResetFailuresFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the ResetFailures method")
+//			},
+//			UnblockFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the Unblock method")
+//			},
+//		}
+//
+//		// use mockedLoginAttemptStore in code that requires LoginAttemptStore
+//		// and then make assertions.
+//
+//	}
+type LoginAttemptStoreMock struct {
+	// BlockFunc mocks the Block method.
+	BlockFunc func(ctx context.Context, key string, until time.Time) error
+
+	// BlockedUntilFunc mocks the BlockedUntil method.
+	BlockedUntilFunc func(ctx context.Context, key string) (time.Time, error)
+
+	// IncrFailuresFunc mocks the IncrFailures method.
+	IncrFailuresFunc func(ctx context.Context, key string, window time.Duration) (int64, error)
+
+	// ResetFailuresFunc mocks the ResetFailures method.
+	ResetFailuresFunc func(ctx context.Context, key string) error
+
+	// UnblockFunc mocks the Unblock method.
+	UnblockFunc func(ctx context.Context, key string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Block holds details about calls to the Block method.
+		Block []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Until is the until argument value.
+			Until time.Time
+		}
+		// BlockedUntil holds details about calls to the BlockedUntil method.
+		BlockedUntil []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// IncrFailures holds details about calls to the IncrFailures method.
+		IncrFailures []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Window is the window argument value.
+			Window time.Duration
+		}
+		// ResetFailures holds details about calls to the ResetFailures method.
+		ResetFailures []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// Unblock holds details about calls to the Unblock method.
+		Unblock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+	}
+	lockBlock         sync.RWMutex
+	lockBlockedUntil  sync.RWMutex
+	lockIncrFailures  sync.RWMutex
+	lockResetFailures sync.RWMutex
+	lockUnblock       sync.RWMutex
+}
+
+// Block calls BlockFunc.
+func (mock *LoginAttemptStoreMock) Block(ctx context.Context, key string, until time.Time) error {
+	if mock.BlockFunc == nil {
+		panic("LoginAttemptStoreMock.BlockFunc: method is nil but LoginAttemptStore.Block was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Key   string
+		Until time.Time
+	}{
+		Ctx:   ctx,
+		Key:   key,
+		Until: until,
+	}
+	mock.lockBlock.Lock()
+	mock.calls.Block = append(mock.calls.Block, callInfo)
+	mock.lockBlock.Unlock()
+	return mock.BlockFunc(ctx, key, until)
+}
+
+// BlockCalls gets all the calls that were made to Block.
+// Check the length with:
+//
+//	len(mockedLoginAttemptStore.BlockCalls())
+func (mock *LoginAttemptStoreMock) BlockCalls() []struct {
+	Ctx   context.Context
+	Key   string
+	Until time.Time
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Key   string
+		Until time.Time
+	}
+	mock.lockBlock.RLock()
+	calls = mock.calls.Block
+	mock.lockBlock.RUnlock()
+	return calls
+}
+
+// BlockedUntil calls BlockedUntilFunc.
+func (mock *LoginAttemptStoreMock) BlockedUntil(ctx context.Context, key string) (time.Time, error) {
+	if mock.BlockedUntilFunc == nil {
+		panic("LoginAttemptStoreMock.BlockedUntilFunc: method is nil but LoginAttemptStore.BlockedUntil was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockBlockedUntil.Lock()
+	mock.calls.BlockedUntil = append(mock.calls.BlockedUntil, callInfo)
+	mock.lockBlockedUntil.Unlock()
+	return mock.BlockedUntilFunc(ctx, key)
+}
+
+// BlockedUntilCalls gets all the calls that were made to BlockedUntil.
+// Check the length with:
+//
+//	len(mockedLoginAttemptStore.BlockedUntilCalls())
+func (mock *LoginAttemptStoreMock) BlockedUntilCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockBlockedUntil.RLock()
+	calls = mock.calls.BlockedUntil
+	mock.lockBlockedUntil.RUnlock()
+	return calls
+}
+
+// IncrFailures calls IncrFailuresFunc.
+func (mock *LoginAttemptStoreMock) IncrFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	if mock.IncrFailuresFunc == nil {
+		panic("LoginAttemptStoreMock.IncrFailuresFunc: method is nil but LoginAttemptStore.IncrFailures was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Key    string
+		Window time.Duration
+	}{
+		Ctx:    ctx,
+		Key:    key,
+		Window: window,
+	}
+	mock.lockIncrFailures.Lock()
+	mock.calls.IncrFailures = append(mock.calls.IncrFailures, callInfo)
+	mock.lockIncrFailures.Unlock()
+	return mock.IncrFailuresFunc(ctx, key, window)
+}
+
+// IncrFailuresCalls gets all the calls that were made to IncrFailures.
+// Check the length with:
+//
+//	len(mockedLoginAttemptStore.IncrFailuresCalls())
+func (mock *LoginAttemptStoreMock) IncrFailuresCalls() []struct {
+	Ctx    context.Context
+	Key    string
+	Window time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Key    string
+		Window time.Duration
+	}
+	mock.lockIncrFailures.RLock()
+	calls = mock.calls.IncrFailures
+	mock.lockIncrFailures.RUnlock()
+	return calls
+}
+
+// ResetFailures calls ResetFailuresFunc.
+func (mock *LoginAttemptStoreMock) ResetFailures(ctx context.Context, key string) error {
+	if mock.ResetFailuresFunc == nil {
+		panic("LoginAttemptStoreMock.ResetFailuresFunc: method is nil but LoginAttemptStore.ResetFailures was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockResetFailures.Lock()
+	mock.calls.ResetFailures = append(mock.calls.ResetFailures, callInfo)
+	mock.lockResetFailures.Unlock()
+	return mock.ResetFailuresFunc(ctx, key)
+}
+
+// ResetFailuresCalls gets all the calls that were made to ResetFailures.
+// Check the length with:
+//
+//	len(mockedLoginAttemptStore.ResetFailuresCalls())
+func (mock *LoginAttemptStoreMock) ResetFailuresCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockResetFailures.RLock()
+	calls = mock.calls.ResetFailures
+	mock.lockResetFailures.RUnlock()
+	return calls
+}
+
+// Unblock calls UnblockFunc.
+func (mock *LoginAttemptStoreMock) Unblock(ctx context.Context, key string) error {
+	if mock.UnblockFunc == nil {
+		panic("LoginAttemptStoreMock.UnblockFunc: method is nil but LoginAttemptStore.Unblock was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockUnblock.Lock()
+	mock.calls.Unblock = append(mock.calls.Unblock, callInfo)
+	mock.lockUnblock.Unlock()
+	return mock.UnblockFunc(ctx, key)
+}
+
+// UnblockCalls gets all the calls that were made to Unblock.
+// Check the length with:
+//
+//	len(mockedLoginAttemptStore.UnblockCalls())
+func (mock *LoginAttemptStoreMock) UnblockCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockUnblock.RLock()
+	calls = mock.calls.Unblock
+	mock.lockUnblock.RUnlock()
+	return calls
+}
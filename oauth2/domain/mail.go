@@ -0,0 +1,14 @@
+package domain
+
+import "context"
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+//go:generate go run github.com/matryer/moq -out mail_sender_mock.go . MailSender
+type MailSender interface {
+	Send(ctx context.Context, m Mail) error
+}
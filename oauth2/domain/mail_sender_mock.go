@@ -0,0 +1,81 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that MailSenderMock does implement MailSender.
+// If this is not the case, regenerate this file with moq.
+var _ MailSender = &MailSenderMock{}
+
+// MailSenderMock is a mock implementation of MailSender.
+//
+//	func TestSomethingThatUsesMailSender(t *testing.T) {
+//
+//		// make and configure a mocked MailSender
+//		mockedMailSender := &MailSenderMock{
+//			SendFunc: func(ctx context.Context, m Mail) error {
+//				panic("mock out the Send method")
+//			},
+//		}
+//
+//		// use mockedMailSender in code that requires MailSender
+//		// and then make assertions.
+//
+//	}
+type MailSenderMock struct {
+	// SendFunc mocks the Send method.
+	SendFunc func(ctx context.Context, m Mail) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Send holds details about calls to the Send method.
+		Send []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// M is the m argument value.
+			M Mail
+		}
+	}
+	lockSend sync.RWMutex
+}
+
+// Send calls SendFunc.
+func (mock *MailSenderMock) Send(ctx context.Context, m Mail) error {
+	if mock.SendFunc == nil {
+		panic("MailSenderMock.SendFunc: method is nil but MailSender.Send was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		M   Mail
+	}{
+		Ctx: ctx,
+		M:   m,
+	}
+	mock.lockSend.Lock()
+	mock.calls.Send = append(mock.calls.Send, callInfo)
+	mock.lockSend.Unlock()
+	return mock.SendFunc(ctx, m)
+}
+
+// SendCalls gets all the calls that were made to Send.
+// Check the length with:
+//
+//	len(mockedMailSender.SendCalls())
+func (mock *MailSenderMock) SendCalls() []struct {
+	Ctx context.Context
+	M   Mail
+} {
+	var calls []struct {
+		Ctx context.Context
+		M   Mail
+	}
+	mock.lockSend.RLock()
+	calls = mock.calls.Send
+	mock.lockSend.RUnlock()
+	return calls
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/totp"
+)
+
+const (
+	// RecoveryCodeCount は一度に発行するリカバリーコードの数
+	RecoveryCodeCount = 10
+	// totpSkew は端末の時計のずれを許容するステップ数
+	totpSkew = 1
+
+	recoveryCodeLen = 10
+)
+
+type TOTPCredentialParams struct {
+	UserID       uuid.UUID
+	Secret       string
+	ConfirmedAt  *time.Time
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+func NewTOTPCredential(p TOTPCredentialParams) TOTPCredential {
+	return &totpCredential{
+		userID:       p.UserID,
+		secret:       p.Secret,
+		confirmedAt:  p.ConfirmedAt,
+		lastUsedStep: p.LastUsedStep,
+		createdAt:    p.CreatedAt,
+	}
+}
+
+// GenerateTOTPCredential は確認前の新しいシークレットを発行する
+func GenerateTOTPCredential(userID uuid.UUID) (TOTPCredential, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	return NewTOTPCredential(TOTPCredentialParams{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}), nil
+}
+
+//go:generate go run github.com/matryer/moq -out totp_credential_mock.go . TOTPCredential
+type TOTPCredential interface {
+	GetUserID() uuid.UUID
+	GetSecret() string
+	GetConfirmedAt() *time.Time
+	GetLastUsedStep() int64
+	GetCreatedAt() time.Time
+	IsConfirmed() bool
+	Confirm(now time.Time)
+	// Match はコードを検証し、一致したタイムステップを返す。使用済みのステップのコードは受け付けない
+	Match(code string, now time.Time) (int64, bool)
+	KeyURI(issuer, account string) string
+}
+
+//go:generate go run github.com/matryer/moq -out totp_credential_repository_mock.go . TOTPCredentialRepository
+type TOTPCredentialRepository interface {
+	// FindTOTPCredential は登録されていない場合に nil を返す
+	FindTOTPCredential(ctx context.Context, userID uuid.UUID) (TOTPCredential, error)
+	StoreTOTPCredential(ctx context.Context, c TOTPCredential) error
+	// UseTOTPStep は前回より新しいステップの場合のみ使用済みとして記録する。記録できなかった場合は false を返す
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	DeleteTOTPCredential(ctx context.Context, userID uuid.UUID) error
+}
+
+//go:generate go run github.com/matryer/moq -out recovery_code_repository_mock.go . RecoveryCodeRepository
+type RecoveryCodeRepository interface {
+	// ReplaceRecoveryCodes はユーザーのリカバリーコードを全て入れ替える
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, hashes []string) error
+	// UseRecoveryCode は未使用のコードを使用済みにする。該当するコードがない場合は false を返す
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+	DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error
+}
+
+type totpCredential struct {
+	userID       uuid.UUID
+	secret       string
+	confirmedAt  *time.Time
+	lastUsedStep int64
+	createdAt    time.Time
+}
+
+func (c *totpCredential) GetUserID() uuid.UUID {
+	return c.userID
+}
+
+func (c *totpCredential) GetSecret() string {
+	return c.secret
+}
+
+func (c *totpCredential) GetConfirmedAt() *time.Time {
+	return c.confirmedAt
+}
+
+func (c *totpCredential) GetLastUsedStep() int64 {
+	return c.lastUsedStep
+}
+
+func (c *totpCredential) GetCreatedAt() time.Time {
+	return c.createdAt
+}
+
+// IsConfirmed は認証アプリでコードを入力して登録を完了したかを判定する
+func (c *totpCredential) IsConfirmed() bool {
+	return c.confirmedAt != nil
+}
+
+func (c *totpCredential) Confirm(now time.Time) {
+	c.confirmedAt = &now
+}
+
+func (c *totpCredential) Match(code string, now time.Time) (int64, bool) {
+	step, ok := totp.Validate(c.secret, normalizeCode(code), now, totpSkew)
+	if !ok || step <= c.lastUsedStep {
+		return 0, false
+	}
+	return step, true
+}
+
+func (c *totpCredential) KeyURI(issuer, account string) string {
+	return totp.KeyURI(issuer, account, c.secret)
+}
+
+// GenerateRecoveryCodes は利用者に渡すリカバリーコードと、保存するハッシュを生成する
+func GenerateRecoveryCodes() (codes []string, hashes []string) {
+	codes = make([]string, RecoveryCodeCount)
+	hashes = make([]string, RecoveryCodeCount)
+	for i := range codes {
+		// rand.Text は Base32 の文字のみを返すため、先頭を切り出しても偏りは生じない
+		b := strings.ToLower(rand.Text()[:recoveryCodeLen])
+		// 読みやすいよう5文字ずつ区切る
+		codes[i] = b[:recoveryCodeLen/2] + "-" + b[recoveryCodeLen/2:]
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+	return codes, hashes
+}
+
+// HashRecoveryCode は区切り文字や大文字小文字の違いを無視してハッシュを計算する
+func HashRecoveryCode(code string) string {
+	return HashOpaqueToken(strings.ToLower(strings.ReplaceAll(normalizeCode(code), "-", "")))
+}
+
+func normalizeCode(code string) string {
+	return strings.Join(strings.Fields(code), "")
+}
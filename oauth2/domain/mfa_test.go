@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPCredential_Match(t *testing.T) {
+	c, err := GenerateTOTPCredential(uuid.New())
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+
+	code, err := totp.Code(c.GetSecret(), totp.Step(now))
+	require.NoError(t, err)
+
+	step, ok := c.Match(code[:3]+" "+code[3:], now)
+	assert.True(t, ok)
+	assert.Equal(t, totp.Step(now), step)
+
+	// 使用済みのステップのコードは受け付けない
+	used := NewTOTPCredential(TOTPCredentialParams{Secret: c.GetSecret(), LastUsedStep: step})
+	_, ok = used.Match(code, now)
+	assert.False(t, ok)
+
+	_, ok = c.Match("000000", now.Add(time.Hour))
+	assert.False(t, ok)
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes := GenerateRecoveryCodes()
+	require.Len(t, codes, RecoveryCodeCount)
+	require.Len(t, hashes, RecoveryCodeCount)
+
+	seen := map[string]bool{}
+	for i, code := range codes {
+		assert.Len(t, code, 11)
+		assert.Equal(t, hashes[i], HashRecoveryCode(code))
+		assert.False(t, seen[hashes[i]])
+		seen[hashes[i]] = true
+	}
+
+	// 区切り文字や大文字小文字の違いは無視する
+	assert.Equal(t, HashRecoveryCode(codes[0]), HashRecoveryCode(strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))))
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/sntkn/go-oauth2/oauth2/pkg/str"
+)
+
+const opaqueTokenLen = 32
+
+// GenerateOpaqueToken はメールのリンクなどで利用者に渡すトークンを生成する。
+// 漏洩に備えてDBにはハッシュのみを保存する
+func GenerateOpaqueToken() (token string, hash string, err error) {
+	token, err = str.GenerateRandomString(opaqueTokenLen)
+	if err != nil {
+		return "", "", err
+	}
+	return token, HashOpaqueToken(token), nil
+}
+
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PasskeyParams struct {
+	CredentialID    []byte
+	UserID          uuid.UUID
+	Name            string
+	PublicKey       []byte
+	AttestationType string
+	AAGUID          []byte
+	SignCount       uint32
+	Transports      []string
+	BackupEligible  bool
+	BackupState     bool
+	CreatedAt       time.Time
+	LastUsedAt      *time.Time
+}
+
+func NewPasskey(p PasskeyParams) Passkey {
+	return &passkey{
+		credentialID:    p.CredentialID,
+		userID:          p.UserID,
+		name:            p.Name,
+		publicKey:       p.PublicKey,
+		attestationType: p.AttestationType,
+		aaguid:          p.AAGUID,
+		signCount:       p.SignCount,
+		transports:      p.Transports,
+		backupEligible:  p.BackupEligible,
+		backupState:     p.BackupState,
+		createdAt:       p.CreatedAt,
+		lastUsedAt:      p.LastUsedAt,
+	}
+}
+
+//go:generate go run github.com/matryer/moq -out passkey_mock.go . Passkey
+type Passkey interface {
+	GetCredentialID() []byte
+	GetUserID() uuid.UUID
+	GetName() string
+	GetPublicKey() []byte
+	GetAttestationType() string
+	GetAAGUID() []byte
+	GetSignCount() uint32
+	GetTransports() []string
+	IsBackupEligible() bool
+	IsBackupState() bool
+	GetCreatedAt() time.Time
+	GetLastUsedAt() *time.Time
+}
+
+//go:generate go run github.com/matryer/moq -out passkey_repository_mock.go . PasskeyRepository
+type PasskeyRepository interface {
+	FindPasskeysByUserID(ctx context.Context, userID uuid.UUID) ([]Passkey, error)
+	// FindPasskey は登録されていない場合に nil を返す
+	FindPasskey(ctx context.Context, credentialID []byte) (Passkey, error)
+	StorePasskey(ctx context.Context, p Passkey) error
+	// UpdatePasskeySignCount はカウンタが前回より進んでいる場合のみ記録する。記録できなかった場合は false を返す。
+	// カウンタを持たない認証器は常に 0 を返すため、その場合は検証しない
+	UpdatePasskeySignCount(ctx context.Context, credentialID []byte, count uint32, usedAt time.Time) (bool, error)
+	DeletePasskey(ctx context.Context, userID uuid.UUID, credentialID []byte) error
+}
+
+type passkey struct {
+	credentialID    []byte
+	userID          uuid.UUID
+	name            string
+	publicKey       []byte
+	attestationType string
+	aaguid          []byte
+	signCount       uint32
+	transports      []string
+	backupEligible  bool
+	backupState     bool
+	createdAt       time.Time
+	lastUsedAt      *time.Time
+}
+
+func (p *passkey) GetCredentialID() []byte {
+	return p.credentialID
+}
+
+func (p *passkey) GetUserID() uuid.UUID {
+	return p.userID
+}
+
+func (p *passkey) GetName() string {
+	return p.name
+}
+
+func (p *passkey) GetPublicKey() []byte {
+	return p.publicKey
+}
+
+func (p *passkey) GetAttestationType() string {
+	return p.attestationType
+}
+
+func (p *passkey) GetAAGUID() []byte {
+	return p.aaguid
+}
+
+func (p *passkey) GetSignCount() uint32 {
+	return p.signCount
+}
+
+func (p *passkey) GetTransports() []string {
+	return p.transports
+}
+
+func (p *passkey) IsBackupEligible() bool {
+	return p.backupEligible
+}
+
+func (p *passkey) IsBackupState() bool {
+	return p.backupState
+}
+
+func (p *passkey) GetCreatedAt() time.Time {
+	return p.createdAt
+}
+
+func (p *passkey) GetLastUsedAt() *time.Time {
+	return p.lastUsedAt
+}
@@ -0,0 +1,476 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Ensure, that PasskeyMock does implement Passkey.
+// If this is not the case, regenerate this file with moq.
+var _ Passkey = &PasskeyMock{}
+
+// PasskeyMock is a mock implementation of Passkey.
+//
+//	func TestSomethingThatUsesPasskey(t *testing.T) {
+//
+//		// make and configure a mocked Passkey
+//		mockedPasskey := &PasskeyMock{
+//			GetAAGUIDFunc: func() []byte {
+//				panic("mock out the GetAAGUID method")
+//			},
+//			GetAttestationTypeFunc: func() string {
+//				panic("mock out the GetAttestationType method")
+//			},
+//			GetCreatedAtFunc: func() time.Time {
+//				panic("mock out the GetCreatedAt method")
+//			},
+//			GetCredentialIDFunc: func() []byte {
+//				panic("mock out the GetCredentialID method")
+//			},
+//			GetLastUsedAtFunc: func() *time.Time {
+//				panic("mock out the GetLastUsedAt method")
+//			},
+//			GetNameFunc: func() string {
+//				panic("mock out the GetName method")
+//			},
+//			GetPublicKeyFunc: func() []byte {
+//				panic("mock out the GetPublicKey method")
+//			},
+//			GetSignCountFunc: func() uint32 {
+//				panic("mock out the GetSignCount method")
+//			},
+//			GetTransportsFunc: func() []string {
+//				panic("mock out the GetTransports method")
+//			},
+//			GetUserIDFunc: func() uuid.UUID {
+//				panic("mock out the GetUserID method")
+//			},
+//			IsBackupEligibleFunc: func() bool {
+//				panic("mock out the IsBackupEligible method")
+//			},
+//			IsBackupStateFunc: func() bool {
+//				panic("mock out the IsBackupState method")
+//			},
+//		}
+//
+//		// use mockedPasskey in code that requires Passkey
+//		// and then make assertions.
+//
+//	}
+type PasskeyMock struct {
+	// GetAAGUIDFunc mocks the GetAAGUID method.
+	GetAAGUIDFunc func() []byte
+
+	// GetAttestationTypeFunc mocks the GetAttestationType method.
+	GetAttestationTypeFunc func() string
+
+	// GetCreatedAtFunc mocks the GetCreatedAt method.
+	GetCreatedAtFunc func() time.Time
+
+	// GetCredentialIDFunc mocks the GetCredentialID method.
+	GetCredentialIDFunc func() []byte
+
+	// GetLastUsedAtFunc mocks the GetLastUsedAt method.
+	GetLastUsedAtFunc func() *time.Time
+
+	// GetNameFunc mocks the GetName method.
+	GetNameFunc func() string
+
+	// GetPublicKeyFunc mocks the GetPublicKey method.
+	GetPublicKeyFunc func() []byte
+
+	// GetSignCountFunc mocks the GetSignCount method.
+	GetSignCountFunc func() uint32
+
+	// GetTransportsFunc mocks the GetTransports method.
+	GetTransportsFunc func() []string
+
+	// GetUserIDFunc mocks the GetUserID method.
+	GetUserIDFunc func() uuid.UUID
+
+	// IsBackupEligibleFunc mocks the IsBackupEligible method.
+	IsBackupEligibleFunc func() bool
+
+	// IsBackupStateFunc mocks the IsBackupState method.
+	IsBackupStateFunc func() bool
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetAAGUID holds details about calls to the GetAAGUID method.
+		GetAAGUID []struct {
+		}
+		// GetAttestationType holds details about calls to the GetAttestationType method.
+		GetAttestationType []struct {
+		}
+		// GetCreatedAt holds details about calls to the GetCreatedAt method.
+		GetCreatedAt []struct {
+		}
+		// GetCredentialID holds details about calls to the GetCredentialID method.
+		GetCredentialID []struct {
+		}
+		// GetLastUsedAt holds details about calls to the GetLastUsedAt method.
+		GetLastUsedAt []struct {
+		}
+		// GetName holds details about calls to the GetName method.
+		GetName []struct {
+		}
+		// GetPublicKey holds details about calls to the GetPublicKey method.
+		GetPublicKey []struct {
+		}
+		// GetSignCount holds details about calls to the GetSignCount method.
+		GetSignCount []struct {
+		}
+		// GetTransports holds details about calls to the GetTransports method.
+		GetTransports []struct {
+		}
+		// GetUserID holds details about calls to the GetUserID method.
+		GetUserID []struct {
+		}
+		// IsBackupEligible holds details about calls to the IsBackupEligible method.
+		IsBackupEligible []struct {
+		}
+		// IsBackupState holds details about calls to the IsBackupState method.
+		IsBackupState []struct {
+		}
+	}
+	lockGetAAGUID          sync.RWMutex
+	lockGetAttestationType sync.RWMutex
+	lockGetCreatedAt       sync.RWMutex
+	lockGetCredentialID    sync.RWMutex
+	lockGetLastUsedAt      sync.RWMutex
+	lockGetName            sync.RWMutex
+	lockGetPublicKey       sync.RWMutex
+	lockGetSignCount       sync.RWMutex
+	lockGetTransports      sync.RWMutex
+	lockGetUserID          sync.RWMutex
+	lockIsBackupEligible   sync.RWMutex
+	lockIsBackupState      sync.RWMutex
+}
+
+// GetAAGUID calls GetAAGUIDFunc.
+func (mock *PasskeyMock) GetAAGUID() []byte {
+	if mock.GetAAGUIDFunc == nil {
+		panic("PasskeyMock.GetAAGUIDFunc: method is nil but Passkey.GetAAGUID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetAAGUID.Lock()
+	mock.calls.GetAAGUID = append(mock.calls.GetAAGUID, callInfo)
+	mock.lockGetAAGUID.Unlock()
+	return mock.GetAAGUIDFunc()
+}
+
+// GetAAGUIDCalls gets all the calls that were made to GetAAGUID.
+// Check the length with:
+//
+//	len(mockedPasskey.GetAAGUIDCalls())
+func (mock *PasskeyMock) GetAAGUIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetAAGUID.RLock()
+	calls = mock.calls.GetAAGUID
+	mock.lockGetAAGUID.RUnlock()
+	return calls
+}
+
+// GetAttestationType calls GetAttestationTypeFunc.
+func (mock *PasskeyMock) GetAttestationType() string {
+	if mock.GetAttestationTypeFunc == nil {
+		panic("PasskeyMock.GetAttestationTypeFunc: method is nil but Passkey.GetAttestationType was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetAttestationType.Lock()
+	mock.calls.GetAttestationType = append(mock.calls.GetAttestationType, callInfo)
+	mock.lockGetAttestationType.Unlock()
+	return mock.GetAttestationTypeFunc()
+}
+
+// GetAttestationTypeCalls gets all the calls that were made to GetAttestationType.
+// Check the length with:
+//
+//	len(mockedPasskey.GetAttestationTypeCalls())
+func (mock *PasskeyMock) GetAttestationTypeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetAttestationType.RLock()
+	calls = mock.calls.GetAttestationType
+	mock.lockGetAttestationType.RUnlock()
+	return calls
+}
+
+// GetCreatedAt calls GetCreatedAtFunc.
+func (mock *PasskeyMock) GetCreatedAt() time.Time {
+	if mock.GetCreatedAtFunc == nil {
+		panic("PasskeyMock.GetCreatedAtFunc: method is nil but Passkey.GetCreatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCreatedAt.Lock()
+	mock.calls.GetCreatedAt = append(mock.calls.GetCreatedAt, callInfo)
+	mock.lockGetCreatedAt.Unlock()
+	return mock.GetCreatedAtFunc()
+}
+
+// GetCreatedAtCalls gets all the calls that were made to GetCreatedAt.
+// Check the length with:
+//
+//	len(mockedPasskey.GetCreatedAtCalls())
+func (mock *PasskeyMock) GetCreatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCreatedAt.RLock()
+	calls = mock.calls.GetCreatedAt
+	mock.lockGetCreatedAt.RUnlock()
+	return calls
+}
+
+// GetCredentialID calls GetCredentialIDFunc.
+func (mock *PasskeyMock) GetCredentialID() []byte {
+	if mock.GetCredentialIDFunc == nil {
+		panic("PasskeyMock.GetCredentialIDFunc: method is nil but Passkey.GetCredentialID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCredentialID.Lock()
+	mock.calls.GetCredentialID = append(mock.calls.GetCredentialID, callInfo)
+	mock.lockGetCredentialID.Unlock()
+	return mock.GetCredentialIDFunc()
+}
+
+// GetCredentialIDCalls gets all the calls that were made to GetCredentialID.
+// Check the length with:
+//
+//	len(mockedPasskey.GetCredentialIDCalls())
+func (mock *PasskeyMock) GetCredentialIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCredentialID.RLock()
+	calls = mock.calls.GetCredentialID
+	mock.lockGetCredentialID.RUnlock()
+	return calls
+}
+
+// GetLastUsedAt calls GetLastUsedAtFunc.
+func (mock *PasskeyMock) GetLastUsedAt() *time.Time {
+	if mock.GetLastUsedAtFunc == nil {
+		panic("PasskeyMock.GetLastUsedAtFunc: method is nil but Passkey.GetLastUsedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetLastUsedAt.Lock()
+	mock.calls.GetLastUsedAt = append(mock.calls.GetLastUsedAt, callInfo)
+	mock.lockGetLastUsedAt.Unlock()
+	return mock.GetLastUsedAtFunc()
+}
+
+// GetLastUsedAtCalls gets all the calls that were made to GetLastUsedAt.
+// Check the length with:
+//
+//	len(mockedPasskey.GetLastUsedAtCalls())
+func (mock *PasskeyMock) GetLastUsedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetLastUsedAt.RLock()
+	calls = mock.calls.GetLastUsedAt
+	mock.lockGetLastUsedAt.RUnlock()
+	return calls
+}
+
+// GetName calls GetNameFunc.
+func (mock *PasskeyMock) GetName() string {
+	if mock.GetNameFunc == nil {
+		panic("PasskeyMock.GetNameFunc: method is nil but Passkey.GetName was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetName.Lock()
+	mock.calls.GetName = append(mock.calls.GetName, callInfo)
+	mock.lockGetName.Unlock()
+	return mock.GetNameFunc()
+}
+
+// GetNameCalls gets all the calls that were made to GetName.
+// Check the length with:
+//
+//	len(mockedPasskey.GetNameCalls())
+func (mock *PasskeyMock) GetNameCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetName.RLock()
+	calls = mock.calls.GetName
+	mock.lockGetName.RUnlock()
+	return calls
+}
+
+// GetPublicKey calls GetPublicKeyFunc.
+func (mock *PasskeyMock) GetPublicKey() []byte {
+	if mock.GetPublicKeyFunc == nil {
+		panic("PasskeyMock.GetPublicKeyFunc: method is nil but Passkey.GetPublicKey was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetPublicKey.Lock()
+	mock.calls.GetPublicKey = append(mock.calls.GetPublicKey, callInfo)
+	mock.lockGetPublicKey.Unlock()
+	return mock.GetPublicKeyFunc()
+}
+
+// GetPublicKeyCalls gets all the calls that were made to GetPublicKey.
+// Check the length with:
+//
+//	len(mockedPasskey.GetPublicKeyCalls())
+func (mock *PasskeyMock) GetPublicKeyCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetPublicKey.RLock()
+	calls = mock.calls.GetPublicKey
+	mock.lockGetPublicKey.RUnlock()
+	return calls
+}
+
+// GetSignCount calls GetSignCountFunc.
+func (mock *PasskeyMock) GetSignCount() uint32 {
+	if mock.GetSignCountFunc == nil {
+		panic("PasskeyMock.GetSignCountFunc: method is nil but Passkey.GetSignCount was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetSignCount.Lock()
+	mock.calls.GetSignCount = append(mock.calls.GetSignCount, callInfo)
+	mock.lockGetSignCount.Unlock()
+	return mock.GetSignCountFunc()
+}
+
+// GetSignCountCalls gets all the calls that were made to GetSignCount.
+// Check the length with:
+//
+//	len(mockedPasskey.GetSignCountCalls())
+func (mock *PasskeyMock) GetSignCountCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetSignCount.RLock()
+	calls = mock.calls.GetSignCount
+	mock.lockGetSignCount.RUnlock()
+	return calls
+}
+
+// GetTransports calls GetTransportsFunc.
+func (mock *PasskeyMock) GetTransports() []string {
+	if mock.GetTransportsFunc == nil {
+		panic("PasskeyMock.GetTransportsFunc: method is nil but Passkey.GetTransports was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetTransports.Lock()
+	mock.calls.GetTransports = append(mock.calls.GetTransports, callInfo)
+	mock.lockGetTransports.Unlock()
+	return mock.GetTransportsFunc()
+}
+
+// GetTransportsCalls gets all the calls that were made to GetTransports.
+// Check the length with:
+//
+//	len(mockedPasskey.GetTransportsCalls())
+func (mock *PasskeyMock) GetTransportsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetTransports.RLock()
+	calls = mock.calls.GetTransports
+	mock.lockGetTransports.RUnlock()
+	return calls
+}
+
+// GetUserID calls GetUserIDFunc.
+func (mock *PasskeyMock) GetUserID() uuid.UUID {
+	if mock.GetUserIDFunc == nil {
+		panic("PasskeyMock.GetUserIDFunc: method is nil but Passkey.GetUserID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetUserID.Lock()
+	mock.calls.GetUserID = append(mock.calls.GetUserID, callInfo)
+	mock.lockGetUserID.Unlock()
+	return mock.GetUserIDFunc()
+}
+
+// GetUserIDCalls gets all the calls that were made to GetUserID.
+// Check the length with:
+//
+//	len(mockedPasskey.GetUserIDCalls())
+func (mock *PasskeyMock) GetUserIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetUserID.RLock()
+	calls = mock.calls.GetUserID
+	mock.lockGetUserID.RUnlock()
+	return calls
+}
+
+// IsBackupEligible calls IsBackupEligibleFunc.
+func (mock *PasskeyMock) IsBackupEligible() bool {
+	if mock.IsBackupEligibleFunc == nil {
+		panic("PasskeyMock.IsBackupEligibleFunc: method is nil but Passkey.IsBackupEligible was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsBackupEligible.Lock()
+	mock.calls.IsBackupEligible = append(mock.calls.IsBackupEligible, callInfo)
+	mock.lockIsBackupEligible.Unlock()
+	return mock.IsBackupEligibleFunc()
+}
+
+// IsBackupEligibleCalls gets all the calls that were made to IsBackupEligible.
+// Check the length with:
+//
+//	len(mockedPasskey.IsBackupEligibleCalls())
+func (mock *PasskeyMock) IsBackupEligibleCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsBackupEligible.RLock()
+	calls = mock.calls.IsBackupEligible
+	mock.lockIsBackupEligible.RUnlock()
+	return calls
+}
+
+// IsBackupState calls IsBackupStateFunc.
+func (mock *PasskeyMock) IsBackupState() bool {
+	if mock.IsBackupStateFunc == nil {
+		panic("PasskeyMock.IsBackupStateFunc: method is nil but Passkey.IsBackupState was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsBackupState.Lock()
+	mock.calls.IsBackupState = append(mock.calls.IsBackupState, callInfo)
+	mock.lockIsBackupState.Unlock()
+	return mock.IsBackupStateFunc()
+}
+
+// IsBackupStateCalls gets all the calls that were made to IsBackupState.
+// Check the length with:
+//
+//	len(mockedPasskey.IsBackupStateCalls())
+func (mock *PasskeyMock) IsBackupStateCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsBackupState.RLock()
+	calls = mock.calls.IsBackupState
+	mock.lockIsBackupState.RUnlock()
+	return calls
+}
@@ -0,0 +1,301 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Ensure, that PasskeyRepositoryMock does implement PasskeyRepository.
+// If this is not the case, regenerate this file with moq.
+var _ PasskeyRepository = &PasskeyRepositoryMock{}
+
+// PasskeyRepositoryMock is a mock implementation of PasskeyRepository.
+//
+//	func TestSomethingThatUsesPasskeyRepository(t *testing.T) {
+//
+//		// make and configure a mocked PasskeyRepository
+//		mockedPasskeyRepository := &PasskeyRepositoryMock{
+//			DeletePasskeyFunc: func(ctx context.Context, userID uuid.UUID, credentialID []byte) error {
+//				panic("mock out the DeletePasskey method")
+//			},
+//			FindPasskeyFunc: func(ctx context.Context, credentialID []byte) (Passkey, error) {
+//				panic("mock out the FindPasskey method")
+//			},
+//			FindPasskeysByUserIDFunc: func(ctx context.Context, userID uuid.UUID) ([]Passkey, error) {
+//				panic("mock out the FindPasskeysByUserID method")
+//			},
+//			StorePasskeyFunc: func(ctx context.Context, p Passkey) error {
+//				panic("mock out the StorePasskey method")
+//			},
+//			UpdatePasskeySignCountFunc: func(ctx context.Context, credentialID []byte, count uint32, usedAt time.Time) (bool, error) {
+//				panic("mock out the UpdatePasskeySignCount method")
+//			},
+//		}
+//
+//		// use mockedPasskeyRepository in code that requires PasskeyRepository
+//		// and then make assertions.
+//
+//	}
+type PasskeyRepositoryMock struct {
+	// DeletePasskeyFunc mocks the DeletePasskey method.
+	DeletePasskeyFunc func(ctx context.Context, userID uuid.UUID, credentialID []byte) error
+
+	// FindPasskeyFunc mocks the FindPasskey method.
+	FindPasskeyFunc func(ctx context.Context, credentialID []byte) (Passkey, error)
+
+	// FindPasskeysByUserIDFunc mocks the FindPasskeysByUserID method.
+	FindPasskeysByUserIDFunc func(ctx context.Context, userID uuid.UUID) ([]Passkey, error)
+
+	// StorePasskeyFunc mocks the StorePasskey method.
+	StorePasskeyFunc func(ctx context.Context, p Passkey) error
+
+	// UpdatePasskeySignCountFunc mocks the UpdatePasskeySignCount method.
+	UpdatePasskeySignCountFunc func(ctx context.Context, credentialID []byte, count uint32, usedAt time.Time) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeletePasskey holds details about calls to the DeletePasskey method.
+		DeletePasskey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// CredentialID is the credentialID argument value.
+			CredentialID []byte
+		}
+		// FindPasskey holds details about calls to the FindPasskey method.
+		FindPasskey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CredentialID is the credentialID argument value.
+			CredentialID []byte
+		}
+		// FindPasskeysByUserID holds details about calls to the FindPasskeysByUserID method.
+		FindPasskeysByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// StorePasskey holds details about calls to the StorePasskey method.
+		StorePasskey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P Passkey
+		}
+		// UpdatePasskeySignCount holds details about calls to the UpdatePasskeySignCount method.
+		UpdatePasskeySignCount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CredentialID is the credentialID argument value.
+			CredentialID []byte
+			// Count is the count argument value.
+			Count uint32
+			// UsedAt is the usedAt argument value.
+			UsedAt time.Time
+		}
+	}
+	lockDeletePasskey          sync.RWMutex
+	lockFindPasskey            sync.RWMutex
+	lockFindPasskeysByUserID   sync.RWMutex
+	lockStorePasskey           sync.RWMutex
+	lockUpdatePasskeySignCount sync.RWMutex
+}
+
+// DeletePasskey calls DeletePasskeyFunc.
+func (mock *PasskeyRepositoryMock) DeletePasskey(ctx context.Context, userID uuid.UUID, credentialID []byte) error {
+	if mock.DeletePasskeyFunc == nil {
+		panic("PasskeyRepositoryMock.DeletePasskeyFunc: method is nil but PasskeyRepository.DeletePasskey was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		UserID       uuid.UUID
+		CredentialID []byte
+	}{
+		Ctx:          ctx,
+		UserID:       userID,
+		CredentialID: credentialID,
+	}
+	mock.lockDeletePasskey.Lock()
+	mock.calls.DeletePasskey = append(mock.calls.DeletePasskey, callInfo)
+	mock.lockDeletePasskey.Unlock()
+	return mock.DeletePasskeyFunc(ctx, userID, credentialID)
+}
+
+// DeletePasskeyCalls gets all the calls that were made to DeletePasskey.
+// Check the length with:
+//
+//	len(mockedPasskeyRepository.DeletePasskeyCalls())
+func (mock *PasskeyRepositoryMock) DeletePasskeyCalls() []struct {
+	Ctx          context.Context
+	UserID       uuid.UUID
+	CredentialID []byte
+} {
+	var calls []struct {
+		Ctx          context.Context
+		UserID       uuid.UUID
+		CredentialID []byte
+	}
+	mock.lockDeletePasskey.RLock()
+	calls = mock.calls.DeletePasskey
+	mock.lockDeletePasskey.RUnlock()
+	return calls
+}
+
+// FindPasskey calls FindPasskeyFunc.
+func (mock *PasskeyRepositoryMock) FindPasskey(ctx context.Context, credentialID []byte) (Passkey, error) {
+	if mock.FindPasskeyFunc == nil {
+		panic("PasskeyRepositoryMock.FindPasskeyFunc: method is nil but PasskeyRepository.FindPasskey was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		CredentialID []byte
+	}{
+		Ctx:          ctx,
+		CredentialID: credentialID,
+	}
+	mock.lockFindPasskey.Lock()
+	mock.calls.FindPasskey = append(mock.calls.FindPasskey, callInfo)
+	mock.lockFindPasskey.Unlock()
+	return mock.FindPasskeyFunc(ctx, credentialID)
+}
+
+// FindPasskeyCalls gets all the calls that were made to FindPasskey.
+// Check the length with:
+//
+//	len(mockedPasskeyRepository.FindPasskeyCalls())
+func (mock *PasskeyRepositoryMock) FindPasskeyCalls() []struct {
+	Ctx          context.Context
+	CredentialID []byte
+} {
+	var calls []struct {
+		Ctx          context.Context
+		CredentialID []byte
+	}
+	mock.lockFindPasskey.RLock()
+	calls = mock.calls.FindPasskey
+	mock.lockFindPasskey.RUnlock()
+	return calls
+}
+
+// FindPasskeysByUserID calls FindPasskeysByUserIDFunc.
+func (mock *PasskeyRepositoryMock) FindPasskeysByUserID(ctx context.Context, userID uuid.UUID) ([]Passkey, error) {
+	if mock.FindPasskeysByUserIDFunc == nil {
+		panic("PasskeyRepositoryMock.FindPasskeysByUserIDFunc: method is nil but PasskeyRepository.FindPasskeysByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockFindPasskeysByUserID.Lock()
+	mock.calls.FindPasskeysByUserID = append(mock.calls.FindPasskeysByUserID, callInfo)
+	mock.lockFindPasskeysByUserID.Unlock()
+	return mock.FindPasskeysByUserIDFunc(ctx, userID)
+}
+
+// FindPasskeysByUserIDCalls gets all the calls that were made to FindPasskeysByUserID.
+// Check the length with:
+//
+//	len(mockedPasskeyRepository.FindPasskeysByUserIDCalls())
+func (mock *PasskeyRepositoryMock) FindPasskeysByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockFindPasskeysByUserID.RLock()
+	calls = mock.calls.FindPasskeysByUserID
+	mock.lockFindPasskeysByUserID.RUnlock()
+	return calls
+}
+
+// StorePasskey calls StorePasskeyFunc.
+func (mock *PasskeyRepositoryMock) StorePasskey(ctx context.Context, p Passkey) error {
+	if mock.StorePasskeyFunc == nil {
+		panic("PasskeyRepositoryMock.StorePasskeyFunc: method is nil but PasskeyRepository.StorePasskey was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   Passkey
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	mock.lockStorePasskey.Lock()
+	mock.calls.StorePasskey = append(mock.calls.StorePasskey, callInfo)
+	mock.lockStorePasskey.Unlock()
+	return mock.StorePasskeyFunc(ctx, p)
+}
+
+// StorePasskeyCalls gets all the calls that were made to StorePasskey.
+// Check the length with:
+//
+//	len(mockedPasskeyRepository.StorePasskeyCalls())
+func (mock *PasskeyRepositoryMock) StorePasskeyCalls() []struct {
+	Ctx context.Context
+	P   Passkey
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   Passkey
+	}
+	mock.lockStorePasskey.RLock()
+	calls = mock.calls.StorePasskey
+	mock.lockStorePasskey.RUnlock()
+	return calls
+}
+
+// UpdatePasskeySignCount calls UpdatePasskeySignCountFunc.
+func (mock *PasskeyRepositoryMock) UpdatePasskeySignCount(ctx context.Context, credentialID []byte, count uint32, usedAt time.Time) (bool, error) {
+	if mock.UpdatePasskeySignCountFunc == nil {
+		panic("PasskeyRepositoryMock.UpdatePasskeySignCountFunc: method is nil but PasskeyRepository.UpdatePasskeySignCount was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		CredentialID []byte
+		Count        uint32
+		UsedAt       time.Time
+	}{
+		Ctx:          ctx,
+		CredentialID: credentialID,
+		Count:        count,
+		UsedAt:       usedAt,
+	}
+	mock.lockUpdatePasskeySignCount.Lock()
+	mock.calls.UpdatePasskeySignCount = append(mock.calls.UpdatePasskeySignCount, callInfo)
+	mock.lockUpdatePasskeySignCount.Unlock()
+	return mock.UpdatePasskeySignCountFunc(ctx, credentialID, count, usedAt)
+}
+
+// UpdatePasskeySignCountCalls gets all the calls that were made to UpdatePasskeySignCount.
+// Check the length with:
+//
+//	len(mockedPasskeyRepository.UpdatePasskeySignCountCalls())
+func (mock *PasskeyRepositoryMock) UpdatePasskeySignCountCalls() []struct {
+	Ctx          context.Context
+	CredentialID []byte
+	Count        uint32
+	UsedAt       time.Time
+} {
+	var calls []struct {
+		Ctx          context.Context
+		CredentialID []byte
+		Count        uint32
+		UsedAt       time.Time
+	}
+	mock.lockUpdatePasskeySignCount.RLock()
+	calls = mock.calls.UpdatePasskeySignCount
+	mock.lockUpdatePasskeySignCount.RUnlock()
+	return calls
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// パスワードのハッシュに使うアルゴリズム
+const (
+	PasswordAlgorithmArgon2id = "argon2id"
+	PasswordAlgorithmBcrypt   = "bcrypt"
+)
+
+const (
+	argon2SaltBytes = 16
+	argon2KeyBytes  = 32
+)
+
+var (
+	ErrUnknownPasswordAlgorithm = errors.New("unknown password hash algorithm")
+	ErrInvalidPasswordHash      = errors.New("invalid password hash")
+)
+
+type PasswordHasherParams struct {
+	// Algorithm は新しくハッシュを作るときのアルゴリズム。照合はどちらのアルゴリズムでもできる
+	Algorithm string
+	// Argon2Memory は KiB を単位として指定する
+	Argon2Memory      uint32
+	Argon2Time        uint32
+	Argon2Parallelism uint8
+	BcryptCost        int
+}
+
+// NewPasswordHasher は設定したアルゴリズムでハッシュを作る PasswordHasher を返す
+func NewPasswordHasher(p PasswordHasherParams) (PasswordHasher, error) {
+	switch p.Algorithm {
+	case PasswordAlgorithmArgon2id, PasswordAlgorithmBcrypt:
+	default:
+		return nil, ErrUnknownPasswordAlgorithm
+	}
+	if p.Algorithm == PasswordAlgorithmBcrypt && (p.BcryptCost < bcrypt.MinCost || p.BcryptCost > bcrypt.MaxCost) {
+		return nil, errors.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	// argon2 はメモリに並列数の8倍以上を必要とする (RFC 9106 3.1)
+	if p.Algorithm == PasswordAlgorithmArgon2id && (p.Argon2Time == 0 || p.Argon2Parallelism == 0 || p.Argon2Memory < 8*uint32(p.Argon2Parallelism)) {
+		return nil, errors.New("invalid argon2id parameters")
+	}
+	return &passwordHasher{params: p}, nil
+}
+
+// PasswordHasher はパスワードを PHC 形式の文字列にハッシュ化し、照合する。
+// bcrypt は従来どおり $2a$ から始まる形式で扱う
+//
+//go:generate go run github.com/matryer/moq -out password_hasher_mock.go . PasswordHasher
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hash, password string) (bool, error)
+	// NeedsRehash はハッシュのアルゴリズムやパラメータが現在の設定と異なる場合に true を返す
+	NeedsRehash(hash string) bool
+}
+
+type passwordHasher struct {
+	params PasswordHasherParams
+}
+
+// argon2Hash は $argon2id$v=19$m=...,t=...,p=...$<salt>$<hash> を分解した値
+type argon2Hash struct {
+	memory      uint32
+	time        uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func (h *passwordHasher) Hash(password string) (string, error) {
+	if h.params.Algorithm == PasswordAlgorithmBcrypt {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.params.BcryptCost)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		return string(hashed), nil
+	}
+
+	salt := make([]byte, argon2SaltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.WithStack(err)
+	}
+	key := argon2.IDKey([]byte(password), salt, h.params.Argon2Time, h.params.Argon2Memory, h.params.Argon2Parallelism, argon2KeyBytes)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.params.Argon2Memory, h.params.Argon2Time, h.params.Argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify はハッシュのアルゴリズムを判別して照合する。一致しない場合は false を返す
+func (h *passwordHasher) Verify(hash, password string) (bool, error) {
+	if isBcryptHash(hash) {
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		return true, nil
+	}
+
+	parsed, err := parseArgon2Hash(hash)
+	if err != nil {
+		return false, err
+	}
+	key := argon2.IDKey([]byte(password), parsed.salt, parsed.time, parsed.memory, parsed.parallelism, uint32(len(parsed.key)))
+	return subtle.ConstantTimeCompare(key, parsed.key) == 1, nil
+}
+
+func (h *passwordHasher) NeedsRehash(hash string) bool {
+	if isBcryptHash(hash) {
+		if h.params.Algorithm != PasswordAlgorithmBcrypt {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || cost != h.params.BcryptCost
+	}
+
+	if h.params.Algorithm != PasswordAlgorithmArgon2id {
+		return true
+	}
+	parsed, err := parseArgon2Hash(hash)
+	if err != nil {
+		return true
+	}
+	return parsed.memory != h.params.Argon2Memory ||
+		parsed.time != h.params.Argon2Time ||
+		parsed.parallelism != h.params.Argon2Parallelism ||
+		len(parsed.key) != argon2KeyBytes
+}
+
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func parseArgon2Hash(hash string) (*argon2Hash, error) {
+	// 先頭の $ で空の要素ができる
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != PasswordAlgorithmArgon2id {
+		return nil, ErrInvalidPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrInvalidPasswordHash
+	}
+
+	var parsed argon2Hash
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &parsed.memory, &parsed.time, &parsed.parallelism); err != nil {
+		return nil, ErrInvalidPasswordHash
+	}
+
+	var err error
+	if parsed.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrInvalidPasswordHash
+	}
+	if parsed.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(parsed.key) == 0 {
+		return nil, ErrInvalidPasswordHash
+	}
+	return &parsed, nil
+}
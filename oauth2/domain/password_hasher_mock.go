@@ -0,0 +1,168 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"sync"
+)
+
+// Ensure, that PasswordHasherMock does implement PasswordHasher.
+// If this is not the case, regenerate this file with moq.
+var _ PasswordHasher = &PasswordHasherMock{}
+
+// PasswordHasherMock is a mock implementation of PasswordHasher.
+//
+//	func TestSomethingThatUsesPasswordHasher(t *testing.T) {
+//
+//		// make and configure a mocked PasswordHasher
+//		mockedPasswordHasher := &PasswordHasherMock{
+//			HashFunc: func(password string) (string, error) {
+//				panic("mock out the Hash method")
+//			},
+//			NeedsRehashFunc: func(hash string) bool {
+//				panic("mock out the NeedsRehash method")
+//			},
+//			VerifyFunc: func(hash string, password string) (bool, error) {
+//				panic("mock out the Verify method")
+//			},
+//		}
+//
+//		// use mockedPasswordHasher in code that requires PasswordHasher
+//		// and then make assertions.
+//
+//	}
+type PasswordHasherMock struct {
+	// HashFunc mocks the Hash method.
+	HashFunc func(password string) (string, error)
+
+	// NeedsRehashFunc mocks the NeedsRehash method.
+	NeedsRehashFunc func(hash string) bool
+
+	// VerifyFunc mocks the Verify method.
+	VerifyFunc func(hash string, password string) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Hash holds details about calls to the Hash method.
+		Hash []struct {
+			// Password is the password argument value.
+			Password string
+		}
+		// NeedsRehash holds details about calls to the NeedsRehash method.
+		NeedsRehash []struct {
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// Verify holds details about calls to the Verify method.
+		Verify []struct {
+			// Hash is the hash argument value.
+			Hash string
+			// Password is the password argument value.
+			Password string
+		}
+	}
+	lockHash        sync.RWMutex
+	lockNeedsRehash sync.RWMutex
+	lockVerify      sync.RWMutex
+}
+
+// Hash calls HashFunc.
+func (mock *PasswordHasherMock) Hash(password string) (string, error) {
+	if mock.HashFunc == nil {
+		panic("PasswordHasherMock.HashFunc: method is nil but PasswordHasher.Hash was just called")
+	}
+	callInfo := struct {
+		Password string
+	}{
+		Password: password,
+	}
+	mock.lockHash.Lock()
+	mock.calls.Hash = append(mock.calls.Hash, callInfo)
+	mock.lockHash.Unlock()
+	return mock.HashFunc(password)
+}
+
+// HashCalls gets all the calls that were made to Hash.
+// Check the length with:
+//
+//	len(mockedPasswordHasher.HashCalls())
+func (mock *PasswordHasherMock) HashCalls() []struct {
+	Password string
+} {
+	var calls []struct {
+		Password string
+	}
+	mock.lockHash.RLock()
+	calls = mock.calls.Hash
+	mock.lockHash.RUnlock()
+	return calls
+}
+
+// NeedsRehash calls NeedsRehashFunc.
+func (mock *PasswordHasherMock) NeedsRehash(hash string) bool {
+	if mock.NeedsRehashFunc == nil {
+		panic("PasswordHasherMock.NeedsRehashFunc: method is nil but PasswordHasher.NeedsRehash was just called")
+	}
+	callInfo := struct {
+		Hash string
+	}{
+		Hash: hash,
+	}
+	mock.lockNeedsRehash.Lock()
+	mock.calls.NeedsRehash = append(mock.calls.NeedsRehash, callInfo)
+	mock.lockNeedsRehash.Unlock()
+	return mock.NeedsRehashFunc(hash)
+}
+
+// NeedsRehashCalls gets all the calls that were made to NeedsRehash.
+// Check the length with:
+//
+//	len(mockedPasswordHasher.NeedsRehashCalls())
+func (mock *PasswordHasherMock) NeedsRehashCalls() []struct {
+	Hash string
+} {
+	var calls []struct {
+		Hash string
+	}
+	mock.lockNeedsRehash.RLock()
+	calls = mock.calls.NeedsRehash
+	mock.lockNeedsRehash.RUnlock()
+	return calls
+}
+
+// Verify calls VerifyFunc.
+func (mock *PasswordHasherMock) Verify(hash string, password string) (bool, error) {
+	if mock.VerifyFunc == nil {
+		panic("PasswordHasherMock.VerifyFunc: method is nil but PasswordHasher.Verify was just called")
+	}
+	callInfo := struct {
+		Hash     string
+		Password string
+	}{
+		Hash:     hash,
+		Password: password,
+	}
+	mock.lockVerify.Lock()
+	mock.calls.Verify = append(mock.calls.Verify, callInfo)
+	mock.lockVerify.Unlock()
+	return mock.VerifyFunc(hash, password)
+}
+
+// VerifyCalls gets all the calls that were made to Verify.
+// Check the length with:
+//
+//	len(mockedPasswordHasher.VerifyCalls())
+func (mock *PasswordHasherMock) VerifyCalls() []struct {
+	Hash     string
+	Password string
+} {
+	var calls []struct {
+		Hash     string
+		Password string
+	}
+	mock.lockVerify.RLock()
+	calls = mock.calls.Verify
+	mock.lockVerify.RUnlock()
+	return calls
+}
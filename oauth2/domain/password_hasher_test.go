@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newArgon2Hasher(t *testing.T, memory uint32) PasswordHasher {
+	t.Helper()
+	h, err := NewPasswordHasher(PasswordHasherParams{
+		Algorithm:         PasswordAlgorithmArgon2id,
+		Argon2Memory:      memory,
+		Argon2Time:        1,
+		Argon2Parallelism: 1,
+	})
+	require.NoError(t, err)
+	return h
+}
+
+func TestPasswordHasher_Argon2id(t *testing.T) {
+	h := newArgon2Hasher(t, 64)
+
+	hash, err := h.Hash("correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+
+	ok, err := h.Verify(hash, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify(hash, "wrong")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 同じパスワードでもソルトが異なる
+	other, err := h.Hash("correct horse battery staple")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	assert.False(t, h.NeedsRehash(hash))
+	assert.True(t, newArgon2Hasher(t, 128).NeedsRehash(hash))
+}
+
+func TestPasswordHasher_Bcrypt(t *testing.T) {
+	h, err := NewPasswordHasher(PasswordHasherParams{Algorithm: PasswordAlgorithmBcrypt, BcryptCost: 4})
+	require.NoError(t, err)
+
+	hash, err := h.Hash("password")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$2a$04$"))
+	assert.False(t, h.NeedsRehash(hash))
+
+	// 設定したアルゴリズムによらず、どちらのハッシュも照合できる
+	argon2 := newArgon2Hasher(t, 64)
+	ok, err := argon2.Verify(hash, "password")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, argon2.NeedsRehash(hash))
+
+	argon2Hash, err := argon2.Hash("password")
+	require.NoError(t, err)
+	ok, err = h.Verify(argon2Hash, "password")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, h.NeedsRehash(argon2Hash))
+}
+
+func TestPasswordHasher_InvalidHash(t *testing.T) {
+	h := newArgon2Hasher(t, 64)
+
+	for _, hash := range []string{
+		"",
+		"plain-text",
+		"$argon2i$v=19$m=64,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=64$c2FsdA$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$!!!$a2V5",
+	} {
+		_, err := h.Verify(hash, "password")
+		assert.ErrorIs(t, err, ErrInvalidPasswordHash, hash)
+		assert.True(t, h.NeedsRehash(hash), hash)
+	}
+}
+
+func TestNewPasswordHasher_InvalidParams(t *testing.T) {
+	_, err := NewPasswordHasher(PasswordHasherParams{Algorithm: "md5"})
+	assert.ErrorIs(t, err, ErrUnknownPasswordAlgorithm)
+
+	_, err = NewPasswordHasher(PasswordHasherParams{Algorithm: PasswordAlgorithmBcrypt, BcryptCost: 100})
+	require.Error(t, err)
+
+	_, err = NewPasswordHasher(PasswordHasherParams{Algorithm: PasswordAlgorithmArgon2id, Argon2Memory: 4, Argon2Time: 1, Argon2Parallelism: 1})
+	require.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// PasswordMaxBytes は bcrypt が扱える長さの上限。これを超えた部分は bcrypt では無視される
+const PasswordMaxBytes = 72
+
+// minEmailLocalPartLength より短いローカル部はパスワードに含まれていても拒否しない
+const minEmailLocalPartLength = 3
+
+// パスワードを拒否した理由
+type PasswordViolation string
+
+const (
+	PasswordViolationTooShort      PasswordViolation = "too_short"
+	PasswordViolationTooLong       PasswordViolation = "too_long"
+	PasswordViolationContainsEmail PasswordViolation = "contains_email"
+	PasswordViolationBreached      PasswordViolation = "breached"
+)
+
+// PasswordPolicyError はパスワードが規則を満たさない場合のエラー。
+// Limit は長さの規則に違反した場合の上限または下限
+type PasswordPolicyError struct {
+	Violation PasswordViolation
+	Limit     int
+}
+
+func (e *PasswordPolicyError) Error() string {
+	switch e.Violation {
+	case PasswordViolationTooShort:
+		return fmt.Sprintf("password must be at least %d characters", e.Limit)
+	case PasswordViolationTooLong:
+		return fmt.Sprintf("password must be at most %d bytes", e.Limit)
+	case PasswordViolationContainsEmail:
+		return "password must not contain the email address"
+	case PasswordViolationBreached:
+		return "password has appeared in a data breach"
+	}
+	return "password does not satisfy the policy"
+}
+
+// MessageKey はメッセージを翻訳するためのキーを返す
+func (e *PasswordPolicyError) MessageKey() string {
+	return "password." + string(e.Violation)
+}
+
+func (e *PasswordPolicyError) MessageArgs() []any {
+	if e.Limit == 0 {
+		return nil
+	}
+	return []any{e.Limit}
+}
+
+// BreachedPasswordList は漏洩したパスワードの一覧
+//
+//go:generate go run github.com/matryer/moq -out breached_password_list_mock.go . BreachedPasswordList
+type BreachedPasswordList interface {
+	Contains(password string) (bool, error)
+}
+
+type PasswordPolicyParams struct {
+	// MinLength は文字数 (rune) で数える
+	MinLength int
+	// BreachedPasswords が nil の場合は漏洩の確認をしない
+	BreachedPasswords BreachedPasswordList
+}
+
+// NewPasswordPolicy はパスワードの規則を返す。パスワードを設定する処理は全てこれで検証する
+func NewPasswordPolicy(p PasswordPolicyParams) PasswordPolicy {
+	return &passwordPolicy{params: p}
+}
+
+//go:generate go run github.com/matryer/moq -out password_policy_mock.go . PasswordPolicy
+type PasswordPolicy interface {
+	// Validate は規則を満たさない場合に *PasswordPolicyError を返す。
+	// 漏洩した一覧を読めない場合はそれ以外のエラーを返す
+	Validate(password, email string) error
+}
+
+type passwordPolicy struct {
+	params PasswordPolicyParams
+}
+
+func (p *passwordPolicy) Validate(password, email string) error {
+	if utf8.RuneCountInString(password) < p.params.MinLength {
+		return &PasswordPolicyError{Violation: PasswordViolationTooShort, Limit: p.params.MinLength}
+	}
+	if len(password) > PasswordMaxBytes {
+		return &PasswordPolicyError{Violation: PasswordViolationTooLong, Limit: PasswordMaxBytes}
+	}
+	if containsEmail(password, email) {
+		return &PasswordPolicyError{Violation: PasswordViolationContainsEmail}
+	}
+
+	if p.params.BreachedPasswords == nil {
+		return nil
+	}
+	breached, err := p.params.BreachedPasswords.Contains(password)
+	if err != nil {
+		return err
+	}
+	if breached {
+		return &PasswordPolicyError{Violation: PasswordViolationBreached}
+	}
+	return nil
+}
+
+// containsEmail はメールアドレス全体か、そのローカル部を大文字と小文字を区別せずに探す
+func containsEmail(password, email string) bool {
+	if email == "" {
+		return false
+	}
+	password = strings.ToLower(password)
+	email = strings.ToLower(email)
+	if strings.Contains(password, email) {
+		return true
+	}
+	local, _, ok := strings.Cut(email, "@")
+	return ok && utf8.RuneCountInString(local) >= minEmailLocalPartLength && strings.Contains(password, local)
+}
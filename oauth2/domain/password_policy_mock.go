@@ -0,0 +1,80 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"sync"
+)
+
+// Ensure, that PasswordPolicyMock does implement PasswordPolicy.
+// If this is not the case, regenerate this file with moq.
+var _ PasswordPolicy = &PasswordPolicyMock{}
+
+// PasswordPolicyMock is a mock implementation of PasswordPolicy.
+//
+//	func TestSomethingThatUsesPasswordPolicy(t *testing.T) {
+//
+//		// make and configure a mocked PasswordPolicy
+//		mockedPasswordPolicy := &PasswordPolicyMock{
+//			ValidateFunc: func(password string, email string) error {
+//				panic("mock out the Validate method")
+//			},
+//		}
+//
+//		// use mockedPasswordPolicy in code that requires PasswordPolicy
+//		// and then make assertions.
+//
+//	}
+type PasswordPolicyMock struct {
+	// ValidateFunc mocks the Validate method.
+	ValidateFunc func(password string, email string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Validate holds details about calls to the Validate method.
+		Validate []struct {
+			// Password is the password argument value.
+			Password string
+			// Email is the email argument value.
+			Email string
+		}
+	}
+	lockValidate sync.RWMutex
+}
+
+// Validate calls ValidateFunc.
+func (mock *PasswordPolicyMock) Validate(password string, email string) error {
+	if mock.ValidateFunc == nil {
+		panic("PasswordPolicyMock.ValidateFunc: method is nil but PasswordPolicy.Validate was just called")
+	}
+	callInfo := struct {
+		Password string
+		Email    string
+	}{
+		Password: password,
+		Email:    email,
+	}
+	mock.lockValidate.Lock()
+	mock.calls.Validate = append(mock.calls.Validate, callInfo)
+	mock.lockValidate.Unlock()
+	return mock.ValidateFunc(password, email)
+}
+
+// ValidateCalls gets all the calls that were made to Validate.
+// Check the length with:
+//
+//	len(mockedPasswordPolicy.ValidateCalls())
+func (mock *PasswordPolicyMock) ValidateCalls() []struct {
+	Password string
+	Email    string
+} {
+	var calls []struct {
+		Password string
+		Email    string
+	}
+	mock.lockValidate.RLock()
+	calls = mock.calls.Validate
+	mock.lockValidate.RUnlock()
+	return calls
+}
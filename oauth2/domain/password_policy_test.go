@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	breached := &BreachedPasswordListMock{
+		ContainsFunc: func(password string) (bool, error) {
+			return password == "password123", nil
+		},
+	}
+	policy := NewPasswordPolicy(PasswordPolicyParams{MinLength: 8, BreachedPasswords: breached})
+
+	tests := []struct {
+		name      string
+		password  string
+		violation PasswordViolation
+	}{
+		{name: "ok", password: "correct horse battery"},
+		{name: "too short", password: "short", violation: PasswordViolationTooShort},
+		// 文字数で数えるため、マルチバイト文字の8文字は受け付ける
+		{name: "multibyte", password: "パスワードです。"},
+		{name: "too long", password: strings.Repeat("a", PasswordMaxBytes+1), violation: PasswordViolationTooLong},
+		// 25文字でも75バイトになる
+		{name: "too long multibyte", password: strings.Repeat("あ", 25), violation: PasswordViolationTooLong},
+		{name: "contains email", password: "xxUser@Example.comxx", violation: PasswordViolationContainsEmail},
+		{name: "contains local part", password: "user-secret-1", violation: PasswordViolationContainsEmail},
+		{name: "breached", password: "password123", violation: PasswordViolationBreached},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, "user@example.com")
+			if tt.violation == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var policyErr *PasswordPolicyError
+			require.ErrorAs(t, err, &policyErr)
+			assert.Equal(t, tt.violation, policyErr.Violation)
+		})
+	}
+}
+
+func TestPasswordPolicy_ShortLocalPart(t *testing.T) {
+	policy := NewPasswordPolicy(PasswordPolicyParams{MinLength: 8})
+
+	// 短いローカル部はありふれた文字列のため拒否しない
+	assert.NoError(t, policy.Validate("jo-long-password", "jo@example.com"))
+	assert.Error(t, policy.Validate("jo@example.com-password", "jo@example.com"))
+}
+
+func TestPasswordPolicyError_Message(t *testing.T) {
+	err := &PasswordPolicyError{Violation: PasswordViolationTooShort, Limit: 8}
+	assert.Equal(t, "password.too_short", err.MessageKey())
+	assert.Equal(t, []any{8}, err.MessageArgs())
+	assert.Equal(t, "password must be at least 8 characters", err.Error())
+
+	err = &PasswordPolicyError{Violation: PasswordViolationBreached}
+	assert.Nil(t, err.MessageArgs())
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PasswordResetTokenParams struct {
+	TokenHash string
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func NewPasswordResetToken(p PasswordResetTokenParams) PasswordResetToken {
+	return &passwordResetToken{
+		tokenHash: p.TokenHash,
+		userID:    p.UserID,
+		expiresAt: p.ExpiresAt,
+		usedAt:    p.UsedAt,
+		createdAt: p.CreatedAt,
+	}
+}
+
+// IssuePasswordResetToken は新しい再設定用トークンを発行する。
+// 戻り値の文字列はメールで送るトークンで、保存されるのはそのハッシュのみ
+func IssuePasswordResetToken(userID uuid.UUID, expires time.Duration) (PasswordResetToken, string, error) {
+	token, hash, err := GenerateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	return NewPasswordResetToken(PasswordResetTokenParams{
+		TokenHash: hash,
+		UserID:    userID,
+		ExpiresAt: now.Add(expires),
+		CreatedAt: now,
+	}), token, nil
+}
+
+//go:generate go run github.com/matryer/moq -out password_reset_token_mock.go . PasswordResetToken
+type PasswordResetToken interface {
+	GetTokenHash() string
+	GetUserID() uuid.UUID
+	GetExpiresAt() time.Time
+	GetCreatedAt() time.Time
+	IsExpired(now time.Time) bool
+	IsUsed() bool
+}
+
+//go:generate go run github.com/matryer/moq -out password_reset_token_repository_mock.go . PasswordResetTokenRepository
+type PasswordResetTokenRepository interface {
+	StorePasswordResetToken(ctx context.Context, t PasswordResetToken) error
+	FindPasswordResetToken(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	// ConsumePasswordResetToken は未使用のトークンを使用済みにする。既に使用済みの場合は false を返す
+	ConsumePasswordResetToken(ctx context.Context, tokenHash string) (bool, error)
+	// ConsumePasswordResetTokensByUserID はユーザーの未使用のトークンを全て使用済みにする
+	ConsumePasswordResetTokensByUserID(ctx context.Context, userID uuid.UUID) error
+	CountPasswordResetTokensSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+}
+
+// SessionRevoker はユーザーのログイン状態を全ての端末で破棄する
+//
+//go:generate go run github.com/matryer/moq -out session_revoker_mock.go . SessionRevoker
+type SessionRevoker interface {
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID, at time.Time) error
+}
+
+type passwordResetToken struct {
+	tokenHash string
+	userID    uuid.UUID
+	expiresAt time.Time
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+func (t *passwordResetToken) GetTokenHash() string {
+	return t.tokenHash
+}
+
+func (t *passwordResetToken) GetUserID() uuid.UUID {
+	return t.userID
+}
+
+func (t *passwordResetToken) GetExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *passwordResetToken) GetCreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *passwordResetToken) IsExpired(now time.Time) bool {
+	return now.After(t.expiresAt)
+}
+
+func (t *passwordResetToken) IsUsed() bool {
+	return t.usedAt != nil
+}
@@ -0,0 +1,261 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Ensure, that PasswordResetTokenMock does implement PasswordResetToken.
+// If this is not the case, regenerate this file with moq.
+var _ PasswordResetToken = &PasswordResetTokenMock{}
+
+// PasswordResetTokenMock is a mock implementation of PasswordResetToken.
+//
+//	func TestSomethingThatUsesPasswordResetToken(t *testing.T) {
+//
+//		// make and configure a mocked PasswordResetToken
+//		mockedPasswordResetToken := &PasswordResetTokenMock{
+//			GetCreatedAtFunc: func() time.Time {
+//				panic("mock out the GetCreatedAt method")
+//			},
+//			GetExpiresAtFunc: func() time.Time {
+//				panic("mock out the GetExpiresAt method")
+//			},
+//			GetTokenHashFunc: func() string {
+//				panic("mock out the GetTokenHash method")
+//			},
+//			GetUserIDFunc: func() uuid.UUID {
+//				panic("mock out the GetUserID method")
+//			},
+//			IsExpiredFunc: func(now time.Time) bool {
+//				panic("mock out the IsExpired method")
+//			},
+//			IsUsedFunc: func() bool {
+//				panic("mock out the IsUsed method")
+//			},
+//		}
+//
+//		// use mockedPasswordResetToken in code that requires PasswordResetToken
+//		// and then make assertions.
+//
+//	}
+type PasswordResetTokenMock struct {
+	// GetCreatedAtFunc mocks the GetCreatedAt method.
+	GetCreatedAtFunc func() time.Time
+
+	// GetExpiresAtFunc mocks the GetExpiresAt method.
+	GetExpiresAtFunc func() time.Time
+
+	// GetTokenHashFunc mocks the GetTokenHash method.
+	GetTokenHashFunc func() string
+
+	// GetUserIDFunc mocks the GetUserID method.
+	GetUserIDFunc func() uuid.UUID
+
+	// IsExpiredFunc mocks the IsExpired method.
+	IsExpiredFunc func(now time.Time) bool
+
+	// IsUsedFunc mocks the IsUsed method.
+	IsUsedFunc func() bool
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetCreatedAt holds details about calls to the GetCreatedAt method.
+		GetCreatedAt []struct {
+		}
+		// GetExpiresAt holds details about calls to the GetExpiresAt method.
+		GetExpiresAt []struct {
+		}
+		// GetTokenHash holds details about calls to the GetTokenHash method.
+		GetTokenHash []struct {
+		}
+		// GetUserID holds details about calls to the GetUserID method.
+		GetUserID []struct {
+		}
+		// IsExpired holds details about calls to the IsExpired method.
+		IsExpired []struct {
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// IsUsed holds details about calls to the IsUsed method.
+		IsUsed []struct {
+		}
+	}
+	lockGetCreatedAt sync.RWMutex
+	lockGetExpiresAt sync.RWMutex
+	lockGetTokenHash sync.RWMutex
+	lockGetUserID    sync.RWMutex
+	lockIsExpired    sync.RWMutex
+	lockIsUsed       sync.RWMutex
+}
+
+// GetCreatedAt calls GetCreatedAtFunc.
+func (mock *PasswordResetTokenMock) GetCreatedAt() time.Time {
+	if mock.GetCreatedAtFunc == nil {
+		panic("PasswordResetTokenMock.GetCreatedAtFunc: method is nil but PasswordResetToken.GetCreatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCreatedAt.Lock()
+	mock.calls.GetCreatedAt = append(mock.calls.GetCreatedAt, callInfo)
+	mock.lockGetCreatedAt.Unlock()
+	return mock.GetCreatedAtFunc()
+}
+
+// GetCreatedAtCalls gets all the calls that were made to GetCreatedAt.
+// Check the length with:
+//
+//	len(mockedPasswordResetToken.GetCreatedAtCalls())
+func (mock *PasswordResetTokenMock) GetCreatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCreatedAt.RLock()
+	calls = mock.calls.GetCreatedAt
+	mock.lockGetCreatedAt.RUnlock()
+	return calls
+}
+
+// GetExpiresAt calls GetExpiresAtFunc.
+func (mock *PasswordResetTokenMock) GetExpiresAt() time.Time {
+	if mock.GetExpiresAtFunc == nil {
+		panic("PasswordResetTokenMock.GetExpiresAtFunc: method is nil but PasswordResetToken.GetExpiresAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetExpiresAt.Lock()
+	mock.calls.GetExpiresAt = append(mock.calls.GetExpiresAt, callInfo)
+	mock.lockGetExpiresAt.Unlock()
+	return mock.GetExpiresAtFunc()
+}
+
+// GetExpiresAtCalls gets all the calls that were made to GetExpiresAt.
+// Check the length with:
+//
+//	len(mockedPasswordResetToken.GetExpiresAtCalls())
+func (mock *PasswordResetTokenMock) GetExpiresAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetExpiresAt.RLock()
+	calls = mock.calls.GetExpiresAt
+	mock.lockGetExpiresAt.RUnlock()
+	return calls
+}
+
+// GetTokenHash calls GetTokenHashFunc.
+func (mock *PasswordResetTokenMock) GetTokenHash() string {
+	if mock.GetTokenHashFunc == nil {
+		panic("PasswordResetTokenMock.GetTokenHashFunc: method is nil but PasswordResetToken.GetTokenHash was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetTokenHash.Lock()
+	mock.calls.GetTokenHash = append(mock.calls.GetTokenHash, callInfo)
+	mock.lockGetTokenHash.Unlock()
+	return mock.GetTokenHashFunc()
+}
+
+// GetTokenHashCalls gets all the calls that were made to GetTokenHash.
+// Check the length with:
+//
+//	len(mockedPasswordResetToken.GetTokenHashCalls())
+func (mock *PasswordResetTokenMock) GetTokenHashCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetTokenHash.RLock()
+	calls = mock.calls.GetTokenHash
+	mock.lockGetTokenHash.RUnlock()
+	return calls
+}
+
+// GetUserID calls GetUserIDFunc.
+func (mock *PasswordResetTokenMock) GetUserID() uuid.UUID {
+	if mock.GetUserIDFunc == nil {
+		panic("PasswordResetTokenMock.GetUserIDFunc: method is nil but PasswordResetToken.GetUserID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetUserID.Lock()
+	mock.calls.GetUserID = append(mock.calls.GetUserID, callInfo)
+	mock.lockGetUserID.Unlock()
+	return mock.GetUserIDFunc()
+}
+
+// GetUserIDCalls gets all the calls that were made to GetUserID.
+// Check the length with:
+//
+//	len(mockedPasswordResetToken.GetUserIDCalls())
+func (mock *PasswordResetTokenMock) GetUserIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetUserID.RLock()
+	calls = mock.calls.GetUserID
+	mock.lockGetUserID.RUnlock()
+	return calls
+}
+
+// IsExpired calls IsExpiredFunc.
+func (mock *PasswordResetTokenMock) IsExpired(now time.Time) bool {
+	if mock.IsExpiredFunc == nil {
+		panic("PasswordResetTokenMock.IsExpiredFunc: method is nil but PasswordResetToken.IsExpired was just called")
+	}
+	callInfo := struct {
+		Now time.Time
+	}{
+		Now: now,
+	}
+	mock.lockIsExpired.Lock()
+	mock.calls.IsExpired = append(mock.calls.IsExpired, callInfo)
+	mock.lockIsExpired.Unlock()
+	return mock.IsExpiredFunc(now)
+}
+
+// IsExpiredCalls gets all the calls that were made to IsExpired.
+// Check the length with:
+//
+//	len(mockedPasswordResetToken.IsExpiredCalls())
+func (mock *PasswordResetTokenMock) IsExpiredCalls() []struct {
+	Now time.Time
+} {
+	var calls []struct {
+		Now time.Time
+	}
+	mock.lockIsExpired.RLock()
+	calls = mock.calls.IsExpired
+	mock.lockIsExpired.RUnlock()
+	return calls
+}
+
+// IsUsed calls IsUsedFunc.
+func (mock *PasswordResetTokenMock) IsUsed() bool {
+	if mock.IsUsedFunc == nil {
+		panic("PasswordResetTokenMock.IsUsedFunc: method is nil but PasswordResetToken.IsUsed was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsUsed.Lock()
+	mock.calls.IsUsed = append(mock.calls.IsUsed, callInfo)
+	mock.lockIsUsed.Unlock()
+	return mock.IsUsedFunc()
+}
+
+// IsUsedCalls gets all the calls that were made to IsUsed.
+// Check the length with:
+//
+//	len(mockedPasswordResetToken.IsUsedCalls())
+func (mock *PasswordResetTokenMock) IsUsedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsUsed.RLock()
+	calls = mock.calls.IsUsed
+	mock.lockIsUsed.RUnlock()
+	return calls
+}
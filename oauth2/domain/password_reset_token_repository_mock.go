@@ -0,0 +1,289 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Ensure, that PasswordResetTokenRepositoryMock does implement PasswordResetTokenRepository.
+// If this is not the case, regenerate this file with moq.
+var _ PasswordResetTokenRepository = &PasswordResetTokenRepositoryMock{}
+
+// PasswordResetTokenRepositoryMock is a mock implementation of PasswordResetTokenRepository.
+//
+//	func TestSomethingThatUsesPasswordResetTokenRepository(t *testing.T) {
+//
+//		// make and configure a mocked PasswordResetTokenRepository
+//		mockedPasswordResetTokenRepository := &PasswordResetTokenRepositoryMock{
+//			ConsumePasswordResetTokenFunc: func(ctx context.Context, tokenHash string) (bool, error) {
+//				panic("mock out the ConsumePasswordResetToken method")
+//			},
+//			ConsumePasswordResetTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the ConsumePasswordResetTokensByUserID method")
+//			},
+//			CountPasswordResetTokensSinceFunc: func(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+//				panic("mock out the CountPasswordResetTokensSince method")
+//			},
+//			FindPasswordResetTokenFunc: func(ctx context.Context, tokenHash string) (PasswordResetToken, error) {
+//				panic("mock out the FindPasswordResetToken method")
+//			},
+//			StorePasswordResetTokenFunc: func(ctx context.Context, t PasswordResetToken) error {
+//				panic("mock out the StorePasswordResetToken method")
+//			},
+//		}
+//
+//		// use mockedPasswordResetTokenRepository in code that requires PasswordResetTokenRepository
+//		// and then make assertions.
+//
+//	}
+type PasswordResetTokenRepositoryMock struct {
+	// ConsumePasswordResetTokenFunc mocks the ConsumePasswordResetToken method.
+	ConsumePasswordResetTokenFunc func(ctx context.Context, tokenHash string) (bool, error)
+
+	// ConsumePasswordResetTokensByUserIDFunc mocks the ConsumePasswordResetTokensByUserID method.
+	ConsumePasswordResetTokensByUserIDFunc func(ctx context.Context, userID uuid.UUID) error
+
+	// CountPasswordResetTokensSinceFunc mocks the CountPasswordResetTokensSince method.
+	CountPasswordResetTokensSinceFunc func(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+
+	// FindPasswordResetTokenFunc mocks the FindPasswordResetToken method.
+	FindPasswordResetTokenFunc func(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+
+	// StorePasswordResetTokenFunc mocks the StorePasswordResetToken method.
+	StorePasswordResetTokenFunc func(ctx context.Context, t PasswordResetToken) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ConsumePasswordResetToken holds details about calls to the ConsumePasswordResetToken method.
+		ConsumePasswordResetToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TokenHash is the tokenHash argument value.
+			TokenHash string
+		}
+		// ConsumePasswordResetTokensByUserID holds details about calls to the ConsumePasswordResetTokensByUserID method.
+		ConsumePasswordResetTokensByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// CountPasswordResetTokensSince holds details about calls to the CountPasswordResetTokensSince method.
+		CountPasswordResetTokensSince []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Since is the since argument value.
+			Since time.Time
+		}
+		// FindPasswordResetToken holds details about calls to the FindPasswordResetToken method.
+		FindPasswordResetToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TokenHash is the tokenHash argument value.
+			TokenHash string
+		}
+		// StorePasswordResetToken holds details about calls to the StorePasswordResetToken method.
+		StorePasswordResetToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// T is the t argument value.
+			T PasswordResetToken
+		}
+	}
+	lockConsumePasswordResetToken          sync.RWMutex
+	lockConsumePasswordResetTokensByUserID sync.RWMutex
+	lockCountPasswordResetTokensSince      sync.RWMutex
+	lockFindPasswordResetToken             sync.RWMutex
+	lockStorePasswordResetToken            sync.RWMutex
+}
+
+// ConsumePasswordResetToken calls ConsumePasswordResetTokenFunc.
+func (mock *PasswordResetTokenRepositoryMock) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (bool, error) {
+	if mock.ConsumePasswordResetTokenFunc == nil {
+		panic("PasswordResetTokenRepositoryMock.ConsumePasswordResetTokenFunc: method is nil but PasswordResetTokenRepository.ConsumePasswordResetToken was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		TokenHash string
+	}{
+		Ctx:       ctx,
+		TokenHash: tokenHash,
+	}
+	mock.lockConsumePasswordResetToken.Lock()
+	mock.calls.ConsumePasswordResetToken = append(mock.calls.ConsumePasswordResetToken, callInfo)
+	mock.lockConsumePasswordResetToken.Unlock()
+	return mock.ConsumePasswordResetTokenFunc(ctx, tokenHash)
+}
+
+// ConsumePasswordResetTokenCalls gets all the calls that were made to ConsumePasswordResetToken.
+// Check the length with:
+//
+//	len(mockedPasswordResetTokenRepository.ConsumePasswordResetTokenCalls())
+func (mock *PasswordResetTokenRepositoryMock) ConsumePasswordResetTokenCalls() []struct {
+	Ctx       context.Context
+	TokenHash string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		TokenHash string
+	}
+	mock.lockConsumePasswordResetToken.RLock()
+	calls = mock.calls.ConsumePasswordResetToken
+	mock.lockConsumePasswordResetToken.RUnlock()
+	return calls
+}
+
+// ConsumePasswordResetTokensByUserID calls ConsumePasswordResetTokensByUserIDFunc.
+func (mock *PasswordResetTokenRepositoryMock) ConsumePasswordResetTokensByUserID(ctx context.Context, userID uuid.UUID) error {
+	if mock.ConsumePasswordResetTokensByUserIDFunc == nil {
+		panic("PasswordResetTokenRepositoryMock.ConsumePasswordResetTokensByUserIDFunc: method is nil but PasswordResetTokenRepository.ConsumePasswordResetTokensByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockConsumePasswordResetTokensByUserID.Lock()
+	mock.calls.ConsumePasswordResetTokensByUserID = append(mock.calls.ConsumePasswordResetTokensByUserID, callInfo)
+	mock.lockConsumePasswordResetTokensByUserID.Unlock()
+	return mock.ConsumePasswordResetTokensByUserIDFunc(ctx, userID)
+}
+
+// ConsumePasswordResetTokensByUserIDCalls gets all the calls that were made to ConsumePasswordResetTokensByUserID.
+// Check the length with:
+//
+//	len(mockedPasswordResetTokenRepository.ConsumePasswordResetTokensByUserIDCalls())
+func (mock *PasswordResetTokenRepositoryMock) ConsumePasswordResetTokensByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockConsumePasswordResetTokensByUserID.RLock()
+	calls = mock.calls.ConsumePasswordResetTokensByUserID
+	mock.lockConsumePasswordResetTokensByUserID.RUnlock()
+	return calls
+}
+
+// CountPasswordResetTokensSince calls CountPasswordResetTokensSinceFunc.
+func (mock *PasswordResetTokenRepositoryMock) CountPasswordResetTokensSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	if mock.CountPasswordResetTokensSinceFunc == nil {
+		panic("PasswordResetTokenRepositoryMock.CountPasswordResetTokensSinceFunc: method is nil but PasswordResetTokenRepository.CountPasswordResetTokensSince was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Since:  since,
+	}
+	mock.lockCountPasswordResetTokensSince.Lock()
+	mock.calls.CountPasswordResetTokensSince = append(mock.calls.CountPasswordResetTokensSince, callInfo)
+	mock.lockCountPasswordResetTokensSince.Unlock()
+	return mock.CountPasswordResetTokensSinceFunc(ctx, userID, since)
+}
+
+// CountPasswordResetTokensSinceCalls gets all the calls that were made to CountPasswordResetTokensSince.
+// Check the length with:
+//
+//	len(mockedPasswordResetTokenRepository.CountPasswordResetTokensSinceCalls())
+func (mock *PasswordResetTokenRepositoryMock) CountPasswordResetTokensSinceCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Since  time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+	}
+	mock.lockCountPasswordResetTokensSince.RLock()
+	calls = mock.calls.CountPasswordResetTokensSince
+	mock.lockCountPasswordResetTokensSince.RUnlock()
+	return calls
+}
+
+// FindPasswordResetToken calls FindPasswordResetTokenFunc.
+func (mock *PasswordResetTokenRepositoryMock) FindPasswordResetToken(ctx context.Context, tokenHash string) (PasswordResetToken, error) {
+	if mock.FindPasswordResetTokenFunc == nil {
+		panic("PasswordResetTokenRepositoryMock.FindPasswordResetTokenFunc: method is nil but PasswordResetTokenRepository.FindPasswordResetToken was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		TokenHash string
+	}{
+		Ctx:       ctx,
+		TokenHash: tokenHash,
+	}
+	mock.lockFindPasswordResetToken.Lock()
+	mock.calls.FindPasswordResetToken = append(mock.calls.FindPasswordResetToken, callInfo)
+	mock.lockFindPasswordResetToken.Unlock()
+	return mock.FindPasswordResetTokenFunc(ctx, tokenHash)
+}
+
+// FindPasswordResetTokenCalls gets all the calls that were made to FindPasswordResetToken.
+// Check the length with:
+//
+//	len(mockedPasswordResetTokenRepository.FindPasswordResetTokenCalls())
+func (mock *PasswordResetTokenRepositoryMock) FindPasswordResetTokenCalls() []struct {
+	Ctx       context.Context
+	TokenHash string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		TokenHash string
+	}
+	mock.lockFindPasswordResetToken.RLock()
+	calls = mock.calls.FindPasswordResetToken
+	mock.lockFindPasswordResetToken.RUnlock()
+	return calls
+}
+
+// StorePasswordResetToken calls StorePasswordResetTokenFunc.
+func (mock *PasswordResetTokenRepositoryMock) StorePasswordResetToken(ctx context.Context, t PasswordResetToken) error {
+	if mock.StorePasswordResetTokenFunc == nil {
+		panic("PasswordResetTokenRepositoryMock.StorePasswordResetTokenFunc: method is nil but PasswordResetTokenRepository.StorePasswordResetToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		T   PasswordResetToken
+	}{
+		Ctx: ctx,
+		T:   t,
+	}
+	mock.lockStorePasswordResetToken.Lock()
+	mock.calls.StorePasswordResetToken = append(mock.calls.StorePasswordResetToken, callInfo)
+	mock.lockStorePasswordResetToken.Unlock()
+	return mock.StorePasswordResetTokenFunc(ctx, t)
+}
+
+// StorePasswordResetTokenCalls gets all the calls that were made to StorePasswordResetToken.
+// Check the length with:
+//
+//	len(mockedPasswordResetTokenRepository.StorePasswordResetTokenCalls())
+func (mock *PasswordResetTokenRepositoryMock) StorePasswordResetTokenCalls() []struct {
+	Ctx context.Context
+	T   PasswordResetToken
+} {
+	var calls []struct {
+		Ctx context.Context
+		T   PasswordResetToken
+	}
+	mock.lockStorePasswordResetToken.RLock()
+	calls = mock.calls.StorePasswordResetToken
+	mock.lockStorePasswordResetToken.RUnlock()
+	return calls
+}
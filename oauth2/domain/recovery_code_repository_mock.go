@@ -0,0 +1,244 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+)
+
+// Ensure, that RecoveryCodeRepositoryMock does implement RecoveryCodeRepository.
+// If this is not the case, regenerate this file with moq.
+var _ RecoveryCodeRepository = &RecoveryCodeRepositoryMock{}
+
+// RecoveryCodeRepositoryMock is a mock implementation of RecoveryCodeRepository.
+//
+//	func TestSomethingThatUsesRecoveryCodeRepository(t *testing.T) {
+//
+//		// make and configure a mocked RecoveryCodeRepository
+//		mockedRecoveryCodeRepository := &RecoveryCodeRepositoryMock{
+//			CountUnusedRecoveryCodesFunc: func(ctx context.Context, userID uuid.UUID) (int, error) {
+//				panic("mock out the CountUnusedRecoveryCodes method")
+//			},
+//			DeleteRecoveryCodesFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the DeleteRecoveryCodes method")
+//			},
+//			ReplaceRecoveryCodesFunc: func(ctx context.Context, userID uuid.UUID, hashes []string) error {
+//				panic("mock out the ReplaceRecoveryCodes method")
+//			},
+//			UseRecoveryCodeFunc: func(ctx context.Context, userID uuid.UUID, hash string) (bool, error) {
+//				panic("mock out the UseRecoveryCode method")
+//			},
+//		}
+//
+//		// use mockedRecoveryCodeRepository in code that requires RecoveryCodeRepository
+//		// and then make assertions.
+//
+//	}
+type RecoveryCodeRepositoryMock struct {
+	// CountUnusedRecoveryCodesFunc mocks the CountUnusedRecoveryCodes method.
+	CountUnusedRecoveryCodesFunc func(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// DeleteRecoveryCodesFunc mocks the DeleteRecoveryCodes method.
+	DeleteRecoveryCodesFunc func(ctx context.Context, userID uuid.UUID) error
+
+	// ReplaceRecoveryCodesFunc mocks the ReplaceRecoveryCodes method.
+	ReplaceRecoveryCodesFunc func(ctx context.Context, userID uuid.UUID, hashes []string) error
+
+	// UseRecoveryCodeFunc mocks the UseRecoveryCode method.
+	UseRecoveryCodeFunc func(ctx context.Context, userID uuid.UUID, hash string) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CountUnusedRecoveryCodes holds details about calls to the CountUnusedRecoveryCodes method.
+		CountUnusedRecoveryCodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// DeleteRecoveryCodes holds details about calls to the DeleteRecoveryCodes method.
+		DeleteRecoveryCodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// ReplaceRecoveryCodes holds details about calls to the ReplaceRecoveryCodes method.
+		ReplaceRecoveryCodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Hashes is the hashes argument value.
+			Hashes []string
+		}
+		// UseRecoveryCode holds details about calls to the UseRecoveryCode method.
+		UseRecoveryCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Hash is the hash argument value.
+			Hash string
+		}
+	}
+	lockCountUnusedRecoveryCodes sync.RWMutex
+	lockDeleteRecoveryCodes      sync.RWMutex
+	lockReplaceRecoveryCodes     sync.RWMutex
+	lockUseRecoveryCode          sync.RWMutex
+}
+
+// CountUnusedRecoveryCodes calls CountUnusedRecoveryCodesFunc.
+func (mock *RecoveryCodeRepositoryMock) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	if mock.CountUnusedRecoveryCodesFunc == nil {
+		panic("RecoveryCodeRepositoryMock.CountUnusedRecoveryCodesFunc: method is nil but RecoveryCodeRepository.CountUnusedRecoveryCodes was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCountUnusedRecoveryCodes.Lock()
+	mock.calls.CountUnusedRecoveryCodes = append(mock.calls.CountUnusedRecoveryCodes, callInfo)
+	mock.lockCountUnusedRecoveryCodes.Unlock()
+	return mock.CountUnusedRecoveryCodesFunc(ctx, userID)
+}
+
+// CountUnusedRecoveryCodesCalls gets all the calls that were made to CountUnusedRecoveryCodes.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.CountUnusedRecoveryCodesCalls())
+func (mock *RecoveryCodeRepositoryMock) CountUnusedRecoveryCodesCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockCountUnusedRecoveryCodes.RLock()
+	calls = mock.calls.CountUnusedRecoveryCodes
+	mock.lockCountUnusedRecoveryCodes.RUnlock()
+	return calls
+}
+
+// DeleteRecoveryCodes calls DeleteRecoveryCodesFunc.
+func (mock *RecoveryCodeRepositoryMock) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	if mock.DeleteRecoveryCodesFunc == nil {
+		panic("RecoveryCodeRepositoryMock.DeleteRecoveryCodesFunc: method is nil but RecoveryCodeRepository.DeleteRecoveryCodes was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteRecoveryCodes.Lock()
+	mock.calls.DeleteRecoveryCodes = append(mock.calls.DeleteRecoveryCodes, callInfo)
+	mock.lockDeleteRecoveryCodes.Unlock()
+	return mock.DeleteRecoveryCodesFunc(ctx, userID)
+}
+
+// DeleteRecoveryCodesCalls gets all the calls that were made to DeleteRecoveryCodes.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.DeleteRecoveryCodesCalls())
+func (mock *RecoveryCodeRepositoryMock) DeleteRecoveryCodesCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockDeleteRecoveryCodes.RLock()
+	calls = mock.calls.DeleteRecoveryCodes
+	mock.lockDeleteRecoveryCodes.RUnlock()
+	return calls
+}
+
+// ReplaceRecoveryCodes calls ReplaceRecoveryCodesFunc.
+func (mock *RecoveryCodeRepositoryMock) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, hashes []string) error {
+	if mock.ReplaceRecoveryCodesFunc == nil {
+		panic("RecoveryCodeRepositoryMock.ReplaceRecoveryCodesFunc: method is nil but RecoveryCodeRepository.ReplaceRecoveryCodes was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Hashes []string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Hashes: hashes,
+	}
+	mock.lockReplaceRecoveryCodes.Lock()
+	mock.calls.ReplaceRecoveryCodes = append(mock.calls.ReplaceRecoveryCodes, callInfo)
+	mock.lockReplaceRecoveryCodes.Unlock()
+	return mock.ReplaceRecoveryCodesFunc(ctx, userID, hashes)
+}
+
+// ReplaceRecoveryCodesCalls gets all the calls that were made to ReplaceRecoveryCodes.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.ReplaceRecoveryCodesCalls())
+func (mock *RecoveryCodeRepositoryMock) ReplaceRecoveryCodesCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Hashes []string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Hashes []string
+	}
+	mock.lockReplaceRecoveryCodes.RLock()
+	calls = mock.calls.ReplaceRecoveryCodes
+	mock.lockReplaceRecoveryCodes.RUnlock()
+	return calls
+}
+
+// UseRecoveryCode calls UseRecoveryCodeFunc.
+func (mock *RecoveryCodeRepositoryMock) UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) (bool, error) {
+	if mock.UseRecoveryCodeFunc == nil {
+		panic("RecoveryCodeRepositoryMock.UseRecoveryCodeFunc: method is nil but RecoveryCodeRepository.UseRecoveryCode was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Hash   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Hash:   hash,
+	}
+	mock.lockUseRecoveryCode.Lock()
+	mock.calls.UseRecoveryCode = append(mock.calls.UseRecoveryCode, callInfo)
+	mock.lockUseRecoveryCode.Unlock()
+	return mock.UseRecoveryCodeFunc(ctx, userID, hash)
+}
+
+// UseRecoveryCodeCalls gets all the calls that were made to UseRecoveryCode.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.UseRecoveryCodeCalls())
+func (mock *RecoveryCodeRepositoryMock) UseRecoveryCodeCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Hash   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Hash   string
+	}
+	mock.lockUseRecoveryCode.RLock()
+	calls = mock.calls.UseRecoveryCode
+	mock.lockUseRecoveryCode.RUnlock()
+	return calls
+}
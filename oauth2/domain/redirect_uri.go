@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+var (
+	ErrRedirectURIInvalid           = errors.New("redirect uri is invalid")
+	ErrRedirectURINotAbsolute       = errors.New("redirect uri must be absolute")
+	ErrRedirectURIFragment          = errors.New("redirect uri must not contain a fragment")
+	ErrRedirectURIWildcard          = errors.New("redirect uri must not contain wildcards")
+	ErrRedirectURIUserinfo          = errors.New("redirect uri must not contain userinfo")
+	ErrRedirectURIPathTraversal     = errors.New("redirect uri must not contain dot segments")
+	ErrRedirectURIOpenRedirect      = errors.New("redirect uri must not forward to another uri")
+	ErrRedirectURIInsecureScheme    = errors.New("http redirect uri is only allowed for loopback hosts")
+	ErrRedirectURIUnsupportedScheme = errors.New("redirect uri scheme is not supported")
+)
+
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+)
+
+// RedirectURI は正規化・検証済みのリダイレクトURI
+//
+// 登録できるのは以下のいずれか (RFC 6749 3.1.2, RFC 8252 7)
+//   - https の URI
+//   - ループバックホスト (127.0.0.1, ::1, localhost) 宛ての http の URI
+//   - ネイティブアプリ向けの逆DNS形式のプライベートスキーム (com.example.app:/callback)
+type RedirectURI string
+
+// NewRedirectURI はリダイレクトURIを検証し、正規化した値を返す
+func NewRedirectURI(raw string) (RedirectURI, error) {
+	u, err := parseRedirectURI(raw)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case u.Scheme == schemeHTTPS:
+		if u.Host == "" {
+			return "", ErrRedirectURIInvalid
+		}
+	case u.Scheme == schemeHTTP:
+		if !isLoopbackHost(u.Hostname()) {
+			return "", ErrRedirectURIInsecureScheme
+		}
+	case isPrivateUseScheme(u.Scheme):
+		// プライベートスキームはホストを持たない形式のみ許可する (RFC 8252 7.1)
+		if u.Host != "" {
+			return "", ErrRedirectURIInvalid
+		}
+	default:
+		return "", ErrRedirectURIUnsupportedScheme
+	}
+
+	if forwardsToAnotherURI(u.Query()) {
+		return "", ErrRedirectURIOpenRedirect
+	}
+
+	return RedirectURI(u.String()), nil
+}
+
+func (r RedirectURI) String() string {
+	return string(r)
+}
+
+// Match は認可リクエストで指定された URI が登録済みの URI と一致するかを判定する
+//
+// 原則として正規化後の完全一致で比較する。
+// ループバックIP宛ての http の場合のみ、ネイティブアプリが実行時にポートを選べるようポートを無視する (RFC 8252 7.3)
+func (r RedirectURI) Match(requested string) bool {
+	req, err := parseRedirectURI(requested)
+	if err != nil {
+		return false
+	}
+
+	if r.String() == req.String() {
+		return true
+	}
+
+	reg, err := url.Parse(r.String())
+	if err != nil {
+		return false
+	}
+
+	if reg.Scheme != schemeHTTP || !isLoopbackIP(reg.Hostname()) {
+		return false
+	}
+
+	return req.Scheme == reg.Scheme &&
+		req.Hostname() == reg.Hostname() &&
+		req.EscapedPath() == reg.EscapedPath() &&
+		req.RawQuery == reg.RawQuery
+}
+
+// parseRedirectURI は登録時とリクエスト時で共通の構文チェックと正規化を行う
+func parseRedirectURI(raw string) (*url.URL, error) {
+	if raw == "" || strings.TrimSpace(raw) != raw {
+		return nil, ErrRedirectURIInvalid
+	}
+	if strings.Contains(raw, "*") {
+		return nil, ErrRedirectURIWildcard
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, ErrRedirectURIInvalid
+	}
+	if !u.IsAbs() || u.Opaque != "" {
+		return nil, ErrRedirectURINotAbsolute
+	}
+	if u.Fragment != "" || strings.Contains(raw, "#") {
+		return nil, ErrRedirectURIFragment
+	}
+	if u.User != nil {
+		return nil, ErrRedirectURIUserinfo
+	}
+	for _, seg := range strings.Split(u.EscapedPath(), "/") {
+		if seg == "." || seg == ".." {
+			return nil, ErrRedirectURIPathTraversal
+		}
+	}
+
+	// スキームとホストは大文字小文字を区別しないため小文字に揃え、既定ポートは省略する
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (u.Scheme == schemeHTTP && port == "80") || (u.Scheme == schemeHTTPS && port == "443") {
+		port = ""
+	}
+	switch {
+	case host == "":
+		u.Host = ""
+	case port == "":
+		u.Host = hostWithBrackets(host)
+	default:
+		u.Host = net.JoinHostPort(host, port)
+	}
+
+	return u, nil
+}
+
+func hostWithBrackets(host string) string {
+	if strings.Contains(host, ":") {
+		return "[" + host + "]"
+	}
+	return host
+}
+
+func isLoopbackIP(host string) bool {
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func isLoopbackHost(host string) bool {
+	return host == "localhost" || isLoopbackIP(host)
+}
+
+// isPrivateUseScheme は逆DNS形式 (ドットを含む) のスキームかを判定する
+func isPrivateUseScheme(scheme string) bool {
+	labels := strings.Split(scheme, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, l := range labels {
+		if l == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// forwardsToAnotherURI はクエリに別のサイトへの URL が含まれているかを判定する。
+// 登録済みのURIがそのままオープンリダイレクタとして悪用されるのを防ぐ。
+// lang=en:us や urn: のようにコロンを含むだけの値は転送先として扱わない
+func forwardsToAnotherURI(q url.Values) bool {
+	for _, values := range q {
+		for _, v := range values {
+			v = strings.TrimSpace(v)
+			if strings.HasPrefix(v, "//") {
+				return true
+			}
+			if u, err := url.Parse(v); err == nil && (u.Scheme == schemeHTTP || u.Scheme == schemeHTTPS) {
+				return true
+			}
+		}
+	}
+	return false
+}
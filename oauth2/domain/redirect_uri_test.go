@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedirectURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    RedirectURI
+		wantErr error
+	}{
+		{name: "https", raw: "https://example.com/callback", want: "https://example.com/callback"},
+		{name: "normalizes scheme, host and default port", raw: "HTTPS://Example.COM:443/callback", want: "https://example.com/callback"},
+		{name: "loopback ip", raw: "http://127.0.0.1:8000/callback", want: "http://127.0.0.1:8000/callback"},
+		{name: "ipv6 loopback", raw: "http://[::1]/callback", want: "http://[::1]/callback"},
+		{name: "localhost", raw: "http://localhost:8000/callback", want: "http://localhost:8000/callback"},
+		{name: "private-use scheme", raw: "com.example.app:/oauth2redirect", want: "com.example.app:/oauth2redirect"},
+		{name: "query is kept", raw: "https://example.com/callback?lang=ja", want: "https://example.com/callback?lang=ja"},
+		{name: "empty", raw: "", wantErr: ErrRedirectURIInvalid},
+		{name: "relative", raw: "/callback", wantErr: ErrRedirectURINotAbsolute},
+		{name: "fragment", raw: "https://example.com/callback#frag", wantErr: ErrRedirectURIFragment},
+		{name: "empty fragment", raw: "https://example.com/callback#", wantErr: ErrRedirectURIFragment},
+		{name: "wildcard host", raw: "https://*.example.com/callback", wantErr: ErrRedirectURIWildcard},
+		{name: "userinfo", raw: "https://example.com@evil.example/callback", wantErr: ErrRedirectURIUserinfo},
+		{name: "dot segment", raw: "https://example.com/a/../callback", wantErr: ErrRedirectURIPathTraversal},
+		{name: "plain http", raw: "http://example.com/callback", wantErr: ErrRedirectURIInsecureScheme},
+		{name: "javascript scheme", raw: "javascript:/alert(1)", wantErr: ErrRedirectURIUnsupportedScheme},
+		{name: "opaque uri", raw: "com.example.app:callback", wantErr: ErrRedirectURINotAbsolute},
+		{name: "private-use scheme with host", raw: "com.example.app://host/callback", wantErr: ErrRedirectURIInvalid},
+		{name: "open redirect", raw: "https://example.com/callback?next=https://evil.example", wantErr: ErrRedirectURIOpenRedirect},
+		{name: "protocol relative open redirect", raw: "https://example.com/callback?next=//evil.example", wantErr: ErrRedirectURIOpenRedirect},
+		{name: "uppercase scheme open redirect", raw: "https://example.com/callback?next=HTTP://evil.example", wantErr: ErrRedirectURIOpenRedirect},
+		{name: "value with colon", raw: "https://example.com/callback?lang=en:us", want: "https://example.com/callback?lang=en:us"},
+		{name: "urn value", raw: "https://example.com/callback?resource=urn:example:api", want: "https://example.com/callback?resource=urn:example:api"},
+		{name: "non-web scheme value", raw: "https://example.com/callback?contact=mailto:a@example.com", want: "https://example.com/callback?contact=mailto:a@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRedirectURI(tt.raw)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRedirectURIMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		registered string
+		requested  string
+		want       bool
+	}{
+		{name: "exact", registered: "https://example.com/callback", requested: "https://example.com/callback", want: true},
+		{name: "normalized", registered: "https://example.com/callback", requested: "HTTPS://EXAMPLE.com:443/callback", want: true},
+		{name: "different path", registered: "https://example.com/callback", requested: "https://example.com/callback/", want: false},
+		{name: "different query", registered: "https://example.com/callback", requested: "https://example.com/callback?a=b", want: false},
+		{name: "different port on https", registered: "https://example.com/callback", requested: "https://example.com:8443/callback", want: false},
+		{name: "fragment", registered: "https://example.com/callback", requested: "https://example.com/callback#x", want: false},
+		{name: "loopback ip ignores port", registered: "http://127.0.0.1/callback", requested: "http://127.0.0.1:51004/callback", want: true},
+		{name: "ipv6 loopback ignores port", registered: "http://[::1]:80/callback", requested: "http://[::1]:51004/callback", want: true},
+		{name: "loopback ip path must match", registered: "http://127.0.0.1/callback", requested: "http://127.0.0.1:51004/other", want: false},
+		{name: "localhost keeps port", registered: "http://localhost:8000/callback", requested: "http://localhost:8001/callback", want: false},
+		{name: "private-use scheme", registered: "com.example.app:/oauth2redirect", requested: "com.example.app:/oauth2redirect", want: true},
+		{name: "wildcard request", registered: "https://example.com/callback", requested: "https://*.com/callback", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registered, err := NewRedirectURI(tt.registered)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, registered.Match(tt.requested))
+		})
+	}
+}
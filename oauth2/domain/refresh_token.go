@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/str"
 )
 
@@ -42,6 +43,10 @@ type RefreshTokenRepository interface {
 	StoreRefreshToken(ctx context.Context, t RefreshToken) error
 	FindRefreshToken(ctx context.Context, refreshToken string) (RefreshToken, error)
 	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	RevokeRefreshTokensByAuthorizationCode(ctx context.Context, code string) error
+	RevokeRefreshTokensByUserAndClient(ctx context.Context, userID, clientID uuid.UUID) error
+	RevokeRefreshTokensByUserID(ctx context.Context, userID uuid.UUID) error
+	RevokeRefreshTokensByClientID(ctx context.Context, clientID uuid.UUID) error
 }
 
 type refreshToken struct {
@@ -71,7 +76,7 @@ func (t *refreshToken) Expiry() int64 {
 }
 
 func (t *refreshToken) IsExpired(now time.Time) bool {
-	return t.expiresAt.After(now)
+	return now.After(t.expiresAt)
 }
 
 func (t *refreshToken) SetNewExpiry(additionalDays int) {
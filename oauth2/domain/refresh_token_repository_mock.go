@@ -5,6 +5,7 @@ package domain
 
 import (
 	"context"
+	"github.com/google/uuid"
 	"sync"
 )
 
@@ -24,6 +25,18 @@ var _ RefreshTokenRepository = &RefreshTokenRepositoryMock{}
 //			RevokeRefreshTokenFunc: func(ctx context.Context, refreshToken string) error {
 //				panic("mock out the RevokeRefreshToken method")
 //			},
+//			RevokeRefreshTokensByAuthorizationCodeFunc: func(ctx context.Context, code string) error {
+//				panic("mock out the RevokeRefreshTokensByAuthorizationCode method")
+//			},
+//			RevokeRefreshTokensByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) error {
+//				panic("mock out the RevokeRefreshTokensByClientID method")
+//			},
+//			RevokeRefreshTokensByUserAndClientFunc: func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error {
+//				panic("mock out the RevokeRefreshTokensByUserAndClient method")
+//			},
+//			RevokeRefreshTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the RevokeRefreshTokensByUserID method")
+//			},
 //			StoreRefreshTokenFunc: func(ctx context.Context, t RefreshToken) error {
 //				panic("mock out the StoreRefreshToken method")
 //			},
@@ -40,6 +53,18 @@ type RefreshTokenRepositoryMock struct {
 	// RevokeRefreshTokenFunc mocks the RevokeRefreshToken method.
 	RevokeRefreshTokenFunc func(ctx context.Context, refreshToken string) error
 
+	// RevokeRefreshTokensByAuthorizationCodeFunc mocks the RevokeRefreshTokensByAuthorizationCode method.
+	RevokeRefreshTokensByAuthorizationCodeFunc func(ctx context.Context, code string) error
+
+	// RevokeRefreshTokensByClientIDFunc mocks the RevokeRefreshTokensByClientID method.
+	RevokeRefreshTokensByClientIDFunc func(ctx context.Context, clientID uuid.UUID) error
+
+	// RevokeRefreshTokensByUserAndClientFunc mocks the RevokeRefreshTokensByUserAndClient method.
+	RevokeRefreshTokensByUserAndClientFunc func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error
+
+	// RevokeRefreshTokensByUserIDFunc mocks the RevokeRefreshTokensByUserID method.
+	RevokeRefreshTokensByUserIDFunc func(ctx context.Context, userID uuid.UUID) error
+
 	// StoreRefreshTokenFunc mocks the StoreRefreshToken method.
 	StoreRefreshTokenFunc func(ctx context.Context, t RefreshToken) error
 
@@ -59,6 +84,36 @@ type RefreshTokenRepositoryMock struct {
 			// RefreshToken is the refreshToken argument value.
 			RefreshToken string
 		}
+		// RevokeRefreshTokensByAuthorizationCode holds details about calls to the RevokeRefreshTokensByAuthorizationCode method.
+		RevokeRefreshTokensByAuthorizationCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+		}
+		// RevokeRefreshTokensByClientID holds details about calls to the RevokeRefreshTokensByClientID method.
+		RevokeRefreshTokensByClientID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ClientID is the clientID argument value.
+			ClientID uuid.UUID
+		}
+		// RevokeRefreshTokensByUserAndClient holds details about calls to the RevokeRefreshTokensByUserAndClient method.
+		RevokeRefreshTokensByUserAndClient []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ClientID is the clientID argument value.
+			ClientID uuid.UUID
+		}
+		// RevokeRefreshTokensByUserID holds details about calls to the RevokeRefreshTokensByUserID method.
+		RevokeRefreshTokensByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
 		// StoreRefreshToken holds details about calls to the StoreRefreshToken method.
 		StoreRefreshToken []struct {
 			// Ctx is the ctx argument value.
@@ -67,9 +122,13 @@ type RefreshTokenRepositoryMock struct {
 			T RefreshToken
 		}
 	}
-	lockFindRefreshToken   sync.RWMutex
-	lockRevokeRefreshToken sync.RWMutex
-	lockStoreRefreshToken  sync.RWMutex
+	lockFindRefreshToken                       sync.RWMutex
+	lockRevokeRefreshToken                     sync.RWMutex
+	lockRevokeRefreshTokensByAuthorizationCode sync.RWMutex
+	lockRevokeRefreshTokensByClientID          sync.RWMutex
+	lockRevokeRefreshTokensByUserAndClient     sync.RWMutex
+	lockRevokeRefreshTokensByUserID            sync.RWMutex
+	lockStoreRefreshToken                      sync.RWMutex
 }
 
 // FindRefreshToken calls FindRefreshTokenFunc.
@@ -144,6 +203,154 @@ func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokenCalls() []struct {
 	return calls
 }
 
+// RevokeRefreshTokensByAuthorizationCode calls RevokeRefreshTokensByAuthorizationCodeFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokensByAuthorizationCode(ctx context.Context, code string) error {
+	if mock.RevokeRefreshTokensByAuthorizationCodeFunc == nil {
+		panic("RefreshTokenRepositoryMock.RevokeRefreshTokensByAuthorizationCodeFunc: method is nil but RefreshTokenRepository.RevokeRefreshTokensByAuthorizationCode was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+	}{
+		Ctx:  ctx,
+		Code: code,
+	}
+	mock.lockRevokeRefreshTokensByAuthorizationCode.Lock()
+	mock.calls.RevokeRefreshTokensByAuthorizationCode = append(mock.calls.RevokeRefreshTokensByAuthorizationCode, callInfo)
+	mock.lockRevokeRefreshTokensByAuthorizationCode.Unlock()
+	return mock.RevokeRefreshTokensByAuthorizationCodeFunc(ctx, code)
+}
+
+// RevokeRefreshTokensByAuthorizationCodeCalls gets all the calls that were made to RevokeRefreshTokensByAuthorizationCode.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeRefreshTokensByAuthorizationCodeCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokensByAuthorizationCodeCalls() []struct {
+	Ctx  context.Context
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+	}
+	mock.lockRevokeRefreshTokensByAuthorizationCode.RLock()
+	calls = mock.calls.RevokeRefreshTokensByAuthorizationCode
+	mock.lockRevokeRefreshTokensByAuthorizationCode.RUnlock()
+	return calls
+}
+
+// RevokeRefreshTokensByClientID calls RevokeRefreshTokensByClientIDFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokensByClientID(ctx context.Context, clientID uuid.UUID) error {
+	if mock.RevokeRefreshTokensByClientIDFunc == nil {
+		panic("RefreshTokenRepositoryMock.RevokeRefreshTokensByClientIDFunc: method is nil but RefreshTokenRepository.RevokeRefreshTokensByClientID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ClientID uuid.UUID
+	}{
+		Ctx:      ctx,
+		ClientID: clientID,
+	}
+	mock.lockRevokeRefreshTokensByClientID.Lock()
+	mock.calls.RevokeRefreshTokensByClientID = append(mock.calls.RevokeRefreshTokensByClientID, callInfo)
+	mock.lockRevokeRefreshTokensByClientID.Unlock()
+	return mock.RevokeRefreshTokensByClientIDFunc(ctx, clientID)
+}
+
+// RevokeRefreshTokensByClientIDCalls gets all the calls that were made to RevokeRefreshTokensByClientID.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeRefreshTokensByClientIDCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokensByClientIDCalls() []struct {
+	Ctx      context.Context
+	ClientID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ClientID uuid.UUID
+	}
+	mock.lockRevokeRefreshTokensByClientID.RLock()
+	calls = mock.calls.RevokeRefreshTokensByClientID
+	mock.lockRevokeRefreshTokensByClientID.RUnlock()
+	return calls
+}
+
+// RevokeRefreshTokensByUserAndClient calls RevokeRefreshTokensByUserAndClientFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokensByUserAndClient(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error {
+	if mock.RevokeRefreshTokensByUserAndClientFunc == nil {
+		panic("RefreshTokenRepositoryMock.RevokeRefreshTokensByUserAndClientFunc: method is nil but RefreshTokenRepository.RevokeRefreshTokensByUserAndClient was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		ClientID: clientID,
+	}
+	mock.lockRevokeRefreshTokensByUserAndClient.Lock()
+	mock.calls.RevokeRefreshTokensByUserAndClient = append(mock.calls.RevokeRefreshTokensByUserAndClient, callInfo)
+	mock.lockRevokeRefreshTokensByUserAndClient.Unlock()
+	return mock.RevokeRefreshTokensByUserAndClientFunc(ctx, userID, clientID)
+}
+
+// RevokeRefreshTokensByUserAndClientCalls gets all the calls that were made to RevokeRefreshTokensByUserAndClient.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeRefreshTokensByUserAndClientCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokensByUserAndClientCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	ClientID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+	}
+	mock.lockRevokeRefreshTokensByUserAndClient.RLock()
+	calls = mock.calls.RevokeRefreshTokensByUserAndClient
+	mock.lockRevokeRefreshTokensByUserAndClient.RUnlock()
+	return calls
+}
+
+// RevokeRefreshTokensByUserID calls RevokeRefreshTokensByUserIDFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokensByUserID(ctx context.Context, userID uuid.UUID) error {
+	if mock.RevokeRefreshTokensByUserIDFunc == nil {
+		panic("RefreshTokenRepositoryMock.RevokeRefreshTokensByUserIDFunc: method is nil but RefreshTokenRepository.RevokeRefreshTokensByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeRefreshTokensByUserID.Lock()
+	mock.calls.RevokeRefreshTokensByUserID = append(mock.calls.RevokeRefreshTokensByUserID, callInfo)
+	mock.lockRevokeRefreshTokensByUserID.Unlock()
+	return mock.RevokeRefreshTokensByUserIDFunc(ctx, userID)
+}
+
+// RevokeRefreshTokensByUserIDCalls gets all the calls that were made to RevokeRefreshTokensByUserID.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeRefreshTokensByUserIDCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokensByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockRevokeRefreshTokensByUserID.RLock()
+	calls = mock.calls.RevokeRefreshTokensByUserID
+	mock.lockRevokeRefreshTokensByUserID.RUnlock()
+	return calls
+}
+
 // StoreRefreshToken calls StoreRefreshTokenFunc.
 func (mock *RefreshTokenRepositoryMock) StoreRefreshToken(ctx context.Context, t RefreshToken) error {
 	if mock.StoreRefreshTokenFunc == nil {
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SAMLConnectionParams struct {
+	ID             uuid.UUID
+	Name           string
+	DisplayName    string
+	IdPMetadata    string
+	EmailAttribute string
+	NameAttribute  string
+	LinkByEmail    bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewSAMLConnection(p SAMLConnectionParams) SAMLConnection {
+	return &samlConnection{
+		id:             p.ID,
+		name:           p.Name,
+		displayName:    p.DisplayName,
+		idpMetadata:    p.IdPMetadata,
+		emailAttribute: p.EmailAttribute,
+		nameAttribute:  p.NameAttribute,
+		linkByEmail:    p.LinkByEmail,
+		createdAt:      p.CreatedAt,
+		updatedAt:      p.UpdatedAt,
+	}
+}
+
+// SAMLConnection は SAML でサインインする企業の IdP との接続。
+// Name は SP のメタデータと ACS のURLに使うため、登録した後は変えない
+//
+//go:generate go run github.com/matryer/moq -out saml_connection_mock.go . SAMLConnection
+type SAMLConnection interface {
+	GetID() uuid.UUID
+	GetName() string
+	GetDisplayName() string
+	// GetIdPMetadata は IdP のメタデータの XML
+	GetIdPMetadata() string
+	// GetEmailAttribute はメールアドレスを持つ属性の名前。空の場合は NameID がメールアドレス形式のときに使う
+	GetEmailAttribute() string
+	GetNameAttribute() string
+	// IsLinkByEmail が true の場合は、メールアドレスが一致する既存のユーザーに紐づける
+	IsLinkByEmail() bool
+	GetCreatedAt() time.Time
+	GetUpdatedAt() time.Time
+}
+
+//go:generate go run github.com/matryer/moq -out saml_connection_repository_mock.go . SAMLConnectionRepository
+type SAMLConnectionRepository interface {
+	// FindSAMLConnectionByName は接続がない場合に nil を返す
+	FindSAMLConnectionByName(ctx context.Context, name string) (SAMLConnection, error)
+	FindSAMLConnections(ctx context.Context) ([]SAMLConnection, error)
+}
+
+// SAMLLogin は ACS で検証したアサーションの内容。
+// IdP からの POST にはセッションの Cookie が付かないため、一度保存してからセッションのリクエストと照合する
+type SAMLLogin struct {
+	Connection string
+	RequestID  string
+	NameID     string
+	Email      string
+	Name       string
+}
+
+// SAMLLoginStore は受け取ったアサーションの記録を保持する。
+// 複数のサーバーで同じアサーションを受け付けないよう、共有のストアに置く
+//
+//go:generate go run github.com/matryer/moq -out saml_login_store_mock.go . SAMLLoginStore
+type SAMLLoginStore interface {
+	// MarkAssertionUsed はアサーションを until まで使用済みにする。初めて使われた場合に true を返す
+	MarkAssertionUsed(ctx context.Context, connection, assertionID string, until time.Time) (bool, error)
+	SaveLogin(ctx context.Context, token string, login SAMLLogin, ttl time.Duration) error
+	// TakeLogin は保存した内容を取り出して削除する。ない場合は nil を返す
+	TakeLogin(ctx context.Context, token string) (*SAMLLogin, error)
+}
+
+type samlConnection struct {
+	id             uuid.UUID
+	name           string
+	displayName    string
+	idpMetadata    string
+	emailAttribute string
+	nameAttribute  string
+	linkByEmail    bool
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+func (c *samlConnection) GetID() uuid.UUID {
+	return c.id
+}
+
+func (c *samlConnection) GetName() string {
+	return c.name
+}
+
+func (c *samlConnection) GetDisplayName() string {
+	return c.displayName
+}
+
+func (c *samlConnection) GetIdPMetadata() string {
+	return c.idpMetadata
+}
+
+func (c *samlConnection) GetEmailAttribute() string {
+	return c.emailAttribute
+}
+
+func (c *samlConnection) GetNameAttribute() string {
+	return c.nameAttribute
+}
+
+func (c *samlConnection) IsLinkByEmail() bool {
+	return c.linkByEmail
+}
+
+func (c *samlConnection) GetCreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *samlConnection) GetUpdatedAt() time.Time {
+	return c.updatedAt
+}
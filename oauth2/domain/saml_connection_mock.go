@@ -0,0 +1,365 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Ensure, that SAMLConnectionMock does implement SAMLConnection.
+// If this is not the case, regenerate this file with moq.
+var _ SAMLConnection = &SAMLConnectionMock{}
+
+// SAMLConnectionMock is a mock implementation of SAMLConnection.
+//
+//	func TestSomethingThatUsesSAMLConnection(t *testing.T) {
+//
+//		// make and configure a mocked SAMLConnection
+//		mockedSAMLConnection := &SAMLConnectionMock{
+//			GetCreatedAtFunc: func() time.Time {
+//				panic("mock out the GetCreatedAt method")
+//			},
+//			GetDisplayNameFunc: func() string {
+//				panic("mock out the GetDisplayName method")
+//			},
+//			GetEmailAttributeFunc: func() string {
+//				panic("mock out the GetEmailAttribute method")
+//			},
+//			GetIDFunc: func() uuid.UUID {
+//				panic("mock out the GetID method")
+//			},
+//			GetIdPMetadataFunc: func() string {
+//				panic("mock out the GetIdPMetadata method")
+//			},
+//			GetNameFunc: func() string {
+//				panic("mock out the GetName method")
+//			},
+//			GetNameAttributeFunc: func() string {
+//				panic("mock out the GetNameAttribute method")
+//			},
+//			GetUpdatedAtFunc: func() time.Time {
+//				panic("mock out the GetUpdatedAt method")
+//			},
+//			IsLinkByEmailFunc: func() bool {
+//				panic("mock out the IsLinkByEmail method")
+//			},
+//		}
+//
+//		// use mockedSAMLConnection in code that requires SAMLConnection
+//		// and then make assertions.
+//
+//	}
+type SAMLConnectionMock struct {
+	// GetCreatedAtFunc mocks the GetCreatedAt method.
+	GetCreatedAtFunc func() time.Time
+
+	// GetDisplayNameFunc mocks the GetDisplayName method.
+	GetDisplayNameFunc func() string
+
+	// GetEmailAttributeFunc mocks the GetEmailAttribute method.
+	GetEmailAttributeFunc func() string
+
+	// GetIDFunc mocks the GetID method.
+	GetIDFunc func() uuid.UUID
+
+	// GetIdPMetadataFunc mocks the GetIdPMetadata method.
+	GetIdPMetadataFunc func() string
+
+	// GetNameFunc mocks the GetName method.
+	GetNameFunc func() string
+
+	// GetNameAttributeFunc mocks the GetNameAttribute method.
+	GetNameAttributeFunc func() string
+
+	// GetUpdatedAtFunc mocks the GetUpdatedAt method.
+	GetUpdatedAtFunc func() time.Time
+
+	// IsLinkByEmailFunc mocks the IsLinkByEmail method.
+	IsLinkByEmailFunc func() bool
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetCreatedAt holds details about calls to the GetCreatedAt method.
+		GetCreatedAt []struct {
+		}
+		// GetDisplayName holds details about calls to the GetDisplayName method.
+		GetDisplayName []struct {
+		}
+		// GetEmailAttribute holds details about calls to the GetEmailAttribute method.
+		GetEmailAttribute []struct {
+		}
+		// GetID holds details about calls to the GetID method.
+		GetID []struct {
+		}
+		// GetIdPMetadata holds details about calls to the GetIdPMetadata method.
+		GetIdPMetadata []struct {
+		}
+		// GetName holds details about calls to the GetName method.
+		GetName []struct {
+		}
+		// GetNameAttribute holds details about calls to the GetNameAttribute method.
+		GetNameAttribute []struct {
+		}
+		// GetUpdatedAt holds details about calls to the GetUpdatedAt method.
+		GetUpdatedAt []struct {
+		}
+		// IsLinkByEmail holds details about calls to the IsLinkByEmail method.
+		IsLinkByEmail []struct {
+		}
+	}
+	lockGetCreatedAt      sync.RWMutex
+	lockGetDisplayName    sync.RWMutex
+	lockGetEmailAttribute sync.RWMutex
+	lockGetID             sync.RWMutex
+	lockGetIdPMetadata    sync.RWMutex
+	lockGetName           sync.RWMutex
+	lockGetNameAttribute  sync.RWMutex
+	lockGetUpdatedAt      sync.RWMutex
+	lockIsLinkByEmail     sync.RWMutex
+}
+
+// GetCreatedAt calls GetCreatedAtFunc.
+func (mock *SAMLConnectionMock) GetCreatedAt() time.Time {
+	if mock.GetCreatedAtFunc == nil {
+		panic("SAMLConnectionMock.GetCreatedAtFunc: method is nil but SAMLConnection.GetCreatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCreatedAt.Lock()
+	mock.calls.GetCreatedAt = append(mock.calls.GetCreatedAt, callInfo)
+	mock.lockGetCreatedAt.Unlock()
+	return mock.GetCreatedAtFunc()
+}
+
+// GetCreatedAtCalls gets all the calls that were made to GetCreatedAt.
+// Check the length with:
+//
+//	len(mockedSAMLConnection.GetCreatedAtCalls())
+func (mock *SAMLConnectionMock) GetCreatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCreatedAt.RLock()
+	calls = mock.calls.GetCreatedAt
+	mock.lockGetCreatedAt.RUnlock()
+	return calls
+}
+
+// GetDisplayName calls GetDisplayNameFunc.
+func (mock *SAMLConnectionMock) GetDisplayName() string {
+	if mock.GetDisplayNameFunc == nil {
+		panic("SAMLConnectionMock.GetDisplayNameFunc: method is nil but SAMLConnection.GetDisplayName was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetDisplayName.Lock()
+	mock.calls.GetDisplayName = append(mock.calls.GetDisplayName, callInfo)
+	mock.lockGetDisplayName.Unlock()
+	return mock.GetDisplayNameFunc()
+}
+
+// GetDisplayNameCalls gets all the calls that were made to GetDisplayName.
+// Check the length with:
+//
+//	len(mockedSAMLConnection.GetDisplayNameCalls())
+func (mock *SAMLConnectionMock) GetDisplayNameCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetDisplayName.RLock()
+	calls = mock.calls.GetDisplayName
+	mock.lockGetDisplayName.RUnlock()
+	return calls
+}
+
+// GetEmailAttribute calls GetEmailAttributeFunc.
+func (mock *SAMLConnectionMock) GetEmailAttribute() string {
+	if mock.GetEmailAttributeFunc == nil {
+		panic("SAMLConnectionMock.GetEmailAttributeFunc: method is nil but SAMLConnection.GetEmailAttribute was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetEmailAttribute.Lock()
+	mock.calls.GetEmailAttribute = append(mock.calls.GetEmailAttribute, callInfo)
+	mock.lockGetEmailAttribute.Unlock()
+	return mock.GetEmailAttributeFunc()
+}
+
+// GetEmailAttributeCalls gets all the calls that were made to GetEmailAttribute.
+// Check the length with:
+//
+//	len(mockedSAMLConnection.GetEmailAttributeCalls())
+func (mock *SAMLConnectionMock) GetEmailAttributeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetEmailAttribute.RLock()
+	calls = mock.calls.GetEmailAttribute
+	mock.lockGetEmailAttribute.RUnlock()
+	return calls
+}
+
+// GetID calls GetIDFunc.
+func (mock *SAMLConnectionMock) GetID() uuid.UUID {
+	if mock.GetIDFunc == nil {
+		panic("SAMLConnectionMock.GetIDFunc: method is nil but SAMLConnection.GetID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetID.Lock()
+	mock.calls.GetID = append(mock.calls.GetID, callInfo)
+	mock.lockGetID.Unlock()
+	return mock.GetIDFunc()
+}
+
+// GetIDCalls gets all the calls that were made to GetID.
+// Check the length with:
+//
+//	len(mockedSAMLConnection.GetIDCalls())
+func (mock *SAMLConnectionMock) GetIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetID.RLock()
+	calls = mock.calls.GetID
+	mock.lockGetID.RUnlock()
+	return calls
+}
+
+// GetIdPMetadata calls GetIdPMetadataFunc.
+func (mock *SAMLConnectionMock) GetIdPMetadata() string {
+	if mock.GetIdPMetadataFunc == nil {
+		panic("SAMLConnectionMock.GetIdPMetadataFunc: method is nil but SAMLConnection.GetIdPMetadata was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetIdPMetadata.Lock()
+	mock.calls.GetIdPMetadata = append(mock.calls.GetIdPMetadata, callInfo)
+	mock.lockGetIdPMetadata.Unlock()
+	return mock.GetIdPMetadataFunc()
+}
+
+// GetIdPMetadataCalls gets all the calls that were made to GetIdPMetadata.
+// Check the length with:
+//
+//	len(mockedSAMLConnection.GetIdPMetadataCalls())
+func (mock *SAMLConnectionMock) GetIdPMetadataCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetIdPMetadata.RLock()
+	calls = mock.calls.GetIdPMetadata
+	mock.lockGetIdPMetadata.RUnlock()
+	return calls
+}
+
+// GetName calls GetNameFunc.
+func (mock *SAMLConnectionMock) GetName() string {
+	if mock.GetNameFunc == nil {
+		panic("SAMLConnectionMock.GetNameFunc: method is nil but SAMLConnection.GetName was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetName.Lock()
+	mock.calls.GetName = append(mock.calls.GetName, callInfo)
+	mock.lockGetName.Unlock()
+	return mock.GetNameFunc()
+}
+
+// GetNameCalls gets all the calls that were made to GetName.
+// Check the length with:
+//
+//	len(mockedSAMLConnection.GetNameCalls())
+func (mock *SAMLConnectionMock) GetNameCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetName.RLock()
+	calls = mock.calls.GetName
+	mock.lockGetName.RUnlock()
+	return calls
+}
+
+// GetNameAttribute calls GetNameAttributeFunc.
+func (mock *SAMLConnectionMock) GetNameAttribute() string {
+	if mock.GetNameAttributeFunc == nil {
+		panic("SAMLConnectionMock.GetNameAttributeFunc: method is nil but SAMLConnection.GetNameAttribute was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetNameAttribute.Lock()
+	mock.calls.GetNameAttribute = append(mock.calls.GetNameAttribute, callInfo)
+	mock.lockGetNameAttribute.Unlock()
+	return mock.GetNameAttributeFunc()
+}
+
+// GetNameAttributeCalls gets all the calls that were made to GetNameAttribute.
+// Check the length with:
+//
+//	len(mockedSAMLConnection.GetNameAttributeCalls())
+func (mock *SAMLConnectionMock) GetNameAttributeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetNameAttribute.RLock()
+	calls = mock.calls.GetNameAttribute
+	mock.lockGetNameAttribute.RUnlock()
+	return calls
+}
+
+// GetUpdatedAt calls GetUpdatedAtFunc.
+func (mock *SAMLConnectionMock) GetUpdatedAt() time.Time {
+	if mock.GetUpdatedAtFunc == nil {
+		panic("SAMLConnectionMock.GetUpdatedAtFunc: method is nil but SAMLConnection.GetUpdatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetUpdatedAt.Lock()
+	mock.calls.GetUpdatedAt = append(mock.calls.GetUpdatedAt, callInfo)
+	mock.lockGetUpdatedAt.Unlock()
+	return mock.GetUpdatedAtFunc()
+}
+
+// GetUpdatedAtCalls gets all the calls that were made to GetUpdatedAt.
+// Check the length with:
+//
+//	len(mockedSAMLConnection.GetUpdatedAtCalls())
+func (mock *SAMLConnectionMock) GetUpdatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetUpdatedAt.RLock()
+	calls = mock.calls.GetUpdatedAt
+	mock.lockGetUpdatedAt.RUnlock()
+	return calls
+}
+
+// IsLinkByEmail calls IsLinkByEmailFunc.
+func (mock *SAMLConnectionMock) IsLinkByEmail() bool {
+	if mock.IsLinkByEmailFunc == nil {
+		panic("SAMLConnectionMock.IsLinkByEmailFunc: method is nil but SAMLConnection.IsLinkByEmail was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsLinkByEmail.Lock()
+	mock.calls.IsLinkByEmail = append(mock.calls.IsLinkByEmail, callInfo)
+	mock.lockIsLinkByEmail.Unlock()
+	return mock.IsLinkByEmailFunc()
+}
+
+// IsLinkByEmailCalls gets all the calls that were made to IsLinkByEmail.
+// Check the length with:
+//
+//	len(mockedSAMLConnection.IsLinkByEmailCalls())
+func (mock *SAMLConnectionMock) IsLinkByEmailCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsLinkByEmail.RLock()
+	calls = mock.calls.IsLinkByEmail
+	mock.lockIsLinkByEmail.RUnlock()
+	return calls
+}
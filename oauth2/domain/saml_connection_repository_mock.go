@@ -0,0 +1,125 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that SAMLConnectionRepositoryMock does implement SAMLConnectionRepository.
+// If this is not the case, regenerate this file with moq.
+var _ SAMLConnectionRepository = &SAMLConnectionRepositoryMock{}
+
+// SAMLConnectionRepositoryMock is a mock implementation of SAMLConnectionRepository.
+//
+//	func TestSomethingThatUsesSAMLConnectionRepository(t *testing.T) {
+//
+//		// make and configure a mocked SAMLConnectionRepository
+//		mockedSAMLConnectionRepository := &SAMLConnectionRepositoryMock{
+//			FindSAMLConnectionByNameFunc: func(ctx context.Context, name string) (SAMLConnection, error) {
+//				panic("mock out the FindSAMLConnectionByName method")
+//			},
+//			FindSAMLConnectionsFunc: func(ctx context.Context) ([]SAMLConnection, error) {
+//				panic("mock out the FindSAMLConnections method")
+//			},
+//		}
+//
+//		// use mockedSAMLConnectionRepository in code that requires SAMLConnectionRepository
+//		// and then make assertions.
+//
+//	}
+type SAMLConnectionRepositoryMock struct {
+	// FindSAMLConnectionByNameFunc mocks the FindSAMLConnectionByName method.
+	FindSAMLConnectionByNameFunc func(ctx context.Context, name string) (SAMLConnection, error)
+
+	// FindSAMLConnectionsFunc mocks the FindSAMLConnections method.
+	FindSAMLConnectionsFunc func(ctx context.Context) ([]SAMLConnection, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// FindSAMLConnectionByName holds details about calls to the FindSAMLConnectionByName method.
+		FindSAMLConnectionByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// FindSAMLConnections holds details about calls to the FindSAMLConnections method.
+		FindSAMLConnections []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockFindSAMLConnectionByName sync.RWMutex
+	lockFindSAMLConnections      sync.RWMutex
+}
+
+// FindSAMLConnectionByName calls FindSAMLConnectionByNameFunc.
+func (mock *SAMLConnectionRepositoryMock) FindSAMLConnectionByName(ctx context.Context, name string) (SAMLConnection, error) {
+	if mock.FindSAMLConnectionByNameFunc == nil {
+		panic("SAMLConnectionRepositoryMock.FindSAMLConnectionByNameFunc: method is nil but SAMLConnectionRepository.FindSAMLConnectionByName was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockFindSAMLConnectionByName.Lock()
+	mock.calls.FindSAMLConnectionByName = append(mock.calls.FindSAMLConnectionByName, callInfo)
+	mock.lockFindSAMLConnectionByName.Unlock()
+	return mock.FindSAMLConnectionByNameFunc(ctx, name)
+}
+
+// FindSAMLConnectionByNameCalls gets all the calls that were made to FindSAMLConnectionByName.
+// Check the length with:
+//
+//	len(mockedSAMLConnectionRepository.FindSAMLConnectionByNameCalls())
+func (mock *SAMLConnectionRepositoryMock) FindSAMLConnectionByNameCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockFindSAMLConnectionByName.RLock()
+	calls = mock.calls.FindSAMLConnectionByName
+	mock.lockFindSAMLConnectionByName.RUnlock()
+	return calls
+}
+
+// FindSAMLConnections calls FindSAMLConnectionsFunc.
+func (mock *SAMLConnectionRepositoryMock) FindSAMLConnections(ctx context.Context) ([]SAMLConnection, error) {
+	if mock.FindSAMLConnectionsFunc == nil {
+		panic("SAMLConnectionRepositoryMock.FindSAMLConnectionsFunc: method is nil but SAMLConnectionRepository.FindSAMLConnections was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockFindSAMLConnections.Lock()
+	mock.calls.FindSAMLConnections = append(mock.calls.FindSAMLConnections, callInfo)
+	mock.lockFindSAMLConnections.Unlock()
+	return mock.FindSAMLConnectionsFunc(ctx)
+}
+
+// FindSAMLConnectionsCalls gets all the calls that were made to FindSAMLConnections.
+// Check the length with:
+//
+//	len(mockedSAMLConnectionRepository.FindSAMLConnectionsCalls())
+func (mock *SAMLConnectionRepositoryMock) FindSAMLConnectionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockFindSAMLConnections.RLock()
+	calls = mock.calls.FindSAMLConnections
+	mock.lockFindSAMLConnections.RUnlock()
+	return calls
+}
@@ -0,0 +1,206 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Ensure, that SAMLLoginStoreMock does implement SAMLLoginStore.
+// If this is not the case, regenerate this file with moq.
+var _ SAMLLoginStore = &SAMLLoginStoreMock{}
+
+// SAMLLoginStoreMock is a mock implementation of SAMLLoginStore.
+//
+//	func TestSomethingThatUsesSAMLLoginStore(t *testing.T) {
+//
+//		// make and configure a mocked SAMLLoginStore
+//		mockedSAMLLoginStore := &SAMLLoginStoreMock{
+//			MarkAssertionUsedFunc: func(ctx context.Context, connection string, assertionID string, until time.Time) (bool, error) {
+//				panic("mock out the MarkAssertionUsed method")
+//			},
+//			SaveLoginFunc: func(ctx context.Context, token string, login SAMLLogin, ttl time.Duration) error {
+//				panic("mock out the SaveLogin method")
+//			},
+//			TakeLoginFunc: func(ctx context.Context, token string) (*SAMLLogin, error) {
+//				panic("mock out the TakeLogin method")
+//			},
+//		}
+//
+//		// use mockedSAMLLoginStore in code that requires SAMLLoginStore
+//		// and then make assertions.
+//
+//	}
+type SAMLLoginStoreMock struct {
+	// MarkAssertionUsedFunc mocks the MarkAssertionUsed method.
+	MarkAssertionUsedFunc func(ctx context.Context, connection string, assertionID string, until time.Time) (bool, error)
+
+	// SaveLoginFunc mocks the SaveLogin method.
+	SaveLoginFunc func(ctx context.Context, token string, login SAMLLogin, ttl time.Duration) error
+
+	// TakeLoginFunc mocks the TakeLogin method.
+	TakeLoginFunc func(ctx context.Context, token string) (*SAMLLogin, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// MarkAssertionUsed holds details about calls to the MarkAssertionUsed method.
+		MarkAssertionUsed []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Connection is the connection argument value.
+			Connection string
+			// AssertionID is the assertionID argument value.
+			AssertionID string
+			// Until is the until argument value.
+			Until time.Time
+		}
+		// SaveLogin holds details about calls to the SaveLogin method.
+		SaveLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+			// Login is the login argument value.
+			Login SAMLLogin
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// TakeLogin holds details about calls to the TakeLogin method.
+		TakeLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+	}
+	lockMarkAssertionUsed sync.RWMutex
+	lockSaveLogin         sync.RWMutex
+	lockTakeLogin         sync.RWMutex
+}
+
+// MarkAssertionUsed calls MarkAssertionUsedFunc.
+func (mock *SAMLLoginStoreMock) MarkAssertionUsed(ctx context.Context, connection string, assertionID string, until time.Time) (bool, error) {
+	if mock.MarkAssertionUsedFunc == nil {
+		panic("SAMLLoginStoreMock.MarkAssertionUsedFunc: method is nil but SAMLLoginStore.MarkAssertionUsed was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Connection  string
+		AssertionID string
+		Until       time.Time
+	}{
+		Ctx:         ctx,
+		Connection:  connection,
+		AssertionID: assertionID,
+		Until:       until,
+	}
+	mock.lockMarkAssertionUsed.Lock()
+	mock.calls.MarkAssertionUsed = append(mock.calls.MarkAssertionUsed, callInfo)
+	mock.lockMarkAssertionUsed.Unlock()
+	return mock.MarkAssertionUsedFunc(ctx, connection, assertionID, until)
+}
+
+// MarkAssertionUsedCalls gets all the calls that were made to MarkAssertionUsed.
+// Check the length with:
+//
+//	len(mockedSAMLLoginStore.MarkAssertionUsedCalls())
+func (mock *SAMLLoginStoreMock) MarkAssertionUsedCalls() []struct {
+	Ctx         context.Context
+	Connection  string
+	AssertionID string
+	Until       time.Time
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Connection  string
+		AssertionID string
+		Until       time.Time
+	}
+	mock.lockMarkAssertionUsed.RLock()
+	calls = mock.calls.MarkAssertionUsed
+	mock.lockMarkAssertionUsed.RUnlock()
+	return calls
+}
+
+// SaveLogin calls SaveLoginFunc.
+func (mock *SAMLLoginStoreMock) SaveLogin(ctx context.Context, token string, login SAMLLogin, ttl time.Duration) error {
+	if mock.SaveLoginFunc == nil {
+		panic("SAMLLoginStoreMock.SaveLoginFunc: method is nil but SAMLLoginStore.SaveLogin was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+		Login SAMLLogin
+		TTL   time.Duration
+	}{
+		Ctx:   ctx,
+		Token: token,
+		Login: login,
+		TTL:   ttl,
+	}
+	mock.lockSaveLogin.Lock()
+	mock.calls.SaveLogin = append(mock.calls.SaveLogin, callInfo)
+	mock.lockSaveLogin.Unlock()
+	return mock.SaveLoginFunc(ctx, token, login, ttl)
+}
+
+// SaveLoginCalls gets all the calls that were made to SaveLogin.
+// Check the length with:
+//
+//	len(mockedSAMLLoginStore.SaveLoginCalls())
+func (mock *SAMLLoginStoreMock) SaveLoginCalls() []struct {
+	Ctx   context.Context
+	Token string
+	Login SAMLLogin
+	TTL   time.Duration
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+		Login SAMLLogin
+		TTL   time.Duration
+	}
+	mock.lockSaveLogin.RLock()
+	calls = mock.calls.SaveLogin
+	mock.lockSaveLogin.RUnlock()
+	return calls
+}
+
+// TakeLogin calls TakeLoginFunc.
+func (mock *SAMLLoginStoreMock) TakeLogin(ctx context.Context, token string) (*SAMLLogin, error) {
+	if mock.TakeLoginFunc == nil {
+		panic("SAMLLoginStoreMock.TakeLoginFunc: method is nil but SAMLLoginStore.TakeLogin was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockTakeLogin.Lock()
+	mock.calls.TakeLogin = append(mock.calls.TakeLogin, callInfo)
+	mock.lockTakeLogin.Unlock()
+	return mock.TakeLoginFunc(ctx, token)
+}
+
+// TakeLoginCalls gets all the calls that were made to TakeLogin.
+// Check the length with:
+//
+//	len(mockedSAMLLoginStore.TakeLoginCalls())
+func (mock *SAMLLoginStoreMock) TakeLoginCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockTakeLogin.RLock()
+	calls = mock.calls.TakeLogin
+	mock.lockTakeLogin.RUnlock()
+	return calls
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+var ErrScopeInvalid = errors.New("scope is invalid")
+
+type ScopeParams struct {
+	Name            string
+	Description     string
+	RequiresConsent bool
+	IsDefault       bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func NewScope(p ScopeParams) Scope {
+	return &scope{
+		name:            p.Name,
+		description:     p.Description,
+		requiresConsent: p.RequiresConsent,
+		isDefault:       p.IsDefault,
+		createdAt:       p.CreatedAt,
+		updatedAt:       p.UpdatedAt,
+	}
+}
+
+//go:generate go run github.com/matryer/moq -out scope_mock.go . Scope
+type Scope interface {
+	GetName() string
+	GetDescription() string
+	RequiresConsent() bool
+	IsDefault() bool
+}
+
+//go:generate go run github.com/matryer/moq -out scope_repository_mock.go . ScopeRepository
+type ScopeRepository interface {
+	FindScopesByNames(ctx context.Context, names []string) ([]Scope, error)
+	FindDefaultScopes(ctx context.Context) ([]Scope, error)
+}
+
+type scope struct {
+	name            string
+	description     string
+	requiresConsent bool
+	isDefault       bool
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+func (s *scope) GetName() string {
+	return s.name
+}
+
+func (s *scope) GetDescription() string {
+	return s.description
+}
+
+func (s *scope) RequiresConsent() bool {
+	return s.requiresConsent
+}
+
+func (s *scope) IsDefault() bool {
+	return s.isDefault
+}
+
+// ScopeSet は重複のないスコープ名の集合を表す値オブジェクト
+type ScopeSet struct {
+	names []string
+}
+
+func NewScopeSet(names ...string) ScopeSet {
+	set := make([]string, 0, len(names))
+	for _, n := range names {
+		if n != "" && !slices.Contains(set, n) {
+			set = append(set, n)
+		}
+	}
+	slices.Sort(set)
+	return ScopeSet{names: set}
+}
+
+// ParseScope はスペース区切りの scope パラメータを解析する (RFC 6749 3.3)
+func ParseScope(s string) (ScopeSet, error) {
+	names := strings.Fields(s)
+	for _, n := range names {
+		if !isScopeToken(n) {
+			return ScopeSet{}, ErrScopeInvalid
+		}
+	}
+	return NewScopeSet(names...), nil
+}
+
+// ScopeSetOf は登録済みスコープから集合を作る
+func ScopeSetOf(scopes []Scope) ScopeSet {
+	names := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		names = append(names, s.GetName())
+	}
+	return NewScopeSet(names...)
+}
+
+func (s ScopeSet) Names() []string {
+	return slices.Clone(s.names)
+}
+
+func (s ScopeSet) String() string {
+	return strings.Join(s.names, " ")
+}
+
+func (s ScopeSet) Len() int {
+	return len(s.names)
+}
+
+func (s ScopeSet) IsEmpty() bool {
+	return len(s.names) == 0
+}
+
+func (s ScopeSet) Contains(name string) bool {
+	_, found := slices.BinarySearch(s.names, name)
+	return found
+}
+
+// IsSubsetOf は全てのスコープが other に含まれているかを判定する
+func (s ScopeSet) IsSubsetOf(other ScopeSet) bool {
+	for _, n := range s.names {
+		if !other.Contains(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s ScopeSet) Intersect(other ScopeSet) ScopeSet {
+	names := make([]string, 0, len(s.names))
+	for _, n := range s.names {
+		if other.Contains(n) {
+			names = append(names, n)
+		}
+	}
+	return ScopeSet{names: names}
+}
+
+// Difference は other に含まれないスコープの集合を返す
+func (s ScopeSet) Difference(other ScopeSet) ScopeSet {
+	names := make([]string, 0, len(s.names))
+	for _, n := range s.names {
+		if !other.Contains(n) {
+			names = append(names, n)
+		}
+	}
+	return ScopeSet{names: names}
+}
+
+func (s ScopeSet) Union(other ScopeSet) ScopeSet {
+	return NewScopeSet(append(s.Names(), other.names...)...)
+}
+
+// scope-token = 1*( %x21 / %x23-5B / %x5D-7E )
+func isScopeToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x21 || c > 0x7e || c == '"' || c == '\\' {
+			return false
+		}
+	}
+	return true
+}
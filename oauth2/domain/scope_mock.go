@@ -0,0 +1,178 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"sync"
+)
+
+// Ensure, that ScopeMock does implement Scope.
+// If this is not the case, regenerate this file with moq.
+var _ Scope = &ScopeMock{}
+
+// ScopeMock is a mock implementation of Scope.
+//
+//	func TestSomethingThatUsesScope(t *testing.T) {
+//
+//		// make and configure a mocked Scope
+//		mockedScope := &ScopeMock{
+//			GetDescriptionFunc: func() string {
+//				panic("mock out the GetDescription method")
+//			},
+//			GetNameFunc: func() string {
+//				panic("mock out the GetName method")
+//			},
+//			IsDefaultFunc: func() bool {
+//				panic("mock out the IsDefault method")
+//			},
+//			RequiresConsentFunc: func() bool {
+//				panic("mock out the RequiresConsent method")
+//			},
+//		}
+//
+//		// use mockedScope in code that requires Scope
+//		// and then make assertions.
+//
+//	}
+type ScopeMock struct {
+	// GetDescriptionFunc mocks the GetDescription method.
+	GetDescriptionFunc func() string
+
+	// GetNameFunc mocks the GetName method.
+	GetNameFunc func() string
+
+	// IsDefaultFunc mocks the IsDefault method.
+	IsDefaultFunc func() bool
+
+	// RequiresConsentFunc mocks the RequiresConsent method.
+	RequiresConsentFunc func() bool
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetDescription holds details about calls to the GetDescription method.
+		GetDescription []struct {
+		}
+		// GetName holds details about calls to the GetName method.
+		GetName []struct {
+		}
+		// IsDefault holds details about calls to the IsDefault method.
+		IsDefault []struct {
+		}
+		// RequiresConsent holds details about calls to the RequiresConsent method.
+		RequiresConsent []struct {
+		}
+	}
+	lockGetDescription  sync.RWMutex
+	lockGetName         sync.RWMutex
+	lockIsDefault       sync.RWMutex
+	lockRequiresConsent sync.RWMutex
+}
+
+// GetDescription calls GetDescriptionFunc.
+func (mock *ScopeMock) GetDescription() string {
+	if mock.GetDescriptionFunc == nil {
+		panic("ScopeMock.GetDescriptionFunc: method is nil but Scope.GetDescription was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetDescription.Lock()
+	mock.calls.GetDescription = append(mock.calls.GetDescription, callInfo)
+	mock.lockGetDescription.Unlock()
+	return mock.GetDescriptionFunc()
+}
+
+// GetDescriptionCalls gets all the calls that were made to GetDescription.
+// Check the length with:
+//
+//	len(mockedScope.GetDescriptionCalls())
+func (mock *ScopeMock) GetDescriptionCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetDescription.RLock()
+	calls = mock.calls.GetDescription
+	mock.lockGetDescription.RUnlock()
+	return calls
+}
+
+// GetName calls GetNameFunc.
+func (mock *ScopeMock) GetName() string {
+	if mock.GetNameFunc == nil {
+		panic("ScopeMock.GetNameFunc: method is nil but Scope.GetName was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetName.Lock()
+	mock.calls.GetName = append(mock.calls.GetName, callInfo)
+	mock.lockGetName.Unlock()
+	return mock.GetNameFunc()
+}
+
+// GetNameCalls gets all the calls that were made to GetName.
+// Check the length with:
+//
+//	len(mockedScope.GetNameCalls())
+func (mock *ScopeMock) GetNameCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetName.RLock()
+	calls = mock.calls.GetName
+	mock.lockGetName.RUnlock()
+	return calls
+}
+
+// IsDefault calls IsDefaultFunc.
+func (mock *ScopeMock) IsDefault() bool {
+	if mock.IsDefaultFunc == nil {
+		panic("ScopeMock.IsDefaultFunc: method is nil but Scope.IsDefault was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsDefault.Lock()
+	mock.calls.IsDefault = append(mock.calls.IsDefault, callInfo)
+	mock.lockIsDefault.Unlock()
+	return mock.IsDefaultFunc()
+}
+
+// IsDefaultCalls gets all the calls that were made to IsDefault.
+// Check the length with:
+//
+//	len(mockedScope.IsDefaultCalls())
+func (mock *ScopeMock) IsDefaultCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsDefault.RLock()
+	calls = mock.calls.IsDefault
+	mock.lockIsDefault.RUnlock()
+	return calls
+}
+
+// RequiresConsent calls RequiresConsentFunc.
+func (mock *ScopeMock) RequiresConsent() bool {
+	if mock.RequiresConsentFunc == nil {
+		panic("ScopeMock.RequiresConsentFunc: method is nil but Scope.RequiresConsent was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockRequiresConsent.Lock()
+	mock.calls.RequiresConsent = append(mock.calls.RequiresConsent, callInfo)
+	mock.lockRequiresConsent.Unlock()
+	return mock.RequiresConsentFunc()
+}
+
+// RequiresConsentCalls gets all the calls that were made to RequiresConsent.
+// Check the length with:
+//
+//	len(mockedScope.RequiresConsentCalls())
+func (mock *ScopeMock) RequiresConsentCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockRequiresConsent.RLock()
+	calls = mock.calls.RequiresConsent
+	mock.lockRequiresConsent.RUnlock()
+	return calls
+}
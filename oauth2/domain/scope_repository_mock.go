@@ -0,0 +1,125 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that ScopeRepositoryMock does implement ScopeRepository.
+// If this is not the case, regenerate this file with moq.
+var _ ScopeRepository = &ScopeRepositoryMock{}
+
+// ScopeRepositoryMock is a mock implementation of ScopeRepository.
+//
+//	func TestSomethingThatUsesScopeRepository(t *testing.T) {
+//
+//		// make and configure a mocked ScopeRepository
+//		mockedScopeRepository := &ScopeRepositoryMock{
+//			FindDefaultScopesFunc: func(ctx context.Context) ([]Scope, error) {
+//				panic("mock out the FindDefaultScopes method")
+//			},
+//			FindScopesByNamesFunc: func(ctx context.Context, names []string) ([]Scope, error) {
+//				panic("mock out the FindScopesByNames method")
+//			},
+//		}
+//
+//		// use mockedScopeRepository in code that requires ScopeRepository
+//		// and then make assertions.
+//
+//	}
+type ScopeRepositoryMock struct {
+	// FindDefaultScopesFunc mocks the FindDefaultScopes method.
+	FindDefaultScopesFunc func(ctx context.Context) ([]Scope, error)
+
+	// FindScopesByNamesFunc mocks the FindScopesByNames method.
+	FindScopesByNamesFunc func(ctx context.Context, names []string) ([]Scope, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// FindDefaultScopes holds details about calls to the FindDefaultScopes method.
+		FindDefaultScopes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// FindScopesByNames holds details about calls to the FindScopesByNames method.
+		FindScopesByNames []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Names is the names argument value.
+			Names []string
+		}
+	}
+	lockFindDefaultScopes sync.RWMutex
+	lockFindScopesByNames sync.RWMutex
+}
+
+// FindDefaultScopes calls FindDefaultScopesFunc.
+func (mock *ScopeRepositoryMock) FindDefaultScopes(ctx context.Context) ([]Scope, error) {
+	if mock.FindDefaultScopesFunc == nil {
+		panic("ScopeRepositoryMock.FindDefaultScopesFunc: method is nil but ScopeRepository.FindDefaultScopes was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockFindDefaultScopes.Lock()
+	mock.calls.FindDefaultScopes = append(mock.calls.FindDefaultScopes, callInfo)
+	mock.lockFindDefaultScopes.Unlock()
+	return mock.FindDefaultScopesFunc(ctx)
+}
+
+// FindDefaultScopesCalls gets all the calls that were made to FindDefaultScopes.
+// Check the length with:
+//
+//	len(mockedScopeRepository.FindDefaultScopesCalls())
+func (mock *ScopeRepositoryMock) FindDefaultScopesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockFindDefaultScopes.RLock()
+	calls = mock.calls.FindDefaultScopes
+	mock.lockFindDefaultScopes.RUnlock()
+	return calls
+}
+
+// FindScopesByNames calls FindScopesByNamesFunc.
+func (mock *ScopeRepositoryMock) FindScopesByNames(ctx context.Context, names []string) ([]Scope, error) {
+	if mock.FindScopesByNamesFunc == nil {
+		panic("ScopeRepositoryMock.FindScopesByNamesFunc: method is nil but ScopeRepository.FindScopesByNames was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Names []string
+	}{
+		Ctx:   ctx,
+		Names: names,
+	}
+	mock.lockFindScopesByNames.Lock()
+	mock.calls.FindScopesByNames = append(mock.calls.FindScopesByNames, callInfo)
+	mock.lockFindScopesByNames.Unlock()
+	return mock.FindScopesByNamesFunc(ctx, names)
+}
+
+// FindScopesByNamesCalls gets all the calls that were made to FindScopesByNames.
+// Check the length with:
+//
+//	len(mockedScopeRepository.FindScopesByNamesCalls())
+func (mock *ScopeRepositoryMock) FindScopesByNamesCalls() []struct {
+	Ctx   context.Context
+	Names []string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Names []string
+	}
+	mock.lockFindScopesByNames.RLock()
+	calls = mock.calls.FindScopesByNames
+	mock.lockFindScopesByNames.RUnlock()
+	return calls
+}
@@ -0,0 +1,89 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Ensure, that SessionRevokerMock does implement SessionRevoker.
+// If this is not the case, regenerate this file with moq.
+var _ SessionRevoker = &SessionRevokerMock{}
+
+// SessionRevokerMock is a mock implementation of SessionRevoker.
+//
+//	func TestSomethingThatUsesSessionRevoker(t *testing.T) {
+//
+//		// make and configure a mocked SessionRevoker
+//		mockedSessionRevoker := &SessionRevokerMock{
+//			RevokeUserSessionsFunc: func(ctx context.Context, userID uuid.UUID, at time.Time) error {
+//				panic("mock out the RevokeUserSessions method")
+//			},
+//		}
+//
+//		// use mockedSessionRevoker in code that requires SessionRevoker
+//		// and then make assertions.
+//
+//	}
+type SessionRevokerMock struct {
+	// RevokeUserSessionsFunc mocks the RevokeUserSessions method.
+	RevokeUserSessionsFunc func(ctx context.Context, userID uuid.UUID, at time.Time) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RevokeUserSessions holds details about calls to the RevokeUserSessions method.
+		RevokeUserSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// At is the at argument value.
+			At time.Time
+		}
+	}
+	lockRevokeUserSessions sync.RWMutex
+}
+
+// RevokeUserSessions calls RevokeUserSessionsFunc.
+func (mock *SessionRevokerMock) RevokeUserSessions(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	if mock.RevokeUserSessionsFunc == nil {
+		panic("SessionRevokerMock.RevokeUserSessionsFunc: method is nil but SessionRevoker.RevokeUserSessions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		At     time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		At:     at,
+	}
+	mock.lockRevokeUserSessions.Lock()
+	mock.calls.RevokeUserSessions = append(mock.calls.RevokeUserSessions, callInfo)
+	mock.lockRevokeUserSessions.Unlock()
+	return mock.RevokeUserSessionsFunc(ctx, userID, at)
+}
+
+// RevokeUserSessionsCalls gets all the calls that were made to RevokeUserSessions.
+// Check the length with:
+//
+//	len(mockedSessionRevoker.RevokeUserSessionsCalls())
+func (mock *SessionRevokerMock) RevokeUserSessionsCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	At     time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		At     time.Time
+	}
+	mock.lockRevokeUserSessions.RLock()
+	calls = mock.calls.RevokeUserSessions
+	mock.lockRevokeUserSessions.RUnlock()
+	return calls
+}
@@ -13,21 +13,25 @@ import (
 )
 
 type TokenParams struct {
-	AccessToken string
-	ClientID    uuid.UUID
-	UserID      uuid.UUID
-	Scope       string
-	ExpiresAt   time.Time
+	AccessToken       string
+	ClientID          uuid.UUID
+	UserID            uuid.UUID
+	Scope             string
+	AuthorizationCode string
+	ExpiresAt         time.Time
+	RevokedAt         *time.Time
 }
 
 func NewToken(p TokenParams) Token {
 	atoken := AccessToken(p.AccessToken)
 	return &token{
-		AccessToken: atoken,
-		ClientID:    p.ClientID,
-		UserID:      p.UserID,
-		Scope:       p.Scope,
-		ExpiresAt:   p.ExpiresAt,
+		AccessToken:       atoken,
+		ClientID:          p.ClientID,
+		UserID:            p.UserID,
+		Scope:             p.Scope,
+		AuthorizationCode: p.AuthorizationCode,
+		ExpiresAt:         p.ExpiresAt,
+		RevokedAt:         p.RevokedAt,
 	}
 }
 
@@ -38,7 +42,10 @@ type Token interface {
 	GetClientID() uuid.UUID
 	GetUserID() uuid.UUID
 	GetScope() string
+	GetAuthorizationCode() string
 	GetExpiresAt() time.Time
+	GetRevokedAt() *time.Time
+	IsRevoked() bool
 	SetNewAccessToken(privateKeyBase64 string) error
 	Expiry() int64
 	SetNewExpiry(additionalMin int)
@@ -49,14 +56,29 @@ type TokenRepository interface {
 	StoreToken(ctx context.Context, token Token) error
 	FindToken(ctx context.Context, accessToken string) (Token, error)
 	RevokeToken(ctx context.Context, accessToken string) error
+	RevokeTokensByAuthorizationCode(ctx context.Context, code string) error
+	RevokeTokensByUserAndClient(ctx context.Context, userID, clientID uuid.UUID) error
+	RevokeTokensByClientID(ctx context.Context, clientID uuid.UUID) error
+	RevokeTokensByUserID(ctx context.Context, userID uuid.UUID) error
+	SummarizeTokensByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]TokenUsage, error)
+}
+
+// TokenUsage はユーザーがクライアントに発行したトークンの利用状況
+type TokenUsage struct {
+	ClientID     uuid.UUID
+	FirstUsedAt  time.Time
+	LastUsedAt   time.Time
+	ActiveTokens int
 }
 
 type token struct {
-	AccessToken AccessToken
-	ClientID    uuid.UUID
-	UserID      uuid.UUID
-	Scope       string
-	ExpiresAt   time.Time
+	AccessToken       AccessToken
+	ClientID          uuid.UUID
+	UserID            uuid.UUID
+	Scope             string
+	AuthorizationCode string
+	ExpiresAt         time.Time
+	RevokedAt         *time.Time
 }
 
 func (t *token) IsNotFound() bool {
@@ -79,10 +101,22 @@ func (t *token) GetScope() string {
 	return t.Scope
 }
 
+func (t *token) GetAuthorizationCode() string {
+	return t.AuthorizationCode
+}
+
 func (t *token) GetExpiresAt() time.Time {
 	return t.ExpiresAt
 }
 
+func (t *token) GetRevokedAt() *time.Time {
+	return t.RevokedAt
+}
+
+func (t *token) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
 func (t *token) SetNewAccessToken(s string) error {
 	t.AccessToken = AccessToken(s)
 
@@ -25,12 +25,18 @@ var _ Token = &TokenMock{}
 //			GetAccessTokenFunc: func() string {
 //				panic("mock out the GetAccessToken method")
 //			},
+//			GetAuthorizationCodeFunc: func() string {
+//				panic("mock out the GetAuthorizationCode method")
+//			},
 //			GetClientIDFunc: func() uuid.UUID {
 //				panic("mock out the GetClientID method")
 //			},
 //			GetExpiresAtFunc: func() time.Time {
 //				panic("mock out the GetExpiresAt method")
 //			},
+//			GetRevokedAtFunc: func() *time.Time {
+//				panic("mock out the GetRevokedAt method")
+//			},
 //			GetScopeFunc: func() string {
 //				panic("mock out the GetScope method")
 //			},
@@ -40,6 +46,9 @@ var _ Token = &TokenMock{}
 //			IsNotFoundFunc: func() bool {
 //				panic("mock out the IsNotFound method")
 //			},
+//			IsRevokedFunc: func() bool {
+//				panic("mock out the IsRevoked method")
+//			},
 //			SetNewAccessTokenFunc: func(privateKeyBase64 string) error {
 //				panic("mock out the SetNewAccessToken method")
 //			},
@@ -59,12 +68,18 @@ type TokenMock struct {
 	// GetAccessTokenFunc mocks the GetAccessToken method.
 	GetAccessTokenFunc func() string
 
+	// GetAuthorizationCodeFunc mocks the GetAuthorizationCode method.
+	GetAuthorizationCodeFunc func() string
+
 	// GetClientIDFunc mocks the GetClientID method.
 	GetClientIDFunc func() uuid.UUID
 
 	// GetExpiresAtFunc mocks the GetExpiresAt method.
 	GetExpiresAtFunc func() time.Time
 
+	// GetRevokedAtFunc mocks the GetRevokedAt method.
+	GetRevokedAtFunc func() *time.Time
+
 	// GetScopeFunc mocks the GetScope method.
 	GetScopeFunc func() string
 
@@ -74,6 +89,9 @@ type TokenMock struct {
 	// IsNotFoundFunc mocks the IsNotFound method.
 	IsNotFoundFunc func() bool
 
+	// IsRevokedFunc mocks the IsRevoked method.
+	IsRevokedFunc func() bool
+
 	// SetNewAccessTokenFunc mocks the SetNewAccessToken method.
 	SetNewAccessTokenFunc func(privateKeyBase64 string) error
 
@@ -88,12 +106,18 @@ type TokenMock struct {
 		// GetAccessToken holds details about calls to the GetAccessToken method.
 		GetAccessToken []struct {
 		}
+		// GetAuthorizationCode holds details about calls to the GetAuthorizationCode method.
+		GetAuthorizationCode []struct {
+		}
 		// GetClientID holds details about calls to the GetClientID method.
 		GetClientID []struct {
 		}
 		// GetExpiresAt holds details about calls to the GetExpiresAt method.
 		GetExpiresAt []struct {
 		}
+		// GetRevokedAt holds details about calls to the GetRevokedAt method.
+		GetRevokedAt []struct {
+		}
 		// GetScope holds details about calls to the GetScope method.
 		GetScope []struct {
 		}
@@ -103,6 +127,9 @@ type TokenMock struct {
 		// IsNotFound holds details about calls to the IsNotFound method.
 		IsNotFound []struct {
 		}
+		// IsRevoked holds details about calls to the IsRevoked method.
+		IsRevoked []struct {
+		}
 		// SetNewAccessToken holds details about calls to the SetNewAccessToken method.
 		SetNewAccessToken []struct {
 			// PrivateKeyBase64 is the privateKeyBase64 argument value.
@@ -114,15 +141,18 @@ type TokenMock struct {
 			AdditionalMin int
 		}
 	}
-	lockExpiry            sync.RWMutex
-	lockGetAccessToken    sync.RWMutex
-	lockGetClientID       sync.RWMutex
-	lockGetExpiresAt      sync.RWMutex
-	lockGetScope          sync.RWMutex
-	lockGetUserID         sync.RWMutex
-	lockIsNotFound        sync.RWMutex
-	lockSetNewAccessToken sync.RWMutex
-	lockSetNewExpiry      sync.RWMutex
+	lockExpiry               sync.RWMutex
+	lockGetAccessToken       sync.RWMutex
+	lockGetAuthorizationCode sync.RWMutex
+	lockGetClientID          sync.RWMutex
+	lockGetExpiresAt         sync.RWMutex
+	lockGetRevokedAt         sync.RWMutex
+	lockGetScope             sync.RWMutex
+	lockGetUserID            sync.RWMutex
+	lockIsNotFound           sync.RWMutex
+	lockIsRevoked            sync.RWMutex
+	lockSetNewAccessToken    sync.RWMutex
+	lockSetNewExpiry         sync.RWMutex
 }
 
 // Expiry calls ExpiryFunc.
@@ -179,6 +209,33 @@ func (mock *TokenMock) GetAccessTokenCalls() []struct {
 	return calls
 }
 
+// GetAuthorizationCode calls GetAuthorizationCodeFunc.
+func (mock *TokenMock) GetAuthorizationCode() string {
+	if mock.GetAuthorizationCodeFunc == nil {
+		panic("TokenMock.GetAuthorizationCodeFunc: method is nil but Token.GetAuthorizationCode was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetAuthorizationCode.Lock()
+	mock.calls.GetAuthorizationCode = append(mock.calls.GetAuthorizationCode, callInfo)
+	mock.lockGetAuthorizationCode.Unlock()
+	return mock.GetAuthorizationCodeFunc()
+}
+
+// GetAuthorizationCodeCalls gets all the calls that were made to GetAuthorizationCode.
+// Check the length with:
+//
+//	len(mockedToken.GetAuthorizationCodeCalls())
+func (mock *TokenMock) GetAuthorizationCodeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetAuthorizationCode.RLock()
+	calls = mock.calls.GetAuthorizationCode
+	mock.lockGetAuthorizationCode.RUnlock()
+	return calls
+}
+
 // GetClientID calls GetClientIDFunc.
 func (mock *TokenMock) GetClientID() uuid.UUID {
 	if mock.GetClientIDFunc == nil {
@@ -233,6 +290,33 @@ func (mock *TokenMock) GetExpiresAtCalls() []struct {
 	return calls
 }
 
+// GetRevokedAt calls GetRevokedAtFunc.
+func (mock *TokenMock) GetRevokedAt() *time.Time {
+	if mock.GetRevokedAtFunc == nil {
+		panic("TokenMock.GetRevokedAtFunc: method is nil but Token.GetRevokedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetRevokedAt.Lock()
+	mock.calls.GetRevokedAt = append(mock.calls.GetRevokedAt, callInfo)
+	mock.lockGetRevokedAt.Unlock()
+	return mock.GetRevokedAtFunc()
+}
+
+// GetRevokedAtCalls gets all the calls that were made to GetRevokedAt.
+// Check the length with:
+//
+//	len(mockedToken.GetRevokedAtCalls())
+func (mock *TokenMock) GetRevokedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetRevokedAt.RLock()
+	calls = mock.calls.GetRevokedAt
+	mock.lockGetRevokedAt.RUnlock()
+	return calls
+}
+
 // GetScope calls GetScopeFunc.
 func (mock *TokenMock) GetScope() string {
 	if mock.GetScopeFunc == nil {
@@ -314,6 +398,33 @@ func (mock *TokenMock) IsNotFoundCalls() []struct {
 	return calls
 }
 
+// IsRevoked calls IsRevokedFunc.
+func (mock *TokenMock) IsRevoked() bool {
+	if mock.IsRevokedFunc == nil {
+		panic("TokenMock.IsRevokedFunc: method is nil but Token.IsRevoked was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsRevoked.Lock()
+	mock.calls.IsRevoked = append(mock.calls.IsRevoked, callInfo)
+	mock.lockIsRevoked.Unlock()
+	return mock.IsRevokedFunc()
+}
+
+// IsRevokedCalls gets all the calls that were made to IsRevoked.
+// Check the length with:
+//
+//	len(mockedToken.IsRevokedCalls())
+func (mock *TokenMock) IsRevokedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsRevoked.RLock()
+	calls = mock.calls.IsRevoked
+	mock.lockIsRevoked.RUnlock()
+	return calls
+}
+
 // SetNewAccessToken calls SetNewAccessTokenFunc.
 func (mock *TokenMock) SetNewAccessToken(privateKeyBase64 string) error {
 	if mock.SetNewAccessTokenFunc == nil {
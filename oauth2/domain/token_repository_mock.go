@@ -5,7 +5,9 @@ package domain
 
 import (
 	"context"
+	"github.com/google/uuid"
 	"sync"
+	"time"
 )
 
 // Ensure, that TokenRepositoryMock does implement TokenRepository.
@@ -24,9 +26,24 @@ var _ TokenRepository = &TokenRepositoryMock{}
 //			RevokeTokenFunc: func(ctx context.Context, accessToken string) error {
 //				panic("mock out the RevokeToken method")
 //			},
+//			RevokeTokensByAuthorizationCodeFunc: func(ctx context.Context, code string) error {
+//				panic("mock out the RevokeTokensByAuthorizationCode method")
+//			},
+//			RevokeTokensByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) error {
+//				panic("mock out the RevokeTokensByClientID method")
+//			},
+//			RevokeTokensByUserAndClientFunc: func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error {
+//				panic("mock out the RevokeTokensByUserAndClient method")
+//			},
+//			RevokeTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the RevokeTokensByUserID method")
+//			},
 //			StoreTokenFunc: func(ctx context.Context, token Token) error {
 //				panic("mock out the StoreToken method")
 //			},
+//			SummarizeTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID, now time.Time) ([]TokenUsage, error) {
+//				panic("mock out the SummarizeTokensByUserID method")
+//			},
 //		}
 //
 //		// use mockedTokenRepository in code that requires TokenRepository
@@ -40,9 +57,24 @@ type TokenRepositoryMock struct {
 	// RevokeTokenFunc mocks the RevokeToken method.
 	RevokeTokenFunc func(ctx context.Context, accessToken string) error
 
+	// RevokeTokensByAuthorizationCodeFunc mocks the RevokeTokensByAuthorizationCode method.
+	RevokeTokensByAuthorizationCodeFunc func(ctx context.Context, code string) error
+
+	// RevokeTokensByClientIDFunc mocks the RevokeTokensByClientID method.
+	RevokeTokensByClientIDFunc func(ctx context.Context, clientID uuid.UUID) error
+
+	// RevokeTokensByUserAndClientFunc mocks the RevokeTokensByUserAndClient method.
+	RevokeTokensByUserAndClientFunc func(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error
+
+	// RevokeTokensByUserIDFunc mocks the RevokeTokensByUserID method.
+	RevokeTokensByUserIDFunc func(ctx context.Context, userID uuid.UUID) error
+
 	// StoreTokenFunc mocks the StoreToken method.
 	StoreTokenFunc func(ctx context.Context, token Token) error
 
+	// SummarizeTokensByUserIDFunc mocks the SummarizeTokensByUserID method.
+	SummarizeTokensByUserIDFunc func(ctx context.Context, userID uuid.UUID, now time.Time) ([]TokenUsage, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// FindToken holds details about calls to the FindToken method.
@@ -59,6 +91,36 @@ type TokenRepositoryMock struct {
 			// AccessToken is the accessToken argument value.
 			AccessToken string
 		}
+		// RevokeTokensByAuthorizationCode holds details about calls to the RevokeTokensByAuthorizationCode method.
+		RevokeTokensByAuthorizationCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+		}
+		// RevokeTokensByClientID holds details about calls to the RevokeTokensByClientID method.
+		RevokeTokensByClientID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ClientID is the clientID argument value.
+			ClientID uuid.UUID
+		}
+		// RevokeTokensByUserAndClient holds details about calls to the RevokeTokensByUserAndClient method.
+		RevokeTokensByUserAndClient []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ClientID is the clientID argument value.
+			ClientID uuid.UUID
+		}
+		// RevokeTokensByUserID holds details about calls to the RevokeTokensByUserID method.
+		RevokeTokensByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
 		// StoreToken holds details about calls to the StoreToken method.
 		StoreToken []struct {
 			// Ctx is the ctx argument value.
@@ -66,10 +128,24 @@ type TokenRepositoryMock struct {
 			// Token is the token argument value.
 			Token Token
 		}
+		// SummarizeTokensByUserID holds details about calls to the SummarizeTokensByUserID method.
+		SummarizeTokensByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Now is the now argument value.
+			Now time.Time
+		}
 	}
-	lockFindToken   sync.RWMutex
-	lockRevokeToken sync.RWMutex
-	lockStoreToken  sync.RWMutex
+	lockFindToken                       sync.RWMutex
+	lockRevokeToken                     sync.RWMutex
+	lockRevokeTokensByAuthorizationCode sync.RWMutex
+	lockRevokeTokensByClientID          sync.RWMutex
+	lockRevokeTokensByUserAndClient     sync.RWMutex
+	lockRevokeTokensByUserID            sync.RWMutex
+	lockStoreToken                      sync.RWMutex
+	lockSummarizeTokensByUserID         sync.RWMutex
 }
 
 // FindToken calls FindTokenFunc.
@@ -144,6 +220,154 @@ func (mock *TokenRepositoryMock) RevokeTokenCalls() []struct {
 	return calls
 }
 
+// RevokeTokensByAuthorizationCode calls RevokeTokensByAuthorizationCodeFunc.
+func (mock *TokenRepositoryMock) RevokeTokensByAuthorizationCode(ctx context.Context, code string) error {
+	if mock.RevokeTokensByAuthorizationCodeFunc == nil {
+		panic("TokenRepositoryMock.RevokeTokensByAuthorizationCodeFunc: method is nil but TokenRepository.RevokeTokensByAuthorizationCode was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+	}{
+		Ctx:  ctx,
+		Code: code,
+	}
+	mock.lockRevokeTokensByAuthorizationCode.Lock()
+	mock.calls.RevokeTokensByAuthorizationCode = append(mock.calls.RevokeTokensByAuthorizationCode, callInfo)
+	mock.lockRevokeTokensByAuthorizationCode.Unlock()
+	return mock.RevokeTokensByAuthorizationCodeFunc(ctx, code)
+}
+
+// RevokeTokensByAuthorizationCodeCalls gets all the calls that were made to RevokeTokensByAuthorizationCode.
+// Check the length with:
+//
+//	len(mockedTokenRepository.RevokeTokensByAuthorizationCodeCalls())
+func (mock *TokenRepositoryMock) RevokeTokensByAuthorizationCodeCalls() []struct {
+	Ctx  context.Context
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+	}
+	mock.lockRevokeTokensByAuthorizationCode.RLock()
+	calls = mock.calls.RevokeTokensByAuthorizationCode
+	mock.lockRevokeTokensByAuthorizationCode.RUnlock()
+	return calls
+}
+
+// RevokeTokensByClientID calls RevokeTokensByClientIDFunc.
+func (mock *TokenRepositoryMock) RevokeTokensByClientID(ctx context.Context, clientID uuid.UUID) error {
+	if mock.RevokeTokensByClientIDFunc == nil {
+		panic("TokenRepositoryMock.RevokeTokensByClientIDFunc: method is nil but TokenRepository.RevokeTokensByClientID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ClientID uuid.UUID
+	}{
+		Ctx:      ctx,
+		ClientID: clientID,
+	}
+	mock.lockRevokeTokensByClientID.Lock()
+	mock.calls.RevokeTokensByClientID = append(mock.calls.RevokeTokensByClientID, callInfo)
+	mock.lockRevokeTokensByClientID.Unlock()
+	return mock.RevokeTokensByClientIDFunc(ctx, clientID)
+}
+
+// RevokeTokensByClientIDCalls gets all the calls that were made to RevokeTokensByClientID.
+// Check the length with:
+//
+//	len(mockedTokenRepository.RevokeTokensByClientIDCalls())
+func (mock *TokenRepositoryMock) RevokeTokensByClientIDCalls() []struct {
+	Ctx      context.Context
+	ClientID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ClientID uuid.UUID
+	}
+	mock.lockRevokeTokensByClientID.RLock()
+	calls = mock.calls.RevokeTokensByClientID
+	mock.lockRevokeTokensByClientID.RUnlock()
+	return calls
+}
+
+// RevokeTokensByUserAndClient calls RevokeTokensByUserAndClientFunc.
+func (mock *TokenRepositoryMock) RevokeTokensByUserAndClient(ctx context.Context, userID uuid.UUID, clientID uuid.UUID) error {
+	if mock.RevokeTokensByUserAndClientFunc == nil {
+		panic("TokenRepositoryMock.RevokeTokensByUserAndClientFunc: method is nil but TokenRepository.RevokeTokensByUserAndClient was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		ClientID: clientID,
+	}
+	mock.lockRevokeTokensByUserAndClient.Lock()
+	mock.calls.RevokeTokensByUserAndClient = append(mock.calls.RevokeTokensByUserAndClient, callInfo)
+	mock.lockRevokeTokensByUserAndClient.Unlock()
+	return mock.RevokeTokensByUserAndClientFunc(ctx, userID, clientID)
+}
+
+// RevokeTokensByUserAndClientCalls gets all the calls that were made to RevokeTokensByUserAndClient.
+// Check the length with:
+//
+//	len(mockedTokenRepository.RevokeTokensByUserAndClientCalls())
+func (mock *TokenRepositoryMock) RevokeTokensByUserAndClientCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	ClientID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		ClientID uuid.UUID
+	}
+	mock.lockRevokeTokensByUserAndClient.RLock()
+	calls = mock.calls.RevokeTokensByUserAndClient
+	mock.lockRevokeTokensByUserAndClient.RUnlock()
+	return calls
+}
+
+// RevokeTokensByUserID calls RevokeTokensByUserIDFunc.
+func (mock *TokenRepositoryMock) RevokeTokensByUserID(ctx context.Context, userID uuid.UUID) error {
+	if mock.RevokeTokensByUserIDFunc == nil {
+		panic("TokenRepositoryMock.RevokeTokensByUserIDFunc: method is nil but TokenRepository.RevokeTokensByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeTokensByUserID.Lock()
+	mock.calls.RevokeTokensByUserID = append(mock.calls.RevokeTokensByUserID, callInfo)
+	mock.lockRevokeTokensByUserID.Unlock()
+	return mock.RevokeTokensByUserIDFunc(ctx, userID)
+}
+
+// RevokeTokensByUserIDCalls gets all the calls that were made to RevokeTokensByUserID.
+// Check the length with:
+//
+//	len(mockedTokenRepository.RevokeTokensByUserIDCalls())
+func (mock *TokenRepositoryMock) RevokeTokensByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockRevokeTokensByUserID.RLock()
+	calls = mock.calls.RevokeTokensByUserID
+	mock.lockRevokeTokensByUserID.RUnlock()
+	return calls
+}
+
 // StoreToken calls StoreTokenFunc.
 func (mock *TokenRepositoryMock) StoreToken(ctx context.Context, token Token) error {
 	if mock.StoreTokenFunc == nil {
@@ -179,3 +403,43 @@ func (mock *TokenRepositoryMock) StoreTokenCalls() []struct {
 	mock.lockStoreToken.RUnlock()
 	return calls
 }
+
+// SummarizeTokensByUserID calls SummarizeTokensByUserIDFunc.
+func (mock *TokenRepositoryMock) SummarizeTokensByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]TokenUsage, error) {
+	if mock.SummarizeTokensByUserIDFunc == nil {
+		panic("TokenRepositoryMock.SummarizeTokensByUserIDFunc: method is nil but TokenRepository.SummarizeTokensByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Now    time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Now:    now,
+	}
+	mock.lockSummarizeTokensByUserID.Lock()
+	mock.calls.SummarizeTokensByUserID = append(mock.calls.SummarizeTokensByUserID, callInfo)
+	mock.lockSummarizeTokensByUserID.Unlock()
+	return mock.SummarizeTokensByUserIDFunc(ctx, userID, now)
+}
+
+// SummarizeTokensByUserIDCalls gets all the calls that were made to SummarizeTokensByUserID.
+// Check the length with:
+//
+//	len(mockedTokenRepository.SummarizeTokensByUserIDCalls())
+func (mock *TokenRepositoryMock) SummarizeTokensByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Now    time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Now    time.Time
+	}
+	mock.lockSummarizeTokensByUserID.RLock()
+	calls = mock.calls.SummarizeTokensByUserID
+	mock.lockSummarizeTokensByUserID.RUnlock()
+	return calls
+}
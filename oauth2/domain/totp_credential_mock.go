@@ -0,0 +1,398 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Ensure, that TOTPCredentialMock does implement TOTPCredential.
+// If this is not the case, regenerate this file with moq.
+var _ TOTPCredential = &TOTPCredentialMock{}
+
+// TOTPCredentialMock is a mock implementation of TOTPCredential.
+//
+//	func TestSomethingThatUsesTOTPCredential(t *testing.T) {
+//
+//		// make and configure a mocked TOTPCredential
+//		mockedTOTPCredential := &TOTPCredentialMock{
+//			ConfirmFunc: func(now time.Time)  {
+//				panic("mock out the Confirm method")
+//			},
+//			GetConfirmedAtFunc: func() *time.Time {
+//				panic("mock out the GetConfirmedAt method")
+//			},
+//			GetCreatedAtFunc: func() time.Time {
+//				panic("mock out the GetCreatedAt method")
+//			},
+//			GetLastUsedStepFunc: func() int64 {
+//				panic("mock out the GetLastUsedStep method")
+//			},
+//			GetSecretFunc: func() string {
+//				panic("mock out the GetSecret method")
+//			},
+//			GetUserIDFunc: func() uuid.UUID {
+//				panic("mock out the GetUserID method")
+//			},
+//			IsConfirmedFunc: func() bool {
+//				panic("mock out the IsConfirmed method")
+//			},
+//			KeyURIFunc: func(issuer string, account string) string {
+//				panic("mock out the KeyURI method")
+//			},
+//			MatchFunc: func(code string, now time.Time) (int64, bool) {
+//				panic("mock out the Match method")
+//			},
+//		}
+//
+//		// use mockedTOTPCredential in code that requires TOTPCredential
+//		// and then make assertions.
+//
+//	}
+type TOTPCredentialMock struct {
+	// ConfirmFunc mocks the Confirm method.
+	ConfirmFunc func(now time.Time)
+
+	// GetConfirmedAtFunc mocks the GetConfirmedAt method.
+	GetConfirmedAtFunc func() *time.Time
+
+	// GetCreatedAtFunc mocks the GetCreatedAt method.
+	GetCreatedAtFunc func() time.Time
+
+	// GetLastUsedStepFunc mocks the GetLastUsedStep method.
+	GetLastUsedStepFunc func() int64
+
+	// GetSecretFunc mocks the GetSecret method.
+	GetSecretFunc func() string
+
+	// GetUserIDFunc mocks the GetUserID method.
+	GetUserIDFunc func() uuid.UUID
+
+	// IsConfirmedFunc mocks the IsConfirmed method.
+	IsConfirmedFunc func() bool
+
+	// KeyURIFunc mocks the KeyURI method.
+	KeyURIFunc func(issuer string, account string) string
+
+	// MatchFunc mocks the Match method.
+	MatchFunc func(code string, now time.Time) (int64, bool)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Confirm holds details about calls to the Confirm method.
+		Confirm []struct {
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// GetConfirmedAt holds details about calls to the GetConfirmedAt method.
+		GetConfirmedAt []struct {
+		}
+		// GetCreatedAt holds details about calls to the GetCreatedAt method.
+		GetCreatedAt []struct {
+		}
+		// GetLastUsedStep holds details about calls to the GetLastUsedStep method.
+		GetLastUsedStep []struct {
+		}
+		// GetSecret holds details about calls to the GetSecret method.
+		GetSecret []struct {
+		}
+		// GetUserID holds details about calls to the GetUserID method.
+		GetUserID []struct {
+		}
+		// IsConfirmed holds details about calls to the IsConfirmed method.
+		IsConfirmed []struct {
+		}
+		// KeyURI holds details about calls to the KeyURI method.
+		KeyURI []struct {
+			// Issuer is the issuer argument value.
+			Issuer string
+			// Account is the account argument value.
+			Account string
+		}
+		// Match holds details about calls to the Match method.
+		Match []struct {
+			// Code is the code argument value.
+			Code string
+			// Now is the now argument value.
+			Now time.Time
+		}
+	}
+	lockConfirm         sync.RWMutex
+	lockGetConfirmedAt  sync.RWMutex
+	lockGetCreatedAt    sync.RWMutex
+	lockGetLastUsedStep sync.RWMutex
+	lockGetSecret       sync.RWMutex
+	lockGetUserID       sync.RWMutex
+	lockIsConfirmed     sync.RWMutex
+	lockKeyURI          sync.RWMutex
+	lockMatch           sync.RWMutex
+}
+
+// Confirm calls ConfirmFunc.
+func (mock *TOTPCredentialMock) Confirm(now time.Time) {
+	if mock.ConfirmFunc == nil {
+		panic("TOTPCredentialMock.ConfirmFunc: method is nil but TOTPCredential.Confirm was just called")
+	}
+	callInfo := struct {
+		Now time.Time
+	}{
+		Now: now,
+	}
+	mock.lockConfirm.Lock()
+	mock.calls.Confirm = append(mock.calls.Confirm, callInfo)
+	mock.lockConfirm.Unlock()
+	mock.ConfirmFunc(now)
+}
+
+// ConfirmCalls gets all the calls that were made to Confirm.
+// Check the length with:
+//
+//	len(mockedTOTPCredential.ConfirmCalls())
+func (mock *TOTPCredentialMock) ConfirmCalls() []struct {
+	Now time.Time
+} {
+	var calls []struct {
+		Now time.Time
+	}
+	mock.lockConfirm.RLock()
+	calls = mock.calls.Confirm
+	mock.lockConfirm.RUnlock()
+	return calls
+}
+
+// GetConfirmedAt calls GetConfirmedAtFunc.
+func (mock *TOTPCredentialMock) GetConfirmedAt() *time.Time {
+	if mock.GetConfirmedAtFunc == nil {
+		panic("TOTPCredentialMock.GetConfirmedAtFunc: method is nil but TOTPCredential.GetConfirmedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetConfirmedAt.Lock()
+	mock.calls.GetConfirmedAt = append(mock.calls.GetConfirmedAt, callInfo)
+	mock.lockGetConfirmedAt.Unlock()
+	return mock.GetConfirmedAtFunc()
+}
+
+// GetConfirmedAtCalls gets all the calls that were made to GetConfirmedAt.
+// Check the length with:
+//
+//	len(mockedTOTPCredential.GetConfirmedAtCalls())
+func (mock *TOTPCredentialMock) GetConfirmedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetConfirmedAt.RLock()
+	calls = mock.calls.GetConfirmedAt
+	mock.lockGetConfirmedAt.RUnlock()
+	return calls
+}
+
+// GetCreatedAt calls GetCreatedAtFunc.
+func (mock *TOTPCredentialMock) GetCreatedAt() time.Time {
+	if mock.GetCreatedAtFunc == nil {
+		panic("TOTPCredentialMock.GetCreatedAtFunc: method is nil but TOTPCredential.GetCreatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCreatedAt.Lock()
+	mock.calls.GetCreatedAt = append(mock.calls.GetCreatedAt, callInfo)
+	mock.lockGetCreatedAt.Unlock()
+	return mock.GetCreatedAtFunc()
+}
+
+// GetCreatedAtCalls gets all the calls that were made to GetCreatedAt.
+// Check the length with:
+//
+//	len(mockedTOTPCredential.GetCreatedAtCalls())
+func (mock *TOTPCredentialMock) GetCreatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCreatedAt.RLock()
+	calls = mock.calls.GetCreatedAt
+	mock.lockGetCreatedAt.RUnlock()
+	return calls
+}
+
+// GetLastUsedStep calls GetLastUsedStepFunc.
+func (mock *TOTPCredentialMock) GetLastUsedStep() int64 {
+	if mock.GetLastUsedStepFunc == nil {
+		panic("TOTPCredentialMock.GetLastUsedStepFunc: method is nil but TOTPCredential.GetLastUsedStep was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetLastUsedStep.Lock()
+	mock.calls.GetLastUsedStep = append(mock.calls.GetLastUsedStep, callInfo)
+	mock.lockGetLastUsedStep.Unlock()
+	return mock.GetLastUsedStepFunc()
+}
+
+// GetLastUsedStepCalls gets all the calls that were made to GetLastUsedStep.
+// Check the length with:
+//
+//	len(mockedTOTPCredential.GetLastUsedStepCalls())
+func (mock *TOTPCredentialMock) GetLastUsedStepCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetLastUsedStep.RLock()
+	calls = mock.calls.GetLastUsedStep
+	mock.lockGetLastUsedStep.RUnlock()
+	return calls
+}
+
+// GetSecret calls GetSecretFunc.
+func (mock *TOTPCredentialMock) GetSecret() string {
+	if mock.GetSecretFunc == nil {
+		panic("TOTPCredentialMock.GetSecretFunc: method is nil but TOTPCredential.GetSecret was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetSecret.Lock()
+	mock.calls.GetSecret = append(mock.calls.GetSecret, callInfo)
+	mock.lockGetSecret.Unlock()
+	return mock.GetSecretFunc()
+}
+
+// GetSecretCalls gets all the calls that were made to GetSecret.
+// Check the length with:
+//
+//	len(mockedTOTPCredential.GetSecretCalls())
+func (mock *TOTPCredentialMock) GetSecretCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetSecret.RLock()
+	calls = mock.calls.GetSecret
+	mock.lockGetSecret.RUnlock()
+	return calls
+}
+
+// GetUserID calls GetUserIDFunc.
+func (mock *TOTPCredentialMock) GetUserID() uuid.UUID {
+	if mock.GetUserIDFunc == nil {
+		panic("TOTPCredentialMock.GetUserIDFunc: method is nil but TOTPCredential.GetUserID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetUserID.Lock()
+	mock.calls.GetUserID = append(mock.calls.GetUserID, callInfo)
+	mock.lockGetUserID.Unlock()
+	return mock.GetUserIDFunc()
+}
+
+// GetUserIDCalls gets all the calls that were made to GetUserID.
+// Check the length with:
+//
+//	len(mockedTOTPCredential.GetUserIDCalls())
+func (mock *TOTPCredentialMock) GetUserIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetUserID.RLock()
+	calls = mock.calls.GetUserID
+	mock.lockGetUserID.RUnlock()
+	return calls
+}
+
+// IsConfirmed calls IsConfirmedFunc.
+func (mock *TOTPCredentialMock) IsConfirmed() bool {
+	if mock.IsConfirmedFunc == nil {
+		panic("TOTPCredentialMock.IsConfirmedFunc: method is nil but TOTPCredential.IsConfirmed was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsConfirmed.Lock()
+	mock.calls.IsConfirmed = append(mock.calls.IsConfirmed, callInfo)
+	mock.lockIsConfirmed.Unlock()
+	return mock.IsConfirmedFunc()
+}
+
+// IsConfirmedCalls gets all the calls that were made to IsConfirmed.
+// Check the length with:
+//
+//	len(mockedTOTPCredential.IsConfirmedCalls())
+func (mock *TOTPCredentialMock) IsConfirmedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsConfirmed.RLock()
+	calls = mock.calls.IsConfirmed
+	mock.lockIsConfirmed.RUnlock()
+	return calls
+}
+
+// KeyURI calls KeyURIFunc.
+func (mock *TOTPCredentialMock) KeyURI(issuer string, account string) string {
+	if mock.KeyURIFunc == nil {
+		panic("TOTPCredentialMock.KeyURIFunc: method is nil but TOTPCredential.KeyURI was just called")
+	}
+	callInfo := struct {
+		Issuer  string
+		Account string
+	}{
+		Issuer:  issuer,
+		Account: account,
+	}
+	mock.lockKeyURI.Lock()
+	mock.calls.KeyURI = append(mock.calls.KeyURI, callInfo)
+	mock.lockKeyURI.Unlock()
+	return mock.KeyURIFunc(issuer, account)
+}
+
+// KeyURICalls gets all the calls that were made to KeyURI.
+// Check the length with:
+//
+//	len(mockedTOTPCredential.KeyURICalls())
+func (mock *TOTPCredentialMock) KeyURICalls() []struct {
+	Issuer  string
+	Account string
+} {
+	var calls []struct {
+		Issuer  string
+		Account string
+	}
+	mock.lockKeyURI.RLock()
+	calls = mock.calls.KeyURI
+	mock.lockKeyURI.RUnlock()
+	return calls
+}
+
+// Match calls MatchFunc.
+func (mock *TOTPCredentialMock) Match(code string, now time.Time) (int64, bool) {
+	if mock.MatchFunc == nil {
+		panic("TOTPCredentialMock.MatchFunc: method is nil but TOTPCredential.Match was just called")
+	}
+	callInfo := struct {
+		Code string
+		Now  time.Time
+	}{
+		Code: code,
+		Now:  now,
+	}
+	mock.lockMatch.Lock()
+	mock.calls.Match = append(mock.calls.Match, callInfo)
+	mock.lockMatch.Unlock()
+	return mock.MatchFunc(code, now)
+}
+
+// MatchCalls gets all the calls that were made to Match.
+// Check the length with:
+//
+//	len(mockedTOTPCredential.MatchCalls())
+func (mock *TOTPCredentialMock) MatchCalls() []struct {
+	Code string
+	Now  time.Time
+} {
+	var calls []struct {
+		Code string
+		Now  time.Time
+	}
+	mock.lockMatch.RLock()
+	calls = mock.calls.Match
+	mock.lockMatch.RUnlock()
+	return calls
+}
@@ -0,0 +1,238 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package domain
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+)
+
+// Ensure, that TOTPCredentialRepositoryMock does implement TOTPCredentialRepository.
+// If this is not the case, regenerate this file with moq.
+var _ TOTPCredentialRepository = &TOTPCredentialRepositoryMock{}
+
+// TOTPCredentialRepositoryMock is a mock implementation of TOTPCredentialRepository.
+//
+//	func TestSomethingThatUsesTOTPCredentialRepository(t *testing.T) {
+//
+//		// make and configure a mocked TOTPCredentialRepository
+//		mockedTOTPCredentialRepository := &TOTPCredentialRepositoryMock{
+//			DeleteTOTPCredentialFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the DeleteTOTPCredential method")
+//			},
+//			FindTOTPCredentialFunc: func(ctx context.Context, userID uuid.UUID) (TOTPCredential, error) {
+//				panic("mock out the FindTOTPCredential method")
+//			},
+//			StoreTOTPCredentialFunc: func(ctx context.Context, c TOTPCredential) error {
+//				panic("mock out the StoreTOTPCredential method")
+//			},
+//			UseTOTPStepFunc: func(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+//				panic("mock out the UseTOTPStep method")
+//			},
+//		}
+//
+//		// use mockedTOTPCredentialRepository in code that requires TOTPCredentialRepository
+//		// and then make assertions.
+//
+//	}
+type TOTPCredentialRepositoryMock struct {
+	// DeleteTOTPCredentialFunc mocks the DeleteTOTPCredential method.
+	DeleteTOTPCredentialFunc func(ctx context.Context, userID uuid.UUID) error
+
+	// FindTOTPCredentialFunc mocks the FindTOTPCredential method.
+	FindTOTPCredentialFunc func(ctx context.Context, userID uuid.UUID) (TOTPCredential, error)
+
+	// StoreTOTPCredentialFunc mocks the StoreTOTPCredential method.
+	StoreTOTPCredentialFunc func(ctx context.Context, c TOTPCredential) error
+
+	// UseTOTPStepFunc mocks the UseTOTPStep method.
+	UseTOTPStepFunc func(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteTOTPCredential holds details about calls to the DeleteTOTPCredential method.
+		DeleteTOTPCredential []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// FindTOTPCredential holds details about calls to the FindTOTPCredential method.
+		FindTOTPCredential []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// StoreTOTPCredential holds details about calls to the StoreTOTPCredential method.
+		StoreTOTPCredential []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// C is the c argument value.
+			C TOTPCredential
+		}
+		// UseTOTPStep holds details about calls to the UseTOTPStep method.
+		UseTOTPStep []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Step is the step argument value.
+			Step int64
+		}
+	}
+	lockDeleteTOTPCredential sync.RWMutex
+	lockFindTOTPCredential   sync.RWMutex
+	lockStoreTOTPCredential  sync.RWMutex
+	lockUseTOTPStep          sync.RWMutex
+}
+
+// DeleteTOTPCredential calls DeleteTOTPCredentialFunc.
+func (mock *TOTPCredentialRepositoryMock) DeleteTOTPCredential(ctx context.Context, userID uuid.UUID) error {
+	if mock.DeleteTOTPCredentialFunc == nil {
+		panic("TOTPCredentialRepositoryMock.DeleteTOTPCredentialFunc: method is nil but TOTPCredentialRepository.DeleteTOTPCredential was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteTOTPCredential.Lock()
+	mock.calls.DeleteTOTPCredential = append(mock.calls.DeleteTOTPCredential, callInfo)
+	mock.lockDeleteTOTPCredential.Unlock()
+	return mock.DeleteTOTPCredentialFunc(ctx, userID)
+}
+
+// DeleteTOTPCredentialCalls gets all the calls that were made to DeleteTOTPCredential.
+// Check the length with:
+//
+//	len(mockedTOTPCredentialRepository.DeleteTOTPCredentialCalls())
+func (mock *TOTPCredentialRepositoryMock) DeleteTOTPCredentialCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockDeleteTOTPCredential.RLock()
+	calls = mock.calls.DeleteTOTPCredential
+	mock.lockDeleteTOTPCredential.RUnlock()
+	return calls
+}
+
+// FindTOTPCredential calls FindTOTPCredentialFunc.
+func (mock *TOTPCredentialRepositoryMock) FindTOTPCredential(ctx context.Context, userID uuid.UUID) (TOTPCredential, error) {
+	if mock.FindTOTPCredentialFunc == nil {
+		panic("TOTPCredentialRepositoryMock.FindTOTPCredentialFunc: method is nil but TOTPCredentialRepository.FindTOTPCredential was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockFindTOTPCredential.Lock()
+	mock.calls.FindTOTPCredential = append(mock.calls.FindTOTPCredential, callInfo)
+	mock.lockFindTOTPCredential.Unlock()
+	return mock.FindTOTPCredentialFunc(ctx, userID)
+}
+
+// FindTOTPCredentialCalls gets all the calls that were made to FindTOTPCredential.
+// Check the length with:
+//
+//	len(mockedTOTPCredentialRepository.FindTOTPCredentialCalls())
+func (mock *TOTPCredentialRepositoryMock) FindTOTPCredentialCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockFindTOTPCredential.RLock()
+	calls = mock.calls.FindTOTPCredential
+	mock.lockFindTOTPCredential.RUnlock()
+	return calls
+}
+
+// StoreTOTPCredential calls StoreTOTPCredentialFunc.
+func (mock *TOTPCredentialRepositoryMock) StoreTOTPCredential(ctx context.Context, c TOTPCredential) error {
+	if mock.StoreTOTPCredentialFunc == nil {
+		panic("TOTPCredentialRepositoryMock.StoreTOTPCredentialFunc: method is nil but TOTPCredentialRepository.StoreTOTPCredential was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		C   TOTPCredential
+	}{
+		Ctx: ctx,
+		C:   c,
+	}
+	mock.lockStoreTOTPCredential.Lock()
+	mock.calls.StoreTOTPCredential = append(mock.calls.StoreTOTPCredential, callInfo)
+	mock.lockStoreTOTPCredential.Unlock()
+	return mock.StoreTOTPCredentialFunc(ctx, c)
+}
+
+// StoreTOTPCredentialCalls gets all the calls that were made to StoreTOTPCredential.
+// Check the length with:
+//
+//	len(mockedTOTPCredentialRepository.StoreTOTPCredentialCalls())
+func (mock *TOTPCredentialRepositoryMock) StoreTOTPCredentialCalls() []struct {
+	Ctx context.Context
+	C   TOTPCredential
+} {
+	var calls []struct {
+		Ctx context.Context
+		C   TOTPCredential
+	}
+	mock.lockStoreTOTPCredential.RLock()
+	calls = mock.calls.StoreTOTPCredential
+	mock.lockStoreTOTPCredential.RUnlock()
+	return calls
+}
+
+// UseTOTPStep calls UseTOTPStepFunc.
+func (mock *TOTPCredentialRepositoryMock) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	if mock.UseTOTPStepFunc == nil {
+		panic("TOTPCredentialRepositoryMock.UseTOTPStepFunc: method is nil but TOTPCredentialRepository.UseTOTPStep was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Step   int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Step:   step,
+	}
+	mock.lockUseTOTPStep.Lock()
+	mock.calls.UseTOTPStep = append(mock.calls.UseTOTPStep, callInfo)
+	mock.lockUseTOTPStep.Unlock()
+	return mock.UseTOTPStepFunc(ctx, userID, step)
+}
+
+// UseTOTPStepCalls gets all the calls that were made to UseTOTPStep.
+// Check the length with:
+//
+//	len(mockedTOTPCredentialRepository.UseTOTPStepCalls())
+func (mock *TOTPCredentialRepositoryMock) UseTOTPStepCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Step   int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Step   int64
+	}
+	mock.lockUseTOTPStep.RLock()
+	calls = mock.calls.UseTOTPStep
+	mock.lockUseTOTPStep.RUnlock()
+	return calls
+}
@@ -5,58 +5,172 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+var (
+	ErrUserEmailAlreadyExists = errors.New("email is already registered")
+	ErrUserNotFound           = errors.New("user not found")
 )
 
 type UserParams struct {
-	ID        uuid.UUID
-	Name      string
-	Email     string
-	Password  string
+	ID              uuid.UUID
+	Name            string
+	Email           string
+	Password        string
+	EmailVerifiedAt *time.Time
+	// PasswordResetRequired が true のユーザーは、パスワードを再設定するまでパスワードでサインインできない
+	PasswordResetRequired bool
+	DisabledAt            *time.Time
+	// IsAdmin が true のユーザーだけが admin スコープのアクセストークンで管理用のAPIを呼び出せる
+	IsAdmin   bool
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
 func NewUser(p UserParams) User {
 	return &user{
-		ID:        p.ID,
-		Name:      p.Name,
-		Email:     p.Email,
-		Password:  p.Password,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+		ID:                    p.ID,
+		Name:                  p.Name,
+		Email:                 p.Email,
+		Password:              p.Password,
+		EmailVerifiedAt:       p.EmailVerifiedAt,
+		PasswordResetRequired: p.PasswordResetRequired,
+		DisabledAt:            p.DisabledAt,
+		Admin:                 p.IsAdmin,
+		CreatedAt:             p.CreatedAt,
+		UpdatedAt:             p.UpdatedAt,
 	}
 }
 
 //go:generate go run github.com/matryer/moq -out user_mock.go . User
 type User interface {
 	GetID() uuid.UUID
+	GetName() string
+	GetEmail() string
+	GetPassword() string
 	IsNotFound() bool
-	IsPasswordMatch(password string) bool
+	IsEmailVerified() bool
+	GetEmailVerifiedAt() *time.Time
+	IsPasswordResetRequired() bool
+	// IsDisabled は管理者が無効にしたユーザーかを判定する。無効なユーザーはサインインもトークンの更新もできない
+	IsDisabled() bool
+	GetDisabledAt() *time.Time
+	// IsAdmin は管理用のAPIを呼び出せるユーザーかを判定する
+	IsAdmin() bool
+	GetCreatedAt() time.Time
+	GetUpdatedAt() time.Time
+	IsPasswordMatch(h PasswordHasher, password string) bool
+	SetPassword(policy PasswordPolicy, h PasswordHasher, password string) error
 }
 
 //go:generate go run github.com/matryer/moq -out user_repository_mock.go . UserRepository
 type UserRepository interface {
 	FindUserByEmail(ctx context.Context, email string) (User, error)
+	FindUserByID(ctx context.Context, id uuid.UUID) (User, error)
+	VerifyEmail(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error
+	// UpdatePassword はハッシュ化済みのパスワードを保存する
+	UpdatePassword(ctx context.Context, u User) error
+	// UpdatePasswordHash は保存されたハッシュが oldHash のままの場合のみ newHash に置き換える。置き換えなかった場合は false を返す
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) (bool, error)
+	// CreateUser はユーザーを登録する。メールアドレスが登録済みの場合は ErrUserEmailAlreadyExists を返す
+	CreateUser(ctx context.Context, u User) (User, error)
+	// UpdateProfile は名前とメールアドレスと確認した日時を変更する。emailVerifiedAt が nil の場合は未確認に戻す。
+	// メールアドレスが他のユーザーに登録済みの場合は ErrUserEmailAlreadyExists を、ユーザーがいない場合は ErrUserNotFound を返す
+	UpdateProfile(ctx context.Context, id uuid.UUID, name, email string, emailVerifiedAt *time.Time) error
+	// SearchUsers はメールアドレスか名前に query を含むユーザーを作成順に offset 件目から limit 件返す。query が空の場合は全てのユーザーを対象にする
+	SearchUsers(ctx context.Context, query string, offset, limit int) ([]User, error)
+	// UpdateDisabledAt は無効にした日時を保存する。nil の場合は有効に戻す
+	UpdateDisabledAt(ctx context.Context, id uuid.UUID, disabledAt *time.Time) error
+	// UpdateAdmin は管理用のAPIを呼び出せるかを変更する
+	UpdateAdmin(ctx context.Context, id uuid.UUID, isAdmin bool) error
+	// RequirePasswordReset は次にパスワードを設定するまでパスワードでサインインできないようにする。UpdatePassword で解除される
+	RequirePasswordReset(ctx context.Context, id uuid.UUID) error
+	// DeleteUser はユーザーと、ユーザーに発行した認可コードとトークンを削除する
+	DeleteUser(ctx context.Context, id uuid.UUID) error
 }
 
 type user struct {
-	ID        uuid.UUID
-	Name      string
-	Email     string
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                    uuid.UUID
+	Name                  string
+	Email                 string
+	Password              string
+	EmailVerifiedAt       *time.Time
+	PasswordResetRequired bool
+	DisabledAt            *time.Time
+	Admin                 bool
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
 }
 
 func (u *user) GetID() uuid.UUID {
 	return u.ID
 }
 
+func (u *user) GetName() string {
+	return u.Name
+}
+
+func (u *user) GetEmail() string {
+	return u.Email
+}
+
+// GetPassword はハッシュ化されたパスワードを返す
+func (u *user) GetPassword() string {
+	return u.Password
+}
+
 func (u *user) IsNotFound() bool {
 	return u.ID == uuid.Nil
 }
 
-func (u *user) IsPasswordMatch(password string) bool {
-	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)) == nil
+func (u *user) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *user) GetEmailVerifiedAt() *time.Time {
+	return u.EmailVerifiedAt
+}
+
+func (u *user) IsPasswordResetRequired() bool {
+	return u.PasswordResetRequired
+}
+
+func (u *user) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+func (u *user) GetDisabledAt() *time.Time {
+	return u.DisabledAt
+}
+
+func (u *user) IsAdmin() bool {
+	return u.Admin
+}
+
+func (u *user) GetCreatedAt() time.Time {
+	return u.CreatedAt
+}
+
+func (u *user) GetUpdatedAt() time.Time {
+	return u.UpdatedAt
+}
+
+// IsPasswordMatch は保存されたハッシュと照合する。ハッシュが壊れている場合も一致しないものとして扱う
+func (u *user) IsPasswordMatch(h PasswordHasher, password string) bool {
+	ok, err := h.Verify(u.Password, password)
+	return err == nil && ok
+}
+
+// SetPassword はパスワードを規則で検証し、ハッシュにして設定する
+func (u *user) SetPassword(policy PasswordPolicy, h PasswordHasher, password string) error {
+	if err := policy.Validate(password, u.Email); err != nil {
+		return err
+	}
+	hashed, err := h.Hash(password)
+	if err != nil {
+		return err
+	}
+	u.Password = hashed
+	return nil
 }
@@ -6,6 +6,7 @@ package domain
 import (
 	"github.com/google/uuid"
 	"sync"
+	"time"
 )
 
 // Ensure, that UserMock does implement User.
@@ -18,15 +19,51 @@ var _ User = &UserMock{}
 //
 //		// make and configure a mocked User
 //		mockedUser := &UserMock{
+//			GetCreatedAtFunc: func() time.Time {
+//				panic("mock out the GetCreatedAt method")
+//			},
+//			GetDisabledAtFunc: func() *time.Time {
+//				panic("mock out the GetDisabledAt method")
+//			},
+//			GetEmailFunc: func() string {
+//				panic("mock out the GetEmail method")
+//			},
+//			GetEmailVerifiedAtFunc: func() *time.Time {
+//				panic("mock out the GetEmailVerifiedAt method")
+//			},
 //			GetIDFunc: func() uuid.UUID {
 //				panic("mock out the GetID method")
 //			},
+//			GetNameFunc: func() string {
+//				panic("mock out the GetName method")
+//			},
+//			GetPasswordFunc: func() string {
+//				panic("mock out the GetPassword method")
+//			},
+//			GetUpdatedAtFunc: func() time.Time {
+//				panic("mock out the GetUpdatedAt method")
+//			},
+//			IsAdminFunc: func() bool {
+//				panic("mock out the IsAdmin method")
+//			},
+//			IsDisabledFunc: func() bool {
+//				panic("mock out the IsDisabled method")
+//			},
+//			IsEmailVerifiedFunc: func() bool {
+//				panic("mock out the IsEmailVerified method")
+//			},
 //			IsNotFoundFunc: func() bool {
 //				panic("mock out the IsNotFound method")
 //			},
-//			IsPasswordMatchFunc: func(password string) bool {
+//			IsPasswordMatchFunc: func(h PasswordHasher, password string) bool {
 //				panic("mock out the IsPasswordMatch method")
 //			},
+//			IsPasswordResetRequiredFunc: func() bool {
+//				panic("mock out the IsPasswordResetRequired method")
+//			},
+//			SetPasswordFunc: func(policy PasswordPolicy, h PasswordHasher, password string) error {
+//				panic("mock out the SetPassword method")
+//			},
 //		}
 //
 //		// use mockedUser in code that requires User
@@ -34,32 +71,232 @@ var _ User = &UserMock{}
 //
 //	}
 type UserMock struct {
+	// GetCreatedAtFunc mocks the GetCreatedAt method.
+	GetCreatedAtFunc func() time.Time
+
+	// GetDisabledAtFunc mocks the GetDisabledAt method.
+	GetDisabledAtFunc func() *time.Time
+
+	// GetEmailFunc mocks the GetEmail method.
+	GetEmailFunc func() string
+
+	// GetEmailVerifiedAtFunc mocks the GetEmailVerifiedAt method.
+	GetEmailVerifiedAtFunc func() *time.Time
+
 	// GetIDFunc mocks the GetID method.
 	GetIDFunc func() uuid.UUID
 
+	// GetNameFunc mocks the GetName method.
+	GetNameFunc func() string
+
+	// GetPasswordFunc mocks the GetPassword method.
+	GetPasswordFunc func() string
+
+	// GetUpdatedAtFunc mocks the GetUpdatedAt method.
+	GetUpdatedAtFunc func() time.Time
+
+	// IsAdminFunc mocks the IsAdmin method.
+	IsAdminFunc func() bool
+
+	// IsDisabledFunc mocks the IsDisabled method.
+	IsDisabledFunc func() bool
+
+	// IsEmailVerifiedFunc mocks the IsEmailVerified method.
+	IsEmailVerifiedFunc func() bool
+
 	// IsNotFoundFunc mocks the IsNotFound method.
 	IsNotFoundFunc func() bool
 
 	// IsPasswordMatchFunc mocks the IsPasswordMatch method.
-	IsPasswordMatchFunc func(password string) bool
+	IsPasswordMatchFunc func(h PasswordHasher, password string) bool
+
+	// IsPasswordResetRequiredFunc mocks the IsPasswordResetRequired method.
+	IsPasswordResetRequiredFunc func() bool
+
+	// SetPasswordFunc mocks the SetPassword method.
+	SetPasswordFunc func(policy PasswordPolicy, h PasswordHasher, password string) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// GetCreatedAt holds details about calls to the GetCreatedAt method.
+		GetCreatedAt []struct {
+		}
+		// GetDisabledAt holds details about calls to the GetDisabledAt method.
+		GetDisabledAt []struct {
+		}
+		// GetEmail holds details about calls to the GetEmail method.
+		GetEmail []struct {
+		}
+		// GetEmailVerifiedAt holds details about calls to the GetEmailVerifiedAt method.
+		GetEmailVerifiedAt []struct {
+		}
 		// GetID holds details about calls to the GetID method.
 		GetID []struct {
 		}
+		// GetName holds details about calls to the GetName method.
+		GetName []struct {
+		}
+		// GetPassword holds details about calls to the GetPassword method.
+		GetPassword []struct {
+		}
+		// GetUpdatedAt holds details about calls to the GetUpdatedAt method.
+		GetUpdatedAt []struct {
+		}
+		// IsAdmin holds details about calls to the IsAdmin method.
+		IsAdmin []struct {
+		}
+		// IsDisabled holds details about calls to the IsDisabled method.
+		IsDisabled []struct {
+		}
+		// IsEmailVerified holds details about calls to the IsEmailVerified method.
+		IsEmailVerified []struct {
+		}
 		// IsNotFound holds details about calls to the IsNotFound method.
 		IsNotFound []struct {
 		}
 		// IsPasswordMatch holds details about calls to the IsPasswordMatch method.
 		IsPasswordMatch []struct {
+			// H is the h argument value.
+			H PasswordHasher
+			// Password is the password argument value.
+			Password string
+		}
+		// IsPasswordResetRequired holds details about calls to the IsPasswordResetRequired method.
+		IsPasswordResetRequired []struct {
+		}
+		// SetPassword holds details about calls to the SetPassword method.
+		SetPassword []struct {
+			// Policy is the policy argument value.
+			Policy PasswordPolicy
+			// H is the h argument value.
+			H PasswordHasher
 			// Password is the password argument value.
 			Password string
 		}
 	}
-	lockGetID           sync.RWMutex
-	lockIsNotFound      sync.RWMutex
-	lockIsPasswordMatch sync.RWMutex
+	lockGetCreatedAt            sync.RWMutex
+	lockGetDisabledAt           sync.RWMutex
+	lockGetEmail                sync.RWMutex
+	lockGetEmailVerifiedAt      sync.RWMutex
+	lockGetID                   sync.RWMutex
+	lockGetName                 sync.RWMutex
+	lockGetPassword             sync.RWMutex
+	lockGetUpdatedAt            sync.RWMutex
+	lockIsAdmin                 sync.RWMutex
+	lockIsDisabled              sync.RWMutex
+	lockIsEmailVerified         sync.RWMutex
+	lockIsNotFound              sync.RWMutex
+	lockIsPasswordMatch         sync.RWMutex
+	lockIsPasswordResetRequired sync.RWMutex
+	lockSetPassword             sync.RWMutex
+}
+
+// GetCreatedAt calls GetCreatedAtFunc.
+func (mock *UserMock) GetCreatedAt() time.Time {
+	if mock.GetCreatedAtFunc == nil {
+		panic("UserMock.GetCreatedAtFunc: method is nil but User.GetCreatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCreatedAt.Lock()
+	mock.calls.GetCreatedAt = append(mock.calls.GetCreatedAt, callInfo)
+	mock.lockGetCreatedAt.Unlock()
+	return mock.GetCreatedAtFunc()
+}
+
+// GetCreatedAtCalls gets all the calls that were made to GetCreatedAt.
+// Check the length with:
+//
+//	len(mockedUser.GetCreatedAtCalls())
+func (mock *UserMock) GetCreatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCreatedAt.RLock()
+	calls = mock.calls.GetCreatedAt
+	mock.lockGetCreatedAt.RUnlock()
+	return calls
+}
+
+// GetDisabledAt calls GetDisabledAtFunc.
+func (mock *UserMock) GetDisabledAt() *time.Time {
+	if mock.GetDisabledAtFunc == nil {
+		panic("UserMock.GetDisabledAtFunc: method is nil but User.GetDisabledAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetDisabledAt.Lock()
+	mock.calls.GetDisabledAt = append(mock.calls.GetDisabledAt, callInfo)
+	mock.lockGetDisabledAt.Unlock()
+	return mock.GetDisabledAtFunc()
+}
+
+// GetDisabledAtCalls gets all the calls that were made to GetDisabledAt.
+// Check the length with:
+//
+//	len(mockedUser.GetDisabledAtCalls())
+func (mock *UserMock) GetDisabledAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetDisabledAt.RLock()
+	calls = mock.calls.GetDisabledAt
+	mock.lockGetDisabledAt.RUnlock()
+	return calls
+}
+
+// GetEmail calls GetEmailFunc.
+func (mock *UserMock) GetEmail() string {
+	if mock.GetEmailFunc == nil {
+		panic("UserMock.GetEmailFunc: method is nil but User.GetEmail was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetEmail.Lock()
+	mock.calls.GetEmail = append(mock.calls.GetEmail, callInfo)
+	mock.lockGetEmail.Unlock()
+	return mock.GetEmailFunc()
+}
+
+// GetEmailCalls gets all the calls that were made to GetEmail.
+// Check the length with:
+//
+//	len(mockedUser.GetEmailCalls())
+func (mock *UserMock) GetEmailCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetEmail.RLock()
+	calls = mock.calls.GetEmail
+	mock.lockGetEmail.RUnlock()
+	return calls
+}
+
+// GetEmailVerifiedAt calls GetEmailVerifiedAtFunc.
+func (mock *UserMock) GetEmailVerifiedAt() *time.Time {
+	if mock.GetEmailVerifiedAtFunc == nil {
+		panic("UserMock.GetEmailVerifiedAtFunc: method is nil but User.GetEmailVerifiedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetEmailVerifiedAt.Lock()
+	mock.calls.GetEmailVerifiedAt = append(mock.calls.GetEmailVerifiedAt, callInfo)
+	mock.lockGetEmailVerifiedAt.Unlock()
+	return mock.GetEmailVerifiedAtFunc()
+}
+
+// GetEmailVerifiedAtCalls gets all the calls that were made to GetEmailVerifiedAt.
+// Check the length with:
+//
+//	len(mockedUser.GetEmailVerifiedAtCalls())
+func (mock *UserMock) GetEmailVerifiedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetEmailVerifiedAt.RLock()
+	calls = mock.calls.GetEmailVerifiedAt
+	mock.lockGetEmailVerifiedAt.RUnlock()
+	return calls
 }
 
 // GetID calls GetIDFunc.
@@ -89,6 +326,168 @@ func (mock *UserMock) GetIDCalls() []struct {
 	return calls
 }
 
+// GetName calls GetNameFunc.
+func (mock *UserMock) GetName() string {
+	if mock.GetNameFunc == nil {
+		panic("UserMock.GetNameFunc: method is nil but User.GetName was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetName.Lock()
+	mock.calls.GetName = append(mock.calls.GetName, callInfo)
+	mock.lockGetName.Unlock()
+	return mock.GetNameFunc()
+}
+
+// GetNameCalls gets all the calls that were made to GetName.
+// Check the length with:
+//
+//	len(mockedUser.GetNameCalls())
+func (mock *UserMock) GetNameCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetName.RLock()
+	calls = mock.calls.GetName
+	mock.lockGetName.RUnlock()
+	return calls
+}
+
+// GetPassword calls GetPasswordFunc.
+func (mock *UserMock) GetPassword() string {
+	if mock.GetPasswordFunc == nil {
+		panic("UserMock.GetPasswordFunc: method is nil but User.GetPassword was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetPassword.Lock()
+	mock.calls.GetPassword = append(mock.calls.GetPassword, callInfo)
+	mock.lockGetPassword.Unlock()
+	return mock.GetPasswordFunc()
+}
+
+// GetPasswordCalls gets all the calls that were made to GetPassword.
+// Check the length with:
+//
+//	len(mockedUser.GetPasswordCalls())
+func (mock *UserMock) GetPasswordCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetPassword.RLock()
+	calls = mock.calls.GetPassword
+	mock.lockGetPassword.RUnlock()
+	return calls
+}
+
+// GetUpdatedAt calls GetUpdatedAtFunc.
+func (mock *UserMock) GetUpdatedAt() time.Time {
+	if mock.GetUpdatedAtFunc == nil {
+		panic("UserMock.GetUpdatedAtFunc: method is nil but User.GetUpdatedAt was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetUpdatedAt.Lock()
+	mock.calls.GetUpdatedAt = append(mock.calls.GetUpdatedAt, callInfo)
+	mock.lockGetUpdatedAt.Unlock()
+	return mock.GetUpdatedAtFunc()
+}
+
+// GetUpdatedAtCalls gets all the calls that were made to GetUpdatedAt.
+// Check the length with:
+//
+//	len(mockedUser.GetUpdatedAtCalls())
+func (mock *UserMock) GetUpdatedAtCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetUpdatedAt.RLock()
+	calls = mock.calls.GetUpdatedAt
+	mock.lockGetUpdatedAt.RUnlock()
+	return calls
+}
+
+// IsAdmin calls IsAdminFunc.
+func (mock *UserMock) IsAdmin() bool {
+	if mock.IsAdminFunc == nil {
+		panic("UserMock.IsAdminFunc: method is nil but User.IsAdmin was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsAdmin.Lock()
+	mock.calls.IsAdmin = append(mock.calls.IsAdmin, callInfo)
+	mock.lockIsAdmin.Unlock()
+	return mock.IsAdminFunc()
+}
+
+// IsAdminCalls gets all the calls that were made to IsAdmin.
+// Check the length with:
+//
+//	len(mockedUser.IsAdminCalls())
+func (mock *UserMock) IsAdminCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsAdmin.RLock()
+	calls = mock.calls.IsAdmin
+	mock.lockIsAdmin.RUnlock()
+	return calls
+}
+
+// IsDisabled calls IsDisabledFunc.
+func (mock *UserMock) IsDisabled() bool {
+	if mock.IsDisabledFunc == nil {
+		panic("UserMock.IsDisabledFunc: method is nil but User.IsDisabled was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsDisabled.Lock()
+	mock.calls.IsDisabled = append(mock.calls.IsDisabled, callInfo)
+	mock.lockIsDisabled.Unlock()
+	return mock.IsDisabledFunc()
+}
+
+// IsDisabledCalls gets all the calls that were made to IsDisabled.
+// Check the length with:
+//
+//	len(mockedUser.IsDisabledCalls())
+func (mock *UserMock) IsDisabledCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsDisabled.RLock()
+	calls = mock.calls.IsDisabled
+	mock.lockIsDisabled.RUnlock()
+	return calls
+}
+
+// IsEmailVerified calls IsEmailVerifiedFunc.
+func (mock *UserMock) IsEmailVerified() bool {
+	if mock.IsEmailVerifiedFunc == nil {
+		panic("UserMock.IsEmailVerifiedFunc: method is nil but User.IsEmailVerified was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsEmailVerified.Lock()
+	mock.calls.IsEmailVerified = append(mock.calls.IsEmailVerified, callInfo)
+	mock.lockIsEmailVerified.Unlock()
+	return mock.IsEmailVerifiedFunc()
+}
+
+// IsEmailVerifiedCalls gets all the calls that were made to IsEmailVerified.
+// Check the length with:
+//
+//	len(mockedUser.IsEmailVerifiedCalls())
+func (mock *UserMock) IsEmailVerifiedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsEmailVerified.RLock()
+	calls = mock.calls.IsEmailVerified
+	mock.lockIsEmailVerified.RUnlock()
+	return calls
+}
+
 // IsNotFound calls IsNotFoundFunc.
 func (mock *UserMock) IsNotFound() bool {
 	if mock.IsNotFoundFunc == nil {
@@ -117,19 +516,21 @@ func (mock *UserMock) IsNotFoundCalls() []struct {
 }
 
 // IsPasswordMatch calls IsPasswordMatchFunc.
-func (mock *UserMock) IsPasswordMatch(password string) bool {
+func (mock *UserMock) IsPasswordMatch(h PasswordHasher, password string) bool {
 	if mock.IsPasswordMatchFunc == nil {
 		panic("UserMock.IsPasswordMatchFunc: method is nil but User.IsPasswordMatch was just called")
 	}
 	callInfo := struct {
+		H        PasswordHasher
 		Password string
 	}{
+		H:        h,
 		Password: password,
 	}
 	mock.lockIsPasswordMatch.Lock()
 	mock.calls.IsPasswordMatch = append(mock.calls.IsPasswordMatch, callInfo)
 	mock.lockIsPasswordMatch.Unlock()
-	return mock.IsPasswordMatchFunc(password)
+	return mock.IsPasswordMatchFunc(h, password)
 }
 
 // IsPasswordMatchCalls gets all the calls that were made to IsPasswordMatch.
@@ -137,9 +538,11 @@ func (mock *UserMock) IsPasswordMatch(password string) bool {
 //
 //	len(mockedUser.IsPasswordMatchCalls())
 func (mock *UserMock) IsPasswordMatchCalls() []struct {
+	H        PasswordHasher
 	Password string
 } {
 	var calls []struct {
+		H        PasswordHasher
 		Password string
 	}
 	mock.lockIsPasswordMatch.RLock()
@@ -147,3 +550,70 @@ func (mock *UserMock) IsPasswordMatchCalls() []struct {
 	mock.lockIsPasswordMatch.RUnlock()
 	return calls
 }
+
+// IsPasswordResetRequired calls IsPasswordResetRequiredFunc.
+func (mock *UserMock) IsPasswordResetRequired() bool {
+	if mock.IsPasswordResetRequiredFunc == nil {
+		panic("UserMock.IsPasswordResetRequiredFunc: method is nil but User.IsPasswordResetRequired was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIsPasswordResetRequired.Lock()
+	mock.calls.IsPasswordResetRequired = append(mock.calls.IsPasswordResetRequired, callInfo)
+	mock.lockIsPasswordResetRequired.Unlock()
+	return mock.IsPasswordResetRequiredFunc()
+}
+
+// IsPasswordResetRequiredCalls gets all the calls that were made to IsPasswordResetRequired.
+// Check the length with:
+//
+//	len(mockedUser.IsPasswordResetRequiredCalls())
+func (mock *UserMock) IsPasswordResetRequiredCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsPasswordResetRequired.RLock()
+	calls = mock.calls.IsPasswordResetRequired
+	mock.lockIsPasswordResetRequired.RUnlock()
+	return calls
+}
+
+// SetPassword calls SetPasswordFunc.
+func (mock *UserMock) SetPassword(policy PasswordPolicy, h PasswordHasher, password string) error {
+	if mock.SetPasswordFunc == nil {
+		panic("UserMock.SetPasswordFunc: method is nil but User.SetPassword was just called")
+	}
+	callInfo := struct {
+		Policy   PasswordPolicy
+		H        PasswordHasher
+		Password string
+	}{
+		Policy:   policy,
+		H:        h,
+		Password: password,
+	}
+	mock.lockSetPassword.Lock()
+	mock.calls.SetPassword = append(mock.calls.SetPassword, callInfo)
+	mock.lockSetPassword.Unlock()
+	return mock.SetPasswordFunc(policy, h, password)
+}
+
+// SetPasswordCalls gets all the calls that were made to SetPassword.
+// Check the length with:
+//
+//	len(mockedUser.SetPasswordCalls())
+func (mock *UserMock) SetPasswordCalls() []struct {
+	Policy   PasswordPolicy
+	H        PasswordHasher
+	Password string
+} {
+	var calls []struct {
+		Policy   PasswordPolicy
+		H        PasswordHasher
+		Password string
+	}
+	mock.lockSetPassword.RLock()
+	calls = mock.calls.SetPassword
+	mock.lockSetPassword.RUnlock()
+	return calls
+}
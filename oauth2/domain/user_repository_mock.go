@@ -5,7 +5,9 @@ package domain
 
 import (
 	"context"
+	"github.com/google/uuid"
 	"sync"
+	"time"
 )
 
 // Ensure, that UserRepositoryMock does implement UserRepository.
@@ -18,9 +20,42 @@ var _ UserRepository = &UserRepositoryMock{}
 //
 //		// make and configure a mocked UserRepository
 //		mockedUserRepository := &UserRepositoryMock{
+//			CreateUserFunc: func(ctx context.Context, u User) (User, error) {
+//				panic("mock out the CreateUser method")
+//			},
+//			DeleteUserFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the DeleteUser method")
+//			},
 //			FindUserByEmailFunc: func(ctx context.Context, email string) (User, error) {
 //				panic("mock out the FindUserByEmail method")
 //			},
+//			FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (User, error) {
+//				panic("mock out the FindUserByID method")
+//			},
+//			RequirePasswordResetFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the RequirePasswordReset method")
+//			},
+//			SearchUsersFunc: func(ctx context.Context, query string, offset int, limit int) ([]User, error) {
+//				panic("mock out the SearchUsers method")
+//			},
+//			UpdateAdminFunc: func(ctx context.Context, id uuid.UUID, isAdmin bool) error {
+//				panic("mock out the UpdateAdmin method")
+//			},
+//			UpdateDisabledAtFunc: func(ctx context.Context, id uuid.UUID, disabledAt *time.Time) error {
+//				panic("mock out the UpdateDisabledAt method")
+//			},
+//			UpdatePasswordFunc: func(ctx context.Context, u User) error {
+//				panic("mock out the UpdatePassword method")
+//			},
+//			UpdatePasswordHashFunc: func(ctx context.Context, id uuid.UUID, oldHash string, newHash string) (bool, error) {
+//				panic("mock out the UpdatePasswordHash method")
+//			},
+//			UpdateProfileFunc: func(ctx context.Context, id uuid.UUID, name string, email string, emailVerifiedAt *time.Time) error {
+//				panic("mock out the UpdateProfile method")
+//			},
+//			VerifyEmailFunc: func(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+//				panic("mock out the VerifyEmail method")
+//			},
 //		}
 //
 //		// use mockedUserRepository in code that requires UserRepository
@@ -28,11 +63,58 @@ var _ UserRepository = &UserRepositoryMock{}
 //
 //	}
 type UserRepositoryMock struct {
+	// CreateUserFunc mocks the CreateUser method.
+	CreateUserFunc func(ctx context.Context, u User) (User, error)
+
+	// DeleteUserFunc mocks the DeleteUser method.
+	DeleteUserFunc func(ctx context.Context, id uuid.UUID) error
+
 	// FindUserByEmailFunc mocks the FindUserByEmail method.
 	FindUserByEmailFunc func(ctx context.Context, email string) (User, error)
 
+	// FindUserByIDFunc mocks the FindUserByID method.
+	FindUserByIDFunc func(ctx context.Context, id uuid.UUID) (User, error)
+
+	// RequirePasswordResetFunc mocks the RequirePasswordReset method.
+	RequirePasswordResetFunc func(ctx context.Context, id uuid.UUID) error
+
+	// SearchUsersFunc mocks the SearchUsers method.
+	SearchUsersFunc func(ctx context.Context, query string, offset int, limit int) ([]User, error)
+
+	// UpdateAdminFunc mocks the UpdateAdmin method.
+	UpdateAdminFunc func(ctx context.Context, id uuid.UUID, isAdmin bool) error
+
+	// UpdateDisabledAtFunc mocks the UpdateDisabledAt method.
+	UpdateDisabledAtFunc func(ctx context.Context, id uuid.UUID, disabledAt *time.Time) error
+
+	// UpdatePasswordFunc mocks the UpdatePassword method.
+	UpdatePasswordFunc func(ctx context.Context, u User) error
+
+	// UpdatePasswordHashFunc mocks the UpdatePasswordHash method.
+	UpdatePasswordHashFunc func(ctx context.Context, id uuid.UUID, oldHash string, newHash string) (bool, error)
+
+	// UpdateProfileFunc mocks the UpdateProfile method.
+	UpdateProfileFunc func(ctx context.Context, id uuid.UUID, name string, email string, emailVerifiedAt *time.Time) error
+
+	// VerifyEmailFunc mocks the VerifyEmail method.
+	VerifyEmailFunc func(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// CreateUser holds details about calls to the CreateUser method.
+		CreateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// U is the u argument value.
+			U User
+		}
+		// DeleteUser holds details about calls to the DeleteUser method.
+		DeleteUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
 		// FindUserByEmail holds details about calls to the FindUserByEmail method.
 		FindUserByEmail []struct {
 			// Ctx is the ctx argument value.
@@ -40,8 +122,174 @@ type UserRepositoryMock struct {
 			// Email is the email argument value.
 			Email string
 		}
+		// FindUserByID holds details about calls to the FindUserByID method.
+		FindUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// RequirePasswordReset holds details about calls to the RequirePasswordReset method.
+		RequirePasswordReset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// SearchUsers holds details about calls to the SearchUsers method.
+		SearchUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Query is the query argument value.
+			Query string
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// UpdateAdmin holds details about calls to the UpdateAdmin method.
+		UpdateAdmin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// IsAdmin is the isAdmin argument value.
+			IsAdmin bool
+		}
+		// UpdateDisabledAt holds details about calls to the UpdateDisabledAt method.
+		UpdateDisabledAt []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// DisabledAt is the disabledAt argument value.
+			DisabledAt *time.Time
+		}
+		// UpdatePassword holds details about calls to the UpdatePassword method.
+		UpdatePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// U is the u argument value.
+			U User
+		}
+		// UpdatePasswordHash holds details about calls to the UpdatePasswordHash method.
+		UpdatePasswordHash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// OldHash is the oldHash argument value.
+			OldHash string
+			// NewHash is the newHash argument value.
+			NewHash string
+		}
+		// UpdateProfile holds details about calls to the UpdateProfile method.
+		UpdateProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Name is the name argument value.
+			Name string
+			// Email is the email argument value.
+			Email string
+			// EmailVerifiedAt is the emailVerifiedAt argument value.
+			EmailVerifiedAt *time.Time
+		}
+		// VerifyEmail holds details about calls to the VerifyEmail method.
+		VerifyEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// VerifiedAt is the verifiedAt argument value.
+			VerifiedAt time.Time
+		}
+	}
+	lockCreateUser           sync.RWMutex
+	lockDeleteUser           sync.RWMutex
+	lockFindUserByEmail      sync.RWMutex
+	lockFindUserByID         sync.RWMutex
+	lockRequirePasswordReset sync.RWMutex
+	lockSearchUsers          sync.RWMutex
+	lockUpdateAdmin          sync.RWMutex
+	lockUpdateDisabledAt     sync.RWMutex
+	lockUpdatePassword       sync.RWMutex
+	lockUpdatePasswordHash   sync.RWMutex
+	lockUpdateProfile        sync.RWMutex
+	lockVerifyEmail          sync.RWMutex
+}
+
+// CreateUser calls CreateUserFunc.
+func (mock *UserRepositoryMock) CreateUser(ctx context.Context, u User) (User, error) {
+	if mock.CreateUserFunc == nil {
+		panic("UserRepositoryMock.CreateUserFunc: method is nil but UserRepository.CreateUser was just called")
 	}
-	lockFindUserByEmail sync.RWMutex
+	callInfo := struct {
+		Ctx context.Context
+		U   User
+	}{
+		Ctx: ctx,
+		U:   u,
+	}
+	mock.lockCreateUser.Lock()
+	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
+	mock.lockCreateUser.Unlock()
+	return mock.CreateUserFunc(ctx, u)
+}
+
+// CreateUserCalls gets all the calls that were made to CreateUser.
+// Check the length with:
+//
+//	len(mockedUserRepository.CreateUserCalls())
+func (mock *UserRepositoryMock) CreateUserCalls() []struct {
+	Ctx context.Context
+	U   User
+} {
+	var calls []struct {
+		Ctx context.Context
+		U   User
+	}
+	mock.lockCreateUser.RLock()
+	calls = mock.calls.CreateUser
+	mock.lockCreateUser.RUnlock()
+	return calls
+}
+
+// DeleteUser calls DeleteUserFunc.
+func (mock *UserRepositoryMock) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	if mock.DeleteUserFunc == nil {
+		panic("UserRepositoryMock.DeleteUserFunc: method is nil but UserRepository.DeleteUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteUser.Lock()
+	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
+	mock.lockDeleteUser.Unlock()
+	return mock.DeleteUserFunc(ctx, id)
+}
+
+// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// Check the length with:
+//
+//	len(mockedUserRepository.DeleteUserCalls())
+func (mock *UserRepositoryMock) DeleteUserCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockDeleteUser.RLock()
+	calls = mock.calls.DeleteUser
+	mock.lockDeleteUser.RUnlock()
+	return calls
 }
 
 // FindUserByEmail calls FindUserByEmailFunc.
@@ -79,3 +327,367 @@ func (mock *UserRepositoryMock) FindUserByEmailCalls() []struct {
 	mock.lockFindUserByEmail.RUnlock()
 	return calls
 }
+
+// FindUserByID calls FindUserByIDFunc.
+func (mock *UserRepositoryMock) FindUserByID(ctx context.Context, id uuid.UUID) (User, error) {
+	if mock.FindUserByIDFunc == nil {
+		panic("UserRepositoryMock.FindUserByIDFunc: method is nil but UserRepository.FindUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockFindUserByID.Lock()
+	mock.calls.FindUserByID = append(mock.calls.FindUserByID, callInfo)
+	mock.lockFindUserByID.Unlock()
+	return mock.FindUserByIDFunc(ctx, id)
+}
+
+// FindUserByIDCalls gets all the calls that were made to FindUserByID.
+// Check the length with:
+//
+//	len(mockedUserRepository.FindUserByIDCalls())
+func (mock *UserRepositoryMock) FindUserByIDCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockFindUserByID.RLock()
+	calls = mock.calls.FindUserByID
+	mock.lockFindUserByID.RUnlock()
+	return calls
+}
+
+// RequirePasswordReset calls RequirePasswordResetFunc.
+func (mock *UserRepositoryMock) RequirePasswordReset(ctx context.Context, id uuid.UUID) error {
+	if mock.RequirePasswordResetFunc == nil {
+		panic("UserRepositoryMock.RequirePasswordResetFunc: method is nil but UserRepository.RequirePasswordReset was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockRequirePasswordReset.Lock()
+	mock.calls.RequirePasswordReset = append(mock.calls.RequirePasswordReset, callInfo)
+	mock.lockRequirePasswordReset.Unlock()
+	return mock.RequirePasswordResetFunc(ctx, id)
+}
+
+// RequirePasswordResetCalls gets all the calls that were made to RequirePasswordReset.
+// Check the length with:
+//
+//	len(mockedUserRepository.RequirePasswordResetCalls())
+func (mock *UserRepositoryMock) RequirePasswordResetCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockRequirePasswordReset.RLock()
+	calls = mock.calls.RequirePasswordReset
+	mock.lockRequirePasswordReset.RUnlock()
+	return calls
+}
+
+// SearchUsers calls SearchUsersFunc.
+func (mock *UserRepositoryMock) SearchUsers(ctx context.Context, query string, offset int, limit int) ([]User, error) {
+	if mock.SearchUsersFunc == nil {
+		panic("UserRepositoryMock.SearchUsersFunc: method is nil but UserRepository.SearchUsers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Query  string
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Query:  query,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockSearchUsers.Lock()
+	mock.calls.SearchUsers = append(mock.calls.SearchUsers, callInfo)
+	mock.lockSearchUsers.Unlock()
+	return mock.SearchUsersFunc(ctx, query, offset, limit)
+}
+
+// SearchUsersCalls gets all the calls that were made to SearchUsers.
+// Check the length with:
+//
+//	len(mockedUserRepository.SearchUsersCalls())
+func (mock *UserRepositoryMock) SearchUsersCalls() []struct {
+	Ctx    context.Context
+	Query  string
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Query  string
+		Offset int
+		Limit  int
+	}
+	mock.lockSearchUsers.RLock()
+	calls = mock.calls.SearchUsers
+	mock.lockSearchUsers.RUnlock()
+	return calls
+}
+
+// UpdateAdmin calls UpdateAdminFunc.
+func (mock *UserRepositoryMock) UpdateAdmin(ctx context.Context, id uuid.UUID, isAdmin bool) error {
+	if mock.UpdateAdminFunc == nil {
+		panic("UserRepositoryMock.UpdateAdminFunc: method is nil but UserRepository.UpdateAdmin was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      uuid.UUID
+		IsAdmin bool
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		IsAdmin: isAdmin,
+	}
+	mock.lockUpdateAdmin.Lock()
+	mock.calls.UpdateAdmin = append(mock.calls.UpdateAdmin, callInfo)
+	mock.lockUpdateAdmin.Unlock()
+	return mock.UpdateAdminFunc(ctx, id, isAdmin)
+}
+
+// UpdateAdminCalls gets all the calls that were made to UpdateAdmin.
+// Check the length with:
+//
+//	len(mockedUserRepository.UpdateAdminCalls())
+func (mock *UserRepositoryMock) UpdateAdminCalls() []struct {
+	Ctx     context.Context
+	ID      uuid.UUID
+	IsAdmin bool
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      uuid.UUID
+		IsAdmin bool
+	}
+	mock.lockUpdateAdmin.RLock()
+	calls = mock.calls.UpdateAdmin
+	mock.lockUpdateAdmin.RUnlock()
+	return calls
+}
+
+// UpdateDisabledAt calls UpdateDisabledAtFunc.
+func (mock *UserRepositoryMock) UpdateDisabledAt(ctx context.Context, id uuid.UUID, disabledAt *time.Time) error {
+	if mock.UpdateDisabledAtFunc == nil {
+		panic("UserRepositoryMock.UpdateDisabledAtFunc: method is nil but UserRepository.UpdateDisabledAt was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		DisabledAt *time.Time
+	}{
+		Ctx:        ctx,
+		ID:         id,
+		DisabledAt: disabledAt,
+	}
+	mock.lockUpdateDisabledAt.Lock()
+	mock.calls.UpdateDisabledAt = append(mock.calls.UpdateDisabledAt, callInfo)
+	mock.lockUpdateDisabledAt.Unlock()
+	return mock.UpdateDisabledAtFunc(ctx, id, disabledAt)
+}
+
+// UpdateDisabledAtCalls gets all the calls that were made to UpdateDisabledAt.
+// Check the length with:
+//
+//	len(mockedUserRepository.UpdateDisabledAtCalls())
+func (mock *UserRepositoryMock) UpdateDisabledAtCalls() []struct {
+	Ctx        context.Context
+	ID         uuid.UUID
+	DisabledAt *time.Time
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		DisabledAt *time.Time
+	}
+	mock.lockUpdateDisabledAt.RLock()
+	calls = mock.calls.UpdateDisabledAt
+	mock.lockUpdateDisabledAt.RUnlock()
+	return calls
+}
+
+// UpdatePassword calls UpdatePasswordFunc.
+func (mock *UserRepositoryMock) UpdatePassword(ctx context.Context, u User) error {
+	if mock.UpdatePasswordFunc == nil {
+		panic("UserRepositoryMock.UpdatePasswordFunc: method is nil but UserRepository.UpdatePassword was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		U   User
+	}{
+		Ctx: ctx,
+		U:   u,
+	}
+	mock.lockUpdatePassword.Lock()
+	mock.calls.UpdatePassword = append(mock.calls.UpdatePassword, callInfo)
+	mock.lockUpdatePassword.Unlock()
+	return mock.UpdatePasswordFunc(ctx, u)
+}
+
+// UpdatePasswordCalls gets all the calls that were made to UpdatePassword.
+// Check the length with:
+//
+//	len(mockedUserRepository.UpdatePasswordCalls())
+func (mock *UserRepositoryMock) UpdatePasswordCalls() []struct {
+	Ctx context.Context
+	U   User
+} {
+	var calls []struct {
+		Ctx context.Context
+		U   User
+	}
+	mock.lockUpdatePassword.RLock()
+	calls = mock.calls.UpdatePassword
+	mock.lockUpdatePassword.RUnlock()
+	return calls
+}
+
+// UpdatePasswordHash calls UpdatePasswordHashFunc.
+func (mock *UserRepositoryMock) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash string, newHash string) (bool, error) {
+	if mock.UpdatePasswordHashFunc == nil {
+		panic("UserRepositoryMock.UpdatePasswordHashFunc: method is nil but UserRepository.UpdatePasswordHash was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      uuid.UUID
+		OldHash string
+		NewHash string
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		OldHash: oldHash,
+		NewHash: newHash,
+	}
+	mock.lockUpdatePasswordHash.Lock()
+	mock.calls.UpdatePasswordHash = append(mock.calls.UpdatePasswordHash, callInfo)
+	mock.lockUpdatePasswordHash.Unlock()
+	return mock.UpdatePasswordHashFunc(ctx, id, oldHash, newHash)
+}
+
+// UpdatePasswordHashCalls gets all the calls that were made to UpdatePasswordHash.
+// Check the length with:
+//
+//	len(mockedUserRepository.UpdatePasswordHashCalls())
+func (mock *UserRepositoryMock) UpdatePasswordHashCalls() []struct {
+	Ctx     context.Context
+	ID      uuid.UUID
+	OldHash string
+	NewHash string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      uuid.UUID
+		OldHash string
+		NewHash string
+	}
+	mock.lockUpdatePasswordHash.RLock()
+	calls = mock.calls.UpdatePasswordHash
+	mock.lockUpdatePasswordHash.RUnlock()
+	return calls
+}
+
+// UpdateProfile calls UpdateProfileFunc.
+func (mock *UserRepositoryMock) UpdateProfile(ctx context.Context, id uuid.UUID, name string, email string, emailVerifiedAt *time.Time) error {
+	if mock.UpdateProfileFunc == nil {
+		panic("UserRepositoryMock.UpdateProfileFunc: method is nil but UserRepository.UpdateProfile was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		ID              uuid.UUID
+		Name            string
+		Email           string
+		EmailVerifiedAt *time.Time
+	}{
+		Ctx:             ctx,
+		ID:              id,
+		Name:            name,
+		Email:           email,
+		EmailVerifiedAt: emailVerifiedAt,
+	}
+	mock.lockUpdateProfile.Lock()
+	mock.calls.UpdateProfile = append(mock.calls.UpdateProfile, callInfo)
+	mock.lockUpdateProfile.Unlock()
+	return mock.UpdateProfileFunc(ctx, id, name, email, emailVerifiedAt)
+}
+
+// UpdateProfileCalls gets all the calls that were made to UpdateProfile.
+// Check the length with:
+//
+//	len(mockedUserRepository.UpdateProfileCalls())
+func (mock *UserRepositoryMock) UpdateProfileCalls() []struct {
+	Ctx             context.Context
+	ID              uuid.UUID
+	Name            string
+	Email           string
+	EmailVerifiedAt *time.Time
+} {
+	var calls []struct {
+		Ctx             context.Context
+		ID              uuid.UUID
+		Name            string
+		Email           string
+		EmailVerifiedAt *time.Time
+	}
+	mock.lockUpdateProfile.RLock()
+	calls = mock.calls.UpdateProfile
+	mock.lockUpdateProfile.RUnlock()
+	return calls
+}
+
+// VerifyEmail calls VerifyEmailFunc.
+func (mock *UserRepositoryMock) VerifyEmail(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+	if mock.VerifyEmailFunc == nil {
+		panic("UserRepositoryMock.VerifyEmailFunc: method is nil but UserRepository.VerifyEmail was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		VerifiedAt time.Time
+	}{
+		Ctx:        ctx,
+		ID:         id,
+		VerifiedAt: verifiedAt,
+	}
+	mock.lockVerifyEmail.Lock()
+	mock.calls.VerifyEmail = append(mock.calls.VerifyEmail, callInfo)
+	mock.lockVerifyEmail.Unlock()
+	return mock.VerifyEmailFunc(ctx, id, verifiedAt)
+}
+
+// VerifyEmailCalls gets all the calls that were made to VerifyEmail.
+// Check the length with:
+//
+//	len(mockedUserRepository.VerifyEmailCalls())
+func (mock *UserRepositoryMock) VerifyEmailCalls() []struct {
+	Ctx        context.Context
+	ID         uuid.UUID
+	VerifiedAt time.Time
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ID         uuid.UUID
+		VerifiedAt time.Time
+	}
+	mock.lockVerifyEmail.RLock()
+	calls = mock.calls.VerifyEmail
+	mock.lockVerifyEmail.RUnlock()
+	return calls
+}
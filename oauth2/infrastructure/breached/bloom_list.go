@@ -0,0 +1,67 @@
+package breached
+
+import (
+	"bufio"
+	"encoding/hex"
+	"os"
+	"strings"
+
+	"github.com/sntkn/go-oauth2/oauth2/pkg/bloom"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+// bloomFalsePositiveRate は漏洩していないパスワードを誤って拒否する確率
+const bloomFalsePositiveRate = 0.001
+
+// BloomList は起動時にファイル全体をブルームフィルタに読み込んで探す。
+// ファイルは1行に1つ、SHA-1 の16進数 (:件数 は省略可) か平文のパスワードを並べる
+type BloomList struct {
+	filter *bloom.Filter
+}
+
+func NewBloomList(path string) (*BloomList, error) {
+	// フィルタの大きさを決めるため、先に行数を数える
+	n := 0
+	if err := eachLine(path, func(string) { n++ }); err != nil {
+		return nil, err
+	}
+
+	filter := bloom.New(n, bloomFalsePositiveRate)
+	if err := eachLine(path, func(line string) {
+		filter.Add([]byte(lineToSHA1Hex(line)))
+	}); err != nil {
+		return nil, err
+	}
+	return &BloomList{filter: filter}, nil
+}
+
+func (l *BloomList) Contains(password string) (bool, error) {
+	return l.filter.Test([]byte(sha1Hex(password))), nil
+}
+
+func eachLine(path string, fn func(line string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			fn(line)
+		}
+	}
+	return errors.WithStack(scanner.Err())
+}
+
+// lineToSHA1Hex は SHA-1 の行はそのまま、それ以外は平文としてハッシュにする
+func lineToSHA1Hex(line string) string {
+	hash, _, _ := strings.Cut(line, ":")
+	if len(hash) == 40 {
+		if _, err := hex.DecodeString(hash); err == nil {
+			return strings.ToUpper(hash)
+		}
+	}
+	return sha1Hex(line)
+}
@@ -0,0 +1,39 @@
+// Package breached は漏洩したパスワードの一覧をローカルのファイルから読む
+package breached
+
+import (
+	"crypto/sha1" //nolint:gosec // 漏洩したパスワードの一覧が SHA-1 で配布されている
+	"encoding/hex"
+	"strings"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+const (
+	ListNone   = ""
+	ListPrefix = "prefix"
+	ListBloom  = "bloom"
+)
+
+var ErrUnknownList = errors.New("unknown breached password list")
+
+// NewList は設定で指定された一覧を返す。指定がない場合は nil を返す
+func NewList(kind, path string) (domain.BreachedPasswordList, error) {
+	switch kind {
+	case ListNone:
+		return nil, nil
+	case ListPrefix:
+		return NewPrefixList(path), nil
+	case ListBloom:
+		return NewBloomList(path)
+	default:
+		return nil, ErrUnknownList
+	}
+}
+
+// sha1Hex はパスワードの SHA-1 を大文字の16進数で返す
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password)) //nolint:gosec
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
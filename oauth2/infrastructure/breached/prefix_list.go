@@ -0,0 +1,50 @@
+package breached
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+// prefixLength は k-anonymity のために SHA-1 を分ける長さ。Pwned Passwords の range API と同じ
+const prefixLength = 5
+
+// PrefixList は SHA-1 の先頭5文字ごとに分けたファイルから探す。
+// ファイルは <dir>/<先頭5文字>.txt で、range API の応答と同じく 残りの35文字:件数 を1行ずつ並べる。
+// 照合のたびに1つのファイルだけを読むため、一覧全体をメモリに載せずに済む
+type PrefixList struct {
+	dir string
+}
+
+func NewPrefixList(dir string) *PrefixList {
+	return &PrefixList{dir: dir}
+}
+
+func (l *PrefixList) Contains(password string) (bool, error) {
+	hash := sha1Hex(password)
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	f, err := os.Open(filepath.Join(l.dir, prefix+".txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(line, suffix) {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, errors.WithStack(err)
+	}
+	return false, nil
+}
@@ -0,0 +1,64 @@
+package kvs
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/valkey"
+)
+
+const (
+	failuresKeyPrefix = "loginFailures:"
+	blockedKeyPrefix  = "loginBlocked:"
+)
+
+// LoginAttemptStore はサインインの失敗回数と制限を valkey に保存する。
+// 期限はキーの有効期限で管理するため、古い記録を削除する必要はない
+type LoginAttemptStore struct {
+	cli valkey.ClientIF
+}
+
+func NewLoginAttemptStore(cli valkey.ClientIF) *LoginAttemptStore {
+	return &LoginAttemptStore{
+		cli: cli,
+	}
+}
+
+func (s *LoginAttemptStore) IncrFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return s.cli.Incr(ctx, failuresKeyPrefix+key, seconds(window))
+}
+
+func (s *LoginAttemptStore) ResetFailures(ctx context.Context, key string) error {
+	return s.cli.Del(ctx, failuresKeyPrefix+key)
+}
+
+func (s *LoginAttemptStore) Block(ctx context.Context, key string, until time.Time) error {
+	return s.cli.Set(ctx, blockedKeyPrefix+key, strconv.FormatInt(until.UnixNano(), 10), seconds(time.Until(until)))
+}
+
+func (s *LoginAttemptStore) BlockedUntil(ctx context.Context, key string) (time.Time, error) {
+	v, err := s.cli.Get(ctx, blockedKeyPrefix+key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if v == "" {
+		return time.Time{}, nil
+	}
+	nano, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.WithStack(err)
+	}
+	return time.Unix(0, nano), nil
+}
+
+func (s *LoginAttemptStore) Unblock(ctx context.Context, key string) error {
+	return s.cli.Del(ctx, blockedKeyPrefix+key)
+}
+
+// seconds はキーの有効期限を秒に切り上げる。解除時刻より先に消えないようにする
+func seconds(d time.Duration) int64 {
+	return max(int64(math.Ceil(d.Seconds())), 1)
+}
@@ -0,0 +1,54 @@
+package kvs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/valkey"
+)
+
+const (
+	samlAssertionKeyPrefix = "samlAssertion:"
+	samlLoginKeyPrefix     = "samlLogin:"
+)
+
+// SAMLLoginStore は使用済みのアサーションと ACS で受け取ったサインインを valkey に保存する
+type SAMLLoginStore struct {
+	cli valkey.ClientIF
+}
+
+func NewSAMLLoginStore(cli valkey.ClientIF) *SAMLLoginStore {
+	return &SAMLLoginStore{
+		cli: cli,
+	}
+}
+
+func (s *SAMLLoginStore) MarkAssertionUsed(ctx context.Context, connection, assertionID string, until time.Time) (bool, error) {
+	return s.cli.SetNX(ctx, samlAssertionKeyPrefix+connection+":"+assertionID, "1", seconds(time.Until(until)))
+}
+
+func (s *SAMLLoginStore) SaveLogin(ctx context.Context, token string, login domain.SAMLLogin, ttl time.Duration) error {
+	b, err := json.Marshal(login)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return s.cli.Set(ctx, samlLoginKeyPrefix+token, string(b), seconds(ttl))
+}
+
+func (s *SAMLLoginStore) TakeLogin(ctx context.Context, token string) (*domain.SAMLLogin, error) {
+	v, err := s.cli.GetDel(ctx, samlLoginKeyPrefix+token)
+	if err != nil {
+		return nil, err
+	}
+	if v == "" {
+		return nil, nil
+	}
+	var login domain.SAMLLogin
+	if err := json.Unmarshal([]byte(v), &login); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &login, nil
+}
@@ -0,0 +1,214 @@
+// Package ldap は LDAP や Active Directory のディレクトリで利用者を認証する
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+// フィルタの中で置き換える値。置き換える前にフィルタ用にエスケープする
+const (
+	loginPlaceholder = "{login}"
+	dnPlaceholder    = "{dn}"
+)
+
+var ErrAmbiguousUser = errors.New("ldap: user filter matched more than one entry")
+
+type Config struct {
+	// URL は ldap:// または ldaps:// のURL
+	URL      string
+	StartTLS bool
+	// BindDN と BindPassword は利用者とグループを検索するアカウント。空の場合は匿名で検索する
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+	// UserFilter は利用者を検索するフィルタ。{login} を入力されたログイン名に置き換える
+	UserFilter string
+	// IDAttribute は利用者を識別する変わらない属性 (entryUUID, objectGUID など)。空の場合は DN を使う
+	IDAttribute    string
+	NameAttribute  string
+	EmailAttribute string
+	// GroupBaseDN が空の場合はグループを検索しない
+	GroupBaseDN string
+	// GroupFilter は利用者が所属するグループを検索するフィルタ。{dn} を利用者の DN に置き換える
+	GroupFilter        string
+	GroupNameAttribute string
+	Timeout            time.Duration
+	// TLSConfig が nil の場合は既定の設定で証明書を検証する
+	TLSConfig *tls.Config
+}
+
+func NewDirectory(cfg Config) *Directory {
+	return &Directory{config: cfg}
+}
+
+// Directory は利用者を検索してから、その DN とパスワードでバインドして認証する
+type Directory struct {
+	config Config
+}
+
+func (d *Directory) Authenticate(ctx context.Context, login, password string) (*domain.DirectoryEntry, error) {
+	// パスワードが空のバインドは認証なしのバインドとして成功してしまう (RFC 4513 5.1.2)
+	if login == "" || password == "" {
+		return nil, domain.ErrDirectoryInvalidCredentials
+	}
+
+	conn, err := d.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// リクエストが中断された場合は接続を閉じて応答を待つのをやめる
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if err := d.bindService(conn); err != nil {
+		return nil, err
+	}
+
+	user, err := d.findUser(conn, login)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Bind(user.DN, password); err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return nil, domain.ErrDirectoryInvalidCredentials
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	entry := &domain.DirectoryEntry{
+		ID:    d.entryID(user),
+		DN:    user.DN,
+		Name:  user.GetAttributeValue(d.config.NameAttribute),
+		Email: user.GetAttributeValue(d.config.EmailAttribute),
+	}
+
+	// 利用者の権限では所属するグループを読めないことがあるため、検索用のアカウントに戻す
+	if d.config.GroupBaseDN != "" {
+		if err := d.bindService(conn); err != nil {
+			return nil, err
+		}
+		if entry.Groups, err = d.findGroups(conn, user.DN); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+func (d *Directory) dial() (*goldap.Conn, error) {
+	dialer := &net.Dialer{Timeout: d.config.Timeout}
+	conn, err := goldap.DialURL(d.config.URL,
+		goldap.DialWithDialer(dialer),
+		goldap.DialWithTLSConfig(d.tlsConfig()),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if d.config.Timeout > 0 {
+		conn.SetTimeout(d.config.Timeout)
+	}
+	if d.config.StartTLS {
+		if err := conn.StartTLS(d.tlsConfig()); err != nil {
+			conn.Close()
+			return nil, errors.WithStack(err)
+		}
+	}
+	return conn, nil
+}
+
+func (d *Directory) tlsConfig() *tls.Config {
+	if d.config.TLSConfig != nil {
+		return d.config.TLSConfig
+	}
+	host := d.config.URL
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+}
+
+func (d *Directory) bindService(conn *goldap.Conn) error {
+	if d.config.BindDN == "" {
+		return errors.WithStack(conn.UnauthenticatedBind(""))
+	}
+	return errors.WithStack(conn.Bind(d.config.BindDN, d.config.BindPassword))
+}
+
+func (d *Directory) findUser(conn *goldap.Conn, login string) (*goldap.Entry, error) {
+	filter := strings.ReplaceAll(d.config.UserFilter, loginPlaceholder, goldap.EscapeFilter(login))
+	attrs := []string{d.config.NameAttribute, d.config.EmailAttribute}
+	if d.config.IDAttribute != "" {
+		attrs = append(attrs, d.config.IDAttribute)
+	}
+
+	// 2件まで取得して、複数の利用者に一致するフィルタを検出する
+	res, err := conn.Search(goldap.NewSearchRequest(
+		d.config.BaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases,
+		2, int(d.config.Timeout/time.Second), false, filter, attrs, nil,
+	))
+	if err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultNoSuchObject) {
+			return nil, domain.ErrDirectoryUserNotFound
+		}
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+			return nil, ErrAmbiguousUser
+		}
+		return nil, errors.WithStack(err)
+	}
+	switch len(res.Entries) {
+	case 0:
+		return nil, domain.ErrDirectoryUserNotFound
+	case 1:
+		return res.Entries[0], nil
+	default:
+		return nil, ErrAmbiguousUser
+	}
+}
+
+func (d *Directory) findGroups(conn *goldap.Conn, userDN string) ([]string, error) {
+	filter := strings.ReplaceAll(d.config.GroupFilter, dnPlaceholder, goldap.EscapeFilter(userDN))
+	res, err := conn.Search(goldap.NewSearchRequest(
+		d.config.GroupBaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases,
+		0, int(d.config.Timeout/time.Second), false, filter, []string{d.config.GroupNameAttribute}, nil,
+	))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	groups := make([]string, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		if name := e.GetAttributeValue(d.config.GroupNameAttribute); name != "" {
+			groups = append(groups, name)
+		}
+	}
+	return groups, nil
+}
+
+// entryID は IDAttribute の値を返す。objectGUID のようなバイナリの値は16進数にする
+func (d *Directory) entryID(e *goldap.Entry) string {
+	if d.config.IDAttribute == "" {
+		return e.DN
+	}
+	raw := e.GetRawAttributeValue(d.config.IDAttribute)
+	if len(raw) == 0 {
+		return e.DN
+	}
+	if utf8.Valid(raw) {
+		return string(raw)
+	}
+	return hex.EncodeToString(raw)
+}
@@ -0,0 +1,142 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/ldap/ldaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	serviceDN = "cn=service,dc=example,dc=com"
+	aliceDN   = "uid=alice,ou=people,dc=example,dc=com"
+)
+
+func newTestDirectory(t *testing.T) (*ldaptest.Server, *Directory) {
+	t.Helper()
+	s := ldaptest.New()
+	t.Cleanup(s.Close)
+
+	s.Add(serviceDN, map[string][]string{"userPassword": {"service-secret"}})
+	s.Add(aliceDN, map[string][]string{
+		"objectClass":  {"inetOrgPerson"},
+		"uid":          {"alice"},
+		"cn":           {"Alice Liddell"},
+		"mail":         {"alice@example.com"},
+		"entryUUID":    {"5b0d2d6e-8f0f-4a6c-9c77-0d1f0e7f5f11"},
+		"userPassword": {"alice-secret"},
+	})
+	s.Add("cn=engineering,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"groupOfNames"},
+		"cn":          {"engineering"},
+		"member":      {aliceDN},
+	})
+	s.Add("cn=sales,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"groupOfNames"},
+		"cn":          {"sales"},
+		"member":      {"uid=bob,ou=people,dc=example,dc=com"},
+	})
+
+	return s, NewDirectory(Config{
+		URL:                s.URL(),
+		BindDN:             serviceDN,
+		BindPassword:       "service-secret",
+		BaseDN:             "ou=people,dc=example,dc=com",
+		UserFilter:         "(&(objectClass=inetOrgPerson)(|(mail={login})(uid={login})))",
+		IDAttribute:        "entryUUID",
+		NameAttribute:      "cn",
+		EmailAttribute:     "mail",
+		GroupBaseDN:        "ou=groups,dc=example,dc=com",
+		GroupFilter:        "(&(objectClass=groupOfNames)(member={dn}))",
+		GroupNameAttribute: "cn",
+		Timeout:            5 * time.Second,
+	})
+}
+
+func TestDirectory_Authenticate(t *testing.T) {
+	s, d := newTestDirectory(t)
+
+	entry, err := d.Authenticate(context.Background(), "alice@example.com", "alice-secret")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.DirectoryEntry{
+		ID:     "5b0d2d6e-8f0f-4a6c-9c77-0d1f0e7f5f11",
+		DN:     aliceDN,
+		Name:   "Alice Liddell",
+		Email:  "alice@example.com",
+		Groups: []string{"engineering"},
+	}, entry)
+	// 検索用のアカウント、利用者、グループの検索用のアカウントの順にバインドする
+	assert.Equal(t, []string{serviceDN, aliceDN, serviceDN}, s.Binds())
+}
+
+func TestDirectory_AuthenticateErrors(t *testing.T) {
+	_, d := newTestDirectory(t)
+	ctx := context.Background()
+
+	_, err := d.Authenticate(ctx, "alice", "wrong")
+	assert.ErrorIs(t, err, domain.ErrDirectoryInvalidCredentials)
+
+	// 空のパスワードは匿名のバインドとして成功してしまうため、問い合わせる前に拒否する
+	_, err = d.Authenticate(ctx, "alice", "")
+	assert.ErrorIs(t, err, domain.ErrDirectoryInvalidCredentials)
+
+	_, err = d.Authenticate(ctx, "carol@example.com", "secret")
+	assert.ErrorIs(t, err, domain.ErrDirectoryUserNotFound)
+}
+
+func TestDirectory_FilterInjection(t *testing.T) {
+	_, d := newTestDirectory(t)
+
+	// ログイン名はフィルタ用にエスケープするため、ワイルドカードとして扱われない
+	_, err := d.Authenticate(context.Background(), "*", "alice-secret")
+	assert.ErrorIs(t, err, domain.ErrDirectoryUserNotFound)
+}
+
+func TestDirectory_AmbiguousUser(t *testing.T) {
+	s, d := newTestDirectory(t)
+	s.Add("uid=alice2,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass": {"inetOrgPerson"},
+		"uid":         {"alice2"},
+		"mail":        {"alice@example.com"},
+	})
+
+	_, err := d.Authenticate(context.Background(), "alice@example.com", "alice-secret")
+	assert.ErrorIs(t, err, ErrAmbiguousUser)
+}
+
+func TestDirectory_ServiceBindFailure(t *testing.T) {
+	s, _ := newTestDirectory(t)
+	d := NewDirectory(Config{
+		URL:          s.URL(),
+		BindDN:       serviceDN,
+		BindPassword: "wrong",
+		BaseDN:       "dc=example,dc=com",
+		UserFilter:   "(uid={login})",
+	})
+
+	// 検索用のアカウントの設定の誤りは、利用者のパスワードの誤りとして扱わない
+	_, err := d.Authenticate(context.Background(), "alice", "alice-secret")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrDirectoryInvalidCredentials)
+}
+
+func TestDirectory_DNAsID(t *testing.T) {
+	s, _ := newTestDirectory(t)
+	s.AllowAnonymousSearch()
+	d := NewDirectory(Config{
+		URL:            s.URL(),
+		BaseDN:         "dc=example,dc=com",
+		UserFilter:     "(uid={login})",
+		NameAttribute:  "cn",
+		EmailAttribute: "mail",
+	})
+
+	entry, err := d.Authenticate(context.Background(), "alice", "alice-secret")
+	require.NoError(t, err)
+	assert.Equal(t, aliceDN, entry.ID)
+	assert.Empty(t, entry.Groups)
+}
@@ -0,0 +1,345 @@
+// Package ldaptest はテストで使う LDAP サーバー。
+// 単純バインドと検索のみに対応し、エントリはメモリ上に保持する
+package ldaptest
+
+import (
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+// LDAP のメッセージの種類 (RFC 4511 4.2 - 4.5)
+const (
+	opBindRequest      = 0
+	opBindResponse     = 1
+	opUnbindRequest    = 2
+	opSearchRequest    = 3
+	opSearchResEntry   = 4
+	opSearchResDone    = 5
+	opExtendedResponse = 24
+)
+
+// 結果コード (RFC 4511 4.1.9)
+const (
+	resultSuccess            = 0
+	resultProtocolError      = 2
+	resultSizeLimitExceeded  = 4
+	resultInvalidCredentials = 49
+	resultInsufficientAccess = 50
+	resultUnwillingToPerform = 53
+)
+
+// 検索のフィルタ (RFC 4511 4.5.1.7)
+const (
+	filterAnd       = 0
+	filterOr        = 1
+	filterNot       = 2
+	filterEquality  = 3
+	filterSubstring = 4
+	filterPresent   = 7
+)
+
+// passwordAttribute のパスワードでバインドできる。検索結果には含めない
+const passwordAttribute = "userPassword"
+
+type Server struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	entries []entry
+	// anonymousSearch が false の場合は、バインドしていない接続の検索を拒否する
+	anonymousSearch bool
+	binds           []string
+	wg              sync.WaitGroup
+}
+
+type entry struct {
+	dn    string
+	attrs map[string][]string
+}
+
+// New はサーバーを起動する。使い終わったら Close で停止する
+func New() *Server {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	s := &Server{listener: l}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// URL は ldap:// のURLを返す
+func (s *Server) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// Add はエントリを追加する。userPassword を指定したエントリはその DN でバインドできる
+func (s *Server) Add(dn string, attrs map[string][]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry{dn: dn, attrs: attrs})
+}
+
+// AllowAnonymousSearch はバインドしていない接続の検索を許可する
+func (s *Server) AllowAnonymousSearch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.anonymousSearch = true
+}
+
+// Binds は成功したバインドの DN を順に返す
+func (s *Server) Binds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.binds...)
+}
+
+func (s *Server) Close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	bound := ""
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id, _ := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		if op.ClassType != ber.ClassApplication {
+			return
+		}
+
+		switch op.Tag {
+		case opBindRequest:
+			code := s.bind(op)
+			if code == resultSuccess {
+				bound = op.Children[1].Value.(string)
+			}
+			write(conn, id, result(opBindResponse, code))
+		case opSearchRequest:
+			s.search(conn, id, op, bound)
+		case opUnbindRequest:
+			return
+		default:
+			// StartTLS などの拡張操作には対応しない
+			write(conn, id, result(opExtendedResponse, resultUnwillingToPerform))
+		}
+	}
+}
+
+func (s *Server) bind(op *ber.Packet) int {
+	if len(op.Children) < 3 {
+		return resultProtocolError
+	}
+	dn, _ := op.Children[1].Value.(string)
+	password := op.Children[2].Data.String()
+	// パスワードが空のバインドは匿名のバインドとして扱う (RFC 4513 5.1.2)
+	if password == "" {
+		return resultSuccess
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.entries {
+		if sameDN(e.dn, dn) && slices.Contains(e.attrs[passwordAttribute], password) {
+			s.binds = append(s.binds, dn)
+			return resultSuccess
+		}
+	}
+	return resultInvalidCredentials
+}
+
+func (s *Server) search(conn net.Conn, id int64, op *ber.Packet, bound string) {
+	if len(op.Children) < 8 {
+		write(conn, id, result(opSearchResDone, resultProtocolError))
+		return
+	}
+	base, _ := op.Children[0].Value.(string)
+	scope, _ := op.Children[1].Value.(int64)
+	sizeLimit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+
+	s.mu.Lock()
+	if bound == "" && !s.anonymousSearch {
+		s.mu.Unlock()
+		write(conn, id, result(opSearchResDone, resultInsufficientAccess))
+		return
+	}
+	var matched []entry
+	for _, e := range s.entries {
+		if inScope(e.dn, base, scope) && match(e, filter) {
+			matched = append(matched, e)
+		}
+	}
+	s.mu.Unlock()
+
+	code := resultSuccess
+	if sizeLimit > 0 && int64(len(matched)) > sizeLimit {
+		matched = matched[:sizeLimit]
+		code = resultSizeLimitExceeded
+	}
+	for _, e := range matched {
+		write(conn, id, searchEntry(e))
+	}
+	write(conn, id, result(opSearchResDone, code))
+}
+
+func match(e entry, f *ber.Packet) bool {
+	switch f.Tag {
+	case filterAnd:
+		for _, c := range f.Children {
+			if !match(e, c) {
+				return false
+			}
+		}
+		return true
+	case filterOr:
+		for _, c := range f.Children {
+			if match(e, c) {
+				return true
+			}
+		}
+		return false
+	case filterNot:
+		return len(f.Children) == 1 && !match(e, f.Children[0])
+	case filterEquality:
+		name, _ := f.Children[0].Value.(string)
+		want, _ := f.Children[1].Value.(string)
+		for _, v := range values(e, name) {
+			if strings.EqualFold(v, want) || sameDN(v, want) {
+				return true
+			}
+		}
+		return false
+	case filterSubstring:
+		name, _ := f.Children[0].Value.(string)
+		for _, v := range values(e, name) {
+			if matchSubstrings(strings.ToLower(v), f.Children[1].Children) {
+				return true
+			}
+		}
+		return false
+	case filterPresent:
+		return len(values(e, f.Data.String())) > 0
+	default:
+		return false
+	}
+}
+
+// matchSubstrings は initial, any, final の部分文字列が順に現れるかを判定する
+func matchSubstrings(v string, parts []*ber.Packet) bool {
+	for _, p := range parts {
+		sub := strings.ToLower(p.Data.String())
+		switch p.Tag {
+		case 0:
+			if !strings.HasPrefix(v, sub) {
+				return false
+			}
+			v = v[len(sub):]
+		case 1:
+			i := strings.Index(v, sub)
+			if i < 0 {
+				return false
+			}
+			v = v[i+len(sub):]
+		case 2:
+			if !strings.HasSuffix(v, sub) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func values(e entry, name string) []string {
+	if strings.EqualFold(name, "objectClass") && len(e.attrs["objectClass"]) == 0 {
+		return []string{"top"}
+	}
+	for k, v := range e.attrs {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+func inScope(dn, base string, scope int64) bool {
+	dn, base = normalizeDN(dn), normalizeDN(base)
+	switch scope {
+	case 0:
+		return dn == base
+	case 1:
+		_, parent, ok := strings.Cut(dn, ",")
+		return ok && parent == base
+	default:
+		return base == "" || dn == base || strings.HasSuffix(dn, ","+base)
+	}
+}
+
+func sameDN(a, b string) bool {
+	return normalizeDN(a) == normalizeDN(b)
+}
+
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, p := range parts {
+		parts[i] = strings.ToLower(strings.TrimSpace(p))
+	}
+	return strings.Join(parts, ",")
+}
+
+func searchEntry(e entry) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, opSearchResEntry, nil, "Search Result Entry")
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "Object Name"))
+	attrs := ber.NewSequence("Attributes")
+	for name, vals := range e.attrs {
+		if strings.EqualFold(name, passwordAttribute) {
+			continue
+		}
+		attr := ber.NewSequence("Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range vals {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	p.AppendChild(attrs)
+	return p
+}
+
+func result(op ber.Tag, code int) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, op, nil, "Result")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return p
+}
+
+func write(conn net.Conn, id int64, op *ber.Packet) {
+	msg := ber.NewSequence("LDAP Message")
+	msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+	msg.AppendChild(op)
+	_, _ = conn.Write(msg.Bytes())
+}
@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+// FileSender はメールを1通ずつファイルに書き出す。ローカル開発用
+type FileSender struct {
+	dir  string
+	from string
+}
+
+func NewFileSender(dir, from string) *FileSender {
+	return &FileSender{
+		dir:  dir,
+		from: from,
+	}
+}
+
+func (s *FileSender) Send(_ context.Context, m domain.Mail) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return errors.WithStack(err)
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102150405"), uuid.NewString())
+	body := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\n\r\n%s\r\n",
+		s.from, m.To, m.Subject, now.Format(time.RFC1123Z), m.Body)
+
+	return errors.WithStack(os.WriteFile(filepath.Join(s.dir, name), []byte(body), 0o600))
+}
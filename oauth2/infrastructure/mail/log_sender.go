@@ -0,0 +1,24 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+)
+
+// LogSender はメールを送信せずにログへ出力する。ローカル開発用
+type LogSender struct {
+	logger *slog.Logger
+}
+
+func NewLogSender(logger *slog.Logger) *LogSender {
+	return &LogSender{
+		logger: logger,
+	}
+}
+
+func (s *LogSender) Send(ctx context.Context, m domain.Mail) error {
+	s.logger.InfoContext(ctx, "mail", "to", m.To, "subject", m.Subject, "body", m.Body)
+	return nil
+}
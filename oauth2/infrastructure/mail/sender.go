@@ -0,0 +1,27 @@
+package mail
+
+import (
+	"log/slog"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+const (
+	SenderLog  = "log"
+	SenderFile = "file"
+)
+
+var ErrUnknownSender = errors.New("unknown mail sender")
+
+// NewSender は設定で指定された送信方法を返す
+func NewSender(kind, dir, from string, logger *slog.Logger) (domain.MailSender, error) {
+	switch kind {
+	case SenderLog:
+		return NewLogSender(logger), nil
+	case SenderFile:
+		return NewFileSender(dir, from), nil
+	default:
+		return nil, ErrUnknownSender
+	}
+}
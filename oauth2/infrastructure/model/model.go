@@ -7,41 +7,131 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID `db:"id"`
-	Name      string    `db:"name"`
-	Email     string    `db:"email"`
-	Password  string    `db:"password"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID                    uuid.UUID  `db:"id"`
+	Name                  string     `db:"name"`
+	Email                 string     `db:"email"`
+	Password              string     `db:"password"`
+	EmailVerifiedAt       *time.Time `db:"email_verified_at"`
+	PasswordResetRequired bool       `db:"password_reset_required"`
+	DisabledAt            *time.Time `db:"disabled_at"`
+	IsAdmin               bool       `db:"is_admin"`
+	CreatedAt             time.Time  `db:"created_at"`
+	UpdatedAt             time.Time  `db:"updated_at"`
+}
+
+type EmailVerificationToken struct {
+	TokenHash string     `db:"token_hash"`
+	UserID    uuid.UUID  `db:"user_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+type PasswordResetToken struct {
+	TokenHash string     `db:"token_hash"`
+	UserID    uuid.UUID  `db:"user_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+type TOTPCredential struct {
+	UserID       uuid.UUID  `db:"user_id"`
+	Secret       string     `db:"secret"`
+	ConfirmedAt  *time.Time `db:"confirmed_at"`
+	LastUsedStep int64      `db:"last_used_step"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at"`
+}
+
+type Passkey struct {
+	CredentialID    []byte     `db:"credential_id"`
+	UserID          uuid.UUID  `db:"user_id"`
+	Name            string     `db:"name"`
+	PublicKey       []byte     `db:"public_key"`
+	AttestationType string     `db:"attestation_type"`
+	AAGUID          []byte     `db:"aaguid"`
+	SignCount       int64      `db:"sign_count"`
+	Transports      string     `db:"transports"`
+	BackupEligible  bool       `db:"backup_eligible"`
+	BackupState     bool       `db:"backup_state"`
+	LastUsedAt      *time.Time `db:"last_used_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+type FederatedIdentity struct {
+	Provider   string     `db:"provider"`
+	Subject    string     `db:"subject"`
+	UserID     uuid.UUID  `db:"user_id"`
+	Email      string     `db:"email"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+type SAMLConnection struct {
+	ID             uuid.UUID `db:"id"`
+	Name           string    `db:"name"`
+	DisplayName    string    `db:"display_name"`
+	IdPMetadata    string    `db:"idp_metadata"`
+	EmailAttribute string    `db:"email_attribute"`
+	NameAttribute  string    `db:"name_attribute"`
+	LinkByEmail    bool      `db:"link_by_email"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+type LockoutEvent struct {
+	ID          uuid.UUID  `db:"id"`
+	Kind        string     `db:"kind"`
+	SubjectType string     `db:"subject_type"`
+	Subject     string     `db:"subject"`
+	IP          string     `db:"ip"`
+	LockedUntil *time.Time `db:"locked_until"`
+	CreatedAt   time.Time  `db:"created_at"`
 }
 
 type Client struct {
-	ID           uuid.UUID `db:"id"`
-	Name         string    `db:"name"`
-	RedirectURIs string    `db:"redirect_uris"`
-	CreatedAt    time.Time `db:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"`
+	ID                     uuid.UUID  `db:"id"`
+	Name                   string     `db:"name"`
+	FirstParty             bool       `db:"first_party"`
+	SecretHash             *string    `db:"secret_hash"`
+	GrantTypes             string     `db:"grant_types"`
+	AccessTokenExpiresMin  int        `db:"access_token_expires_min"`
+	RefreshTokenExpiresDay int        `db:"refresh_token_expires_day"`
+	DisabledAt             *time.Time `db:"disabled_at"`
+	CreatedAt              time.Time  `db:"created_at"`
+	UpdatedAt              time.Time  `db:"updated_at"`
 }
 
-type AuthorizationCode struct {
-	Code        string    `db:"code"`
+type ClientRedirectURI struct {
+	ID          uuid.UUID `db:"id"`
 	ClientID    uuid.UUID `db:"client_id"`
-	UserID      uuid.UUID `db:"user_id"`
-	Scope       string    `db:"scope"`
 	RedirectURI string    `db:"redirect_uri"`
-	ExpiresAt   time.Time `db:"expires_at"`
 	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type AuthorizationCode struct {
+	Code        string     `db:"code"`
+	ClientID    uuid.UUID  `db:"client_id"`
+	UserID      uuid.UUID  `db:"user_id"`
+	Scope       string     `db:"scope"`
+	RedirectURI string     `db:"redirect_uri"`
+	ExpiresAt   time.Time  `db:"expires_at"`
+	RevokedAt   *time.Time `db:"revoked_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
 }
 
 type Token struct {
-	AccessToken string    `db:"access_token"`
-	ClientID    uuid.UUID `db:"client_id"`
-	UserID      uuid.UUID `db:"user_id"`
-	Scope       string    `db:"scope"`
-	ExpiresAt   time.Time `db:"expires_at"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	AccessToken       string     `db:"access_token"`
+	ClientID          uuid.UUID  `db:"client_id"`
+	UserID            uuid.UUID  `db:"user_id"`
+	Scope             string     `db:"scope"`
+	AuthorizationCode *string    `db:"authorization_code"`
+	ExpiresAt         time.Time  `db:"expires_at"`
+	RevokedAt         *time.Time `db:"revoked_at"`
+	CreatedAt         time.Time  `db:"created_at"`
+	UpdatedAt         time.Time  `db:"updated_at"`
 }
 
 type RefreshToken struct {
@@ -51,3 +141,28 @@ type RefreshToken struct {
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
 }
+
+type Scope struct {
+	Name            string    `db:"name"`
+	Description     string    `db:"description"`
+	RequiresConsent bool      `db:"requires_consent"`
+	IsDefault       bool      `db:"is_default"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+type TokenUsage struct {
+	ClientID     uuid.UUID `db:"client_id"`
+	FirstUsedAt  time.Time `db:"first_used_at"`
+	LastUsedAt   time.Time `db:"last_used_at"`
+	ActiveTokens int       `db:"active_tokens"`
+}
+
+type Consent struct {
+	UserID    uuid.UUID  `db:"user_id"`
+	ClientID  uuid.UUID  `db:"client_id"`
+	Scope     string     `db:"scope"`
+	ExpiresAt *time.Time `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
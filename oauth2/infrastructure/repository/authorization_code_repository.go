@@ -22,14 +22,17 @@ type AuthorizationCodeRepository struct {
 }
 
 func (r *AuthorizationCodeRepository) FindAuthorizationCode(ctx context.Context, code string) (domain.AuthorizationCode, error) {
-	q := "SELECT user_id, client_id, scope, expires_at FROM oauth2_codes WHERE code = $1 AND revoked_at IS NULL"
+	// 使用済みのコードも返し、再利用の検知は呼び出し側で行う
+	q := "SELECT user_id, client_id, scope, redirect_uri, expires_at, revoked_at FROM oauth2_codes WHERE code = $1"
 	mapper := func(ac model.AuthorizationCode) (domain.AuthorizationCode, error) {
 		return domain.NewAuthorizationCode(domain.AuthorizationCodeParams[uuid.UUID]{
-			Code:      code,
-			UserID:    ac.UserID,
-			ClientID:  ac.ClientID,
-			Scope:     ac.Scope,
-			ExpiresAt: ac.ExpiresAt,
+			Code:        code,
+			UserID:      ac.UserID,
+			ClientID:    ac.ClientID,
+			Scope:       ac.Scope,
+			RedirectURI: ac.RedirectURI,
+			ExpiresAt:   ac.ExpiresAt,
+			RevokedAt:   ac.RevokedAt,
 		})
 	}
 
@@ -48,14 +51,15 @@ func (r *AuthorizationCodeRepository) FindValidAuthorizationCode(
 	code string,
 	expiresAt time.Time,
 ) (domain.AuthorizationCode, error) {
-	q := "SELECT user_id, client_id, scope, expires_at FROM oauth2_codes WHERE code = $1 AND revoked_at IS NULL AND expires_at > $2"
+	q := "SELECT user_id, client_id, scope, redirect_uri, expires_at FROM oauth2_codes WHERE code = $1 AND revoked_at IS NULL AND expires_at > $2"
 	mapper := func(ac model.AuthorizationCode) (domain.AuthorizationCode, error) {
 		return domain.NewAuthorizationCode(domain.AuthorizationCodeParams[uuid.UUID]{
-			Code:      code,
-			UserID:    ac.UserID,
-			ClientID:  ac.ClientID,
-			Scope:     ac.Scope,
-			ExpiresAt: ac.ExpiresAt,
+			Code:        code,
+			UserID:      ac.UserID,
+			ClientID:    ac.ClientID,
+			Scope:       ac.Scope,
+			RedirectURI: ac.RedirectURI,
+			ExpiresAt:   ac.ExpiresAt,
 		})
 	}
 
@@ -96,8 +100,16 @@ func (r *AuthorizationCodeRepository) StoreAuthorizationCode(ctx context.Context
 	return p.Code, nil
 }
 
-func (r *AuthorizationCodeRepository) RevokeCode(ctx context.Context, code string) error {
-	updateQuery := "UPDATE oauth2_codes SET revoked_at = $1 WHERE code = $2"
-	_, err := r.db.ExecContext(ctx, updateQuery, time.Now(), code)
-	return errors.WithStack(err)
+func (r *AuthorizationCodeRepository) RedeemCode(ctx context.Context, code string) (bool, error) {
+	// 条件付き UPDATE で同時に交換された場合もどちらか一方だけが成功する
+	updateQuery := "UPDATE oauth2_codes SET revoked_at = $1, updated_at = $1 WHERE code = $2 AND revoked_at IS NULL"
+	res, err := r.db.ExecContext(ctx, updateQuery, time.Now(), code)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return n == 1, nil
 }
@@ -3,13 +3,17 @@ package repository
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/sntkn/go-oauth2/oauth2/domain"
 	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
 )
 
+const clientColumns = "id, name, first_party, secret_hash, grant_types, access_token_expires_min, refresh_token_expires_day, disabled_at, created_at, updated_at"
+
 func NewClientRepository(db *sqlx.DB) *ClientRepository {
 	return &ClientRepository{
 		db: db,
@@ -21,16 +25,9 @@ type ClientRepository struct {
 }
 
 func (r *ClientRepository) FindClientByClientID(ctx context.Context, clientID uuid.UUID) (domain.Client, error) {
-	q := "SELECT id, redirect_uris FROM oauth2_clients WHERE id = $1"
+	q := "SELECT " + clientColumns + " FROM oauth2_clients WHERE id = $1"
 	mapper := func(c model.Client) (domain.Client, error) {
-		redirectURIs := []string{}
-		if c.RedirectURIs != "" {
-			redirectURIs = strings.Split(c.RedirectURIs, ",")
-		}
-		return domain.NewClient(domain.ClientParams{
-			ID:           c.ID,
-			RedirectURIs: redirectURIs,
-		}), nil
+		return r.toDomainClient(ctx, c)
 	}
 
 	client, ok, err := fetchAndMap[model.Client, domain.Client](ctx, r.db, q, mapper, clientID)
@@ -43,3 +40,214 @@ func (r *ClientRepository) FindClientByClientID(ctx context.Context, clientID uu
 
 	return client, nil
 }
+
+func (r *ClientRepository) FindClients(ctx context.Context, offset, limit int) ([]domain.Client, error) {
+	var rows []model.Client
+	q := "SELECT " + clientColumns + " FROM oauth2_clients ORDER BY created_at, id OFFSET $1 LIMIT $2"
+	if err := r.db.SelectContext(ctx, &rows, q, offset, limit); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	clients := make([]domain.Client, 0, len(rows))
+	for _, m := range rows {
+		c, err := r.toDomainClient(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+	return clients, nil
+}
+
+func (r *ClientRepository) AddRedirectURI(ctx context.Context, clientID uuid.UUID, redirectURI domain.RedirectURI) error {
+	m := &model.ClientRedirectURI{
+		ID:          uuid.New(),
+		ClientID:    clientID,
+		RedirectURI: redirectURI.String(),
+		CreatedAt:   time.Now(),
+	}
+	q := `
+		INSERT INTO oauth2_client_redirect_uris (id, client_id, redirect_uri, created_at)
+		VALUES (:id, :client_id, :redirect_uri, :created_at)
+		ON CONFLICT (client_id, redirect_uri) DO NOTHING
+	`
+	_, err := r.db.NamedExecContext(ctx, q, m)
+	return errors.WithStack(err)
+}
+
+func (r *ClientRepository) CreateClient(ctx context.Context, c domain.Client) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	q := `
+		INSERT INTO oauth2_clients (` + clientColumns + `)
+		VALUES (:id, :name, :first_party, :secret_hash, :grant_types, :access_token_expires_min, :refresh_token_expires_day, :disabled_at, :created_at, :updated_at)
+	`
+	if _, err = tx.NamedExecContext(ctx, q, toModelClient(c)); err != nil {
+		return errors.WithStack(err)
+	}
+	if err = replaceClientSettings(ctx, tx, c); err != nil {
+		return err
+	}
+	return errors.WithStack(tx.Commit())
+}
+
+func (r *ClientRepository) UpdateClient(ctx context.Context, c domain.Client) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	m := toModelClient(c)
+	m.UpdatedAt = time.Now()
+	q := `
+		UPDATE oauth2_clients SET
+			name = :name,
+			first_party = :first_party,
+			secret_hash = :secret_hash,
+			grant_types = :grant_types,
+			access_token_expires_min = :access_token_expires_min,
+			refresh_token_expires_day = :refresh_token_expires_day,
+			disabled_at = :disabled_at,
+			updated_at = :updated_at
+		WHERE id = :id
+	`
+	if _, err = tx.NamedExecContext(ctx, q, m); err != nil {
+		return errors.WithStack(err)
+	}
+	if err = replaceClientSettings(ctx, tx, c); err != nil {
+		return err
+	}
+	return errors.WithStack(tx.Commit())
+}
+
+func (r *ClientRepository) DeleteClient(ctx context.Context, clientID uuid.UUID) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// 同意、リダイレクトURI、スコープは外部キーの ON DELETE CASCADE で削除される
+	queries := []string{
+		"DELETE FROM oauth2_refresh_tokens WHERE access_token IN (SELECT access_token FROM oauth2_tokens WHERE client_id = $1)",
+		"DELETE FROM oauth2_tokens WHERE client_id = $1",
+		"DELETE FROM oauth2_codes WHERE client_id = $1",
+		"DELETE FROM oauth2_clients WHERE id = $1",
+	}
+	for _, q := range queries {
+		if _, err = tx.ExecContext(ctx, q, clientID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return errors.WithStack(tx.Commit())
+}
+
+// replaceClientSettings はリダイレクトURIと許可するスコープを置き換える
+func replaceClientSettings(ctx context.Context, tx *sqlx.Tx, c domain.Client) error {
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, "DELETE FROM oauth2_client_redirect_uris WHERE client_id = $1", c.GetID()); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, u := range c.GetRedirectURIs() {
+		q := "INSERT INTO oauth2_client_redirect_uris (id, client_id, redirect_uri, created_at) VALUES ($1, $2, $3, $4)"
+		if _, err := tx.ExecContext(ctx, q, uuid.New(), c.GetID(), u.String(), now); err != nil {
+			return errors.WithStack(err)
+		}
+		// 登録順を保つため作成日時をずらす
+		now = now.Add(time.Microsecond)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM oauth2_client_scopes WHERE client_id = $1", c.GetID()); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, name := range c.GetAllowedScopes().Names() {
+		q := "INSERT INTO oauth2_client_scopes (client_id, scope_name, created_at) VALUES ($1, $2, $3)"
+		if _, err := tx.ExecContext(ctx, q, c.GetID(), name, now); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func (r *ClientRepository) toDomainClient(ctx context.Context, m model.Client) (domain.Client, error) {
+	redirectURIs, err := r.findRedirectURIs(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	allowedScopes, err := r.findAllowedScopes(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var secretHash string
+	if m.SecretHash != nil {
+		secretHash = *m.SecretHash
+	}
+	return domain.NewClient(domain.ClientParams{
+		ID:                     m.ID,
+		Name:                   m.Name,
+		RedirectURIs:           redirectURIs,
+		AllowedScopes:          allowedScopes,
+		GrantTypes:             strings.Fields(m.GrantTypes),
+		FirstParty:             m.FirstParty,
+		SecretHash:             secretHash,
+		AccessTokenExpiresMin:  m.AccessTokenExpiresMin,
+		RefreshTokenExpiresDay: m.RefreshTokenExpiresDay,
+		DisabledAt:             m.DisabledAt,
+		CreatedAt:              m.CreatedAt,
+		UpdatedAt:              m.UpdatedAt,
+	}), nil
+}
+
+func toModelClient(c domain.Client) *model.Client {
+	m := &model.Client{
+		ID:                     c.GetID(),
+		Name:                   c.GetName(),
+		FirstParty:             c.IsFirstParty(),
+		GrantTypes:             strings.Join(c.GetGrantTypes(), " "),
+		AccessTokenExpiresMin:  c.GetAccessTokenExpiresMin(),
+		RefreshTokenExpiresDay: c.GetRefreshTokenExpiresDay(),
+		DisabledAt:             c.GetDisabledAt(),
+		CreatedAt:              c.GetCreatedAt(),
+		UpdatedAt:              c.GetUpdatedAt(),
+	}
+	if hash := c.GetSecretHash(); hash != "" {
+		m.SecretHash = &hash
+	}
+	return m
+}
+
+func (r *ClientRepository) findRedirectURIs(ctx context.Context, clientID uuid.UUID) ([]string, error) {
+	var redirectURIs []string
+	q := "SELECT redirect_uri FROM oauth2_client_redirect_uris WHERE client_id = $1 ORDER BY created_at"
+	if err := r.db.SelectContext(ctx, &redirectURIs, q, clientID); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return redirectURIs, nil
+}
+
+func (r *ClientRepository) findAllowedScopes(ctx context.Context, clientID uuid.UUID) ([]string, error) {
+	var scopes []string
+	q := "SELECT scope_name FROM oauth2_client_scopes WHERE client_id = $1"
+	if err := r.db.SelectContext(ctx, &scopes, q, clientID); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return scopes, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewConsentRepository(db *sqlx.DB) *ConsentRepository {
+	return &ConsentRepository{
+		db: db,
+	}
+}
+
+type ConsentRepository struct {
+	db *sqlx.DB
+}
+
+func (r *ConsentRepository) FindConsent(ctx context.Context, userID, clientID uuid.UUID) (domain.Consent, error) {
+	q := "SELECT user_id, client_id, scope, expires_at, created_at, updated_at FROM oauth2_consents WHERE user_id = $1 AND client_id = $2"
+	mapper := func(c model.Consent) (domain.Consent, error) {
+		return domain.NewConsent(domain.ConsentParams{
+			UserID:    c.UserID,
+			ClientID:  c.ClientID,
+			Scope:     c.Scope,
+			ExpiresAt: c.ExpiresAt,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+
+	consent, ok, err := fetchAndMap[model.Consent, domain.Consent](ctx, r.db, q, mapper, userID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return consent, nil
+}
+
+func (r *ConsentRepository) FindConsentsByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Consent, error) {
+	q := "SELECT user_id, client_id, scope, expires_at, created_at, updated_at FROM oauth2_consents WHERE user_id = $1 ORDER BY created_at"
+	var rows []model.Consent
+	if err := r.db.SelectContext(ctx, &rows, q, userID); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	consents := make([]domain.Consent, 0, len(rows))
+	for _, c := range rows {
+		consent, err := domain.NewConsent(domain.ConsentParams{
+			UserID:    c.UserID,
+			ClientID:  c.ClientID,
+			Scope:     c.Scope,
+			ExpiresAt: c.ExpiresAt,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		consents = append(consents, consent)
+	}
+	return consents, nil
+}
+
+func (r *ConsentRepository) StoreConsent(ctx context.Context, c domain.Consent) error {
+	m := &model.Consent{
+		UserID:    c.GetUserID(),
+		ClientID:  c.GetClientID(),
+		Scope:     c.GetScopes().String(),
+		ExpiresAt: c.GetExpiresAt(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	q := `
+		INSERT INTO oauth2_consents (user_id, client_id, scope, expires_at, created_at, updated_at)
+		VALUES (:user_id, :client_id, :scope, :expires_at, :created_at, :updated_at)
+		ON CONFLICT (user_id, client_id)
+		DO UPDATE SET scope = EXCLUDED.scope, expires_at = EXCLUDED.expires_at, updated_at = EXCLUDED.updated_at
+	`
+	_, err := r.db.NamedExecContext(ctx, q, m)
+	return errors.WithStack(err)
+}
+
+func (r *ConsentRepository) DeleteConsent(ctx context.Context, userID, clientID uuid.UUID) error {
+	q := "DELETE FROM oauth2_consents WHERE user_id = $1 AND client_id = $2"
+	_, err := r.db.ExecContext(ctx, q, userID, clientID)
+	return errors.WithStack(err)
+}
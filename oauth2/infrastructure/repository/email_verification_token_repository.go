@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewEmailVerificationTokenRepository(db *sqlx.DB) *EmailVerificationTokenRepository {
+	return &EmailVerificationTokenRepository{
+		db: db,
+	}
+}
+
+type EmailVerificationTokenRepository struct {
+	db *sqlx.DB
+}
+
+func (r *EmailVerificationTokenRepository) StoreEmailVerificationToken(ctx context.Context, t domain.EmailVerificationToken) error {
+	m := &model.EmailVerificationToken{
+		TokenHash: t.GetTokenHash(),
+		UserID:    t.GetUserID(),
+		ExpiresAt: t.GetExpiresAt(),
+		CreatedAt: t.GetCreatedAt(),
+	}
+	q := `
+		INSERT INTO email_verification_tokens (token_hash, user_id, expires_at, created_at)
+		VALUES (:token_hash, :user_id, :expires_at, :created_at)
+	`
+	_, err := r.db.NamedExecContext(ctx, q, m)
+	return errors.WithStack(err)
+}
+
+func (r *EmailVerificationTokenRepository) FindEmailVerificationToken(ctx context.Context, tokenHash string) (domain.EmailVerificationToken, error) {
+	q := "SELECT token_hash, user_id, expires_at, used_at, created_at FROM email_verification_tokens WHERE token_hash = $1"
+	mapper := func(t model.EmailVerificationToken) (domain.EmailVerificationToken, error) {
+		return domain.NewEmailVerificationToken(domain.EmailVerificationTokenParams{
+			TokenHash: t.TokenHash,
+			UserID:    t.UserID,
+			ExpiresAt: t.ExpiresAt,
+			UsedAt:    t.UsedAt,
+			CreatedAt: t.CreatedAt,
+		}), nil
+	}
+
+	token, ok, err := fetchAndMap[model.EmailVerificationToken, domain.EmailVerificationToken](ctx, r.db, q, mapper, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return token, nil
+}
+
+func (r *EmailVerificationTokenRepository) ConsumeEmailVerificationToken(ctx context.Context, tokenHash string) (bool, error) {
+	q := "UPDATE email_verification_tokens SET used_at = $1 WHERE token_hash = $2 AND used_at IS NULL"
+	res, err := r.db.ExecContext(ctx, q, time.Now(), tokenHash)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return n == 1, nil
+}
+
+func (r *EmailVerificationTokenRepository) CountEmailVerificationTokensSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	q := "SELECT COUNT(*) FROM email_verification_tokens WHERE user_id = $1 AND created_at > $2"
+	var n int
+	if err := r.db.GetContext(ctx, &n, q, userID, since); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return n, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewFederatedIdentityRepository(db *sqlx.DB) *FederatedIdentityRepository {
+	return &FederatedIdentityRepository{
+		db: db,
+	}
+}
+
+type FederatedIdentityRepository struct {
+	db *sqlx.DB
+}
+
+func (r *FederatedIdentityRepository) FindFederatedIdentity(ctx context.Context, provider, subject string) (domain.FederatedIdentity, error) {
+	q := `
+		SELECT provider, subject, user_id, email, last_used_at, created_at
+		FROM user_federated_identities
+		WHERE provider = $1 AND subject = $2
+	`
+	mapper := func(m model.FederatedIdentity) (domain.FederatedIdentity, error) {
+		return domain.NewFederatedIdentity(domain.FederatedIdentityParams{
+			Provider:   m.Provider,
+			Subject:    m.Subject,
+			UserID:     m.UserID,
+			Email:      m.Email,
+			CreatedAt:  m.CreatedAt,
+			LastUsedAt: m.LastUsedAt,
+		}), nil
+	}
+
+	fi, ok, err := fetchAndMap[model.FederatedIdentity, domain.FederatedIdentity](ctx, r.db, q, mapper, provider, subject)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return fi, nil
+}
+
+func (r *FederatedIdentityRepository) StoreFederatedIdentity(ctx context.Context, fi domain.FederatedIdentity) error {
+	m := &model.FederatedIdentity{
+		Provider:   fi.GetProvider(),
+		Subject:    fi.GetSubject(),
+		UserID:     fi.GetUserID(),
+		Email:      fi.GetEmail(),
+		LastUsedAt: fi.GetLastUsedAt(),
+		CreatedAt:  fi.GetCreatedAt(),
+	}
+	// 同じ利用者のコールバックが同時に届いた場合は先に保存した紐づけを残す
+	q := `
+		INSERT INTO user_federated_identities (provider, subject, user_id, email, last_used_at, created_at)
+		VALUES (:provider, :subject, :user_id, :email, :last_used_at, :created_at)
+		ON CONFLICT (provider, subject) DO NOTHING
+	`
+	_, err := r.db.NamedExecContext(ctx, q, m)
+	return errors.WithStack(err)
+}
+
+func (r *FederatedIdentityRepository) TouchFederatedIdentity(ctx context.Context, provider, subject, email string, usedAt time.Time) error {
+	q := "UPDATE user_federated_identities SET email = $1, last_used_at = $2 WHERE provider = $3 AND subject = $4"
+	_, err := r.db.ExecContext(ctx, q, email, usedAt, provider, subject)
+	return errors.WithStack(err)
+}
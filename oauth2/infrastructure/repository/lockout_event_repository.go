@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewLockoutEventRepository(db *sqlx.DB) *LockoutEventRepository {
+	return &LockoutEventRepository{
+		db: db,
+	}
+}
+
+type LockoutEventRepository struct {
+	db *sqlx.DB
+}
+
+func (r *LockoutEventRepository) StoreLockoutEvent(ctx context.Context, e domain.LockoutEvent) error {
+	m := &model.LockoutEvent{
+		ID:          e.ID,
+		Kind:        e.Kind,
+		SubjectType: e.SubjectType,
+		Subject:     e.Subject,
+		IP:          e.IP,
+		LockedUntil: e.LockedUntil,
+		CreatedAt:   e.CreatedAt,
+	}
+	q := `
+		INSERT INTO login_lockout_events (id, kind, subject_type, subject, ip, locked_until, created_at)
+		VALUES (:id, :kind, :subject_type, :subject, :ip, :locked_until, :created_at)
+	`
+	_, err := r.db.NamedExecContext(ctx, q, m)
+	return errors.WithStack(err)
+}
+
+func (r *LockoutEventRepository) FindRecentLockoutEvents(ctx context.Context, limit int) ([]domain.LockoutEvent, error) {
+	var rows []model.LockoutEvent
+	q := "SELECT id, kind, subject_type, subject, ip, locked_until, created_at FROM login_lockout_events ORDER BY created_at DESC LIMIT $1"
+	if err := r.db.SelectContext(ctx, &rows, q, limit); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	events := make([]domain.LockoutEvent, 0, len(rows))
+	for _, e := range rows {
+		events = append(events, domain.LockoutEvent{
+			ID:          e.ID,
+			Kind:        e.Kind,
+			SubjectType: e.SubjectType,
+			Subject:     e.Subject,
+			IP:          e.IP,
+			LockedUntil: e.LockedUntil,
+			CreatedAt:   e.CreatedAt,
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewPasskeyRepository(db *sqlx.DB) *PasskeyRepository {
+	return &PasskeyRepository{
+		db: db,
+	}
+}
+
+type PasskeyRepository struct {
+	db *sqlx.DB
+}
+
+const passkeyColumns = "credential_id, user_id, name, public_key, attestation_type, aaguid, sign_count, transports, backup_eligible, backup_state, last_used_at, created_at"
+
+func (r *PasskeyRepository) FindPasskeysByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Passkey, error) {
+	var rows []model.Passkey
+	q := "SELECT " + passkeyColumns + " FROM user_passkeys WHERE user_id = $1 ORDER BY created_at"
+	if err := r.db.SelectContext(ctx, &rows, q, userID); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	passkeys := make([]domain.Passkey, 0, len(rows))
+	for _, p := range rows {
+		passkeys = append(passkeys, toDomainPasskey(p))
+	}
+	return passkeys, nil
+}
+
+func (r *PasskeyRepository) FindPasskey(ctx context.Context, credentialID []byte) (domain.Passkey, error) {
+	q := "SELECT " + passkeyColumns + " FROM user_passkeys WHERE credential_id = $1"
+	mapper := func(p model.Passkey) (domain.Passkey, error) {
+		return toDomainPasskey(p), nil
+	}
+
+	p, ok, err := fetchAndMap[model.Passkey, domain.Passkey](ctx, r.db, q, mapper, credentialID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return p, nil
+}
+
+func (r *PasskeyRepository) StorePasskey(ctx context.Context, p domain.Passkey) error {
+	m := &model.Passkey{
+		CredentialID:    p.GetCredentialID(),
+		UserID:          p.GetUserID(),
+		Name:            p.GetName(),
+		PublicKey:       p.GetPublicKey(),
+		AttestationType: p.GetAttestationType(),
+		AAGUID:          p.GetAAGUID(),
+		SignCount:       int64(p.GetSignCount()),
+		Transports:      strings.Join(p.GetTransports(), ","),
+		BackupEligible:  p.IsBackupEligible(),
+		BackupState:     p.IsBackupState(),
+		LastUsedAt:      p.GetLastUsedAt(),
+		CreatedAt:       p.GetCreatedAt(),
+	}
+	q := `
+		INSERT INTO user_passkeys (` + passkeyColumns + `)
+		VALUES (:credential_id, :user_id, :name, :public_key, :attestation_type, :aaguid, :sign_count, :transports,
+			:backup_eligible, :backup_state, :last_used_at, :created_at)
+	`
+	_, err := r.db.NamedExecContext(ctx, q, m)
+	return errors.WithStack(err)
+}
+
+func (r *PasskeyRepository) UpdatePasskeySignCount(ctx context.Context, credentialID []byte, count uint32, usedAt time.Time) (bool, error) {
+	// 同じ認証器の応答が同時に送られても一方しか成功しないよう、条件付きで更新する
+	q := "UPDATE user_passkeys SET sign_count = $1, last_used_at = $2 WHERE credential_id = $3 AND (sign_count < $1 OR $1 = 0)"
+	res, err := r.db.ExecContext(ctx, q, int64(count), usedAt, credentialID)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return n == 1, nil
+}
+
+func (r *PasskeyRepository) DeletePasskey(ctx context.Context, userID uuid.UUID, credentialID []byte) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM user_passkeys WHERE user_id = $1 AND credential_id = $2", userID, credentialID)
+	return errors.WithStack(err)
+}
+
+func toDomainPasskey(p model.Passkey) domain.Passkey {
+	var transports []string
+	if p.Transports != "" {
+		transports = strings.Split(p.Transports, ",")
+	}
+	return domain.NewPasskey(domain.PasskeyParams{
+		CredentialID:    p.CredentialID,
+		UserID:          p.UserID,
+		Name:            p.Name,
+		PublicKey:       p.PublicKey,
+		AttestationType: p.AttestationType,
+		AAGUID:          p.AAGUID,
+		SignCount:       uint32(p.SignCount),
+		Transports:      transports,
+		BackupEligible:  p.BackupEligible,
+		BackupState:     p.BackupState,
+		CreatedAt:       p.CreatedAt,
+		LastUsedAt:      p.LastUsedAt,
+	})
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewPasswordResetTokenRepository(db *sqlx.DB) *PasswordResetTokenRepository {
+	return &PasswordResetTokenRepository{
+		db: db,
+	}
+}
+
+type PasswordResetTokenRepository struct {
+	db *sqlx.DB
+}
+
+func (r *PasswordResetTokenRepository) StorePasswordResetToken(ctx context.Context, t domain.PasswordResetToken) error {
+	m := &model.PasswordResetToken{
+		TokenHash: t.GetTokenHash(),
+		UserID:    t.GetUserID(),
+		ExpiresAt: t.GetExpiresAt(),
+		CreatedAt: t.GetCreatedAt(),
+	}
+	q := `
+		INSERT INTO password_reset_tokens (token_hash, user_id, expires_at, created_at)
+		VALUES (:token_hash, :user_id, :expires_at, :created_at)
+	`
+	_, err := r.db.NamedExecContext(ctx, q, m)
+	return errors.WithStack(err)
+}
+
+func (r *PasswordResetTokenRepository) FindPasswordResetToken(ctx context.Context, tokenHash string) (domain.PasswordResetToken, error) {
+	q := "SELECT token_hash, user_id, expires_at, used_at, created_at FROM password_reset_tokens WHERE token_hash = $1"
+	mapper := func(t model.PasswordResetToken) (domain.PasswordResetToken, error) {
+		return domain.NewPasswordResetToken(domain.PasswordResetTokenParams{
+			TokenHash: t.TokenHash,
+			UserID:    t.UserID,
+			ExpiresAt: t.ExpiresAt,
+			UsedAt:    t.UsedAt,
+			CreatedAt: t.CreatedAt,
+		}), nil
+	}
+
+	token, ok, err := fetchAndMap[model.PasswordResetToken, domain.PasswordResetToken](ctx, r.db, q, mapper, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return token, nil
+}
+
+func (r *PasswordResetTokenRepository) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (bool, error) {
+	q := "UPDATE password_reset_tokens SET used_at = $1 WHERE token_hash = $2 AND used_at IS NULL"
+	res, err := r.db.ExecContext(ctx, q, time.Now(), tokenHash)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return n == 1, nil
+}
+
+func (r *PasswordResetTokenRepository) ConsumePasswordResetTokensByUserID(ctx context.Context, userID uuid.UUID) error {
+	q := "UPDATE password_reset_tokens SET used_at = $1 WHERE user_id = $2 AND used_at IS NULL"
+	_, err := r.db.ExecContext(ctx, q, time.Now(), userID)
+	return errors.WithStack(err)
+}
+
+func (r *PasswordResetTokenRepository) CountPasswordResetTokensSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	q := "SELECT COUNT(*) FROM password_reset_tokens WHERE user_id = $1 AND created_at > $2"
+	var n int
+	if err := r.db.GetContext(ctx, &n, q, userID, since); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return n, nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewRecoveryCodeRepository(db *sqlx.DB) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{
+		db: db,
+	}
+}
+
+type RecoveryCodeRepository struct {
+	db *sqlx.DB
+}
+
+func (r *RecoveryCodeRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, hashes []string) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE user_id = $1", userID); err != nil {
+		return errors.WithStack(err)
+	}
+	now := time.Now()
+	for _, hash := range hashes {
+		if _, err = tx.ExecContext(ctx, "INSERT INTO user_recovery_codes (user_id, code_hash, created_at) VALUES ($1, $2, $3)", userID, hash, now); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return errors.WithStack(tx.Commit())
+}
+
+func (r *RecoveryCodeRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) (bool, error) {
+	q := "UPDATE user_recovery_codes SET used_at = $1 WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL"
+	res, err := r.db.ExecContext(ctx, q, time.Now(), userID, hash)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return n == 1, nil
+}
+
+func (r *RecoveryCodeRepository) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	var n int
+	if err := r.db.GetContext(ctx, &n, "SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL", userID); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return n, nil
+}
+
+func (r *RecoveryCodeRepository) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE user_id = $1", userID)
+	return errors.WithStack(err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/sntkn/go-oauth2/oauth2/domain"
 	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
@@ -28,14 +29,14 @@ func (r *RefreshTokenRepository) StoreRefreshToken(ctx context.Context, t domain
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
-	q := `INSERT INTO oauth2_refresh_tokens (domain, access_token, expires_at, created_at, updated_at)
-	VALUES (:domain, :access_token, :expires_at, :created_at, :updated_at)`
+	q := `INSERT INTO oauth2_refresh_tokens (refresh_token, access_token, expires_at, created_at, updated_at)
+	VALUES (:refresh_token, :access_token, :expires_at, :created_at, :updated_at)`
 	_, err := r.db.NamedExecContext(ctx, q, rtoken)
 	return errors.WithStack(err)
 }
 
 func (r *RefreshTokenRepository) FindRefreshToken(ctx context.Context, refreshToken string) (domain.RefreshToken, error) {
-	q := "SELECT access_token, expires_at FROM oauth2_refresh_tokens WHERE domain = $1"
+	q := "SELECT access_token, expires_at FROM oauth2_refresh_tokens WHERE refresh_token = $1 AND revoked_at IS NULL"
 	mapper := func(rt model.RefreshToken) (domain.RefreshToken, error) {
 		return domain.NewRefreshToken(domain.RefreshTokenParams{
 			RefreshToken: domain.RefreshTokenString(refreshToken),
@@ -55,7 +56,47 @@ func (r *RefreshTokenRepository) FindRefreshToken(ctx context.Context, refreshTo
 }
 
 func (r *RefreshTokenRepository) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
-	updateQuery := "UPDATE oauth2_refresh_tokens SET revoked_at = $1 WHERE domain = $2"
+	updateQuery := "UPDATE oauth2_refresh_tokens SET revoked_at = $1 WHERE refresh_token = $2"
 	_, err := r.db.ExecContext(ctx, updateQuery, time.Now(), refreshToken)
 	return errors.WithStack(err)
 }
+
+func (r *RefreshTokenRepository) RevokeRefreshTokensByAuthorizationCode(ctx context.Context, code string) error {
+	updateQuery := `
+		UPDATE oauth2_refresh_tokens SET revoked_at = $1
+		WHERE revoked_at IS NULL
+		AND access_token IN (SELECT access_token FROM oauth2_tokens WHERE authorization_code = $2)
+	`
+	_, err := r.db.ExecContext(ctx, updateQuery, time.Now(), code)
+	return errors.WithStack(err)
+}
+
+func (r *RefreshTokenRepository) RevokeRefreshTokensByUserAndClient(ctx context.Context, userID, clientID uuid.UUID) error {
+	updateQuery := `
+		UPDATE oauth2_refresh_tokens SET revoked_at = $1
+		WHERE revoked_at IS NULL
+		AND access_token IN (SELECT access_token FROM oauth2_tokens WHERE user_id = $2 AND client_id = $3)
+	`
+	_, err := r.db.ExecContext(ctx, updateQuery, time.Now(), userID, clientID)
+	return errors.WithStack(err)
+}
+
+func (r *RefreshTokenRepository) RevokeRefreshTokensByUserID(ctx context.Context, userID uuid.UUID) error {
+	updateQuery := `
+		UPDATE oauth2_refresh_tokens SET revoked_at = $1
+		WHERE revoked_at IS NULL
+		AND access_token IN (SELECT access_token FROM oauth2_tokens WHERE user_id = $2)
+	`
+	_, err := r.db.ExecContext(ctx, updateQuery, time.Now(), userID)
+	return errors.WithStack(err)
+}
+
+func (r *RefreshTokenRepository) RevokeRefreshTokensByClientID(ctx context.Context, clientID uuid.UUID) error {
+	updateQuery := `
+		UPDATE oauth2_refresh_tokens SET revoked_at = $1
+		WHERE revoked_at IS NULL
+		AND access_token IN (SELECT access_token FROM oauth2_tokens WHERE client_id = $2)
+	`
+	_, err := r.db.ExecContext(ctx, updateQuery, time.Now(), clientID)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+const samlConnectionColumns = "id, name, display_name, idp_metadata, email_attribute, name_attribute, link_by_email, created_at, updated_at"
+
+func NewSAMLConnectionRepository(db *sqlx.DB) *SAMLConnectionRepository {
+	return &SAMLConnectionRepository{
+		db: db,
+	}
+}
+
+type SAMLConnectionRepository struct {
+	db *sqlx.DB
+}
+
+func (r *SAMLConnectionRepository) FindSAMLConnectionByName(ctx context.Context, name string) (domain.SAMLConnection, error) {
+	q := "SELECT " + samlConnectionColumns + " FROM saml_connections WHERE name = $1"
+	mapper := func(m model.SAMLConnection) (domain.SAMLConnection, error) {
+		return toDomainSAMLConnection(m), nil
+	}
+
+	c, ok, err := fetchAndMap[model.SAMLConnection, domain.SAMLConnection](ctx, r.db, q, mapper, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return c, nil
+}
+
+func (r *SAMLConnectionRepository) FindSAMLConnections(ctx context.Context) ([]domain.SAMLConnection, error) {
+	var rows []model.SAMLConnection
+	q := "SELECT " + samlConnectionColumns + " FROM saml_connections ORDER BY display_name"
+	if err := r.db.SelectContext(ctx, &rows, q); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	connections := make([]domain.SAMLConnection, 0, len(rows))
+	for _, m := range rows {
+		connections = append(connections, toDomainSAMLConnection(m))
+	}
+	return connections, nil
+}
+
+func toDomainSAMLConnection(m model.SAMLConnection) domain.SAMLConnection {
+	return domain.NewSAMLConnection(domain.SAMLConnectionParams{
+		ID:             m.ID,
+		Name:           m.Name,
+		DisplayName:    m.DisplayName,
+		IdPMetadata:    m.IdPMetadata,
+		EmailAttribute: m.EmailAttribute,
+		NameAttribute:  m.NameAttribute,
+		LinkByEmail:    m.LinkByEmail,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	})
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewScopeRepository(db *sqlx.DB) *ScopeRepository {
+	return &ScopeRepository{
+		db: db,
+	}
+}
+
+type ScopeRepository struct {
+	db *sqlx.DB
+}
+
+func (r *ScopeRepository) FindScopesByNames(ctx context.Context, names []string) ([]domain.Scope, error) {
+	if len(names) == 0 {
+		return []domain.Scope{}, nil
+	}
+
+	q, args, err := sqlx.In("SELECT name, description, requires_consent, is_default FROM oauth2_scopes WHERE name IN (?) ORDER BY name", names)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return r.selectScopes(ctx, r.db.Rebind(q), args...)
+}
+
+func (r *ScopeRepository) FindDefaultScopes(ctx context.Context) ([]domain.Scope, error) {
+	q := "SELECT name, description, requires_consent, is_default FROM oauth2_scopes WHERE is_default ORDER BY name"
+	return r.selectScopes(ctx, q)
+}
+
+func (r *ScopeRepository) selectScopes(ctx context.Context, q string, args ...any) ([]domain.Scope, error) {
+	var rows []model.Scope
+	if err := r.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	scopes := make([]domain.Scope, 0, len(rows))
+	for _, s := range rows {
+		scopes = append(scopes, domain.NewScope(domain.ScopeParams{
+			Name:            s.Name,
+			Description:     s.Description,
+			RequiresConsent: s.RequiresConsent,
+			IsDefault:       s.IsDefault,
+		}))
+	}
+	return scopes, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/sntkn/go-oauth2/oauth2/domain"
 	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
@@ -30,21 +31,32 @@ func (r *TokenRepository) StoreToken(ctx context.Context, accessToken domain.Tok
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
+	if code := accessToken.GetAuthorizationCode(); code != "" {
+		m.AuthorizationCode = &code
+	}
 	q := `
-		INSERT INTO oauth2_tokens (access_token, client_id, user_id, scope, expires_at, created_at, updated_at)
-		VALUES (:access_token, :client_id, :user_id, :scope, :expires_at, :created_at, :updated_at)
+		INSERT INTO oauth2_tokens (access_token, client_id, user_id, scope, authorization_code, expires_at, created_at, updated_at)
+		VALUES (:access_token, :client_id, :user_id, :scope, :authorization_code, :expires_at, :created_at, :updated_at)
 	`
 	_, err := r.db.NamedExecContext(ctx, q, m)
 	return errors.WithStack(err)
 }
 
 func (r *TokenRepository) FindToken(ctx context.Context, accessToken string) (domain.Token, error) {
-	q := "SELECT user_id, client_id, scope FROM oauth2_tokens WHERE access_token = $1"
+	q := "SELECT access_token, user_id, client_id, scope, authorization_code, expires_at, revoked_at FROM oauth2_tokens WHERE access_token = $1"
 	mapper := func(tkn model.Token) (domain.Token, error) {
+		var code string
+		if tkn.AuthorizationCode != nil {
+			code = *tkn.AuthorizationCode
+		}
 		return domain.NewToken(domain.TokenParams{
-			UserID:   tkn.UserID,
-			ClientID: tkn.ClientID,
-			Scope:    tkn.Scope,
+			AccessToken:       tkn.AccessToken,
+			UserID:            tkn.UserID,
+			ClientID:          tkn.ClientID,
+			Scope:             tkn.Scope,
+			AuthorizationCode: code,
+			ExpiresAt:         tkn.ExpiresAt,
+			RevokedAt:         tkn.RevokedAt,
 		}), nil
 	}
 
@@ -63,3 +75,55 @@ func (r *TokenRepository) RevokeToken(ctx context.Context, accessToken string) e
 	_, err := r.db.ExecContext(ctx, updateQuery, time.Now(), accessToken)
 	return errors.WithStack(err)
 }
+
+func (r *TokenRepository) RevokeTokensByAuthorizationCode(ctx context.Context, code string) error {
+	updateQuery := "UPDATE oauth2_tokens SET revoked_at = $1 WHERE authorization_code = $2 AND revoked_at IS NULL"
+	_, err := r.db.ExecContext(ctx, updateQuery, time.Now(), code)
+	return errors.WithStack(err)
+}
+
+func (r *TokenRepository) RevokeTokensByUserAndClient(ctx context.Context, userID, clientID uuid.UUID) error {
+	updateQuery := "UPDATE oauth2_tokens SET revoked_at = $1 WHERE user_id = $2 AND client_id = $3 AND revoked_at IS NULL"
+	_, err := r.db.ExecContext(ctx, updateQuery, time.Now(), userID, clientID)
+	return errors.WithStack(err)
+}
+
+func (r *TokenRepository) RevokeTokensByClientID(ctx context.Context, clientID uuid.UUID) error {
+	updateQuery := "UPDATE oauth2_tokens SET revoked_at = $1 WHERE client_id = $2 AND revoked_at IS NULL"
+	_, err := r.db.ExecContext(ctx, updateQuery, time.Now(), clientID)
+	return errors.WithStack(err)
+}
+
+func (r *TokenRepository) RevokeTokensByUserID(ctx context.Context, userID uuid.UUID) error {
+	updateQuery := "UPDATE oauth2_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL"
+	_, err := r.db.ExecContext(ctx, updateQuery, time.Now(), userID)
+	return errors.WithStack(err)
+}
+
+func (r *TokenRepository) SummarizeTokensByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]domain.TokenUsage, error) {
+	q := `
+		SELECT
+			client_id,
+			MIN(created_at) AS first_used_at,
+			MAX(created_at) AS last_used_at,
+			COUNT(*) FILTER (WHERE revoked_at IS NULL AND expires_at > $2) AS active_tokens
+		FROM oauth2_tokens
+		WHERE user_id = $1
+		GROUP BY client_id
+	`
+	var rows []model.TokenUsage
+	if err := r.db.SelectContext(ctx, &rows, q, userID, now); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	usages := make([]domain.TokenUsage, 0, len(rows))
+	for _, u := range rows {
+		usages = append(usages, domain.TokenUsage{
+			ClientID:     u.ClientID,
+			FirstUsedAt:  u.FirstUsedAt,
+			LastUsedAt:   u.LastUsedAt,
+			ActiveTokens: u.ActiveTokens,
+		})
+	}
+	return usages, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/secretbox"
+)
+
+// NewTOTPCredentialRepository の box はシークレットの暗号化に使う
+func NewTOTPCredentialRepository(db *sqlx.DB, box *secretbox.Box) *TOTPCredentialRepository {
+	return &TOTPCredentialRepository{
+		db:  db,
+		box: box,
+	}
+}
+
+type TOTPCredentialRepository struct {
+	db  *sqlx.DB
+	box *secretbox.Box
+}
+
+func (r *TOTPCredentialRepository) FindTOTPCredential(ctx context.Context, userID uuid.UUID) (domain.TOTPCredential, error) {
+	q := "SELECT user_id, secret, confirmed_at, last_used_step, created_at FROM user_totp_credentials WHERE user_id = $1"
+	mapper := func(c model.TOTPCredential) (domain.TOTPCredential, error) {
+		secret, err := r.box.Open(c.Secret)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return domain.NewTOTPCredential(domain.TOTPCredentialParams{
+			UserID:       c.UserID,
+			Secret:       string(secret),
+			ConfirmedAt:  c.ConfirmedAt,
+			LastUsedStep: c.LastUsedStep,
+			CreatedAt:    c.CreatedAt,
+		}), nil
+	}
+
+	c, ok, err := fetchAndMap[model.TOTPCredential, domain.TOTPCredential](ctx, r.db, q, mapper, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return c, nil
+}
+
+func (r *TOTPCredentialRepository) StoreTOTPCredential(ctx context.Context, c domain.TOTPCredential) error {
+	secret, err := r.box.Seal([]byte(c.GetSecret()))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	now := time.Now()
+	m := &model.TOTPCredential{
+		UserID:       c.GetUserID(),
+		Secret:       secret,
+		ConfirmedAt:  c.GetConfirmedAt(),
+		LastUsedStep: c.GetLastUsedStep(),
+		CreatedAt:    c.GetCreatedAt(),
+		UpdatedAt:    now,
+	}
+	q := `
+		INSERT INTO user_totp_credentials (user_id, secret, confirmed_at, last_used_step, created_at, updated_at)
+		VALUES (:user_id, :secret, :confirmed_at, :last_used_step, :created_at, :updated_at)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, confirmed_at = EXCLUDED.confirmed_at,
+			last_used_step = EXCLUDED.last_used_step, updated_at = EXCLUDED.updated_at
+	`
+	_, err = r.db.NamedExecContext(ctx, q, m)
+	return errors.WithStack(err)
+}
+
+func (r *TOTPCredentialRepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	// 同じコードが同時に送られても一方しか成功しないよう、条件付きで更新する
+	q := "UPDATE user_totp_credentials SET last_used_step = $1, updated_at = $2 WHERE user_id = $3 AND last_used_step < $1"
+	res, err := r.db.ExecContext(ctx, q, step, time.Now(), userID)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return n == 1, nil
+}
+
+func (r *TOTPCredentialRepository) DeleteTOTPCredential(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM user_totp_credentials WHERE user_id = $1", userID)
+	return errors.WithStack(err)
+}
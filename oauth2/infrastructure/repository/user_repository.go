@@ -2,10 +2,15 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/sntkn/go-oauth2/oauth2/domain"
 	"github.com/sntkn/go-oauth2/oauth2/infrastructure/model"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
 )
 
 func NewUserRepository(db *sqlx.DB) *UserRepository {
@@ -18,17 +23,24 @@ type UserRepository struct {
 	db *sqlx.DB
 }
 
+const userColumns = "id, name, email, password, email_verified_at, password_reset_required, disabled_at, is_admin, created_at, updated_at"
+
 func (r *UserRepository) FindUserByEmail(ctx context.Context, email string) (domain.User, error) {
-	q := "SELECT id, email, password FROM users WHERE email = $1"
+	q := "SELECT " + userColumns + " FROM users WHERE email = $1"
+	return r.findUser(ctx, q, email)
+}
+
+func (r *UserRepository) FindUserByID(ctx context.Context, id uuid.UUID) (domain.User, error) {
+	q := "SELECT " + userColumns + " FROM users WHERE id = $1"
+	return r.findUser(ctx, q, id)
+}
+
+func (r *UserRepository) findUser(ctx context.Context, q string, args ...any) (domain.User, error) {
 	mapper := func(u model.User) (domain.User, error) {
-		return domain.NewUser(domain.UserParams{
-			ID:       u.ID,
-			Email:    u.Email,
-			Password: u.Password,
-		}), nil
+		return toDomainUser(u), nil
 	}
 
-	user, ok, err := fetchAndMap[model.User, domain.User](ctx, r.db, q, mapper, email)
+	user, ok, err := fetchAndMap[model.User, domain.User](ctx, r.db, q, mapper, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -38,3 +50,166 @@ func (r *UserRepository) FindUserByEmail(ctx context.Context, email string) (dom
 
 	return user, nil
 }
+
+func (r *UserRepository) VerifyEmail(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+	q := "UPDATE users SET email_verified_at = $1, updated_at = $1 WHERE id = $2 AND email_verified_at IS NULL"
+	_, err := r.db.ExecContext(ctx, q, verifiedAt, id)
+	return errors.WithStack(err)
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, u domain.User) error {
+	q := "UPDATE users SET password = $1, password_reset_required = FALSE, updated_at = $2 WHERE id = $3"
+	_, err := r.db.ExecContext(ctx, q, u.GetPassword(), time.Now(), u.GetID())
+	return errors.WithStack(err)
+}
+
+func (r *UserRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) (bool, error) {
+	q := "UPDATE users SET password = $1, updated_at = $2 WHERE id = $3 AND password = $4"
+	res, err := r.db.ExecContext(ctx, q, newHash, time.Now(), id, oldHash)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return n == 1, nil
+}
+
+func (r *UserRepository) CreateUser(ctx context.Context, u domain.User) (domain.User, error) {
+	now := time.Now()
+	q := `
+		INSERT INTO users (name, email, password, is_admin, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (email) DO NOTHING
+		RETURNING id
+	`
+	var id uuid.UUID
+	if err := r.db.QueryRowxContext(ctx, q, u.GetName(), u.GetEmail(), u.GetPassword(), u.IsAdmin(), now, now).Scan(&id); err != nil {
+		// 一意制約に違反した場合は行が返らない
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserEmailAlreadyExists
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return domain.NewUser(domain.UserParams{
+		ID:        id,
+		Name:      u.GetName(),
+		Email:     u.GetEmail(),
+		Password:  u.GetPassword(),
+		IsAdmin:   u.IsAdmin(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}), nil
+}
+
+func (r *UserRepository) UpdateProfile(ctx context.Context, id uuid.UUID, name, email string, emailVerifiedAt *time.Time) error {
+	q := `
+		UPDATE users SET name = $1, email = $2, email_verified_at = $3, updated_at = $4
+		WHERE id = $5 AND NOT EXISTS (SELECT 1 FROM users WHERE email = $2 AND id <> $5)
+	`
+	res, err := r.db.ExecContext(ctx, q, name, email, emailVerifiedAt, time.Now(), id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if n == 0 {
+		// 更新しなかった理由がユーザーがいないためか、メールアドレスが使われているためかを区別する
+		var exists bool
+		if err := r.db.GetContext(ctx, &exists, "SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)", id); err != nil {
+			return errors.WithStack(err)
+		}
+		if !exists {
+			return domain.ErrUserNotFound
+		}
+		return domain.ErrUserEmailAlreadyExists
+	}
+	return nil
+}
+
+func (r *UserRepository) SearchUsers(ctx context.Context, query string, offset, limit int) ([]domain.User, error) {
+	var rows []model.User
+	q := `
+		SELECT ` + userColumns + ` FROM users
+		WHERE $1 = '' OR email ILIKE '%' || $1 || '%' OR name ILIKE '%' || $1 || '%'
+		ORDER BY created_at, id OFFSET $2 LIMIT $3
+	`
+	if err := r.db.SelectContext(ctx, &rows, q, escapeLike(query), offset, limit); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	users := make([]domain.User, 0, len(rows))
+	for _, u := range rows {
+		users = append(users, toDomainUser(u))
+	}
+	return users, nil
+}
+
+func (r *UserRepository) UpdateDisabledAt(ctx context.Context, id uuid.UUID, disabledAt *time.Time) error {
+	q := "UPDATE users SET disabled_at = $1, updated_at = $2 WHERE id = $3"
+	_, err := r.db.ExecContext(ctx, q, disabledAt, time.Now(), id)
+	return errors.WithStack(err)
+}
+
+func (r *UserRepository) UpdateAdmin(ctx context.Context, id uuid.UUID, isAdmin bool) error {
+	q := "UPDATE users SET is_admin = $1, updated_at = $2 WHERE id = $3"
+	_, err := r.db.ExecContext(ctx, q, isAdmin, time.Now(), id)
+	return errors.WithStack(err)
+}
+
+func (r *UserRepository) RequirePasswordReset(ctx context.Context, id uuid.UUID) error {
+	q := "UPDATE users SET password_reset_required = TRUE, updated_at = $1 WHERE id = $2"
+	_, err := r.db.ExecContext(ctx, q, time.Now(), id)
+	return errors.WithStack(err)
+}
+
+func (r *UserRepository) DeleteUser(ctx context.Context, id uuid.UUID) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// 確認用のトークン、二要素認証、パスキー、外部の紐づけ、同意は外部キーの ON DELETE CASCADE で削除される
+	queries := []string{
+		"DELETE FROM oauth2_refresh_tokens WHERE access_token IN (SELECT access_token FROM oauth2_tokens WHERE user_id = $1)",
+		"DELETE FROM oauth2_tokens WHERE user_id = $1",
+		"DELETE FROM oauth2_codes WHERE user_id = $1",
+		"DELETE FROM posts WHERE user_id = $1",
+		"DELETE FROM users WHERE id = $1",
+	}
+	for _, q := range queries {
+		if _, err = tx.ExecContext(ctx, q, id); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return errors.WithStack(tx.Commit())
+}
+
+func toDomainUser(u model.User) domain.User {
+	return domain.NewUser(domain.UserParams{
+		ID:                    u.ID,
+		Name:                  u.Name,
+		Email:                 u.Email,
+		Password:              u.Password,
+		EmailVerifiedAt:       u.EmailVerifiedAt,
+		PasswordResetRequired: u.PasswordResetRequired,
+		DisabledAt:            u.DisabledAt,
+		IsAdmin:               u.IsAdmin,
+		CreatedAt:             u.CreatedAt,
+		UpdatedAt:             u.UpdatedAt,
+	})
+}
+
+// escapeLike は LIKE のパターンで特別な意味を持つ文字をエスケープする
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -77,7 +77,7 @@ func (*Token) Parse(tokenStr string, publicKeyBase64 string) (*CustomClaims, err
 	}
 
 	// 公開鍵を使ってJWTをパース
-	parsedToken, err := jwt.Parse(tokenStr, func(token *jwt.Token) (any, error) {
+	parsedToken, err := jwt.ParseWithClaims(tokenStr, &CustomClaims{}, func(token *jwt.Token) (any, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
@@ -0,0 +1,67 @@
+package accesstoken
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateKeys(t *testing.T) (string, string) {
+	t.Helper()
+	pub, pri, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(pri), base64.StdEncoding.EncodeToString(pub)
+}
+
+func TestParse(t *testing.T) {
+	pri, pub := generateKeys(t)
+	userID := uuid.New()
+	clientID := uuid.New()
+
+	tokenStr, err := NewTokenService().Generate(&TokenParams{
+		UserID:    userID,
+		ClientID:  clientID,
+		Scope:     "read admin",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, pri)
+	require.NoError(t, err)
+
+	claims, err := NewTokenService().Parse(tokenStr, pub)
+	require.NoError(t, err)
+	assert.Equal(t, userID.String(), claims.UserID)
+	assert.Equal(t, clientID.String(), claims.ClientID)
+	assert.Equal(t, "read admin", claims.Scope)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	pri, pub := generateKeys(t)
+	_, otherPub := generateKeys(t)
+
+	expired, err := NewTokenService().Generate(&TokenParams{
+		UserID:    uuid.New(),
+		ClientID:  uuid.New(),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}, pri)
+	require.NoError(t, err)
+
+	// 期限切れのトークンは受け付けない
+	_, err = NewTokenService().Parse(expired, pub)
+	require.Error(t, err)
+
+	valid, err := NewTokenService().Generate(&TokenParams{
+		UserID:    uuid.New(),
+		ClientID:  uuid.New(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, pri)
+	require.NoError(t, err)
+
+	// 別の鍵で署名されたトークンは受け付けない
+	_, err = NewTokenService().Parse(valid, otherPub)
+	require.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package csrf
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+)
+
+const (
+	sessionKey = "csrfToken"
+	tokenBytes = 32
+
+	// FormField はフォームでトークンを送信するフィールド名
+	FormField = "csrf_token"
+	// HeaderName はフォーム以外からトークンを送信するヘッダー名
+	HeaderName = "X-CSRF-Token"
+)
+
+// Token はセッションに紐づくトークンを返す。まだ発行していない場合は新しく発行する
+func Token(c *gin.Context, s session.SessionClient) (string, error) {
+	token, err := s.GetSessionData(c, sessionKey)
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		return token, nil
+	}
+
+	b := make([]byte, tokenBytes)
+	// crypto/rand.Read はエラーを返さない
+	_, _ = rand.Read(b)
+	token = base64.RawURLEncoding.EncodeToString(b)
+
+	if err := s.SetSessionData(c, sessionKey, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Verify は送信されたトークンがセッションのトークンと一致するかを判定する
+func Verify(c *gin.Context, s session.SessionClient, token string) (bool, error) {
+	expected, err := s.GetSessionData(c, sessionKey)
+	if err != nil {
+		return false, err
+	}
+	if expected == "" || token == "" {
+		return false, nil
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1, nil
+}
@@ -0,0 +1,71 @@
+package csrf
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestContext() *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(nil)
+	return c
+}
+
+func newSessionClientMock(store map[string]string) *session.SessionClientMock {
+	return &session.SessionClientMock{
+		GetSessionDataFunc: func(_ *gin.Context, key string) (string, error) {
+			return store[key], nil
+		},
+		SetSessionDataFunc: func(_ *gin.Context, key string, input string) error {
+			store[key] = input
+			return nil
+		},
+	}
+}
+
+func TestToken(t *testing.T) {
+	c := setupTestContext()
+	s := newSessionClientMock(map[string]string{})
+
+	token, err := Token(c, s)
+	require.NoError(t, err)
+	assert.Len(t, token, 43)
+
+	// 同じセッションでは同じトークンを返す
+	again, err := Token(c, s)
+	require.NoError(t, err)
+	assert.Equal(t, token, again)
+	assert.Len(t, s.SetSessionDataCalls(), 1)
+
+	other, err := Token(c, newSessionClientMock(map[string]string{}))
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestVerify(t *testing.T) {
+	c := setupTestContext()
+	s := newSessionClientMock(map[string]string{})
+
+	ok, err := Verify(c, s, "token")
+	require.NoError(t, err)
+	assert.False(t, ok, "no token has been issued")
+
+	token, err := Token(c, s)
+	require.NoError(t, err)
+
+	ok, err = Verify(c, s, token)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Verify(c, s, token+"x")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = Verify(c, s, "")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
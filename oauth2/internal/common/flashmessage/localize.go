@@ -0,0 +1,49 @@
+package flashmessage
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// Localizable は利用者の言語に翻訳して表示するメッセージ
+type Localizable interface {
+	MessageKey() string
+	MessageArgs() []any
+}
+
+// 先頭の言語を Accept-Language が合わない場合に使う
+var supported = []language.Tag{language.Japanese, language.English}
+
+var matcher = language.NewMatcher(supported)
+
+var catalog = map[language.Tag]map[string]string{
+	language.Japanese: {
+		"password.too_short":      "パスワードは%d文字以上にしてください",
+		"password.too_long":       "パスワードは%dバイト以内にしてください",
+		"password.contains_email": "パスワードにメールアドレスを含めないでください",
+		"password.breached":       "このパスワードは過去に漏洩したことがあるため使えません",
+	},
+	language.English: {
+		"password.too_short":      "Password must be at least %d characters.",
+		"password.too_long":       "Password must be at most %d bytes.",
+		"password.contains_email": "Password must not contain your email address.",
+		"password.breached":       "This password has appeared in a data breach and cannot be used.",
+	},
+}
+
+// Localize は Accept-Language に合わせてメッセージを翻訳する。翻訳がない場合はキーを返す
+func Localize(c *gin.Context, m Localizable) string {
+	tags, _, _ := language.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		index = 0
+	}
+
+	format, ok := catalog[supported[index]][m.MessageKey()]
+	if !ok {
+		return m.MessageKey()
+	}
+	return fmt.Sprintf(format, m.MessageArgs()...)
+}
@@ -1,9 +1,12 @@
 package session
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"time"
+	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
@@ -12,7 +15,75 @@ import (
 
 type Creator func(c *gin.Context) *Session
 
-const sessionExpirationSeconds = 3600
+const (
+	sessionExpirationSeconds = 3600
+	sessionIDBytes           = 32
+	sessionCookieName        = "sessionID"
+
+	CookiePrefixHost   = "__Host-"
+	CookiePrefixSecure = "__Secure-"
+)
+
+var (
+	ErrInvalidCookiePrefix   = errors.New("cookie prefix must be __Host- or __Secure-")
+	ErrInsecurePrefixCookie  = errors.New("prefixed cookie must be secure")
+	ErrHostCookieWithDomain  = errors.New("__Host- cookie must not have a domain")
+	ErrInvalidCookieSameSite = errors.New("cookie SameSite must be Lax, Strict or None")
+	ErrInsecureSameSiteNone  = errors.New("SameSite=None cookie must be secure")
+)
+
+// CookieOptions はセッションIDを保存するクッキーの属性
+type CookieOptions struct {
+	Domain   string
+	Secure   bool
+	SameSite http.SameSite
+	// Prefix にはクッキー名の接頭辞 (__Host- または __Secure-) を指定する
+	Prefix string
+}
+
+// Name は接頭辞を付けたクッキー名を返す
+func (o CookieOptions) Name() string {
+	return o.Prefix + sessionCookieName
+}
+
+// Validate はブラウザに拒否される組み合わせでないかを確認する
+func (o CookieOptions) Validate() error {
+	switch o.Prefix {
+	case "":
+	case CookiePrefixHost:
+		// __Host- は Secure かつ Domain なし、Path=/ のクッキーのみ受け付けられる
+		if o.Domain != "" {
+			return ErrHostCookieWithDomain
+		}
+		if !o.Secure {
+			return ErrInsecurePrefixCookie
+		}
+	case CookiePrefixSecure:
+		if !o.Secure {
+			return ErrInsecurePrefixCookie
+		}
+	default:
+		return ErrInvalidCookiePrefix
+	}
+	if o.SameSite == http.SameSiteNoneMode && !o.Secure {
+		return ErrInsecureSameSiteNone
+	}
+	return nil
+}
+
+// ParseSameSite は設定値の文字列を SameSite 属性に変換する
+func ParseSameSite(s string) (http.SameSite, error) {
+	switch strings.ToLower(s) {
+	case "lax":
+		return http.SameSiteLaxMode, nil
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
+	default:
+		return http.SameSiteDefaultMode, ErrInvalidCookieSameSite
+	}
+}
 
 //go:generate go run github.com/matryer/moq -out session_manager_mock.go . SessionManager
 type SessionManager interface {
@@ -22,34 +93,46 @@ type SessionManager interface {
 type DefaultSessionManager struct {
 	cli     valkey.ClientIF
 	expires int
+	cookie  CookieOptions
 }
 
-func NewSessionManager(cli valkey.ClientIF, expires int) *DefaultSessionManager {
+func NewSessionManager(cli valkey.ClientIF, expires int, cookie CookieOptions) (*DefaultSessionManager, error) {
+	if err := cookie.Validate(); err != nil {
+		return nil, err
+	}
 	return &DefaultSessionManager{
 		cli:     cli,
 		expires: expires,
-	}
+		cookie:  cookie,
+	}, nil
 }
 
 func (m *DefaultSessionManager) NewSession(c *gin.Context) SessionClient {
-	// セッションIDをクッキーから取得
-	sessionID, err := c.Cookie("sessionID")
-	if err != nil {
-		// セッションIDがない場合は新しいセッションIDを生成
-		sessionID = GenerateSessionID()
-		// クッキーにセッションIDをセット
-		c.SetCookie("sessionID", sessionID, m.expires, "/", "localhost", false, true)
+	s := &Session{
+		SessionStore: m.cli,
+		Expires:      m.expires,
+		Cookie:       m.cookie,
 	}
 
-	return &Session{
-		SessionID:    sessionID,
-		SessionStore: m.cli,
+	// セッションIDをクッキーから取得
+	sessionID, err := c.Cookie(m.cookie.Name())
+	if err != nil || !isValidSessionID(sessionID) {
+		// セッションIDがない、または生成した形式でない場合は新しいセッションIDを発行する
+		s.SessionID = GenerateSessionID()
+		s.setCookie(c)
+		return s
 	}
+
+	s.SessionID = sessionID
+	return s
 }
 
 type Session struct {
 	SessionID    string
 	SessionStore valkey.ClientIF
+	// Expires はセッションデータの有効期間 (秒)。クッキーの有効期間と揃える
+	Expires int
+	Cookie  CookieOptions
 }
 
 //go:generate go run github.com/matryer/moq -out session_mock.go . SessionClient
@@ -58,6 +141,7 @@ type SessionClient interface {
 	SetSessionData(c *gin.Context, key string, input string) error
 	DelSessionData(c *gin.Context, key string) error
 	PullSessionData(c *gin.Context, key string) (string, error)
+	RegenerateID(c *gin.Context, keys ...string) error
 }
 
 // Load retrieves typed session data.
@@ -99,9 +183,54 @@ func Pop[T any](c *gin.Context, s SessionClient, key string) (T, bool, error) {
 	return value, true, nil
 }
 
-// セッションIDを生成する関数
+// GenerateSessionID は推測できない 256 ビットのセッションIDを生成する
 func GenerateSessionID() string {
-	return time.Now().Format("20060102150405")
+	b := make([]byte, sessionIDBytes)
+	// crypto/rand.Read はエラーを返さない
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func isValidSessionID(id string) bool {
+	b, err := base64.RawURLEncoding.DecodeString(id)
+	return err == nil && len(b) == sessionIDBytes
+}
+
+// RegenerateID はセッションIDを新しく発行し直す。認証の成功後に呼び出してセッション固定攻撃を防ぐ。
+// keys に指定したデータのみ新しいセッションに引き継ぎ、古いセッションからは削除する
+func (s *Session) RegenerateID(c *gin.Context, keys ...string) error {
+	newSess := *s
+	newSess.SessionID = GenerateSessionID()
+
+	for _, key := range keys {
+		v, err := s.PullSessionData(c, key)
+		if err != nil {
+			return err
+		}
+		if v == "" {
+			continue
+		}
+		if err := newSess.SetSessionData(c, key, v); err != nil {
+			return err
+		}
+	}
+
+	s.SessionID = newSess.SessionID
+	s.setCookie(c)
+	return nil
+}
+
+func (s *Session) setCookie(c *gin.Context) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     s.Cookie.Name(),
+		Value:    s.SessionID,
+		Path:     "/",
+		Domain:   s.Cookie.Domain,
+		MaxAge:   s.Expires,
+		Secure:   s.Cookie.Secure,
+		HttpOnly: true,
+		SameSite: s.Cookie.SameSite,
+	})
 }
 
 // セッションデータを取得する関数
@@ -110,7 +239,14 @@ func (s *Session) GetSessionData(c *gin.Context, key string) (string, error) {
 }
 
 func (s *Session) SetSessionData(c *gin.Context, key string, input string) error {
-	return s.SessionStore.Set(c, s.fullKey(key), input, sessionExpirationSeconds)
+	return s.SessionStore.Set(c, s.fullKey(key), input, s.expiration())
+}
+
+func (s *Session) expiration() int64 {
+	if s.Expires <= 0 {
+		return sessionExpirationSeconds
+	}
+	return int64(s.Expires)
 }
 
 func (s *Session) DelSessionData(c *gin.Context, key string) error {
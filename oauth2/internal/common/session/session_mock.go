@@ -27,6 +27,9 @@ var _ SessionClient = &SessionClientMock{}
 //			PullSessionDataFunc: func(c *gin.Context, key string) (string, error) {
 //				panic("mock out the PullSessionData method")
 //			},
+//			RegenerateIDFunc: func(c *gin.Context, keys ...string) error {
+//				panic("mock out the RegenerateID method")
+//			},
 //			SetSessionDataFunc: func(c *gin.Context, key string, input string) error {
 //				panic("mock out the SetSessionData method")
 //			},
@@ -46,6 +49,9 @@ type SessionClientMock struct {
 	// PullSessionDataFunc mocks the PullSessionData method.
 	PullSessionDataFunc func(c *gin.Context, key string) (string, error)
 
+	// RegenerateIDFunc mocks the RegenerateID method.
+	RegenerateIDFunc func(c *gin.Context, keys ...string) error
+
 	// SetSessionDataFunc mocks the SetSessionData method.
 	SetSessionDataFunc func(c *gin.Context, key string, input string) error
 
@@ -72,6 +78,13 @@ type SessionClientMock struct {
 			// Key is the key argument value.
 			Key string
 		}
+		// RegenerateID holds details about calls to the RegenerateID method.
+		RegenerateID []struct {
+			// C is the c argument value.
+			C *gin.Context
+			// Keys is the keys argument value.
+			Keys []string
+		}
 		// SetSessionData holds details about calls to the SetSessionData method.
 		SetSessionData []struct {
 			// C is the c argument value.
@@ -85,6 +98,7 @@ type SessionClientMock struct {
 	lockDelSessionData  sync.RWMutex
 	lockGetSessionData  sync.RWMutex
 	lockPullSessionData sync.RWMutex
+	lockRegenerateID    sync.RWMutex
 	lockSetSessionData  sync.RWMutex
 }
 
@@ -196,6 +210,42 @@ func (mock *SessionClientMock) PullSessionDataCalls() []struct {
 	return calls
 }
 
+// RegenerateID calls RegenerateIDFunc.
+func (mock *SessionClientMock) RegenerateID(c *gin.Context, keys ...string) error {
+	if mock.RegenerateIDFunc == nil {
+		panic("SessionClientMock.RegenerateIDFunc: method is nil but SessionClient.RegenerateID was just called")
+	}
+	callInfo := struct {
+		C    *gin.Context
+		Keys []string
+	}{
+		C:    c,
+		Keys: keys,
+	}
+	mock.lockRegenerateID.Lock()
+	mock.calls.RegenerateID = append(mock.calls.RegenerateID, callInfo)
+	mock.lockRegenerateID.Unlock()
+	return mock.RegenerateIDFunc(c, keys...)
+}
+
+// RegenerateIDCalls gets all the calls that were made to RegenerateID.
+// Check the length with:
+//
+//	len(mockedSessionClient.RegenerateIDCalls())
+func (mock *SessionClientMock) RegenerateIDCalls() []struct {
+	C    *gin.Context
+	Keys []string
+} {
+	var calls []struct {
+		C    *gin.Context
+		Keys []string
+	}
+	mock.lockRegenerateID.RLock()
+	calls = mock.calls.RegenerateID
+	mock.lockRegenerateID.RUnlock()
+	return calls
+}
+
 // SetSessionData calls SetSessionDataFunc.
 func (mock *SessionClientMock) SetSessionData(c *gin.Context, key string, input string) error {
 	if mock.SetSessionDataFunc == nil {
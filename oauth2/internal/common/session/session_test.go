@@ -3,6 +3,8 @@ package session
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -56,6 +58,31 @@ func TestSetSessionData(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSetSessionData_Expires(t *testing.T) {
+	mockValkey := &valkey.ClientIFMock{
+		SetFunc: func(_ context.Context, _ string, _ string, _ int64) error {
+			return nil
+		},
+	}
+
+	c := setupTestContext()
+
+	session := &Session{
+		SessionID:    "sessionID",
+		SessionStore: mockValkey,
+		Expires:      86400,
+	}
+
+	err := session.SetSessionData(c, "testKey", "testValue")
+	require.NoError(t, err)
+	assert.Equal(t, int64(86400), mockValkey.SetCalls()[0].Expiration)
+
+	session.Expires = 0
+	err = session.SetSessionData(c, "testKey", "testValue")
+	require.NoError(t, err)
+	assert.Equal(t, int64(sessionExpirationSeconds), mockValkey.SetCalls()[1].Expiration)
+}
+
 func TestDelSessionData(t *testing.T) {
 	mockValkey := &valkey.ClientIFMock{
 		DelFunc: func(_ context.Context, _ string) error {
@@ -164,3 +191,138 @@ func TestLoadSavePopTypedSessionData(t *testing.T) {
 		assert.Equal(t, input, actual)
 	})
 }
+
+func TestGenerateSessionID(t *testing.T) {
+	id1 := GenerateSessionID()
+	id2 := GenerateSessionID()
+
+	assert.True(t, isValidSessionID(id1))
+	assert.Len(t, id1, 43)
+	assert.NotEqual(t, id1, id2)
+}
+
+func TestNewSession(t *testing.T) {
+	m, err := NewSessionManager(&valkey.ClientIFMock{}, 3600, CookieOptions{
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Prefix:   CookiePrefixHost,
+	})
+	require.NoError(t, err)
+
+	t.Run("issues a new session id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+		sess := m.NewSession(c).(*Session)
+		assert.True(t, isValidSessionID(sess.SessionID))
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, "__Host-sessionID", cookies[0].Name)
+		assert.Equal(t, sess.SessionID, cookies[0].Value)
+		assert.Equal(t, "/", cookies[0].Path)
+		assert.Empty(t, cookies[0].Domain)
+		assert.True(t, cookies[0].Secure)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+		assert.Equal(t, 3600, cookies[0].MaxAge)
+	})
+
+	t.Run("reuses a valid session id", func(t *testing.T) {
+		id := GenerateSessionID()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Request.AddCookie(&http.Cookie{Name: "__Host-sessionID", Value: id})
+
+		sess := m.NewSession(c).(*Session)
+		assert.Equal(t, id, sess.SessionID)
+		assert.Empty(t, w.Result().Cookies())
+	})
+
+	t.Run("replaces a session id chosen by the client", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Request.AddCookie(&http.Cookie{Name: "__Host-sessionID", Value: "20240101000000"})
+
+		sess := m.NewSession(c).(*Session)
+		assert.NotEqual(t, "20240101000000", sess.SessionID)
+		assert.True(t, isValidSessionID(sess.SessionID))
+	})
+}
+
+func TestRegenerateID(t *testing.T) {
+	store := map[string]string{
+		"old:flashMessage": "message",
+		"old:sign":         "sign",
+	}
+	mockValkey := &valkey.ClientIFMock{
+		GetFunc: func(_ context.Context, key string) (string, error) {
+			return store[key], nil
+		},
+		SetFunc: func(_ context.Context, key string, value string, _ int64) error {
+			store[key] = value
+			return nil
+		},
+		DelFunc: func(_ context.Context, key string) error {
+			delete(store, key)
+			return nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	sess := &Session{SessionID: "old", SessionStore: mockValkey, Expires: 3600}
+
+	err := sess.RegenerateID(c, "flashMessage", "missing")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, "old", sess.SessionID)
+	assert.Equal(t, "message", store[sess.SessionID+":flashMessage"])
+	assert.NotContains(t, store, "old:flashMessage")
+	assert.NotContains(t, store, sess.SessionID+":sign")
+	assert.NotContains(t, store, sess.SessionID+":missing")
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, sess.SessionID, cookies[0].Value)
+}
+
+func TestCookieOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CookieOptions
+		wantErr error
+	}{
+		{name: "default", opts: CookieOptions{}},
+		{name: "host prefix", opts: CookieOptions{Secure: true, Prefix: CookiePrefixHost}},
+		{name: "host prefix with domain", opts: CookieOptions{Secure: true, Domain: "example.com", Prefix: CookiePrefixHost}, wantErr: ErrHostCookieWithDomain},
+		{name: "host prefix without secure", opts: CookieOptions{Prefix: CookiePrefixHost}, wantErr: ErrInsecurePrefixCookie},
+		{name: "secure prefix with domain", opts: CookieOptions{Secure: true, Domain: "example.com", Prefix: CookiePrefixSecure}},
+		{name: "secure prefix without secure", opts: CookieOptions{Prefix: CookiePrefixSecure}, wantErr: ErrInsecurePrefixCookie},
+		{name: "unknown prefix", opts: CookieOptions{Secure: true, Prefix: "__Foo-"}, wantErr: ErrInvalidCookiePrefix},
+		{name: "samesite none without secure", opts: CookieOptions{SameSite: http.SameSiteNoneMode}, wantErr: ErrInsecureSameSiteNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseSameSite(t *testing.T) {
+	v, err := ParseSameSite("Strict")
+	require.NoError(t, err)
+	assert.Equal(t, http.SameSiteStrictMode, v)
+
+	_, err = ParseSameSite("invalid")
+	require.ErrorIs(t, err, ErrInvalidCookieSameSite)
+}
@@ -0,0 +1,132 @@
+package sso
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/valkey"
+)
+
+const (
+	sessionKey       = "sso"
+	revokedKeyPrefix = "ssoRevoked:"
+)
+
+// 認証方式 (RFC 8176)
+const (
+	AMRPassword = "pwd"
+	AMROTP      = "otp"
+	AMRMFA      = "mfa"
+	// AMRHardwareKey は認証器に保護された鍵による認証 (パスキー)
+	AMRHardwareKey = "hwk"
+	// AMRFederated は上流の ID プロバイダでの認証。RFC 8176 にはないが Microsoft Entra ID などで使われている
+	AMRFederated = "fed"
+	// AMRRecoveryCode はリカバリーコードによる認証。RFC 8176 にはないため、認証アプリのコード (otp) と区別する独自の値を使う
+	AMRRecoveryCode = "rcv"
+)
+
+// 認証の強度 (acr)。NIST SP 800-63B の認証保証レベルに対応させる
+const (
+	ACRSingleFactor = "aal1"
+	ACRMultiFactor  = "aal2"
+)
+
+// ACR は認証方式から認証の強度を決める
+func ACR(amr []string) string {
+	if slices.Contains(amr, AMRMFA) {
+		return ACRMultiFactor
+	}
+	return ACRSingleFactor
+}
+
+// Session は認可リクエストをまたいで維持されるログイン状態
+type Session struct {
+	UserID   string
+	Email    string
+	AuthTime time.Time
+	AMR      []string
+	ACR      string
+}
+
+// IsFresh は max_age 秒以内に認証されたかを判定する。max_age が指定されていない場合は nil を渡す
+func (s *Session) IsFresh(maxAge *int, now time.Time) bool {
+	if maxAge == nil {
+		return true
+	}
+	return !now.After(s.AuthTime.Add(time.Duration(*maxAge) * time.Second))
+}
+
+// Load はSSOセッションを取得する。ログインしていない場合や、失効させられたセッションの場合は nil を返す
+func Load(c *gin.Context, s session.SessionClient, r *Revoker) (*Session, error) {
+	sess, ok, err := session.Load[Session](c, s, sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || sess.UserID == "" {
+		return nil, nil
+	}
+	if r == nil {
+		return &sess, nil
+	}
+
+	revoked, err := r.IsRevoked(c.Request.Context(), &sess)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		if err := Delete(c, s); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return &sess, nil
+}
+
+func Save(c *gin.Context, s session.SessionClient, sess Session) error {
+	return session.Save(c, s, sessionKey, sess)
+}
+
+func Delete(c *gin.Context, s session.SessionClient) error {
+	return s.DelSessionData(c, sessionKey)
+}
+
+// Revoker はユーザーのSSOセッションを全ての端末でまとめて失効させる。
+// ユーザーごとに失効させた時刻を保存し、それ以前に認証したセッションを無効として扱う
+type Revoker struct {
+	cli     valkey.ClientIF
+	expires int64
+}
+
+// NewRevoker の expires にはセッションの有効期限を秒で指定する。
+// それより前に認証したセッションは残っていないため、記録もその時間だけ保持すればよい
+func NewRevoker(cli valkey.ClientIF, expires int) *Revoker {
+	return &Revoker{
+		cli:     cli,
+		expires: int64(expires),
+	}
+}
+
+func (r *Revoker) RevokeUserSessions(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	return r.cli.Set(ctx, revokedKeyPrefix+userID.String(), strconv.FormatInt(at.UnixNano(), 10), r.expires)
+}
+
+// IsRevoked はセッションが失効させた時刻以前に認証されたものかを判定する
+func (r *Revoker) IsRevoked(ctx context.Context, s *Session) (bool, error) {
+	v, err := r.cli.Get(ctx, revokedKeyPrefix+s.UserID)
+	if err != nil {
+		return false, err
+	}
+	if v == "" {
+		return false, nil
+	}
+	revokedAt, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	return !s.AuthTime.After(time.Unix(0, revokedAt)), nil
+}
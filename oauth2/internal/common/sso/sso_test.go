@@ -0,0 +1,127 @@
+package sso
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/valkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestContext() *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(nil)
+	return c
+}
+
+func newSessionClientMock(store map[string]string) *session.SessionClientMock {
+	return &session.SessionClientMock{
+		GetSessionDataFunc: func(_ *gin.Context, key string) (string, error) {
+			return store[key], nil
+		},
+		SetSessionDataFunc: func(_ *gin.Context, key string, input string) error {
+			store[key] = input
+			return nil
+		},
+		DelSessionDataFunc: func(_ *gin.Context, key string) error {
+			delete(store, key)
+			return nil
+		},
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	c := setupTestContext()
+	s := newSessionClientMock(map[string]string{})
+	authTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err := Save(c, s, Session{UserID: "user", Email: "user@example.com", AuthTime: authTime, AMR: []string{AMRPassword}})
+	require.NoError(t, err)
+
+	sess, err := Load(c, s, nil)
+	require.NoError(t, err)
+	require.NotNil(t, sess)
+	assert.Equal(t, "user", sess.UserID)
+	assert.True(t, authTime.Equal(sess.AuthTime))
+	assert.Equal(t, []string{AMRPassword}, sess.AMR)
+
+	require.NoError(t, Delete(c, s))
+	sess, err = Load(c, s, nil)
+	require.NoError(t, err)
+	assert.Nil(t, sess)
+}
+
+func TestIsFresh(t *testing.T) {
+	now := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+	sess := &Session{UserID: "user", AuthTime: now.Add(-10 * time.Minute)}
+	maxAge := func(v int) *int { return &v }
+
+	assert.True(t, sess.IsFresh(nil, now))
+	assert.True(t, sess.IsFresh(maxAge(600), now))
+	assert.False(t, sess.IsFresh(maxAge(599), now))
+	assert.False(t, sess.IsFresh(maxAge(0), now))
+}
+
+func newValkeyMock(store map[string]string) *valkey.ClientIFMock {
+	return &valkey.ClientIFMock{
+		SetFunc: func(_ context.Context, key string, value string, _ int64) error {
+			store[key] = value
+			return nil
+		},
+		GetFunc: func(_ context.Context, key string) (string, error) {
+			return store[key], nil
+		},
+	}
+}
+
+func TestLoad_Revoked(t *testing.T) {
+	c := setupTestContext()
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	s := newSessionClientMock(map[string]string{})
+	cli := newValkeyMock(map[string]string{})
+	r := NewRevoker(cli, 3600)
+	userID := uuid.New()
+	authTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, Save(c, s, Session{UserID: userID.String(), AuthTime: authTime}))
+
+	// 失効させる前に認証したセッションは残る
+	require.NoError(t, r.RevokeUserSessions(context.Background(), userID, authTime.Add(-time.Second)))
+	sess, err := Load(c, s, r)
+	require.NoError(t, err)
+	require.NotNil(t, sess)
+	assert.Equal(t, int64(3600), cli.SetCalls()[0].Expiration)
+
+	// 失効させた時刻以前に認証したセッションは破棄される
+	require.NoError(t, r.RevokeUserSessions(context.Background(), userID, authTime))
+	sess, err = Load(c, s, r)
+	require.NoError(t, err)
+	assert.Nil(t, sess)
+	assert.Len(t, s.DelSessionDataCalls(), 1)
+}
+
+func TestLoad_RevokedOtherUser(t *testing.T) {
+	c := setupTestContext()
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	s := newSessionClientMock(map[string]string{})
+	r := NewRevoker(newValkeyMock(map[string]string{}), 3600)
+	authTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, Save(c, s, Session{UserID: uuid.NewString(), AuthTime: authTime}))
+	require.NoError(t, r.RevokeUserSessions(context.Background(), uuid.New(), authTime.Add(time.Hour)))
+
+	sess, err := Load(c, s, r)
+	require.NoError(t, err)
+	assert.NotNil(t, sess)
+}
+
+func TestACR(t *testing.T) {
+	assert.Equal(t, ACRSingleFactor, ACR([]string{AMRPassword}))
+	assert.Equal(t, ACRMultiFactor, ACR([]string{AMRPassword, AMROTP, AMRMFA}))
+}
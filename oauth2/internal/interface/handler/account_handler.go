@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/csrf"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/flashmessage"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/sso"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewAccountHandler(opt HandlerOption) *AccountHandler {
+	clientRepo := repository.NewClientRepository(opt.DB)
+	consentRepo := repository.NewConsentRepository(opt.DB)
+	tokenRepo := repository.NewTokenRepository(opt.DB)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(opt.DB)
+	uc := usecase.NewAccountUsecase(clientRepo, consentRepo, tokenRepo, refreshTokenRepo)
+	return &AccountHandler{
+		uc:         uc,
+		mfaUC:      newMFAUsecase(opt),
+		passkeyUC:  newPasskeyUsecase(opt),
+		session:    opt.Session,
+		ssoRevoker: opt.SSORevoker,
+		config:     opt.Config,
+	}
+}
+
+type AccountHandler struct {
+	uc         usecase.IAccountUsecase
+	mfaUC      usecase.IMFAUsecase
+	passkeyUC  usecase.IPasskeyUsecase
+	session    session.SessionManager
+	ssoRevoker *sso.Revoker
+	config     *config.Config
+}
+
+func (h *AccountHandler) Apps(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	ssoSess, ok := h.loadSSOSession(c, sess)
+	if !ok {
+		return
+	}
+
+	mess, err := flashmessage.Flash(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	apps, err := h.uc.ConnectedApps(c.Request.Context(), ssoSess.UserID)
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	token, err := csrf.Token(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.HTML(http.StatusOK, "apps.html", gin.H{"apps": apps, "mess": mess, "csrf": token})
+}
+
+func (h *AccountHandler) RevokeApp(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	ssoSess, ok := h.loadSSOSession(c, sess)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.RevokeApp(c.Request.Context(), ssoSess.UserID, c.Param("client_id")); err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	if err := flashmessage.AddMessage(c, sess, flashmessage.Success, "アクセスを取り消しました"); err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/account/apps")
+}
+
+// loadSSOSession はログイン中のユーザーを取得する。ログインしていない場合はエラー画面を表示して false を返す
+func (h *AccountHandler) loadSSOSession(c *gin.Context, sess session.SessionClient) (*sso.Session, bool) {
+	ssoSess, err := sso.Load(c, sess, h.ssoRevoker)
+	if err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if ssoSess == nil {
+		c.HTML(http.StatusUnauthorized, "400.html", gin.H{"error": "login required"})
+		return nil, false
+	}
+
+	return ssoSess, true
+}
+
+// loadSSOSessionJSON は loadSSOSession と同じだが、画面の代わりにJSONでエラーを返す
+func (h *AccountHandler) loadSSOSessionJSON(c *gin.Context, sess session.SessionClient) (*sso.Session, bool) {
+	ssoSess, err := sso.Load(c, sess, h.ssoRevoker)
+	if err != nil {
+		abortWithJSONError(c, errors.WithStack(err))
+		return nil, false
+	}
+
+	if ssoSess == nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "login required"})
+		return nil, false
+	}
+
+	return ssoSess, true
+}
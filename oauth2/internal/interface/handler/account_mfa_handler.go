@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/csrf"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/flashmessage"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+const qrCodeSize = 256
+
+func newMFAUsecase(opt HandlerOption) usecase.IMFAUsecase {
+	userRepo := repository.NewUserRepository(opt.DB)
+	totpRepo := repository.NewTOTPCredentialRepository(opt.DB, opt.SecretBox)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(opt.DB)
+	return usecase.NewMFAUsecase(userRepo, totpRepo, recoveryCodeRepo, opt.Config)
+}
+
+// MFA は二要素認証の状態を表示する。未登録の場合は認証アプリに読み込ませる QR コードを表示する
+func (h *AccountHandler) MFA(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	ssoSess, ok := h.loadSSOSession(c, sess)
+	if !ok {
+		return
+	}
+
+	mess, err := flashmessage.Flash(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := csrf.Token(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	status, err := h.mfaUC.Status(c.Request.Context(), ssoSess.UserID)
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+	if status.Enabled {
+		c.HTML(http.StatusOK, "mfa.html", gin.H{"status": status, "mess": mess, "csrf": token})
+		return
+	}
+
+	enrollment, err := h.mfaUC.BeginEnrollment(c.Request.Context(), ssoSess.UserID)
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	png, err := qrcode.Encode(enrollment.KeyURI, qrcode.Medium, qrCodeSize)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+	// data URI はテンプレートで無害化されないよう template.URL として渡す
+	qr := template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(png)) //nolint:gosec // サーバーで生成した画像のみを埋め込む
+
+	c.HTML(http.StatusOK, "mfa.html", gin.H{
+		"status": status,
+		"secret": enrollment.Secret,
+		"qr":     qr,
+		"mess":   mess,
+		"csrf":   token,
+	})
+}
+
+type MFACodeInput struct {
+	Code string `form:"code" binding:"required"`
+}
+
+// ConfirmMFA は認証アプリのコードを確認して二要素認証を有効にし、リカバリーコードを表示する
+func (h *AccountHandler) ConfirmMFA(c *gin.Context) {
+	h.showRecoveryCodes(c, h.mfaUC.ConfirmEnrollment, "二要素認証を有効にしました")
+}
+
+// RegenerateRecoveryCodes はリカバリーコードを発行し直して表示する
+func (h *AccountHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	h.showRecoveryCodes(c, h.mfaUC.RegenerateRecoveryCodes, "リカバリーコードを発行し直しました")
+}
+
+func (h *AccountHandler) showRecoveryCodes(c *gin.Context, issue func(ctx context.Context, userID, code string) ([]string, error), message string) {
+	sess := h.session.NewSession(c)
+
+	ssoSess, ok := h.loadSSOSession(c, sess)
+	if !ok {
+		return
+	}
+
+	var input MFACodeInput
+	if err := c.ShouldBind(&input); err != nil {
+		if flashErr := flashmessage.AddMessage(c, sess, flashmessage.Error, err.Error()); flashErr != nil {
+			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": flashErr.Error()})
+			return
+		}
+		c.Redirect(http.StatusFound, "/account/mfa")
+		return
+	}
+
+	codes, err := issue(c.Request.Context(), ssoSess.UserID, input.Code)
+	if err != nil {
+		h.redirectMFAError(c, sess, err)
+		return
+	}
+
+	// リカバリーコードは保存されないため、この画面でのみ表示する
+	c.HTML(http.StatusOK, "mfa_recovery_codes.html", gin.H{"codes": codes, "message": message})
+}
+
+// DisableMFA は二要素認証を解除する
+func (h *AccountHandler) DisableMFA(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	ssoSess, ok := h.loadSSOSession(c, sess)
+	if !ok {
+		return
+	}
+
+	var input MFACodeInput
+	if err := c.ShouldBind(&input); err != nil {
+		if flashErr := flashmessage.AddMessage(c, sess, flashmessage.Error, err.Error()); flashErr != nil {
+			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": flashErr.Error()})
+			return
+		}
+		c.Redirect(http.StatusFound, "/account/mfa")
+		return
+	}
+
+	if err := h.mfaUC.Disable(c.Request.Context(), ssoSess.UserID, input.Code); err != nil {
+		h.redirectMFAError(c, sess, err)
+		return
+	}
+
+	if err := flashmessage.AddMessage(c, sess, flashmessage.Success, "二要素認証を解除しました"); err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/account/mfa")
+}
+
+// redirectMFAError はコードの誤りをフラッシュメッセージで知らせて設定画面に戻る
+func (h *AccountHandler) redirectMFAError(c *gin.Context, sess session.SessionClient, err error) {
+	if !errors.Is(err, usecase.ErrInvalidMFACode) {
+		handleError(c, sess, err)
+		return
+	}
+	if flashErr := flashmessage.AddMessage(c, sess, flashmessage.Error, err.Error()); flashErr != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": flashErr.Error()})
+		return
+	}
+	c.Redirect(http.StatusFound, "/account/mfa")
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/csrf"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/flashmessage"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+const defaultPasskeyName = "Passkey"
+
+func newPasskeyUsecase(opt HandlerOption) usecase.IPasskeyUsecase {
+	userRepo := repository.NewUserRepository(opt.DB)
+	passkeyRepo := repository.NewPasskeyRepository(opt.DB)
+	return usecase.NewPasskeyUsecase(userRepo, passkeyRepo, opt.WebAuthn)
+}
+
+// Passkeys は登録済みのパスキーの一覧と登録ボタンを表示する
+func (h *AccountHandler) Passkeys(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	ssoSess, ok := h.loadSSOSession(c, sess)
+	if !ok {
+		return
+	}
+
+	mess, err := flashmessage.Flash(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	passkeys, err := h.passkeyUC.Passkeys(c.Request.Context(), ssoSess.UserID)
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	token, err := csrf.Token(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.HTML(http.StatusOK, "passkeys.html", gin.H{"passkeys": passkeys, "mess": mess, "csrf": token})
+}
+
+// BeginPasskeyRegistration は navigator.credentials.create に渡すオプションを返す
+func (h *AccountHandler) BeginPasskeyRegistration(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	ssoSess, ok := h.loadSSOSessionJSON(c, sess)
+	if !ok {
+		return
+	}
+
+	creation, data, err := h.passkeyUC.BeginRegistration(c.Request.Context(), ssoSess.UserID)
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+
+	if err := session.Save(c, sess, "webauthn_registration", data); err != nil {
+		abortWithJSONError(c, errors.WithStack(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, creation)
+}
+
+type FinishPasskeyRegistrationInput struct {
+	Name string `form:"name" binding:"max=255"`
+}
+
+// FinishPasskeyRegistration は認証器の応答を検証してパスキーを登録する
+func (h *AccountHandler) FinishPasskeyRegistration(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	ssoSess, ok := h.loadSSOSessionJSON(c, sess)
+	if !ok {
+		return
+	}
+
+	var input FinishPasskeyRegistrationInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Name == "" {
+		input.Name = defaultPasskeyName
+	}
+
+	// チャレンジは一度しか使えないよう取り出した時点で削除する
+	data, ok, err := session.Pop[webauthn.SessionData](c, sess, "webauthn_registration")
+	if err != nil {
+		abortWithJSONError(c, errors.WithStack(err))
+		return
+	}
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "registration has not started"})
+		return
+	}
+
+	if _, err := h.passkeyUC.FinishRegistration(c.Request.Context(), usecase.FinishPasskeyRegistrationParams{
+		UserID:  ssoSess.UserID,
+		Name:    input.Name,
+		Session: data,
+		Body:    c.Request.Body,
+	}); err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+
+	if err := flashmessage.AddMessage(c, sess, flashmessage.Success, "パスキーを登録しました"); err != nil {
+		abortWithJSONError(c, errors.WithStack(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"redirect": "/account/passkeys"})
+}
+
+// DeletePasskey はパスキーの登録を解除する
+func (h *AccountHandler) DeletePasskey(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	ssoSess, ok := h.loadSSOSession(c, sess)
+	if !ok {
+		return
+	}
+
+	if err := h.passkeyUC.DeletePasskey(c.Request.Context(), ssoSess.UserID, c.Param("credential_id")); err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	if err := flashmessage.AddMessage(c, sess, flashmessage.Success, "パスキーを削除しました"); err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/account/passkeys")
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+)
+
+const defaultClientsLimit = 50
+
+func newClientAdminUsecase(opt HandlerOption) usecase.IClientAdminUsecase {
+	clientRepo := repository.NewClientRepository(opt.DB)
+	scopeRepo := repository.NewScopeRepository(opt.DB)
+	tokenRepo := repository.NewTokenRepository(opt.DB)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(opt.DB)
+	return usecase.NewClientAdminUsecase(clientRepo, scopeRepo, tokenRepo, refreshTokenRepo)
+}
+
+type ClientResponse struct {
+	ID                     uuid.UUID  `json:"id"`
+	Name                   string     `json:"name"`
+	RedirectURIs           []string   `json:"redirect_uris"`
+	Scopes                 []string   `json:"scopes"`
+	GrantTypes             []string   `json:"grant_types"`
+	FirstParty             bool       `json:"first_party"`
+	Confidential           bool       `json:"confidential"`
+	AccessTokenExpiresMin  int        `json:"access_token_expires_min"`
+	RefreshTokenExpiresDay int        `json:"refresh_token_expires_day"`
+	DisabledAt             *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+	// ClientSecret は作成とシークレットの再発行の応答にだけ含める
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+func NewClientResponse(c domain.Client) ClientResponse {
+	redirectURIs := make([]string, 0, len(c.GetRedirectURIs()))
+	for _, u := range c.GetRedirectURIs() {
+		redirectURIs = append(redirectURIs, u.String())
+	}
+	return ClientResponse{
+		ID:                     c.GetID(),
+		Name:                   c.GetName(),
+		RedirectURIs:           redirectURIs,
+		Scopes:                 c.GetAllowedScopes().Names(),
+		GrantTypes:             c.GetGrantTypes(),
+		FirstParty:             c.IsFirstParty(),
+		Confidential:           c.IsConfidential(),
+		AccessTokenExpiresMin:  c.GetAccessTokenExpiresMin(),
+		RefreshTokenExpiresDay: c.GetRefreshTokenExpiresDay(),
+		DisabledAt:             c.GetDisabledAt(),
+		CreatedAt:              c.GetCreatedAt(),
+		UpdatedAt:              c.GetUpdatedAt(),
+	}
+}
+
+type ClientURI struct {
+	ClientID string `uri:"client_id" binding:"required,uuid"`
+}
+
+func bindClientID(c *gin.Context) (uuid.UUID, bool) {
+	var uri ClientURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return uuid.Nil, false
+	}
+	return uuid.MustParse(uri.ClientID), true
+}
+
+type ClientsInput struct {
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// Clients はクライアントを作成順に返す。続きがある場合は next_offset を含める
+func (h *AdminHandler) Clients(c *gin.Context) {
+	var input ClientsInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Limit == 0 {
+		input.Limit = defaultClientsLimit
+	}
+
+	clients, more, err := h.clientUC.ListClients(c.Request.Context(), input.Offset, input.Limit)
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+
+	res := make([]ClientResponse, 0, len(clients))
+	for _, client := range clients {
+		res = append(res, NewClientResponse(client))
+	}
+	body := gin.H{"clients": res}
+	if more {
+		body["next_offset"] = input.Offset + len(clients)
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+type CreateClientInput struct {
+	Name                   string   `json:"name" binding:"required"`
+	RedirectURIs           []string `json:"redirect_uris" binding:"required,min=1"`
+	Scopes                 []string `json:"scopes"`
+	GrantTypes             []string `json:"grant_types"`
+	FirstParty             bool     `json:"first_party"`
+	Public                 bool     `json:"public"`
+	AccessTokenExpiresMin  int      `json:"access_token_expires_min"`
+	RefreshTokenExpiresDay int      `json:"refresh_token_expires_day"`
+}
+
+// CreateClient はクライアントを登録する。シークレットは応答でこの時だけ返す
+func (h *AdminHandler) CreateClient(c *gin.Context) {
+	var input CreateClientInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	client, secret, err := h.clientUC.CreateClient(c.Request.Context(), usecase.CreateClientParams{
+		Name:                   input.Name,
+		RedirectURIs:           input.RedirectURIs,
+		AllowedScopes:          input.Scopes,
+		GrantTypes:             input.GrantTypes,
+		FirstParty:             input.FirstParty,
+		Public:                 input.Public,
+		AccessTokenExpiresMin:  input.AccessTokenExpiresMin,
+		RefreshTokenExpiresDay: input.RefreshTokenExpiresDay,
+	})
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+
+	res := NewClientResponse(client)
+	res.ClientSecret = secret
+	c.JSON(http.StatusCreated, res)
+}
+
+func (h *AdminHandler) Client(c *gin.Context) {
+	clientID, ok := bindClientID(c)
+	if !ok {
+		return
+	}
+
+	client, err := h.clientUC.GetClient(c.Request.Context(), clientID)
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, NewClientResponse(client))
+}
+
+// UpdateClientInput は省略した項目を変更しない
+type UpdateClientInput struct {
+	Name                   *string  `json:"name"`
+	RedirectURIs           []string `json:"redirect_uris" binding:"omitempty,min=1"`
+	Scopes                 []string `json:"scopes"`
+	GrantTypes             []string `json:"grant_types"`
+	AccessTokenExpiresMin  *int     `json:"access_token_expires_min"`
+	RefreshTokenExpiresDay *int     `json:"refresh_token_expires_day"`
+}
+
+func (h *AdminHandler) UpdateClient(c *gin.Context) {
+	clientID, ok := bindClientID(c)
+	if !ok {
+		return
+	}
+	var input UpdateClientInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	client, err := h.clientUC.UpdateClient(c.Request.Context(), clientID, usecase.UpdateClientParams{
+		Name:                   input.Name,
+		RedirectURIs:           input.RedirectURIs,
+		AllowedScopes:          input.Scopes,
+		GrantTypes:             input.GrantTypes,
+		AccessTokenExpiresMin:  input.AccessTokenExpiresMin,
+		RefreshTokenExpiresDay: input.RefreshTokenExpiresDay,
+	})
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, NewClientResponse(client))
+}
+
+// RotateClientSecret は新しいシークレットを発行して返す。以前のシークレットはすぐに使えなくなる
+func (h *AdminHandler) RotateClientSecret(c *gin.Context) {
+	clientID, ok := bindClientID(c)
+	if !ok {
+		return
+	}
+
+	secret, err := h.clientUC.RotateClientSecret(c.Request.Context(), clientID)
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"client_id": clientID, "client_secret": secret})
+}
+
+// DisableClient はクライアントを無効にし、発行済みのトークンを失効させる
+func (h *AdminHandler) DisableClient(c *gin.Context) {
+	clientID, ok := bindClientID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.clientUC.DisableClient(c.Request.Context(), clientID); err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AdminHandler) DeleteClient(c *gin.Context) {
+	clientID, ok := bindClientID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.clientUC.DeleteClient(c.Request.Context(), clientID); err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+)
+
+const defaultLockoutEventsLimit = 50
+
+func newLockoutUsecase(opt HandlerOption) usecase.ILockoutUsecase {
+	eventRepo := repository.NewLockoutEventRepository(opt.DB)
+	return usecase.NewLockoutUsecase(opt.LoginAttempts, eventRepo, opt.Config)
+}
+
+func newAdminAuthUsecase(opt HandlerOption) usecase.IAdminAuthUsecase {
+	tokenRepo := repository.NewTokenRepository(opt.DB)
+	userRepo := repository.NewUserRepository(opt.DB)
+	clientRepo := repository.NewClientRepository(opt.DB)
+	return usecase.NewAdminAuthUsecase(tokenRepo, userRepo, clientRepo)
+}
+
+// AdminHandler は admin スコープのアクセストークンで呼び出す管理用のAPI
+func NewAdminHandler(opt HandlerOption) *AdminHandler {
+	return &AdminHandler{
+		authUC:          newAdminAuthUsecase(opt),
+		lockoutUC:       newLockoutUsecase(opt),
+		clientUC:        newClientAdminUsecase(opt),
+		userUC:          newUserAdminUsecase(opt),
+		passwordResetUC: newPasswordResetUsecase(opt),
+	}
+}
+
+type AdminHandler struct {
+	authUC          usecase.IAdminAuthUsecase
+	lockoutUC       usecase.ILockoutUsecase
+	clientUC        usecase.IClientAdminUsecase
+	userUC          usecase.IUserAdminUsecase
+	passwordResetUC usecase.IPasswordResetUsecase
+}
+
+// Authorize は AuthMiddleware の後に使い、失効したアクセストークン、無効にしたユーザーやクライアントのアクセストークン、
+// 管理者に指定していないユーザーのアクセストークンを拒否する
+func (h *AdminHandler) Authorize(c *gin.Context) {
+	if err := h.authUC.Authorize(c.Request.Context(), c.GetString("accessToken")); err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.Next()
+}
+
+type LockoutEventResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Kind        string     `json:"kind"`
+	SubjectType string     `json:"subject_type"`
+	Subject     string     `json:"subject"`
+	IP          string     `json:"ip,omitempty"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type LockoutEventsInput struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// LockoutEvents は最近のロックと解除の記録を新しい順に返す
+func (h *AdminHandler) LockoutEvents(c *gin.Context) {
+	var input LockoutEventsInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Limit == 0 {
+		input.Limit = defaultLockoutEventsLimit
+	}
+
+	events, err := h.lockoutUC.Events(c.Request.Context(), input.Limit)
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+
+	res := make([]LockoutEventResponse, 0, len(events))
+	for _, e := range events {
+		res = append(res, LockoutEventResponse{
+			ID:          e.ID,
+			Kind:        e.Kind,
+			SubjectType: e.SubjectType,
+			Subject:     e.Subject,
+			IP:          e.IP,
+			LockedUntil: e.LockedUntil,
+			CreatedAt:   e.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"events": res})
+}
+
+type UnlockInput struct {
+	Email string `json:"email" binding:"required_without=IP,omitempty,email"`
+	IP    string `json:"ip" binding:"required_without=Email,omitempty,ip"`
+}
+
+// Unlock はアカウントまたは接続元のロックを解除する
+func (h *AdminHandler) Unlock(c *gin.Context) {
+	var input UnlockInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.lockoutUC.Unlock(c.Request.Context(), usecase.UnlockParams{
+		Email: input.Email,
+		IP:    input.IP,
+	}); err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
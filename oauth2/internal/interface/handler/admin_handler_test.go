@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/accesstoken"
+	"github.com/sntkn/go-oauth2/oauth2/internal/interface/middleware"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// adminFixture はメモリ上のユーザー、クライアント、アクセストークンを持ち、main と同じ順に管理用のAPIのミドルウェアを並べる
+type adminFixture struct {
+	users      map[uuid.UUID]domain.UserParams
+	clients    map[uuid.UUID]domain.Client
+	tokens     map[string]domain.TokenParams
+	clientID   uuid.UUID
+	privateKey string
+	router     *gin.Engine
+}
+
+func newAdminFixture(t *testing.T) *adminFixture {
+	t.Helper()
+	pub, pri, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	f := &adminFixture{
+		users:      map[uuid.UUID]domain.UserParams{},
+		clients:    map[uuid.UUID]domain.Client{},
+		tokens:     map[string]domain.TokenParams{},
+		clientID:   uuid.New(),
+		privateKey: base64.StdEncoding.EncodeToString(pri),
+	}
+	f.clients[f.clientID] = domain.NewClient(domain.ClientParams{ID: f.clientID, Name: "console", SecretHash: "hash"})
+
+	userRepo := &domain.UserRepositoryMock{
+		FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return domain.NewUser(f.users[id]), nil
+		},
+		UpdateDisabledAtFunc: func(ctx context.Context, id uuid.UUID, disabledAt *time.Time) error {
+			p := f.users[id]
+			p.DisabledAt = disabledAt
+			f.users[id] = p
+			return nil
+		},
+	}
+	tokenRepo := &domain.TokenRepositoryMock{
+		FindTokenFunc: func(ctx context.Context, accessToken string) (domain.Token, error) {
+			p, ok := f.tokens[accessToken]
+			if !ok {
+				return nil, nil
+			}
+			return domain.NewToken(p), nil
+		},
+		RevokeTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID) error {
+			f.revokeTokens(func(p domain.TokenParams) bool { return p.UserID == userID })
+			return nil
+		},
+		RevokeTokensByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) error {
+			f.revokeTokens(func(p domain.TokenParams) bool { return p.ClientID == clientID })
+			return nil
+		},
+	}
+	refreshTokenRepo := &domain.RefreshTokenRepositoryMock{
+		RevokeRefreshTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID) error {
+			return nil
+		},
+		RevokeRefreshTokensByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) error {
+			return nil
+		},
+	}
+	clientRepo := &domain.ClientRepositoryMock{
+		FindClientByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) (domain.Client, error) {
+			if c, ok := f.clients[clientID]; ok {
+				return c, nil
+			}
+			return domain.NewClient(domain.ClientParams{}), nil
+		},
+		UpdateClientFunc: func(ctx context.Context, c domain.Client) error {
+			f.clients[c.GetID()] = c
+			return nil
+		},
+	}
+	sessionRevoker := &domain.SessionRevokerMock{
+		RevokeUserSessionsFunc: func(ctx context.Context, userID uuid.UUID, at time.Time) error {
+			return nil
+		},
+	}
+
+	h := &AdminHandler{
+		authUC:   usecase.NewAdminAuthUsecase(tokenRepo, userRepo, clientRepo),
+		clientUC: usecase.NewClientAdminUsecase(clientRepo, nil, tokenRepo, refreshTokenRepo),
+		userUC:   usecase.NewUserAdminUsecase(userRepo, tokenRepo, refreshTokenRepo, sessionRevoker, nil, nil),
+	}
+	cfg := &config.Config{PublicKey: base64.StdEncoding.EncodeToString(pub)}
+
+	gin.SetMode(gin.TestMode)
+	f.router = gin.New()
+	admin := f.router.Group("/admin",
+		middleware.AuthMiddleware(cfg, accesstoken.NewTokenService()),
+		h.Authorize,
+		middleware.RequireScope("admin"),
+	)
+	admin.GET("/users/:user_id", h.User)
+	admin.POST("/users/:user_id/disable", h.DisableUser)
+	admin.POST("/clients/:client_id/secret", h.RotateClientSecret)
+	admin.POST("/clients/:client_id/disable", h.DisableClient)
+	return f
+}
+
+// addUser は admin スコープのアクセストークンを発行済みのユーザーを追加し、そのトークンを返す
+func (f *adminFixture) addUser(t *testing.T, email string, isAdmin bool) (uuid.UUID, string) {
+	t.Helper()
+	id := uuid.New()
+	f.users[id] = domain.UserParams{ID: id, Name: email, Email: email, IsAdmin: isAdmin}
+
+	p := domain.TokenParams{UserID: id, ClientID: f.clientID, Scope: "admin", ExpiresAt: time.Now().Add(time.Hour)}
+	tokenStr, err := accesstoken.NewTokenService().Generate(&accesstoken.TokenParams{
+		UserID:    p.UserID,
+		ClientID:  p.ClientID,
+		Scope:     p.Scope,
+		ExpiresAt: p.ExpiresAt,
+	}, f.privateKey)
+	require.NoError(t, err)
+	p.AccessToken = tokenStr
+	f.tokens[tokenStr] = p
+	return id, tokenStr
+}
+
+func (f *adminFixture) revokeTokens(match func(domain.TokenParams) bool) {
+	now := time.Now()
+	for k, p := range f.tokens {
+		if match(p) {
+			p.RevokedAt = &now
+			f.tokens[k] = p
+		}
+	}
+}
+
+func (f *adminFixture) do(method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAdmin_DisabledUserToken(t *testing.T) {
+	f := newAdminFixture(t)
+	aliceID, aliceToken := f.addUser(t, "alice@example.com", true)
+	bobID, bobToken := f.addUser(t, "bob@example.com", true)
+
+	require.Equal(t, http.StatusOK, f.do(http.MethodGet, "/admin/users/"+aliceID.String(), bobToken).Code)
+	require.Equal(t, http.StatusNoContent, f.do(http.MethodPost, "/admin/users/"+bobID.String()+"/disable", aliceToken).Code)
+
+	// 署名と有効期限が正しくても、無効にしたユーザーのアクセストークンは使えない
+	w := f.do(http.MethodGet, "/admin/users/"+aliceID.String(), bobToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "access token is revoked")
+
+	assert.Equal(t, http.StatusOK, f.do(http.MethodGet, "/admin/users/"+bobID.String(), aliceToken).Code)
+}
+
+func TestAdmin_RejectedTokens(t *testing.T) {
+	f := newAdminFixture(t)
+	aliceID, aliceToken := f.addUser(t, "alice@example.com", true)
+
+	// 失効させる前に無効にしたユーザーのトークンも拒否する
+	disabledID, disabledToken := f.addUser(t, "disabled@example.com", true)
+	disabledAt := time.Now()
+	p := f.users[disabledID]
+	p.DisabledAt = &disabledAt
+	f.users[disabledID] = p
+
+	_, revokedToken := f.addUser(t, "revoked@example.com", true)
+	tp := f.tokens[revokedToken]
+	tp.RevokedAt = &disabledAt
+	f.tokens[revokedToken] = tp
+
+	// 削除したユーザーのトークンは記録が残っていても拒否する
+	deletedID, deletedToken := f.addUser(t, "deleted@example.com", true)
+	delete(f.users, deletedID)
+
+	// 保存されていないトークン
+	_, unknownToken := f.addUser(t, "unknown@example.com", true)
+	delete(f.tokens, unknownToken)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "disabled user", token: disabledToken},
+		{name: "revoked token", token: revokedToken},
+		{name: "deleted user", token: deletedToken},
+		{name: "unknown token", token: unknownToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := f.do(http.MethodGet, "/admin/users/"+aliceID.String(), tt.token)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+
+	assert.Equal(t, http.StatusOK, f.do(http.MethodGet, "/admin/users/"+aliceID.String(), aliceToken).Code)
+}
+
+func TestAdmin_NonAdminUser(t *testing.T) {
+	f := newAdminFixture(t)
+	aliceID, aliceToken := f.addUser(t, "alice@example.com", true)
+	bobID, bobToken := f.addUser(t, "bob@example.com", false)
+
+	// admin スコープを持っていても管理者に指定していないユーザーは呼び出せない
+	w := f.do(http.MethodGet, "/admin/users/"+aliceID.String(), bobToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "the user is not an administrator")
+
+	// 管理者から外したユーザーのトークンは次の呼び出しから拒否する
+	p := f.users[aliceID]
+	p.IsAdmin = false
+	f.users[aliceID] = p
+	assert.Equal(t, http.StatusForbidden, f.do(http.MethodGet, "/admin/users/"+bobID.String(), aliceToken).Code)
+}
+
+func TestAdmin_ClientRevocation(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "rotate secret", path: "/secret"},
+		{name: "disable client", path: "/disable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAdminFixture(t)
+			aliceID, aliceToken := f.addUser(t, "alice@example.com", true)
+
+			w := f.do(http.MethodPost, "/admin/clients/"+f.clientID.String()+tt.path, aliceToken)
+			require.Less(t, w.Code, http.StatusMultipleChoices)
+
+			// 発行したクライアントのシークレットを変えたり無効にしたりすると、発行済みの admin のトークンは使えない
+			w = f.do(http.MethodGet, "/admin/users/"+aliceID.String(), aliceToken)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+)
+
+const defaultUsersLimit = 50
+
+func newUserAdminUsecase(opt HandlerOption) usecase.IUserAdminUsecase {
+	userRepo := repository.NewUserRepository(opt.DB)
+	tokenRepo := repository.NewTokenRepository(opt.DB)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(opt.DB)
+	return usecase.NewUserAdminUsecase(userRepo, tokenRepo, refreshTokenRepo, opt.SSORevoker, opt.PasswordHasher, opt.PasswordPolicy)
+}
+
+// UserResponse はパスワードのハッシュを含めず、パスワードを持つかどうかだけを返す
+type UserResponse struct {
+	ID                    uuid.UUID  `json:"id"`
+	Name                  string     `json:"name"`
+	Email                 string     `json:"email"`
+	EmailVerifiedAt       *time.Time `json:"email_verified_at,omitempty"`
+	HasPassword           bool       `json:"has_password"`
+	PasswordResetRequired bool       `json:"password_reset_required"`
+	DisabledAt            *time.Time `json:"disabled_at,omitempty"`
+	IsAdmin               bool       `json:"is_admin"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+func NewUserResponse(u domain.User) UserResponse {
+	return UserResponse{
+		ID:                    u.GetID(),
+		Name:                  u.GetName(),
+		Email:                 u.GetEmail(),
+		EmailVerifiedAt:       u.GetEmailVerifiedAt(),
+		HasPassword:           u.GetPassword() != "",
+		PasswordResetRequired: u.IsPasswordResetRequired(),
+		DisabledAt:            u.GetDisabledAt(),
+		IsAdmin:               u.IsAdmin(),
+		CreatedAt:             u.GetCreatedAt(),
+		UpdatedAt:             u.GetUpdatedAt(),
+	}
+}
+
+type UserURI struct {
+	UserID string `uri:"user_id" binding:"required,uuid"`
+}
+
+func bindUserID(c *gin.Context) (uuid.UUID, bool) {
+	var uri UserURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return uuid.Nil, false
+	}
+	return uuid.MustParse(uri.UserID), true
+}
+
+type UsersInput struct {
+	Query  string `form:"q"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// Users はメールアドレスか名前に q を含むユーザーを作成順に返す。続きがある場合は next_offset を含める
+func (h *AdminHandler) Users(c *gin.Context) {
+	var input UsersInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Limit == 0 {
+		input.Limit = defaultUsersLimit
+	}
+
+	users, more, err := h.userUC.SearchUsers(c.Request.Context(), input.Query, input.Offset, input.Limit)
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+
+	res := make([]UserResponse, 0, len(users))
+	for _, u := range users {
+		res = append(res, NewUserResponse(u))
+	}
+	body := gin.H{"users": res}
+	if more {
+		body["next_offset"] = input.Offset + len(users)
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+type CreateUserInput struct {
+	Name          string `json:"name" binding:"required,max=255"`
+	Email         string `json:"email" binding:"required,email,max=255"`
+	Password      string `json:"password"`
+	EmailVerified bool   `json:"email_verified"`
+	IsAdmin       bool   `json:"is_admin"`
+}
+
+func (h *AdminHandler) CreateUser(c *gin.Context) {
+	var input CreateUserInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userUC.CreateUser(c.Request.Context(), usecase.CreateUserParams{
+		Name:          input.Name,
+		Email:         input.Email,
+		Password:      input.Password,
+		EmailVerified: input.EmailVerified,
+		IsAdmin:       input.IsAdmin,
+	})
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, NewUserResponse(user))
+}
+
+func (h *AdminHandler) User(c *gin.Context) {
+	userID, ok := bindUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userUC.GetUser(c.Request.Context(), userID)
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, NewUserResponse(user))
+}
+
+// UpdateUserInput は省略した項目を変更しない
+type UpdateUserInput struct {
+	Name    *string `json:"name" binding:"omitempty,min=1,max=255"`
+	Email   *string `json:"email" binding:"omitempty,email,max=255"`
+	IsAdmin *bool   `json:"is_admin"`
+}
+
+func (h *AdminHandler) UpdateUser(c *gin.Context) {
+	userID, ok := bindUserID(c)
+	if !ok {
+		return
+	}
+	var input UpdateUserInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userUC.UpdateUser(c.Request.Context(), userID, usecase.UpdateUserParams{
+		Name:    input.Name,
+		Email:   input.Email,
+		IsAdmin: input.IsAdmin,
+	})
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, NewUserResponse(user))
+}
+
+// ForcePasswordReset は今のパスワードを使えなくし、再設定用のリンクをメールで送る
+func (h *AdminHandler) ForcePasswordReset(c *gin.Context) {
+	userID, ok := bindUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.passwordResetUC.ForcePasswordReset(c.Request.Context(), userID); err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// DisableUser はユーザーを無効にし、発行済みのトークンとSSOセッションを失効させる
+func (h *AdminHandler) DisableUser(c *gin.Context) {
+	userID, ok := bindUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userUC.DisableUser(c.Request.Context(), userID); err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AdminHandler) EnableUser(c *gin.Context) {
+	userID, ok := bindUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userUC.EnableUser(c.Request.Context(), userID); err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AdminHandler) DeleteUser(c *gin.Context) {
+	userID, ok := bindUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userUC.DeleteUser(c.Request.Context(), userID); err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
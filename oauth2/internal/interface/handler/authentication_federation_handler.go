@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/sso"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func newFederationUsecase(opt HandlerOption) usecase.IFederationUsecase {
+	userRepo := repository.NewUserRepository(opt.DB)
+	identityRepo := repository.NewFederatedIdentityRepository(opt.DB)
+	return usecase.NewFederationUsecase(userRepo, identityRepo, opt.FederationProviders)
+}
+
+// BeginFederatedSignin は上流のプロバイダの認可エンドポイントにリダイレクトする
+func (h *AuthenticationHandler) BeginFederatedSignin(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	req, err := h.federationUC.BeginSignin(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	if err := session.Save(c, sess, "federation_request", req); err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, req.AuthURL)
+}
+
+// FederatedSigninCallback は上流のプロバイダから戻った利用者を紐づけたユーザーとしてログイン状態にする
+func (h *AuthenticationHandler) FederatedSigninCallback(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	// state は一度しか使えないよう取り出した時点で削除する
+	req, ok, err := session.Pop[usecase.FederationRequest](c, sess, "federation_request")
+	if err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+	if !ok || req.Provider != c.Param("provider") {
+		c.HTML(http.StatusBadRequest, "400.html", gin.H{"error": "federated sign-in has not started"})
+		return
+	}
+
+	user, err := h.federationUC.FinishSignin(c.Request.Context(), usecase.FinishFederationSigninParams{
+		Request: req,
+		State:   c.Query("state"),
+		Code:    c.Query("code"),
+		Error:   c.Query("error"),
+	})
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	h.signinExternalUser(c, sess, user)
+}
+
+// signinExternalUser は上流の ID プロバイダで認証した利用者をログイン状態にする。
+// 二要素認証を有効にしている場合は、パスワードでのサインインと同じくコードの入力を求める
+func (h *AuthenticationHandler) signinExternalUser(c *gin.Context, sess session.SessionClient, user domain.User) {
+	if err := h.verifyUC.CheckSignin(c.Request.Context(), user); err != nil {
+		h.requireEmailVerification(c, sess, user, err)
+		return
+	}
+
+	amr := []string{sso.AMRFederated}
+	enabled, err := h.mfaUC.IsEnabled(c.Request.Context(), user.GetID().String())
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+	if enabled {
+		if err := session.Save(c, sess, "mfa_pending", MFAPendingUser{
+			UserID: user.GetID().String(),
+			Email:  user.GetEmail(),
+			AMR:    amr,
+		}); err != nil {
+			c.Error(errors.WithStack(err))
+			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+			return
+		}
+		c.Redirect(http.StatusFound, "/client/signin/mfa")
+		return
+	}
+
+	if _, err := h.completeSignin(c, sess, user.GetID().String(), user.GetEmail(), amr); err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/oauth2/consent")
+}
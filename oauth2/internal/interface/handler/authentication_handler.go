@@ -2,12 +2,19 @@ package handler
 
 import (
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
 	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/csrf"
 	"github.com/sntkn/go-oauth2/oauth2/internal/common/flashmessage"
 	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/sso"
 	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
@@ -16,26 +23,66 @@ import (
 func NewAuthenticationHandler(opt HandlerOption) *AuthenticationHandler {
 	userRepo := repository.NewUserRepository(opt.DB)
 	clientRepo := repository.NewClientRepository(opt.DB)
-	uc := usecase.NewAuthenticationUsecase(userRepo, clientRepo)
+	scopeRepo := repository.NewScopeRepository(opt.DB)
+	uc := usecase.NewAuthenticationUsecase(userRepo, clientRepo, scopeRepo, opt.PasswordHasher, opt.PasswordPolicy, newCredentialVerifiers(opt)...)
 	return &AuthenticationHandler{
-		uc:      uc,
-		session: opt.Session,
-		config:  opt.Config,
+		uc:           uc,
+		verifyUC:     newEmailVerificationUsecase(opt),
+		mfaUC:        newMFAUsecase(opt),
+		passkeyUC:    newPasskeyUsecase(opt),
+		lockoutUC:    newLockoutUsecase(opt),
+		federationUC: newFederationUsecase(opt),
+		samlUC:       newSAMLUsecase(opt),
+		session:      opt.Session,
+		ssoRevoker:   opt.SSORevoker,
+		config:       opt.Config,
 	}
 }
 
+// newCredentialVerifiers はローカルのパスワードの次に試す検証器を返す
+func newCredentialVerifiers(opt HandlerOption) []usecase.CredentialVerifier {
+	if opt.Directory == nil {
+		return nil
+	}
+	userRepo := repository.NewUserRepository(opt.DB)
+	identityRepo := repository.NewFederatedIdentityRepository(opt.DB)
+	return []usecase.CredentialVerifier{usecase.NewDirectoryVerifier(userRepo, identityRepo, *opt.Directory)}
+}
+
 type AuthenticationHandler struct {
-	uc      usecase.IAuthenticationUsecase
-	session session.SessionManager
-	config  *config.Config
+	uc           usecase.IAuthenticationUsecase
+	verifyUC     usecase.IEmailVerificationUsecase
+	mfaUC        usecase.IMFAUsecase
+	passkeyUC    usecase.IPasskeyUsecase
+	lockoutUC    usecase.ILockoutUsecase
+	federationUC usecase.IFederationUsecase
+	samlUC       usecase.ISAMLUsecase
+	session      session.SessionManager
+	ssoRevoker   *sso.Revoker
+	config       *config.Config
 }
 
 type EntrySign struct {
 	ResponseType string `form:"response_type" binding:"required"`
 	ClientID     string `form:"client_id" binding:"required,uuid"`
-	Scope        string `form:"scope" binding:"required"`
+	Scope        string `form:"scope"`
 	RedirectURI  string `form:"redirect_uri" binding:"required"`
 	State        string `form:"state" binding:"required"`
+	Prompt       string `form:"prompt"`
+	MaxAge       *int   `form:"max_age" binding:"omitempty,min=0"`
+}
+
+const (
+	promptNone  = "none"
+	promptLogin = "login"
+
+	// maxMFAAttempts 回コードを誤るとパスワードの入力からやり直させる
+	maxMFAAttempts = 5
+)
+
+// HasPrompt は prompt パラメータに指定された値が含まれるかを判定する (OpenID Connect Core 3.1.2.1)
+func (s EntrySign) HasPrompt(value string) bool {
+	return slices.Contains(strings.Fields(s.Prompt), value)
 }
 
 func (h *AuthenticationHandler) Entry(c *gin.Context) {
@@ -54,12 +101,54 @@ func (h *AuthenticationHandler) Entry(c *gin.Context) {
 		return
 	}
 
-	_, err = h.uc.AuthenticateClient(c.Request.Context(), clientID, sign.RedirectURI)
+	client, err := h.uc.AuthenticateClient(c.Request.Context(), clientID, sign.RedirectURI)
 	if err != nil {
 		handleError(c, sess, err)
 		return
 	}
 
+	// redirect_uri の検証後は、スコープのエラーをクライアントにリダイレクトで返す
+	scope, err := h.uc.ResolveScope(c.Request.Context(), client, sign.Scope)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidScope) {
+			redirectWithError(c, sign.RedirectURI, sign.State, usecase.ErrInvalidScope.Message)
+			return
+		}
+		handleError(c, sess, err)
+		return
+	}
+	sign.Scope = scope.String()
+
+	// prompt=none は他の値と組み合わせられない
+	if sign.HasPrompt(promptNone) && len(strings.Fields(sign.Prompt)) > 1 {
+		redirectWithError(c, sign.RedirectURI, sign.State, "invalid_request")
+		return
+	}
+
+	ssoSess, err := sso.Load(c, sess, h.ssoRevoker)
+	if err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	// 有効なSSOセッションがあればパスワード入力を省略して同意画面に進む
+	if ssoSess != nil && ssoSess.IsFresh(sign.MaxAge, time.Now()) && !sign.HasPrompt(promptLogin) {
+		if err := h.saveLogin(c, sess, sign, *ssoSess); err != nil {
+			c.Error(errors.WithStack(err))
+			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+			return
+		}
+		c.Redirect(http.StatusFound, "/oauth2/consent")
+		return
+	}
+
+	// prompt=none ではログイン画面を表示できない
+	if sign.HasPrompt(promptNone) {
+		redirectWithError(c, sign.RedirectURI, sign.State, "login_required")
+		return
+	}
+
 	// セッションデータを書き込む
 	if err := session.Save(c, sess, "sign", sign); err != nil {
 		c.Error(err)
@@ -98,7 +187,26 @@ func (h *AuthenticationHandler) Signin(c *gin.Context) {
 		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
 		return
 	}
-	c.HTML(http.StatusOK, "signin.html", gin.H{"f": form, "mess": mess})
+
+	token, err := csrf.Token(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	samlConnections, err := h.samlUC.Connections(c.Request.Context())
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	c.HTML(http.StatusOK, "signin.html", gin.H{
+		"f":               form,
+		"mess":            mess,
+		"csrf":            token,
+		"providers":       h.federationUC.Providers(),
+		"samlConnections": samlConnections,
+	})
 }
 
 type PostSigninInput struct {
@@ -109,12 +217,6 @@ type PostSigninInput struct {
 func (h *AuthenticationHandler) PostSignin(c *gin.Context) {
 	sess := h.session.NewSession(c)
 	var input PostSigninInput
-	sign, _, err := session.Load[EntrySign](c, sess, "sign")
-	if err != nil {
-		c.HTML(http.StatusBadRequest, "400.html", gin.H{"error": err.Error()})
-		return
-	}
-
 	if err := c.ShouldBind(&input); err != nil {
 		if flashErr := flashmessage.AddMessage(c, sess, "error", err.Error()); flashErr != nil {
 			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": flashErr.Error()})
@@ -124,46 +226,449 @@ func (h *AuthenticationHandler) PostSignin(c *gin.Context) {
 		return
 	}
 
+	// 失敗が続いている間はパスワードを確認せず、誤った場合と同じ応答を返す
+	if err := h.lockoutUC.Check(c.Request.Context(), input.Email, c.ClientIP()); err != nil {
+		h.failSignin(c, sess, input.Email, err)
+		return
+	}
+
 	user, err := h.uc.AuthenticateUser(c.Request.Context(), input.Email, input.Password)
 
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserOrPasswordNotMatch) {
+			if recordErr := h.lockoutUC.RecordFailure(c.Request.Context(), input.Email, c.ClientIP()); recordErr != nil {
+				handleError(c, sess, recordErr)
+				return
+			}
+		}
+		h.failSignin(c, sess, input.Email, err)
+		return
+	}
+
+	// メールアドレスを確認するまでサインインさせない設定の場合は確認画面に進む
+	if err := h.verifyUC.CheckSignin(c.Request.Context(), user); err != nil {
+		h.requireEmailVerification(c, sess, user, err)
+		return
+	}
+
+	// 二要素認証を有効にしている場合は、コードの入力を済ませるまでログイン状態にしない。
+	// 失敗回数も二要素目で数え続けるため、コードを確認するまで消さない
+	enabled, err := h.mfaUC.IsEnabled(c.Request.Context(), user.GetID().String())
 	if err != nil {
 		handleError(c, sess, err)
-		// サインインフォームをセッションに保存
-		if err := session.Save(c, sess, "signin_form", SigninForm{
-			Email: input.Email,
+		return
+	}
+	if enabled {
+		if err := session.Save(c, sess, "mfa_pending", MFAPendingUser{
+			UserID: user.GetID().String(),
+			Email:  input.Email,
 		}); err != nil {
 			c.Error(errors.WithStack(err))
 			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
 			return
 		}
+		c.Redirect(http.StatusFound, "/client/signin/mfa")
 		return
 	}
 
-	// signセッションを削除
-	if err := sess.DelSessionData(c, "sign"); err != nil {
-		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+	if err := h.lockoutUC.RecordSuccess(c.Request.Context(), input.Email); err != nil {
+		handleError(c, sess, err)
 		return
 	}
 
-	// サインインフォームセッションを削除
-	if err := sess.DelSessionData(c, "signin_form"); err != nil {
+	if _, err := h.completeSignin(c, sess, user.GetID().String(), input.Email, []string{sso.AMRPassword}); err != nil {
+		c.Error(errors.WithStack(err))
 		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
 		return
 	}
 
-	// ログイン状態をセッションに保存
-	if err := session.Save(c, sess, "login", AuthedUser{
-		Email:       input.Email,
-		UserID:      user.GetID().String(),
-		ClientID:    sign.ClientID,
-		RedirectURI: sign.RedirectURI,
-		Scope:       sign.Scope,
-		Expires:     h.config.AuthCodeExpires,
+	c.Redirect(http.StatusFound, "/oauth2/consent")
+}
+
+// failSignin はサインインフォームに入力を残してエラーを表示する
+func (h *AuthenticationHandler) failSignin(c *gin.Context, sess session.SessionClient, email string, err error) {
+	handleError(c, sess, err)
+	// サインインフォームをセッションに保存
+	if err := session.Save(c, sess, "signin_form", SigninForm{
+		Email: email,
 	}); err != nil {
 		c.Error(errors.WithStack(err))
 		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+	}
+}
+
+// SigninMFA はパスワードの次に認証アプリのコードを入力する画面を表示する
+func (h *AuthenticationHandler) SigninMFA(c *gin.Context) {
+	sess := h.session.NewSession(c)
+	mess, err := flashmessage.Flash(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok, err := session.Load[MFAPendingUser](c, sess, "mfa_pending"); err != nil || !ok {
+		c.HTML(http.StatusBadRequest, "400.html", gin.H{"error": "login required"})
+		return
+	}
+
+	token, err := csrf.Token(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.HTML(http.StatusOK, "signin_mfa.html", gin.H{"mess": mess, "csrf": token})
+}
+
+type PostSigninMFAInput struct {
+	Code         string `form:"code" binding:"required_without=RecoveryCode"`
+	RecoveryCode string `form:"recovery_code"`
+}
+
+func (h *AuthenticationHandler) PostSigninMFA(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	pendingUser, ok, err := session.Load[MFAPendingUser](c, sess, "mfa_pending")
+	if err != nil || !ok {
+		c.HTML(http.StatusBadRequest, "400.html", gin.H{"error": "login required"})
+		return
+	}
+
+	var input PostSigninMFAInput
+	if err := c.ShouldBind(&input); err != nil {
+		if flashErr := flashmessage.AddMessage(c, sess, flashmessage.Error, err.Error()); flashErr != nil {
+			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": flashErr.Error()})
+			return
+		}
+		c.Redirect(http.StatusFound, "/client/signin/mfa")
+		return
+	}
+
+	// コードの誤りもパスワードの誤りと同じロックの対象にする。制限中はコードを確認せずにパスワードの入力からやり直させる
+	if err := h.lockoutUC.Check(c.Request.Context(), pendingUser.Email, c.ClientIP()); err != nil {
+		if delErr := sess.DelSessionData(c, "mfa_pending"); delErr != nil {
+			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": delErr.Error()})
+			return
+		}
+		handleError(c, sess, err)
+		return
+	}
+
+	amr := pendingUser.AMR
+	if len(amr) == 0 {
+		amr = []string{sso.AMRPassword}
+	}
+	if input.Code != "" {
+		err = h.mfaUC.VerifyTOTP(c.Request.Context(), pendingUser.UserID, input.Code)
+		amr = append(amr, sso.AMROTP, sso.AMRMFA)
+	} else {
+		err = h.mfaUC.VerifyRecoveryCode(c.Request.Context(), pendingUser.UserID, input.RecoveryCode)
+		amr = append(amr, sso.AMRRecoveryCode, sso.AMRMFA)
+	}
+	if err != nil {
+		h.failSigninMFA(c, sess, pendingUser, err)
+		return
+	}
+
+	if err := h.lockoutUC.RecordSuccess(c.Request.Context(), pendingUser.Email); err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	if err := sess.DelSessionData(c, "mfa_pending"); err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := h.completeSignin(c, sess, pendingUser.UserID, pendingUser.Email, amr); err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
 		return
 	}
 
 	c.Redirect(http.StatusFound, "/oauth2/consent")
 }
+
+// BeginPasskeySignin は navigator.credentials.get に渡すオプションを返す。
+// 利用者は認証器に保存されたパスキーから選ぶため、メールアドレスの入力は求めない
+func (h *AuthenticationHandler) BeginPasskeySignin(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	assertion, data, err := h.passkeyUC.BeginLogin(c.Request.Context())
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+
+	if err := session.Save(c, sess, "webauthn_login", data); err != nil {
+		abortWithJSONError(c, errors.WithStack(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, assertion)
+}
+
+// FinishPasskeySignin は認証器の署名を検証し、PostSignin と同じログイン状態にする。
+// ユーザー検証を伴うパスキーはそれ自体が二要素にあたるため、認証アプリのコードは求めない
+func (h *AuthenticationHandler) FinishPasskeySignin(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	// チャレンジは一度しか使えないよう取り出した時点で削除する
+	data, ok, err := session.Pop[webauthn.SessionData](c, sess, "webauthn_login")
+	if err != nil {
+		abortWithJSONError(c, errors.WithStack(err))
+		return
+	}
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "passkey sign-in has not started"})
+		return
+	}
+
+	user, err := h.passkeyUC.FinishLogin(c.Request.Context(), data, c.Request.Body)
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+
+	if err := h.verifyUC.CheckSignin(c.Request.Context(), user); err != nil {
+		if !errors.Is(err, usecase.ErrEmailNotVerified) {
+			abortWithJSONError(c, err)
+			return
+		}
+		if err := holdUnverifiedUser(c, sess, user, err); err != nil {
+			abortWithJSONError(c, errors.WithStack(err))
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"redirect": "/client/verify-email"})
+		return
+	}
+
+	amr := []string{sso.AMRHardwareKey, sso.AMRMFA}
+	if _, err := h.completeSignin(c, sess, user.GetID().String(), user.GetEmail(), amr); err != nil {
+		abortWithJSONError(c, errors.WithStack(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"redirect": "/oauth2/consent"})
+}
+
+// failSigninMFA はコードの誤りを数え、上限に達したらパスワードの入力からやり直させる
+func (h *AuthenticationHandler) failSigninMFA(c *gin.Context, sess session.SessionClient, pendingUser MFAPendingUser, err error) {
+	if !errors.Is(err, usecase.ErrInvalidMFACode) {
+		handleError(c, sess, err)
+		return
+	}
+
+	if err := h.lockoutUC.RecordFailure(c.Request.Context(), pendingUser.Email, c.ClientIP()); err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	redirectURI := "/client/signin/mfa"
+	pendingUser.Attempts++
+	if pendingUser.Attempts >= maxMFAAttempts {
+		redirectURI = "/client/signin"
+		err = sess.DelSessionData(c, "mfa_pending")
+	} else {
+		err = session.Save(c, sess, "mfa_pending", pendingUser)
+	}
+	if err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := flashmessage.AddMessage(c, sess, flashmessage.Error, usecase.ErrInvalidMFACode.Error()); err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+	c.Redirect(http.StatusFound, redirectURI)
+}
+
+// requireEmailVerification は確認待ちのユーザーをセッションに保存し、確認メールの案内画面に進む
+func (h *AuthenticationHandler) requireEmailVerification(c *gin.Context, sess session.SessionClient, user domain.User, err error) {
+	if !errors.Is(err, usecase.ErrEmailNotVerified) {
+		handleError(c, sess, err)
+		return
+	}
+
+	if err := holdUnverifiedUser(c, sess, user, err); err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/client/verify-email")
+}
+
+// holdUnverifiedUser は確認待ちのユーザーをセッションに保存し、確認を促すメッセージを追加する
+func holdUnverifiedUser(c *gin.Context, sess session.SessionClient, user domain.User, reason error) error {
+	if err := session.Save(c, sess, "unverified_user", UnverifiedUser{
+		UserID: user.GetID().String(),
+		Email:  user.GetEmail(),
+	}); err != nil {
+		return err
+	}
+	return flashmessage.AddMessage(c, sess, flashmessage.Error, reason.Error())
+}
+
+type SessionSignupForm struct {
+	Name  string
+	Email string
+}
+
+func (h *AuthenticationHandler) Signup(c *gin.Context) {
+	sess := h.session.NewSession(c)
+	mess, err := flashmessage.Flash(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	form, _, err := session.Pop[SessionSignupForm](c, sess, "signup_form")
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := csrf.Token(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.HTML(http.StatusOK, "signup.html", gin.H{"f": form, "mess": mess, "csrf": token})
+}
+
+type PostSignupInput struct {
+	Name                 string `form:"name" binding:"required,max=255"`
+	Email                string `form:"email" binding:"required,email,max=255"`
+	Password             string `form:"password" binding:"required"`
+	PasswordConfirmation string `form:"password_confirmation" binding:"required,eqfield=Password"`
+}
+
+func (h *AuthenticationHandler) PostSignup(c *gin.Context) {
+	sess := h.session.NewSession(c)
+	var input PostSignupInput
+
+	if err := c.ShouldBind(&input); err != nil {
+		if flashErr := flashmessage.AddMessage(c, sess, "error", err.Error()); flashErr != nil {
+			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": flashErr.Error()})
+			return
+		}
+		if err := h.saveSignupForm(c, sess, input); err != nil {
+			c.Error(errors.WithStack(err))
+			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+			return
+		}
+		c.Redirect(http.StatusFound, "/client/signup")
+		return
+	}
+
+	user, err := h.uc.Signup(c.Request.Context(), usecase.SignupParams{
+		Name:     input.Name,
+		Email:    input.Email,
+		Password: input.Password,
+	})
+	if err != nil {
+		handleError(c, sess, err)
+		// サインアップフォームをセッションに保存
+		if err := h.saveSignupForm(c, sess, input); err != nil {
+			c.Error(errors.WithStack(err))
+			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+			return
+		}
+		return
+	}
+
+	// 確認メールの送信に失敗しても登録は完了しているため、再送できるよう処理を続ける
+	if err := h.verifyUC.SendVerificationEmail(c.Request.Context(), user.GetID().String()); err != nil {
+		c.Error(errors.WithStack(err))
+	}
+
+	if err := h.verifyUC.CheckSignin(c.Request.Context(), user); err != nil {
+		h.requireEmailVerification(c, sess, user, err)
+		return
+	}
+
+	pending, err := h.completeSignin(c, sess, user.GetID().String(), input.Email, []string{sso.AMRPassword})
+	if err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	// 認可リクエストの途中で登録した場合はそのまま同意画面に進む
+	if pending {
+		c.Redirect(http.StatusFound, "/oauth2/consent")
+		return
+	}
+
+	c.HTML(http.StatusOK, "signup_finished.html", gin.H{})
+}
+
+func (h *AuthenticationHandler) saveSignupForm(c *gin.Context, sess session.SessionClient, input PostSignupInput) error {
+	return session.Save(c, sess, "signup_form", SessionSignupForm{
+		Name:  input.Name,
+		Email: input.Email,
+	})
+}
+
+// completeSignin は認証に成功したユーザーをログイン状態にする。amr には認証に使った方式を渡す。
+// 保留中の認可リクエストがあれば同意画面に進めるようにして true を返す
+func (h *AuthenticationHandler) completeSignin(c *gin.Context, sess session.SessionClient, userID, email string, amr []string) (bool, error) {
+	sign, pending, err := session.Pop[EntrySign](c, sess, "sign")
+	if err != nil {
+		return false, err
+	}
+
+	// 入力フォームのセッションを削除
+	for _, key := range []string{"signin_form", "signup_form"} {
+		if err := sess.DelSessionData(c, key); err != nil {
+			return false, err
+		}
+	}
+
+	// セッション固定攻撃を防ぐため、認証に成功したらセッションIDを発行し直す
+	if err := sess.RegenerateID(c, "flashMessage"); err != nil {
+		return false, err
+	}
+
+	// 以降の認可リクエストでパスワード入力を省略できるようSSOセッションを保存
+	ssoSess := sso.Session{
+		UserID:   userID,
+		Email:    email,
+		AuthTime: time.Now(),
+		AMR:      amr,
+		ACR:      sso.ACR(amr),
+	}
+	if err := sso.Save(c, sess, ssoSess); err != nil {
+		return false, err
+	}
+
+	if !pending || sign.ClientID == "" {
+		return false, nil
+	}
+
+	// ログイン状態をセッションに保存
+	if err := h.saveLogin(c, sess, sign, ssoSess); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// saveLogin は認証済みのユーザーと認可リクエストの内容をセッションに保存する
+func (h *AuthenticationHandler) saveLogin(c *gin.Context, sess session.SessionClient, sign EntrySign, ssoSess sso.Session) error {
+	return session.Save(c, sess, "login", AuthedUser{
+		Email:       ssoSess.Email,
+		UserID:      ssoSess.UserID,
+		AMR:         ssoSess.AMR,
+		ACR:         ssoSess.ACR,
+		ClientID:    sign.ClientID,
+		RedirectURI: sign.RedirectURI,
+		Scope:       sign.Scope,
+		State:       sign.State,
+		Prompt:      sign.Prompt,
+		Expires:     h.config.AuthCodeExpires,
+	})
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func newSAMLUsecase(opt HandlerOption) usecase.ISAMLUsecase {
+	userRepo := repository.NewUserRepository(opt.DB)
+	identityRepo := repository.NewFederatedIdentityRepository(opt.DB)
+	connectionRepo := repository.NewSAMLConnectionRepository(opt.DB)
+	return usecase.NewSAMLUsecase(userRepo, identityRepo, connectionRepo, opt.SAMLLogins, opt.SAML)
+}
+
+// SAMLMetadata は IdP に登録する SP のメタデータを返す
+func (h *AuthenticationHandler) SAMLMetadata(c *gin.Context) {
+	md, err := h.samlUC.Metadata(c.Request.Context(), c.Param("connection"))
+	if err != nil {
+		abortWithJSONError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/samlmetadata+xml", md)
+}
+
+// BeginSAMLSignin は認証リクエストを作り、IdP のシングルサインオンのURLにリダイレクトする
+func (h *AuthenticationHandler) BeginSAMLSignin(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	req, err := h.samlUC.BeginSignin(c.Request.Context(), c.Param("connection"))
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	if err := session.Save(c, sess, "saml_request", req); err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, req.URL)
+}
+
+// SAMLAssertionConsumer は IdP から POST された応答を検証し、サインインを終えるURLにリダイレクトする。
+// クロスサイトの POST ではセッションの Cookie が送られないため、照合はリダイレクトした先で行う
+func (h *AuthenticationHandler) SAMLAssertionConsumer(c *gin.Context) {
+	connection := c.Param("connection")
+	token, err := h.samlUC.ConsumeResponse(c.Request.Context(), connection, c.PostForm("SAMLResponse"))
+	if err != nil {
+		if usecaseErr, ok := err.(*errors.UsecaseError); ok && usecaseErr.Code != http.StatusInternalServerError {
+			c.HTML(usecaseErr.Code, "400.html", gin.H{"error": usecaseErr.Error()})
+			return
+		}
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/signin/saml/"+url.PathEscape(connection)+"/finish?"+url.Values{"token": {token}}.Encode())
+}
+
+// FinishSAMLSignin は受け取ったアサーションの NameID に紐づけたユーザーとしてログイン状態にする
+func (h *AuthenticationHandler) FinishSAMLSignin(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	// 認証リクエストは一度しか使えないよう取り出した時点で削除する
+	req, ok, err := session.Pop[usecase.SAMLRequest](c, sess, "saml_request")
+	if err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+	if !ok || req.Connection != c.Param("connection") {
+		c.HTML(http.StatusBadRequest, "400.html", gin.H{"error": "SAML sign-in has not started"})
+		return
+	}
+
+	user, err := h.samlUC.FinishSignin(c.Request.Context(), req, c.Query("token"))
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	h.signinExternalUser(c, sess, user)
+}
@@ -2,12 +2,15 @@ package handler
 
 import (
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sntkn/go-oauth2/oauth2/domain"
 	"github.com/sntkn/go-oauth2/oauth2/domain/domainservice"
 	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/csrf"
 	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
 	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
@@ -19,19 +22,25 @@ func NewAuthorizationHandler(opt HandlerOption) *AuthorizationHandler {
 	codeRepo := repository.NewAuthorizationCodeRepository(opt.DB)
 	tokenRepo := repository.NewTokenRepository(opt.DB)
 	refreshTokenRepo := repository.NewRefreshTokenRepository(opt.DB)
+	scopeRepo := repository.NewScopeRepository(opt.DB)
+	consentRepo := repository.NewConsentRepository(opt.DB)
 	tokenService := domainservice.NewTokenService(tokenRepo, refreshTokenRepo, opt.Config)
-	uc := usecase.NewAuthorizationUsecase(clientRepo, codeRepo, tokenService)
+	consentService := domainservice.NewConsentService(consentRepo, opt.Config)
+	userRepo := repository.NewUserRepository(opt.DB)
+	uc := usecase.NewAuthorizationUsecase(clientRepo, codeRepo, scopeRepo, userRepo, tokenService, consentService)
 	return &AuthorizationHandler{
-		uc:      uc,
-		session: opt.Session,
-		config:  opt.Config,
+		uc:       uc,
+		verifyUC: newEmailVerificationUsecase(opt),
+		session:  opt.Session,
+		config:   opt.Config,
 	}
 }
 
 type AuthorizationHandler struct {
-	uc      usecase.IAuthorizationUsecase
-	session session.SessionManager
-	config  *config.Config
+	uc       usecase.IAuthorizationUsecase
+	verifyUC usecase.IEmailVerificationUsecase
+	session  session.SessionManager
+	config   *config.Config
 }
 
 func (h *AuthorizationHandler) Consent(c *gin.Context) {
@@ -56,19 +65,57 @@ func (h *AuthorizationHandler) Consent(c *gin.Context) {
 		c.HTML(http.StatusBadRequest, "400.html", gin.H{"error": err.Error()})
 		return
 	}
+	userID, err := uuid.Parse(authUser.UserID)
+	if err != nil {
+		c.HTML(http.StatusBadRequest, "400.html", gin.H{"error": err.Error()})
+		return
+	}
 
-	// クライアント情報を取得
-	client, err := h.uc.Consent(c.Request.Context(), clientID)
+	// メールアドレスを確認していないユーザーには許可しないスコープがある
+	if err := h.verifyUC.CheckScopes(c.Request.Context(), authUser.UserID, authUser.Scope); err != nil {
+		if errors.Is(err, usecase.ErrEmailNotVerified) {
+			redirectWithError(c, authUser.RedirectURI, authUser.State, "access_denied")
+			return
+		}
+		handleError(c, sess, err)
+		return
+	}
+
+	// クライアント情報と未同意のスコープを取得
+	res, err := h.uc.Consent(c.Request.Context(), usecase.ConsentParams{
+		UserID:   userID,
+		ClientID: clientID,
+		Scope:    authUser.Scope,
+	})
 	if err != nil {
 		handleError(c, sess, err)
 		return
 	}
 
-	c.HTML(http.StatusOK, "consent.html", gin.H{"cli": client})
+	// 要求されたスコープが全て同意済みなら同意画面を省略する
+	if !res.Required {
+		h.issueCode(c, sess, authUser)
+		return
+	}
+
+	// prompt=none では同意画面を表示できない
+	if slices.Contains(strings.Fields(authUser.Prompt), promptNone) {
+		redirectWithError(c, authUser.RedirectURI, authUser.State, "consent_required")
+		return
+	}
+
+	token, err := csrf.Token(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.HTML(http.StatusOK, "consent.html", gin.H{"cli": res.Client, "scopes": res.Scopes, "csrf": token})
 }
 
 type ConcentForm struct {
-	Agree bool `form:"agree" binding:"required"`
+	Action string   `form:"action" binding:"required,oneof=allow deny"`
+	Scopes []string `form:"scopes"`
 }
 
 func (h *AuthorizationHandler) PostConsent(c *gin.Context) {
@@ -95,7 +142,37 @@ func (h *AuthorizationHandler) PostConsent(c *gin.Context) {
 		return
 	}
 
-	// 同意画面のビジネスロジックを書く
+	// 拒否された場合は認可コードを発行せずにクライアントへ返す
+	if concentForm.Action == "deny" {
+		redirectWithError(c, authUser.RedirectURI, authUser.State, "access_denied")
+		return
+	}
+
+	// 同意したスコープを記録する
+	granted, err := h.uc.GrantConsent(c.Request.Context(), usecase.GrantConsentParams{
+		UserID:   authUser.UserID,
+		ClientID: authUser.ClientID,
+		Scope:    authUser.Scope,
+		Approved: concentForm.Scopes,
+	})
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	// 全てのスコープのチェックを外された場合は拒否として扱う
+	if granted.IsEmpty() {
+		redirectWithError(c, authUser.RedirectURI, authUser.State, "access_denied")
+		return
+	}
+
+	// 認可コードには実際に許可されたスコープのみを記録する
+	authUser.Scope = granted.String()
+	h.issueCode(c, sess, authUser)
+}
+
+// issueCode は認可コードを発行してクライアントにリダイレクトする
+func (h *AuthorizationHandler) issueCode(c *gin.Context, sess session.SessionClient, authUser AuthedUser) {
 	code, err := h.uc.GenerateAuthorizationCode(c.Request.Context(), usecase.GenerateAuthorizationCodeParams{
 		UserID:      authUser.UserID,
 		ClientID:    authUser.ClientID,
@@ -109,14 +186,22 @@ func (h *AuthorizationHandler) PostConsent(c *gin.Context) {
 		return
 	}
 
-	// リダイレクト
-	c.Redirect(http.StatusFound, code.GenerateRedirectURIWithCode())
+	redirectURI, err := code.GenerateRedirectURIWithCode(authUser.State)
+	if err != nil {
+		handleError(c, sess, err)
+		return
+	}
+	c.Redirect(http.StatusFound, redirectURI)
 }
 
 type TokenRequest struct {
 	Code         string `json:"code" binding:"required_without=RefreshToken,required_with_field_value=GrantType authorization_code"`
 	RefreshToken string `json:"refresh_token" binding:"required_without=Code,required_with_field_value=GrantType refresh_token"`
 	GrantType    string `json:"grant_type" binding:"required,oneof=authorization_code refresh_token"`
+	ClientID     string `json:"client_id" binding:"omitempty,uuid"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope"`
+	RedirectURI  string `json:"redirect_uri" binding:"required_with_field_value=GrantType authorization_code"`
 }
 
 type TokenResponse struct {
@@ -137,11 +222,27 @@ func (h *AuthorizationHandler) Token(c *gin.Context) {
 		return
 	}
 
+	// Basic 認証ヘッダーがある場合はそちらのクライアントを優先する (RFC 6749 2.3.1)
+	if clientID, clientSecret, ok := c.Request.BasicAuth(); ok {
+		input.ClientID = clientID
+		input.ClientSecret = clientSecret
+	}
+
 	switch input.GrantType {
 	case "authorization_code":
-		atoken, rtoken, err = h.uc.GenerateTokenByCode(c.Request.Context(), input.Code)
+		atoken, rtoken, err = h.uc.GenerateTokenByCode(c.Request.Context(), usecase.GenerateTokenByCodeParams{
+			Code:         input.Code,
+			ClientID:     input.ClientID,
+			ClientSecret: input.ClientSecret,
+			RedirectURI:  input.RedirectURI,
+		})
 	case "refresh_token":
-		atoken, rtoken, err = h.uc.GenerateTokenByRefreshToken(c.Request.Context(), input.RefreshToken)
+		atoken, rtoken, err = h.uc.GenerateTokenByRefreshToken(c.Request.Context(), usecase.GenerateTokenByRefreshTokenParams{
+			RefreshToken: input.RefreshToken,
+			Scope:        input.Scope,
+			ClientID:     input.ClientID,
+			ClientSecret: input.ClientSecret,
+		})
 	default:
 		// ここには到達しない
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": errors.New("invalid grant type")})
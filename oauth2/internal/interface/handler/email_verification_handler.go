@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/csrf"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/flashmessage"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/sso"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewEmailVerificationHandler(opt HandlerOption) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		uc:         newEmailVerificationUsecase(opt),
+		session:    opt.Session,
+		ssoRevoker: opt.SSORevoker,
+		config:     opt.Config,
+	}
+}
+
+func newEmailVerificationUsecase(opt HandlerOption) usecase.IEmailVerificationUsecase {
+	userRepo := repository.NewUserRepository(opt.DB)
+	tokenRepo := repository.NewEmailVerificationTokenRepository(opt.DB)
+	return usecase.NewEmailVerificationUsecase(userRepo, tokenRepo, opt.MailSender, opt.Config)
+}
+
+type EmailVerificationHandler struct {
+	uc         usecase.IEmailVerificationUsecase
+	session    session.SessionManager
+	ssoRevoker *sso.Revoker
+	config     *config.Config
+}
+
+// VerifyEmail は確認メールの案内と再送ボタンを表示する
+func (h *EmailVerificationHandler) VerifyEmail(c *gin.Context) {
+	sess := h.session.NewSession(c)
+	mess, err := flashmessage.Flash(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := csrf.Token(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.HTML(http.StatusOK, "verify_email.html", gin.H{"mess": mess, "csrf": token})
+}
+
+// ResendVerificationEmail は確認メールを再送する
+func (h *EmailVerificationHandler) ResendVerificationEmail(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	userID, err := h.loadUserID(c, sess)
+	if err != nil {
+		c.Error(errors.WithStack(err))
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+	if userID == "" {
+		c.HTML(http.StatusUnauthorized, "400.html", gin.H{"error": "login required"})
+		return
+	}
+
+	if err := h.uc.SendVerificationEmail(c.Request.Context(), userID); err != nil {
+		// 短時間の再送はフラッシュメッセージで知らせる
+		if usecaseErr, ok := err.(*errors.UsecaseError); ok && usecaseErr.Code == http.StatusTooManyRequests {
+			if flashErr := flashmessage.AddMessage(c, sess, flashmessage.Error, usecaseErr.Error()); flashErr != nil {
+				c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": flashErr.Error()})
+				return
+			}
+			c.Redirect(http.StatusFound, "/client/verify-email")
+			return
+		}
+		handleError(c, sess, err)
+		return
+	}
+
+	if err := flashmessage.AddMessage(c, sess, flashmessage.Success, "確認メールを送信しました"); err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/client/verify-email")
+}
+
+type ConfirmEmailInput struct {
+	Token string `form:"token" binding:"required"`
+}
+
+// ConfirmEmail はメールのリンクから開かれ、メールアドレスを確認済みにする
+func (h *EmailVerificationHandler) ConfirmEmail(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	var input ConfirmEmailInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.HTML(http.StatusBadRequest, "400.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.uc.VerifyEmail(c.Request.Context(), input.Token); err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	// 確認待ちの状態を解除する
+	if err := sess.DelSessionData(c, "unverified_user"); err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.HTML(http.StatusOK, "email_verified.html", gin.H{})
+}
+
+// loadUserID はサインイン中、または確認待ちのユーザーのIDを返す
+func (h *EmailVerificationHandler) loadUserID(c *gin.Context, sess session.SessionClient) (string, error) {
+	ssoSess, err := sso.Load(c, sess, h.ssoRevoker)
+	if err != nil {
+		return "", err
+	}
+	if ssoSess != nil {
+		return ssoSess.UserID, nil
+	}
+
+	unverified, _, err := session.Load[UnverifiedUser](c, sess, "unverified_user")
+	if err != nil {
+		return "", err
+	}
+	return unverified.UserID, nil
+}
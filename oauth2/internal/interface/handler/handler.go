@@ -1,14 +1,21 @@
 package handler
 
 import (
+	"log/slog"
 	"net/http"
+	"net/url"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/jmoiron/sqlx"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
 	"github.com/sntkn/go-oauth2/oauth2/internal/common/flashmessage"
 	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/sso"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/secretbox"
 )
 
 type SigninForm struct {
@@ -23,18 +30,59 @@ type AuthedUser struct {
 	ClientID    string
 	RedirectURI string
 	Scope       string
+	State       string
+	Prompt      string
+	AMR         []string
+	ACR         string
 	Expires     int
 }
 
 type HandlerOption struct {
-	Session session.SessionManager
-	DB      *sqlx.DB
-	Config  *config.Config
+	Session        session.SessionManager
+	DB             *sqlx.DB
+	Config         *config.Config
+	MailSender     domain.MailSender
+	SSORevoker     *sso.Revoker
+	SecretBox      *secretbox.Box
+	WebAuthn       *webauthn.WebAuthn
+	LoginAttempts  domain.LoginAttemptStore
+	PasswordHasher domain.PasswordHasher
+	PasswordPolicy domain.PasswordPolicy
+	// FederationProviders は「〜でサインイン」に使う上流の OpenID プロバイダ
+	FederationProviders []usecase.FederationProvider
+	// Directory が nil でない場合は、ローカルのパスワードを持たない利用者をディレクトリで認証する
+	Directory *usecase.DirectoryVerifierParams
+	// SAML は SAML の接続で共有する SP の設定。接続は DB に登録する
+	SAML       usecase.SAMLServiceProviderParams
+	SAMLLogins domain.SAMLLoginStore
+	// Logger は応答の後に続ける処理の失敗を記録する
+	Logger *slog.Logger
+}
+
+// MFAPendingUser はパスワードの確認を終え、二要素目の入力を待っているユーザー
+type MFAPendingUser struct {
+	UserID   string
+	Email    string
+	Attempts int
+	// AMR は一要素目の認証方式。空の場合はパスワード
+	AMR []string
+}
+
+// UnverifiedUser はメールアドレスを確認するまでサインインできないユーザー。確認メールの再送に使う
+type UnverifiedUser struct {
+	UserID string
+	Email  string
 }
 
 func handleError(c *gin.Context, sess session.SessionClient, err error) {
 	if usecaseErr, ok := err.(*errors.UsecaseError); ok {
-		if flashErr := flashmessage.AddMessage(c, sess, "error", usecaseErr.Error()); flashErr != nil {
+		message := usecaseErr.Error()
+		// 理由を利用者に伝えるエラーは、利用者の言語のメッセージにする
+		var localizable flashmessage.Localizable
+		if errors.As(usecaseErr.Err, &localizable) {
+			message = flashmessage.Localize(c, localizable)
+		}
+		if flashErr := flashmessage.AddMessage(c, sess, "error", message); flashErr != nil {
 			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": flashErr.Error()})
 			return
 		}
@@ -46,8 +94,39 @@ func handleError(c *gin.Context, sess session.SessionClient, err error) {
 		case http.StatusInternalServerError:
 			c.Error(errors.WithStack(err)) // TODO: trigger usecase
 			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": usecaseErr.Error()})
+		default:
+			c.HTML(usecaseErr.Code, "400.html", gin.H{"error": err.Error()})
 		}
 	} else {
 		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
 	}
 }
+
+// abortWithJSONError は fetch で呼ばれるエンドポイントのエラーをJSONで返す
+func abortWithJSONError(c *gin.Context, err error) {
+	if usecaseErr, ok := err.(*errors.UsecaseError); ok {
+		if usecaseErr.Code == http.StatusInternalServerError {
+			c.Error(errors.WithStack(err))
+		}
+		c.AbortWithStatusJSON(usecaseErr.Code, gin.H{"error": usecaseErr.Error()})
+		return
+	}
+	c.Error(err)
+	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// redirectWithError は認可エラーをクライアントの redirect_uri にクエリで返す (RFC 6749 4.1.2.1)
+func redirectWithError(c *gin.Context, redirectURI, state, code string) {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		c.HTML(http.StatusBadRequest, "400.html", gin.H{"error": err.Error()})
+		return
+	}
+	q := u.Query()
+	q.Set("error", code)
+	if state != "" {
+		q.Set("state", state)
+	}
+	u.RawQuery = q.Encode()
+	c.Redirect(http.StatusFound, u.String())
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/infrastructure/repository"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/csrf"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/flashmessage"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/sso"
+	"github.com/sntkn/go-oauth2/oauth2/internal/usecase"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+// パスワード再設定の受付後は、登録の有無にかかわらず同じメッセージを表示する
+const passwordResetRequestedMessage = "If an account exists for that email address, a password reset link has been sent."
+
+func newPasswordResetUsecase(opt HandlerOption) usecase.IPasswordResetUsecase {
+	userRepo := repository.NewUserRepository(opt.DB)
+	tokenRepo := repository.NewPasswordResetTokenRepository(opt.DB)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(opt.DB)
+	return usecase.NewPasswordResetUsecase(userRepo, tokenRepo, refreshTokenRepo, opt.SSORevoker, opt.MailSender, opt.PasswordHasher, opt.PasswordPolicy, opt.Config, opt.Logger)
+}
+
+func NewPasswordResetHandler(opt HandlerOption) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		uc:      newPasswordResetUsecase(opt),
+		session: opt.Session,
+		config:  opt.Config,
+	}
+}
+
+type PasswordResetHandler struct {
+	uc      usecase.IPasswordResetUsecase
+	session session.SessionManager
+	config  *config.Config
+}
+
+func (h *PasswordResetHandler) ForgotPassword(c *gin.Context) {
+	sess := h.session.NewSession(c)
+	mess, err := flashmessage.Flash(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := csrf.Token(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.HTML(http.StatusOK, "forgot_password.html", gin.H{"mess": mess, "csrf": token})
+}
+
+type PostForgotPasswordInput struct {
+	Email string `form:"email" binding:"required,email,max=255"`
+}
+
+func (h *PasswordResetHandler) PostForgotPassword(c *gin.Context) {
+	sess := h.session.NewSession(c)
+	var input PostForgotPasswordInput
+	if err := c.ShouldBind(&input); err != nil {
+		if flashErr := flashmessage.AddMessage(c, sess, flashmessage.Error, err.Error()); flashErr != nil {
+			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": flashErr.Error()})
+			return
+		}
+		c.Redirect(http.StatusFound, "/client/forgot-password")
+		return
+	}
+
+	// メールの送信は応答の後に行われ、失敗はユースケースがログに記録する
+	if err := h.uc.RequestPasswordReset(c.Request.Context(), input.Email); err != nil {
+		c.Error(errors.WithStack(err))
+	}
+
+	if err := flashmessage.AddMessage(c, sess, flashmessage.Notice, passwordResetRequestedMessage); err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/client/forgot-password")
+}
+
+type ResetPasswordInput struct {
+	Token string `form:"token" binding:"required"`
+}
+
+func (h *PasswordResetHandler) ResetPassword(c *gin.Context) {
+	sess := h.session.NewSession(c)
+
+	var input ResetPasswordInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.HTML(http.StatusBadRequest, "400.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.uc.ValidateResetToken(c.Request.Context(), input.Token); err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	mess, err := flashmessage.Flash(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := csrf.Token(c, sess)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.HTML(http.StatusOK, "reset_password.html", gin.H{"mess": mess, "csrf": token, "token": input.Token})
+}
+
+type PostResetPasswordInput struct {
+	Token                string `form:"token" binding:"required"`
+	Password             string `form:"password" binding:"required"`
+	PasswordConfirmation string `form:"password_confirmation" binding:"required,eqfield=Password"`
+}
+
+func (h *PasswordResetHandler) PostResetPassword(c *gin.Context) {
+	sess := h.session.NewSession(c)
+	var input PostResetPasswordInput
+	if err := c.ShouldBind(&input); err != nil {
+		if flashErr := flashmessage.AddMessage(c, sess, flashmessage.Error, err.Error()); flashErr != nil {
+			c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": flashErr.Error()})
+			return
+		}
+		c.Redirect(http.StatusFound, "/client/reset-password?token="+url.QueryEscape(input.Token))
+		return
+	}
+
+	if err := h.uc.ResetPassword(c.Request.Context(), usecase.ResetPasswordParams{
+		Token:    input.Token,
+		Password: input.Password,
+	}); err != nil {
+		handleError(c, sess, err)
+		return
+	}
+
+	// この端末のログイン状態も破棄し、新しいパスワードでサインインし直してもらう
+	if err := sso.Delete(c, sess); err != nil {
+		c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+		return
+	}
+
+	c.HTML(http.StatusOK, "password_reset_finished.html", gin.H{})
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
 	"github.com/sntkn/go-oauth2/oauth2/internal/common/accesstoken"
 	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
 )
@@ -35,3 +36,21 @@ func AuthMiddleware(cfg *config.Config, tokenParser accesstoken.Parser) gin.Hand
 		c.Next()
 	}
 }
+
+// RequireScope は AuthMiddleware で検証したアクセストークンに scope が含まれない場合に拒否する
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := c.Get("claims")
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing access token"})
+			return
+		}
+		granted, err := domain.ParseScope(claims.(*accesstoken.CustomClaims).Scope)
+		if err != nil || !granted.Contains(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient_scope"})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/csrf"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/flashmessage"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+)
+
+const csrfErrorMessage = "invalid csrf token"
+
+// CSRFMiddleware は状態を変更するリクエストのCSRFトークンを検証する
+func CSRFMiddleware(sm session.SessionManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		sess := sm.NewSession(c)
+
+		token := c.PostForm(csrf.FormField)
+		if token == "" {
+			token = c.GetHeader(csrf.HeaderName)
+		}
+
+		ok, err := csrf.Verify(c, sess, token)
+		if err != nil {
+			abortWithServerError(c, err)
+			return
+		}
+
+		if !ok {
+			// フラッシュメッセージはこのページで取り出して表示し、次に開いたページには残さない
+			if err := flashmessage.AddMessage(c, sess, flashmessage.Error, csrfErrorMessage); err != nil {
+				abortWithServerError(c, err)
+				return
+			}
+			mess, err := flashmessage.Flash(c, sess)
+			if err != nil {
+				abortWithServerError(c, err)
+				return
+			}
+			c.HTML(http.StatusBadRequest, "400.html", gin.H{"mess": mess})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func abortWithServerError(c *gin.Context, err error) {
+	c.Error(err)
+	c.HTML(http.StatusInternalServerError, "500.html", gin.H{"error": err.Error()})
+	c.Abort()
+}
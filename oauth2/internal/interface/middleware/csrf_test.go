@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/csrf"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSRFMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := map[string]string{}
+	sess := &session.SessionClientMock{
+		GetSessionDataFunc: func(_ *gin.Context, key string) (string, error) {
+			return store[key], nil
+		},
+		SetSessionDataFunc: func(_ *gin.Context, key string, input string) error {
+			store[key] = input
+			return nil
+		},
+		DelSessionDataFunc: func(_ *gin.Context, key string) error {
+			delete(store, key)
+			return nil
+		},
+	}
+	sm := &session.SessionManagerMock{
+		NewSessionFunc: func(c *gin.Context) session.SessionClient {
+			return sess
+		},
+	}
+
+	r := gin.New()
+	r.SetHTMLTemplate(template.Must(template.New("400.html").Parse(`{{ range .mess.Error }}{{ . }}{{ end }}`)))
+	r.POST("/form", CSRFMiddleware(sm), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	token, err := csrf.Token(c, sess)
+	require.NoError(t, err)
+
+	post := func(form url.Values, header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/form", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if header != "" {
+			req.Header.Set(csrf.HeaderName, header)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		name     string
+		form     url.Values
+		header   string
+		wantCode int
+	}{
+		{name: "missing token", form: url.Values{}, wantCode: http.StatusBadRequest},
+		{name: "wrong token", form: url.Values{csrf.FormField: {"wrong"}}, wantCode: http.StatusBadRequest},
+		{name: "valid form token", form: url.Values{csrf.FormField: {token}}, wantCode: http.StatusOK},
+		{name: "valid header token", form: url.Values{}, header: token, wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(tt.form, tt.header)
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusBadRequest {
+				assert.Contains(t, w.Body.String(), csrfErrorMessage)
+			}
+		})
+	}
+
+	// フラッシュメッセージは 400 のページで取り出し、次のページには残さない
+	assert.NotContains(t, store, "flashMessage")
+	assert.Len(t, sess.DelSessionDataCalls(), 2)
+}
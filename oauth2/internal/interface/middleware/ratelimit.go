@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/internal/common/accesstoken"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/ratelimit"
+)
+
+// maxPeekBodyBytes はクライアントIDを探すために読み込む本文の上限
+const maxPeekBodyBytes = 1 << 20
+
+// RateLimitKey はリクエストを数える単位を返す。空文字を返した場合はそのルールでは数えない
+type RateLimitKey func(c *gin.Context) string
+
+type RateLimitRule struct {
+	// Name はルートと単位を表す名前。ルールごとに別々に数える
+	Name   string
+	Limit  int
+	Window time.Duration
+	Key    RateLimitKey
+}
+
+// RateLimitMiddleware は全てのルールで回数を数え、いずれかの上限を超えた場合は 429 を返す。
+// 応答にはもっとも制限の厳しいルールの RateLimit-* ヘッダーを付ける。
+// Limit が 0 のルールは無効として扱う
+func RateLimitMiddleware(l *ratelimit.Limiter, rules ...RateLimitRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			tightest *ratelimit.Result
+			rule     RateLimitRule
+		)
+		for _, r := range rules {
+			if r.Limit <= 0 {
+				continue
+			}
+			key := r.Key(c)
+			if key == "" {
+				continue
+			}
+
+			res, err := l.Allow(c.Request.Context(), r.Name+":"+key, r.Limit, r.Window, time.Now())
+			if err != nil {
+				// 回数を数えられない場合でもリクエストは止めない
+				c.Error(errors.WithStack(err))
+				continue
+			}
+			if tightest == nil || isTighter(res, *tightest) {
+				tightest, rule = &res, r
+			}
+		}
+		if tightest == nil {
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Policy", strconv.Itoa(rule.Limit)+";w="+strconv.Itoa(ceilSeconds(rule.Window)))
+		c.Header("RateLimit-Limit", strconv.Itoa(tightest.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(tightest.Reset)))
+
+		if !tightest.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(tightest.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too_many_requests"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RateLimitByIP は接続元のIPアドレスごとに数える
+func RateLimitByIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// RateLimitByClientID はクライアントごとに数える。接続元をまたいで数えるため、多数のIPアドレスに分散したクライアントも制限できる。
+// トークンエンドポイントと同じく、Basic 認証のクライアントIDを本文の client_id より優先する
+func RateLimitByClientID(c *gin.Context) string {
+	if clientID, _, ok := c.Request.BasicAuth(); ok {
+		return clientID
+	}
+	if clientID := c.Query("client_id"); clientID != "" {
+		return clientID
+	}
+	if !strings.HasPrefix(c.ContentType(), gin.MIMEJSON) {
+		return c.PostForm("client_id")
+	}
+
+	// ハンドラーが本文を読めるよう、読み込んだ内容を戻しておく
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPeekBodyBytes))
+	if err != nil {
+		return ""
+	}
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+
+	var input struct {
+		ClientID string `json:"client_id"`
+	}
+	if err := json.Unmarshal(body, &input); err != nil {
+		return ""
+	}
+	return input.ClientID
+}
+
+// RateLimitByUser はアクセストークンのユーザーごとに数える。AuthMiddleware の後に使う
+func RateLimitByUser(c *gin.Context) string {
+	claims, ok := c.Get("claims")
+	if !ok {
+		return ""
+	}
+	return claims.(*accesstoken.CustomClaims).UserID
+}
+
+// isTighter は a の方が先に上限に達する (拒否した場合は長く待たせる) かを判定する
+func isTighter(a, b ratelimit.Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
+
+func ceilSeconds(d time.Duration) int {
+	return max(int(math.Ceil(d.Seconds())), 1)
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/ratelimit"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/valkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimiter() *ratelimit.Limiter {
+	counts := map[string]int64{}
+	return ratelimit.New(&valkey.ClientIFMock{
+		IncrFunc: func(ctx context.Context, key string, expiration int64) (int64, error) {
+			counts[key]++
+			return counts[key], nil
+		},
+		GetFunc: func(ctx context.Context, key string) (string, error) {
+			if n, ok := counts[key]; ok {
+				return strconv.FormatInt(n, 10), nil
+			}
+			return "", nil
+		},
+	})
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	var bodies []string
+	r.POST("/oauth2/token", RateLimitMiddleware(newRateLimiter(),
+		RateLimitRule{Name: "token:client", Limit: 2, Window: time.Hour, Key: RateLimitByClientID},
+		RateLimitRule{Name: "token:ip", Limit: 3, Window: time.Hour, Key: RateLimitByIP},
+	), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		bodies = append(bodies, string(body))
+		c.Status(http.StatusOK)
+	})
+
+	post := func(clientID string, remoteAddr ...string) *httptest.ResponseRecorder {
+		body := `{"grant_type":"client_credentials","client_id":"` + clientID + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/oauth2/token", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if len(remoteAddr) > 0 {
+			req.RemoteAddr = remoteAddr[0]
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("client-a")
+	require.Equal(t, http.StatusOK, w.Code)
+	// 残りの少ないクライアントごとのルールのヘッダーを返す
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2;w=3600", w.Header().Get("RateLimit-Policy"))
+	// ハンドラーは本文をそのまま読める
+	assert.Equal(t, `{"grant_type":"client_credentials","client_id":"client-a"}`, bodies[0])
+
+	require.Equal(t, http.StatusOK, post("client-a").Code)
+
+	w = post("client-a")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+	// 別のクライアントでも接続元ごとの上限には達する
+	w = post("client-b")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "3", w.Header().Get("RateLimit-Limit"))
+	assert.Len(t, bodies, 2)
+
+	// 接続元を変えても同じクライアントの上限は共有する
+	w = post("client-a", "192.0.2.10:1234")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Len(t, bodies, 2)
+}
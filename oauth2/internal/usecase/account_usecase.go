@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewAccountUsecase(
+	clientRepo domain.ClientRepository,
+	consentRepo domain.ConsentRepository,
+	tokenRepo domain.TokenRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+) IAccountUsecase {
+	return &AccountUsecase{
+		clientRepo:       clientRepo,
+		consentRepo:      consentRepo,
+		tokenRepo:        tokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+type IAccountUsecase interface {
+	ConnectedApps(ctx context.Context, userID string) ([]ConnectedApp, error)
+	RevokeApp(ctx context.Context, userID, clientID string) error
+}
+
+type AccountUsecase struct {
+	clientRepo       domain.ClientRepository
+	consentRepo      domain.ConsentRepository
+	tokenRepo        domain.TokenRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+}
+
+// ConnectedApp はユーザーがアクセスを許可しているクライアント
+type ConnectedApp struct {
+	Client       domain.Client
+	ClientID     uuid.UUID
+	Scopes       domain.ScopeSet
+	FirstUsedAt  time.Time
+	LastUsedAt   time.Time
+	ActiveTokens int
+}
+
+// ConnectedApps は同意またはトークンの発行履歴があるクライアントを、最後に使われた順に返す
+func (uc *AccountUsecase) ConnectedApps(ctx context.Context, userID string) ([]ConnectedApp, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+
+	consents, err := uc.consentRepo.FindConsentsByUserID(ctx, uid)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	usages, err := uc.tokenRepo.SummarizeTokensByUserID(ctx, uid, time.Now())
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	apps := map[uuid.UUID]*ConnectedApp{}
+	for _, c := range consents {
+		apps[c.GetClientID()] = &ConnectedApp{
+			ClientID:    c.GetClientID(),
+			Scopes:      c.GetScopes(),
+			FirstUsedAt: c.GetCreatedAt(),
+			LastUsedAt:  c.GetUpdatedAt(),
+		}
+	}
+	for _, u := range usages {
+		app, ok := apps[u.ClientID]
+		if !ok {
+			app = &ConnectedApp{ClientID: u.ClientID, FirstUsedAt: u.FirstUsedAt, LastUsedAt: u.LastUsedAt}
+			apps[u.ClientID] = app
+		}
+		if u.FirstUsedAt.Before(app.FirstUsedAt) {
+			app.FirstUsedAt = u.FirstUsedAt
+		}
+		if u.LastUsedAt.After(app.LastUsedAt) {
+			app.LastUsedAt = u.LastUsedAt
+		}
+		app.ActiveTokens = u.ActiveTokens
+	}
+
+	res := make([]ConnectedApp, 0, len(apps))
+	for _, app := range apps {
+		client, err := uc.clientRepo.FindClientByClientID(ctx, app.ClientID)
+		if err != nil {
+			return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+		// 削除済みのクライアントは表示しない
+		if client.IsNotFound() {
+			continue
+		}
+		app.Client = client
+		res = append(res, *app)
+	}
+
+	slices.SortFunc(res, func(a, b ConnectedApp) int {
+		return b.LastUsedAt.Compare(a.LastUsedAt)
+	})
+
+	return res, nil
+}
+
+// RevokeApp はクライアントへの同意を取り消し、そのユーザーに発行した全てのトークンを失効させる
+func (uc *AccountUsecase) RevokeApp(ctx context.Context, userID, clientID string) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+	cid, err := uuid.Parse(clientID)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusBadRequest, "invalid client id")
+	}
+
+	// リフレッシュトークンはアクセストークン経由で特定するため先に失効させる
+	if err := uc.refreshTokenRepo.RevokeRefreshTokensByUserAndClient(ctx, uid, cid); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	if err := uc.tokenRepo.RevokeTokensByUserAndClient(ctx, uid, cid); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	if err := uc.consentRepo.DeleteConsent(ctx, uid, cid); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	return nil
+}
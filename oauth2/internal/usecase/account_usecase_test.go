@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectedApps_Success(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	consentedClientID := uuid.New()
+	firstPartyClientID := uuid.New()
+	deletedClientID := uuid.New()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockConsentRepo := &domain.ConsentRepositoryMock{
+		FindConsentsByUserIDFunc: func(ctx context.Context, userID uuid.UUID) ([]domain.Consent, error) {
+			c, err := domain.NewConsent(domain.ConsentParams{
+				UserID:    userID,
+				ClientID:  consentedClientID,
+				Scope:     "read write",
+				CreatedAt: base.Add(24 * time.Hour),
+				UpdatedAt: base.Add(24 * time.Hour),
+			})
+			return []domain.Consent{c}, err
+		},
+	}
+	mockTokenRepo := &domain.TokenRepositoryMock{
+		SummarizeTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID, now time.Time) ([]domain.TokenUsage, error) {
+			return []domain.TokenUsage{
+				{ClientID: consentedClientID, FirstUsedAt: base, LastUsedAt: base.Add(48 * time.Hour), ActiveTokens: 2},
+				{ClientID: firstPartyClientID, FirstUsedAt: base, LastUsedAt: base.Add(72 * time.Hour), ActiveTokens: 1},
+				{ClientID: deletedClientID, FirstUsedAt: base, LastUsedAt: base, ActiveTokens: 0},
+			}, nil
+		},
+	}
+	mockClientRepo := &domain.ClientRepositoryMock{
+		FindClientByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) (domain.Client, error) {
+			return &domain.ClientMock{
+				IsNotFoundFunc: func() bool {
+					return clientID == deletedClientID
+				},
+			}, nil
+		},
+	}
+
+	uc := NewAccountUsecase(mockClientRepo, mockConsentRepo, mockTokenRepo, nil)
+	apps, err := uc.ConnectedApps(ctx, userID.String())
+	require.NoError(t, err)
+	require.Len(t, apps, 2)
+
+	assert.Equal(t, firstPartyClientID, apps[0].ClientID)
+	assert.True(t, apps[0].Scopes.IsEmpty())
+	assert.Equal(t, 1, apps[0].ActiveTokens)
+
+	assert.Equal(t, consentedClientID, apps[1].ClientID)
+	assert.Equal(t, "read write", apps[1].Scopes.String())
+	assert.Equal(t, base, apps[1].FirstUsedAt)
+	assert.Equal(t, base.Add(48*time.Hour), apps[1].LastUsedAt)
+	assert.Equal(t, 2, apps[1].ActiveTokens)
+
+	assert.Equal(t, userID, mockConsentRepo.FindConsentsByUserIDCalls()[0].UserID)
+	assert.Equal(t, userID, mockTokenRepo.SummarizeTokensByUserIDCalls()[0].UserID)
+}
+
+func TestConnectedApps_InvalidUserID(t *testing.T) {
+	ctx := context.Background()
+
+	uc := NewAccountUsecase(nil, nil, nil, nil)
+	_, err := uc.ConnectedApps(ctx, "invalid")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "invalid user id", err.(*errors.UsecaseError).Message)
+}
+
+func TestConnectedApps_FindConsentsError(t *testing.T) {
+	ctx := context.Background()
+	mockConsentRepo := &domain.ConsentRepositoryMock{
+		FindConsentsByUserIDFunc: func(ctx context.Context, userID uuid.UUID) ([]domain.Consent, error) {
+			return nil, errors.New("FindConsentsByUserID error")
+		},
+	}
+
+	uc := NewAccountUsecase(nil, mockConsentRepo, nil, nil)
+	_, err := uc.ConnectedApps(ctx, uuid.NewString())
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "FindConsentsByUserID error", err.(*errors.UsecaseError).Message)
+}
+
+func TestRevokeApp_Success(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	clientID := uuid.New()
+
+	mockRefreshTokenRepo := &domain.RefreshTokenRepositoryMock{
+		RevokeRefreshTokensByUserAndClientFunc: func(ctx context.Context, userID, clientID uuid.UUID) error {
+			return nil
+		},
+	}
+	mockTokenRepo := &domain.TokenRepositoryMock{
+		RevokeTokensByUserAndClientFunc: func(ctx context.Context, userID, clientID uuid.UUID) error {
+			return nil
+		},
+	}
+	mockConsentRepo := &domain.ConsentRepositoryMock{
+		DeleteConsentFunc: func(ctx context.Context, userID, clientID uuid.UUID) error {
+			return nil
+		},
+	}
+
+	uc := NewAccountUsecase(nil, mockConsentRepo, mockTokenRepo, mockRefreshTokenRepo)
+	err := uc.RevokeApp(ctx, userID.String(), clientID.String())
+	require.NoError(t, err)
+
+	require.Len(t, mockRefreshTokenRepo.RevokeRefreshTokensByUserAndClientCalls(), 1)
+	assert.Equal(t, userID, mockRefreshTokenRepo.RevokeRefreshTokensByUserAndClientCalls()[0].UserID)
+	assert.Equal(t, clientID, mockRefreshTokenRepo.RevokeRefreshTokensByUserAndClientCalls()[0].ClientID)
+	require.Len(t, mockTokenRepo.RevokeTokensByUserAndClientCalls(), 1)
+	assert.Equal(t, clientID, mockTokenRepo.RevokeTokensByUserAndClientCalls()[0].ClientID)
+	require.Len(t, mockConsentRepo.DeleteConsentCalls(), 1)
+	assert.Equal(t, userID, mockConsentRepo.DeleteConsentCalls()[0].UserID)
+}
+
+func TestRevokeApp_InvalidClientID(t *testing.T) {
+	ctx := context.Background()
+
+	uc := NewAccountUsecase(nil, nil, nil, nil)
+	err := uc.RevokeApp(ctx, uuid.NewString(), "invalid")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "invalid client id", err.(*errors.UsecaseError).Message)
+}
+
+func TestRevokeApp_RevokeTokensError(t *testing.T) {
+	ctx := context.Background()
+	mockRefreshTokenRepo := &domain.RefreshTokenRepositoryMock{
+		RevokeRefreshTokensByUserAndClientFunc: func(ctx context.Context, userID, clientID uuid.UUID) error {
+			return nil
+		},
+	}
+	mockTokenRepo := &domain.TokenRepositoryMock{
+		RevokeTokensByUserAndClientFunc: func(ctx context.Context, userID, clientID uuid.UUID) error {
+			return errors.New("RevokeTokensByUserAndClient error")
+		},
+	}
+	mockConsentRepo := &domain.ConsentRepositoryMock{}
+
+	uc := NewAccountUsecase(nil, mockConsentRepo, mockTokenRepo, mockRefreshTokenRepo)
+	err := uc.RevokeApp(ctx, uuid.NewString(), uuid.NewString())
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "RevokeTokensByUserAndClient error", err.(*errors.UsecaseError).Message)
+	assert.Empty(t, mockConsentRepo.DeleteConsentCalls())
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+// 失効したアクセストークンや、無効にしたユーザーやクライアントのアクセストークンは署名が正しくても使えない
+var ErrAccessTokenRevoked = errors.NewUsecaseError(http.StatusUnauthorized, "access token is revoked")
+
+// 管理者に指定していないユーザーは admin スコープのアクセストークンを持っていても管理用のAPIを呼び出せない
+var ErrAdminRequired = errors.NewUsecaseError(http.StatusForbidden, "the user is not an administrator")
+
+func NewAdminAuthUsecase(tokenRepo domain.TokenRepository, userRepo domain.UserRepository, clientRepo domain.ClientRepository) IAdminAuthUsecase {
+	return &AdminAuthUsecase{
+		tokenRepo:  tokenRepo,
+		userRepo:   userRepo,
+		clientRepo: clientRepo,
+	}
+}
+
+type IAdminAuthUsecase interface {
+	Authorize(ctx context.Context, accessToken string) error
+}
+
+type AdminAuthUsecase struct {
+	tokenRepo  domain.TokenRepository
+	userRepo   domain.UserRepository
+	clientRepo domain.ClientRepository
+}
+
+// Authorize は管理用のAPIを呼び出したアクセストークンが、保存された記録で失効しておらず、
+// 持ち主が管理者に指定された有効なユーザーで、発行したクライアントが無効にされていないかを検証する。
+// 署名と有効期限は AuthMiddleware で検証済みとする
+func (uc *AdminAuthUsecase) Authorize(ctx context.Context, accessToken string) error {
+	token, err := uc.tokenRepo.FindToken(ctx, accessToken)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if token == nil || token.IsNotFound() || token.IsRevoked() {
+		return ErrAccessTokenRevoked
+	}
+
+	user, err := uc.userRepo.FindUserByID(ctx, token.GetUserID())
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if user.IsNotFound() || user.IsDisabled() {
+		return ErrAccessTokenRevoked
+	}
+
+	client, err := uc.clientRepo.FindClientByClientID(ctx, token.GetClientID())
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if client.IsNotFound() || client.IsDisabled() {
+		return ErrAccessTokenRevoked
+	}
+
+	if !user.IsAdmin() {
+		return ErrAdminRequired
+	}
+	return nil
+}
@@ -9,21 +9,39 @@ import (
 	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
 )
 
-func NewAuthenticationUsecase(userRepo domain.UserRepository, clientRepo domain.ClientRepository) IAuthenticationUsecase {
+func NewAuthenticationUsecase(
+	userRepo domain.UserRepository,
+	clientRepo domain.ClientRepository,
+	scopeRepo domain.ScopeRepository,
+	hasher domain.PasswordHasher,
+	policy domain.PasswordPolicy,
+	verifiers ...CredentialVerifier,
+) IAuthenticationUsecase {
 	return &AuthenticationUsecase{
 		userRepo:   userRepo,
 		clientRepo: clientRepo,
+		scopeRepo:  scopeRepo,
+		hasher:     hasher,
+		policy:     policy,
+		// ローカルのパスワードを最初に確認し、パスワードを持たない利用者を他の検証器に任せる
+		verifiers: append([]CredentialVerifier{NewPasswordVerifier(userRepo, hasher)}, verifiers...),
 	}
 }
 
 type IAuthenticationUsecase interface {
 	AuthenticateUser(ctx context.Context, email, password string) (domain.User, error)
 	AuthenticateClient(ctx context.Context, clientID uuid.UUID, redirectURI string) (domain.Client, error)
+	ResolveScope(ctx context.Context, client domain.Client, scope string) (domain.ScopeSet, error)
+	Signup(ctx context.Context, p SignupParams) (domain.User, error)
 }
 
 type AuthenticationUsecase struct {
 	userRepo   domain.UserRepository
 	clientRepo domain.ClientRepository
+	scopeRepo  domain.ScopeRepository
+	hasher     domain.PasswordHasher
+	policy     domain.PasswordPolicy
+	verifiers  []CredentialVerifier
 }
 
 func (uc *AuthenticationUsecase) AuthenticateClient(ctx context.Context, clientID uuid.UUID, redirectURI string) (domain.Client, error) {
@@ -32,8 +50,8 @@ func (uc *AuthenticationUsecase) AuthenticateClient(ctx context.Context, clientI
 		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
 
-	// クライアントがない場合はエラー
-	if client.IsNotFound() {
+	// クライアントがない場合はエラー。無効にしたクライアントも同じく扱う
+	if client.IsNotFound() || client.IsDisabled() {
 		return nil, errors.NewUsecaseError(http.StatusBadRequest, "client not found")
 	}
 
@@ -45,23 +63,95 @@ func (uc *AuthenticationUsecase) AuthenticateClient(ctx context.Context, clientI
 	return client, nil
 }
 
+// ResolveScope は認可リクエストの scope を検証し、発行対象のスコープを返す。
+// 省略された場合はクライアントに許可されたデフォルトスコープを使う
+func (uc *AuthenticationUsecase) ResolveScope(ctx context.Context, client domain.Client, scope string) (domain.ScopeSet, error) {
+	requested, err := domain.ParseScope(scope)
+	if err != nil {
+		return domain.ScopeSet{}, ErrInvalidScope
+	}
+
+	if requested.IsEmpty() {
+		defaults, err := uc.scopeRepo.FindDefaultScopes(ctx)
+		if err != nil {
+			return domain.ScopeSet{}, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+		requested = domain.ScopeSetOf(defaults).Intersect(client.GetAllowedScopes())
+		if requested.IsEmpty() {
+			return domain.ScopeSet{}, ErrInvalidScope
+		}
+	}
+
+	// 未登録のスコープが含まれていないか
+	registered, err := uc.scopeRepo.FindScopesByNames(ctx, requested.Names())
+	if err != nil {
+		return domain.ScopeSet{}, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if !requested.IsSubsetOf(domain.ScopeSetOf(registered)) {
+		return domain.ScopeSet{}, ErrInvalidScope
+	}
+
+	if !client.IsScopeAllowed(requested) {
+		return domain.ScopeSet{}, ErrInvalidScope
+	}
+
+	return requested, nil
+}
+
+// AuthenticateUser は検証器を順に試し、最初に利用者を扱った検証器の結果を返す
 func (uc *AuthenticationUsecase) AuthenticateUser(ctx context.Context, email, password string) (domain.User, error) {
-	// validate user credentials
-	user, err := uc.userRepo.FindUserByEmail(ctx, email)
+	for _, v := range uc.verifiers {
+		user, err := v.VerifyCredential(ctx, email, password)
+		if errors.Is(err, ErrCredentialNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// 無効なユーザーかどうかも、パスワードを誤った場合と区別できないようにする
+		if user.IsDisabled() {
+			return nil, ErrUserOrPasswordNotMatch
+		}
+		return user, nil
+	}
 
+	// どの検証器も扱っていない場合はエラー
+	return nil, ErrUserOrPasswordNotMatch
+}
+
+type SignupParams struct {
+	Name     string
+	Email    string
+	Password string
+}
+
+func (uc *AuthenticationUsecase) Signup(ctx context.Context, p SignupParams) (domain.User, error) {
+	existing, err := uc.userRepo.FindUserByEmail(ctx, p.Email)
 	if err != nil {
 		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
 
-	// ユーザーが存在しない場合はエラー
-	if user.IsNotFound() {
-		return nil, errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "user or password not match", "client/signin")
+	// メールアドレスが登録済みの場合はエラー
+	if !existing.IsNotFound() {
+		return nil, errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, domain.ErrUserEmailAlreadyExists.Error(), "/client/signup")
+	}
+
+	user := domain.NewUser(domain.UserParams{
+		Name:  p.Name,
+		Email: p.Email,
+	})
+	if err := user.SetPassword(uc.policy, uc.hasher, p.Password); err != nil {
+		return nil, passwordPolicyError(err, "/client/signup")
 	}
 
-	// パスワードを比較して認証
-	if !user.IsPasswordMatch(password) {
-		return nil, errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "user or password not match", "client/signin")
+	created, err := uc.userRepo.CreateUser(ctx, user)
+	if err != nil {
+		// 確認後に同じメールアドレスで登録された場合
+		if errors.Is(err, domain.ErrUserEmailAlreadyExists) {
+			return nil, errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, domain.ErrUserEmailAlreadyExists.Error(), "/client/signup")
+		}
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
 
-	return user, nil
+	return created, nil
 }
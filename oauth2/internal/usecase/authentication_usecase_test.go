@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sntkn/go-oauth2/oauth2/domain"
@@ -22,6 +23,9 @@ func TestAuthenticateClient_Success(t *testing.T) {
 				IsNotFoundFunc: func() bool {
 					return false
 				},
+				IsDisabledFunc: func() bool {
+					return false
+				},
 				IsRedirectURIMatchFunc: func(uri string) bool {
 					return true
 				},
@@ -29,7 +33,7 @@ func TestAuthenticateClient_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo)
+	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo, nil, newTestHasher(t), newTestPolicy())
 	_, err := uc.AuthenticateClient(ctx, uuid.New(), "https://example.com/callback")
 	require.NoError(t, err)
 }
@@ -51,7 +55,7 @@ func TestAuthenticateClient_ClientNotFound(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo)
+	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo, nil, newTestHasher(t), newTestPolicy())
 	_, err := uc.AuthenticateClient(ctx, uuid.New(), "https://example.com/callback")
 	require.Error(t, err)
 	assert.Equal(t, http.StatusBadRequest, err.(*errors.UsecaseError).Code)
@@ -68,6 +72,9 @@ func TestAuthenticateClient_RedirectURINotMatch(t *testing.T) {
 				IsNotFoundFunc: func() bool {
 					return false
 				},
+				IsDisabledFunc: func() bool {
+					return false
+				},
 				IsRedirectURIMatchFunc: func(uri string) bool {
 					return false
 				},
@@ -75,7 +82,7 @@ func TestAuthenticateClient_RedirectURINotMatch(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo)
+	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo, nil, newTestHasher(t), newTestPolicy())
 	_, err := uc.AuthenticateClient(ctx, uuid.New(), "https://example.com/callback")
 	require.Error(t, err)
 	assert.Equal(t, http.StatusBadRequest, err.(*errors.UsecaseError).Code)
@@ -92,33 +99,155 @@ func TestAuthenticateClient_FindClientError(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo)
+	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo, nil, newTestHasher(t), newTestPolicy())
 	_, err := uc.AuthenticateClient(ctx, uuid.New(), "https://example.com/callback")
 	require.Error(t, err)
 	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
 	assert.Equal(t, "FindClientByClientID error", err.(*errors.UsecaseError).Message)
 }
 
-func TestAuthenticateUser_Success(t *testing.T) {
-	ctx := context.Background()
-	mockUserRepo := &domain.UserRepositoryMock{
+// newTestHasher はテストが遅くならないよう、小さいパラメータの argon2id を使う
+func newTestHasher(t *testing.T) domain.PasswordHasher {
+	t.Helper()
+	h, err := domain.NewPasswordHasher(domain.PasswordHasherParams{
+		Algorithm:         domain.PasswordAlgorithmArgon2id,
+		Argon2Memory:      64,
+		Argon2Time:        1,
+		Argon2Parallelism: 1,
+	})
+	require.NoError(t, err)
+	return h
+}
+
+func newTestPolicy() domain.PasswordPolicy {
+	return domain.NewPasswordPolicy(domain.PasswordPolicyParams{MinLength: 8})
+}
+
+// newHashedUserRepo は password を hasher でハッシュにしたユーザーを返すリポジトリのモックを返す
+func newHashedUserRepo(t *testing.T, hasher domain.PasswordHasher, password string) *domain.UserRepositoryMock {
+	t.Helper()
+	hash, err := hasher.Hash(password)
+	require.NoError(t, err)
+	u := domain.NewUser(domain.UserParams{ID: uuid.New(), Email: "test@example.com", Password: hash})
+	return &domain.UserRepositoryMock{
 		FindUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
-			return &domain.UserMock{
-				IsNotFoundFunc: func() bool {
-					return false
-				},
-				IsPasswordMatchFunc: func(password string) bool {
-					return true
-				},
-			}, nil
+			return u, nil
+		},
+		UpdatePasswordHashFunc: func(ctx context.Context, id uuid.UUID, oldHash, newHash string) (bool, error) {
+			return true, nil
 		},
 	}
+}
+
+func TestAuthenticateUser_Success(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := newHashedUserRepo(t, newTestHasher(t), "password123")
 
 	mockClientRepo := &domain.ClientRepositoryMock{}
 
-	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo)
+	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo, nil, newTestHasher(t), newTestPolicy())
 	_, err := uc.AuthenticateUser(ctx, "test@example.com", "password123")
 	require.NoError(t, err)
+	// 現在の設定で作られたハッシュは作り直さない
+	assert.Empty(t, mockUserRepo.UpdatePasswordHashCalls())
+}
+
+func TestAuthenticateUser_Disabled(t *testing.T) {
+	ctx := context.Background()
+	hasher := newTestHasher(t)
+	hash, err := hasher.Hash("password123")
+	require.NoError(t, err)
+	disabledAt := time.Now()
+
+	tests := []struct {
+		name string
+		user domain.UserParams
+		want error
+	}{
+		{"disabled", domain.UserParams{DisabledAt: &disabledAt}, ErrUserOrPasswordNotMatch},
+		{"password reset required", domain.UserParams{PasswordResetRequired: true}, ErrPasswordResetRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.user
+			p.ID = uuid.New()
+			p.Email = "test@example.com"
+			p.Password = hash
+			mockUserRepo := &domain.UserRepositoryMock{
+				FindUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
+					return domain.NewUser(p), nil
+				},
+			}
+
+			uc := NewAuthenticationUsecase(mockUserRepo, nil, nil, hasher, newTestPolicy())
+			_, err := uc.AuthenticateUser(ctx, "test@example.com", "password123")
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestAuthenticateUser_RehashOutdatedAlgorithm(t *testing.T) {
+	ctx := context.Background()
+	bcryptHasher, err := domain.NewPasswordHasher(domain.PasswordHasherParams{
+		Algorithm:  domain.PasswordAlgorithmBcrypt,
+		BcryptCost: 4,
+	})
+	require.NoError(t, err)
+	mockUserRepo := newHashedUserRepo(t, bcryptHasher, "password123")
+	stored, err := mockUserRepo.FindUserByEmail(ctx, "test@example.com")
+	require.NoError(t, err)
+	oldHash := stored.GetPassword()
+
+	hasher := newTestHasher(t)
+	uc := NewAuthenticationUsecase(mockUserRepo, nil, nil, hasher, newTestPolicy())
+	user, err := uc.AuthenticateUser(ctx, "test@example.com", "password123")
+	require.NoError(t, err)
+
+	// bcrypt のハッシュを argon2id に置き換える
+	require.Len(t, mockUserRepo.UpdatePasswordHashCalls(), 1)
+	call := mockUserRepo.UpdatePasswordHashCalls()[0]
+	assert.Equal(t, user.GetID(), call.ID)
+	assert.Equal(t, oldHash, call.OldHash)
+	assert.Contains(t, call.NewHash, "$argon2id$")
+	assert.False(t, hasher.NeedsRehash(call.NewHash))
+
+	ok, err := hasher.Verify(call.NewHash, "password123")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestAuthenticateUser_RehashOutdatedParameters(t *testing.T) {
+	ctx := context.Background()
+	weak, err := domain.NewPasswordHasher(domain.PasswordHasherParams{
+		Algorithm:         domain.PasswordAlgorithmArgon2id,
+		Argon2Memory:      32,
+		Argon2Time:        1,
+		Argon2Parallelism: 1,
+	})
+	require.NoError(t, err)
+	mockUserRepo := newHashedUserRepo(t, weak, "password123")
+
+	uc := NewAuthenticationUsecase(mockUserRepo, nil, nil, newTestHasher(t), newTestPolicy())
+	_, err = uc.AuthenticateUser(ctx, "test@example.com", "password123")
+	require.NoError(t, err)
+
+	require.Len(t, mockUserRepo.UpdatePasswordHashCalls(), 1)
+	assert.Contains(t, mockUserRepo.UpdatePasswordHashCalls()[0].NewHash, "m=64,t=1,p=1")
+}
+
+func TestAuthenticateUser_NoRehashOnMismatch(t *testing.T) {
+	ctx := context.Background()
+	bcryptHasher, err := domain.NewPasswordHasher(domain.PasswordHasherParams{
+		Algorithm:  domain.PasswordAlgorithmBcrypt,
+		BcryptCost: 4,
+	})
+	require.NoError(t, err)
+	mockUserRepo := newHashedUserRepo(t, bcryptHasher, "password123")
+
+	uc := NewAuthenticationUsecase(mockUserRepo, nil, nil, newTestHasher(t), newTestPolicy())
+	_, err = uc.AuthenticateUser(ctx, "test@example.com", "wrong-password")
+	assert.ErrorIs(t, err, ErrUserOrPasswordNotMatch)
+	assert.Empty(t, mockUserRepo.UpdatePasswordHashCalls())
 }
 
 func TestAuthenticateUser_UserNotFound(t *testing.T) {
@@ -135,7 +264,7 @@ func TestAuthenticateUser_UserNotFound(t *testing.T) {
 
 	mockClientRepo := &domain.ClientRepositoryMock{}
 
-	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo)
+	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo, nil, newTestHasher(t), newTestPolicy())
 	result, err := uc.AuthenticateUser(ctx, "test@example.com", "password123")
 
 	require.Error(t, err)
@@ -153,7 +282,10 @@ func TestAuthenticateUser_PasswordMismatch(t *testing.T) {
 				IsNotFoundFunc: func() bool {
 					return false
 				},
-				IsPasswordMatchFunc: func(password string) bool {
+				GetPasswordFunc: func() string {
+					return "hashed"
+				},
+				IsPasswordMatchFunc: func(h domain.PasswordHasher, password string) bool {
 					return false
 				},
 			}, nil
@@ -162,7 +294,7 @@ func TestAuthenticateUser_PasswordMismatch(t *testing.T) {
 
 	mockClientRepo := &domain.ClientRepositoryMock{}
 
-	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo)
+	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo, nil, newTestHasher(t), newTestPolicy())
 	result, err := uc.AuthenticateUser(ctx, "test@example.com", "password123")
 
 	require.Error(t, err)
@@ -182,7 +314,7 @@ func TestAuthenticateUser_FindUserError(t *testing.T) {
 
 	mockClientRepo := &domain.ClientRepositoryMock{}
 
-	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo)
+	uc := NewAuthenticationUsecase(mockUserRepo, mockClientRepo, nil, newTestHasher(t), newTestPolicy())
 	result, err := uc.AuthenticateUser(ctx, "test@example.com", "password123")
 
 	require.Error(t, err)
@@ -190,3 +322,176 @@ func TestAuthenticateUser_FindUserError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
 	assert.Equal(t, "FindUserByEmail error", err.(*errors.UsecaseError).Message)
 }
+
+func TestResolveScope(t *testing.T) {
+	ctx := context.Background()
+	client := domain.NewClient(domain.ClientParams{
+		ID:            uuid.New(),
+		AllowedScopes: []string{"read", "write", "profile"},
+	})
+	registered := func(names ...string) []domain.Scope {
+		scopes := make([]domain.Scope, 0, len(names))
+		for _, n := range names {
+			scopes = append(scopes, domain.NewScope(domain.ScopeParams{Name: n}))
+		}
+		return scopes
+	}
+	mockScopeRepo := &domain.ScopeRepositoryMock{
+		FindScopesByNamesFunc: func(ctx context.Context, names []string) ([]domain.Scope, error) {
+			known := []domain.Scope{}
+			for _, n := range names {
+				if n != "unknown" {
+					known = append(known, registered(n)...)
+				}
+			}
+			return known, nil
+		},
+		FindDefaultScopesFunc: func(ctx context.Context) ([]domain.Scope, error) {
+			return registered("profile", "openid"), nil
+		},
+	}
+
+	uc := NewAuthenticationUsecase(nil, nil, mockScopeRepo, newTestHasher(t), newTestPolicy())
+
+	t.Run("requested scopes are normalized", func(t *testing.T) {
+		scope, err := uc.ResolveScope(ctx, client, "write read read")
+		require.NoError(t, err)
+		assert.Equal(t, "read write", scope.String())
+	})
+
+	t.Run("default scopes allowed for the client are used when omitted", func(t *testing.T) {
+		scope, err := uc.ResolveScope(ctx, client, "")
+		require.NoError(t, err)
+		assert.Equal(t, "profile", scope.String())
+	})
+
+	t.Run("unregistered scope", func(t *testing.T) {
+		_, err := uc.ResolveScope(ctx, client, "read unknown")
+		require.ErrorIs(t, err, ErrInvalidScope)
+	})
+
+	t.Run("scope not allowed for the client", func(t *testing.T) {
+		_, err := uc.ResolveScope(ctx, client, "read admin")
+		require.ErrorIs(t, err, ErrInvalidScope)
+	})
+
+	t.Run("malformed scope", func(t *testing.T) {
+		_, err := uc.ResolveScope(ctx, client, `read "write"`)
+		require.ErrorIs(t, err, ErrInvalidScope)
+	})
+}
+
+func TestResolveScope_FindDefaultScopesError(t *testing.T) {
+	ctx := context.Background()
+	mockScopeRepo := &domain.ScopeRepositoryMock{
+		FindDefaultScopesFunc: func(ctx context.Context) ([]domain.Scope, error) {
+			return nil, errors.New("FindDefaultScopes error")
+		},
+	}
+
+	uc := NewAuthenticationUsecase(nil, nil, mockScopeRepo, newTestHasher(t), newTestPolicy())
+	_, err := uc.ResolveScope(ctx, domain.NewClient(domain.ClientParams{}), "")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "FindDefaultScopes error", err.(*errors.UsecaseError).Message)
+}
+
+func TestSignup_Success(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := &domain.UserRepositoryMock{
+		FindUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
+			return domain.NewUser(domain.UserParams{}), nil
+		},
+		CreateUserFunc: func(ctx context.Context, u domain.User) (domain.User, error) {
+			return u, nil
+		},
+	}
+
+	uc := NewAuthenticationUsecase(mockUserRepo, nil, nil, newTestHasher(t), newTestPolicy())
+	_, err := uc.Signup(ctx, SignupParams{Name: "test", Email: "test@example.com", Password: "password123"})
+	require.NoError(t, err)
+
+	created := mockUserRepo.CreateUserCalls()[0].U
+	assert.Equal(t, "test", created.GetName())
+	assert.Equal(t, "test@example.com", created.GetEmail())
+	assert.NotEqual(t, "password123", created.GetPassword())
+	assert.True(t, created.IsPasswordMatch(newTestHasher(t), "password123"))
+}
+
+func TestSignup_PasswordPolicy(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := &domain.UserRepositoryMock{
+		FindUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
+			return domain.NewUser(domain.UserParams{}), nil
+		},
+	}
+
+	uc := NewAuthenticationUsecase(mockUserRepo, nil, nil, newTestHasher(t), newTestPolicy())
+	_, err := uc.Signup(ctx, SignupParams{Name: "test", Email: "test@example.com", Password: "test@example.com1"})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusFound, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "/client/signup", err.(*errors.UsecaseError).RedirectURI)
+
+	// 画面で翻訳できるよう理由を残す
+	var policyErr *domain.PasswordPolicyError
+	require.ErrorAs(t, err, &policyErr)
+	assert.Equal(t, domain.PasswordViolationContainsEmail, policyErr.Violation)
+	assert.Empty(t, mockUserRepo.CreateUserCalls())
+}
+
+func TestSignup_EmailAlreadyExists(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := &domain.UserRepositoryMock{
+		FindUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
+			return &domain.UserMock{
+				IsNotFoundFunc: func() bool {
+					return false
+				},
+			}, nil
+		},
+	}
+
+	uc := NewAuthenticationUsecase(mockUserRepo, nil, nil, newTestHasher(t), newTestPolicy())
+	_, err := uc.Signup(ctx, SignupParams{Name: "test", Email: "test@example.com", Password: "password123"})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusFound, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "email is already registered", err.(*errors.UsecaseError).Message)
+	assert.Equal(t, "/client/signup", err.(*errors.UsecaseError).RedirectURI)
+	assert.Empty(t, mockUserRepo.CreateUserCalls())
+}
+
+func TestSignup_EmailRegisteredConcurrently(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := &domain.UserRepositoryMock{
+		FindUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
+			return domain.NewUser(domain.UserParams{}), nil
+		},
+		CreateUserFunc: func(ctx context.Context, u domain.User) (domain.User, error) {
+			return nil, domain.ErrUserEmailAlreadyExists
+		},
+	}
+
+	uc := NewAuthenticationUsecase(mockUserRepo, nil, nil, newTestHasher(t), newTestPolicy())
+	_, err := uc.Signup(ctx, SignupParams{Name: "test", Email: "test@example.com", Password: "password123"})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusFound, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "email is already registered", err.(*errors.UsecaseError).Message)
+}
+
+func TestSignup_CreateUserError(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := &domain.UserRepositoryMock{
+		FindUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
+			return domain.NewUser(domain.UserParams{}), nil
+		},
+		CreateUserFunc: func(ctx context.Context, u domain.User) (domain.User, error) {
+			return nil, errors.New("CreateUser error")
+		},
+	}
+
+	uc := NewAuthenticationUsecase(mockUserRepo, nil, nil, newTestHasher(t), newTestPolicy())
+	_, err := uc.Signup(ctx, SignupParams{Name: "test", Email: "test@example.com", Password: "password123"})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "CreateUser error", err.(*errors.UsecaseError).Message)
+}
@@ -12,10 +12,11 @@ import (
 )
 
 type IAuthorizationUsecase interface {
-	Consent(ctx context.Context, clientID uuid.UUID) (domain.Client, error)
+	Consent(ctx context.Context, p ConsentParams) (*ConsentResult, error)
+	GrantConsent(ctx context.Context, p GrantConsentParams) (domain.ScopeSet, error)
 	GenerateAuthorizationCode(ctx context.Context, p GenerateAuthorizationCodeParams) (domain.AuthorizationCode, error)
-	GenerateTokenByCode(ctx context.Context, code string) (domain.Token, domain.RefreshToken, error)
-	GenerateTokenByRefreshToken(ctx context.Context, refreshToken string) (domain.Token, domain.RefreshToken, error)
+	GenerateTokenByCode(ctx context.Context, p GenerateTokenByCodeParams) (domain.Token, domain.RefreshToken, error)
+	GenerateTokenByRefreshToken(ctx context.Context, p GenerateTokenByRefreshTokenParams) (domain.Token, domain.RefreshToken, error)
 	// GenerateAuthorizationCode(user *model.User, client *model.Client, scopes []string) (*model.AuthorizationCode, error)
 	// ValidateAuthorizationCode(code string, clientID string) (*model.AuthorizationCode, error)
 }
@@ -23,35 +24,155 @@ type IAuthorizationUsecase interface {
 func NewAuthorizationUsecase(
 	clientRepo domain.ClientRepository,
 	codeRepo domain.AuthorizationCodeRepository,
+	scopeRepo domain.ScopeRepository,
+	userRepo domain.UserRepository,
 	tokenService domainservice.TokenService,
+	consentService domainservice.ConsentService,
 ) IAuthorizationUsecase {
 	return &AuthorizationUsecase{
-		clientRepo:   clientRepo,
-		codeRepo:     codeRepo,
-		tokenService: tokenService,
+		clientRepo:     clientRepo,
+		codeRepo:       codeRepo,
+		scopeRepo:      scopeRepo,
+		userRepo:       userRepo,
+		tokenService:   tokenService,
+		consentService: consentService,
 	}
 }
 
 type AuthorizationUsecase struct {
-	clientRepo   domain.ClientRepository
-	codeRepo     domain.AuthorizationCodeRepository
-	tokenService domainservice.TokenService
+	clientRepo     domain.ClientRepository
+	codeRepo       domain.AuthorizationCodeRepository
+	scopeRepo      domain.ScopeRepository
+	userRepo       domain.UserRepository
+	tokenService   domainservice.TokenService
+	consentService domainservice.ConsentService
+}
+
+type ConsentParams struct {
+	UserID   uuid.UUID
+	ClientID uuid.UUID
+	Scope    string
+}
+
+type ConsentResult struct {
+	Client domain.Client
+	// Required が false の場合は同意画面を表示せずに認可コードを発行してよい
+	Required bool
+	// Scopes はまだ同意を得ていないスコープ
+	Scopes []domain.Scope
 }
 
 func (uc *AuthorizationUsecase) Consent(
 	ctx context.Context,
-	clientID uuid.UUID,
-) (domain.Client, error) {
+	p ConsentParams,
+) (*ConsentResult, error) {
+	requested, err := domain.ParseScope(p.Scope)
+	if err != nil {
+		return nil, ErrInvalidScope
+	}
+
+	client, pending, err := uc.pendingScopes(ctx, p.UserID, p.ClientID, requested)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConsentResult{
+		Client:   client,
+		Required: len(pending) > 0,
+		Scopes:   pending,
+	}, nil
+}
+
+type GrantConsentParams struct {
+	UserID   string
+	ClientID string
+	Scope    string
+	// Approved は同意画面でユーザーがチェックしたスコープ
+	Approved []string
+}
+
+// GrantConsent は同意したスコープを記録し、次回以降の同意画面を省略できるようにする。
+// 同意画面でチェックを外されたスコープを除いた、実際に許可されたスコープを返す
+func (uc *AuthorizationUsecase) GrantConsent(
+	ctx context.Context,
+	p GrantConsentParams,
+) (domain.ScopeSet, error) {
+	clientID, err := uuid.Parse(p.ClientID)
+	if err != nil {
+		return domain.ScopeSet{}, errors.NewUsecaseError(http.StatusBadRequest, "invalid client id")
+	}
+	userID, err := uuid.Parse(p.UserID)
+	if err != nil {
+		return domain.ScopeSet{}, errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+
+	requested, err := domain.ParseScope(p.Scope)
+	if err != nil {
+		return domain.ScopeSet{}, ErrInvalidScope
+	}
+
+	_, pending, err := uc.pendingScopes(ctx, userID, clientID, requested)
+	if err != nil {
+		return domain.ScopeSet{}, err
+	}
+
+	// 確認したスコープのうちチェックされなかったものを除く
+	declined := domain.ScopeSetOf(pending).Difference(domain.NewScopeSet(p.Approved...))
+	granted := requested.Difference(declined)
+	if granted.IsEmpty() {
+		return granted, nil
+	}
+
+	if _, err := uc.consentService.GrantConsent(ctx, userID, clientID, granted); err != nil {
+		return domain.ScopeSet{}, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	return granted, nil
+}
+
+// pendingScopes は要求されたスコープのうち、まだ同意を得ていないものを返す
+func (uc *AuthorizationUsecase) pendingScopes(
+	ctx context.Context,
+	userID, clientID uuid.UUID,
+	requested domain.ScopeSet,
+) (domain.Client, []domain.Scope, error) {
 	client, err := uc.clientRepo.FindClientByClientID(ctx, clientID)
 	if err != nil {
-		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
 
 	if client.IsNotFound() {
-		return nil, errors.NewUsecaseError(http.StatusBadRequest, "client not found")
+		return nil, nil, errors.NewUsecaseError(http.StatusBadRequest, "client not found")
 	}
 
-	return client, nil
+	// 自社のクライアントは同意を求めない
+	if client.IsFirstParty() {
+		return client, nil, nil
+	}
+
+	scopes, err := uc.scopeRepo.FindScopesByNames(ctx, requested.Names())
+	if err != nil {
+		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	consent, err := uc.consentService.FindConsent(ctx, userID, clientID)
+	if err != nil {
+		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	// 同意が必要なスコープのうち、まだ許可されていないものだけを確認する
+	var pending []domain.Scope
+	for _, s := range scopes {
+		if !s.RequiresConsent() {
+			continue
+		}
+		if consent != nil && consent.GetScopes().Contains(s.GetName()) {
+			continue
+		}
+		pending = append(pending, s)
+	}
+
+	return client, pending, nil
 }
 
 type GenerateAuthorizationCodeParams struct {
@@ -66,6 +187,15 @@ func (uc *AuthorizationUsecase) GenerateAuthorizationCode(
 	ctx context.Context,
 	p GenerateAuthorizationCodeParams,
 ) (domain.AuthorizationCode, error) {
+	clientID, err := uuid.Parse(p.ClientID)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "invalid client id")
+	}
+	userID, err := uuid.Parse(p.UserID)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+
 	randomString, err := domain.GenerateCode()
 	if err != nil {
 		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
@@ -73,6 +203,8 @@ func (uc *AuthorizationUsecase) GenerateAuthorizationCode(
 
 	code, err := uc.codeRepo.StoreAuthorizationCode(ctx, domain.StoreAuthorizationCodeParams{
 		Code:        randomString,
+		ClientID:    clientID,
+		UserID:      userID,
 		Scope:       p.Scope,
 		RedirectURI: p.RedirectURI,
 		ExpiresAt:   time.Now().Add(time.Duration(p.Expires) * time.Second),
@@ -92,11 +224,33 @@ func (uc *AuthorizationUsecase) GenerateAuthorizationCode(
 	return c, nil
 }
 
+// トークンエンドポイントでクライアントを認証できない (RFC 6749 5.2 invalid_client)
+var ErrInvalidClient = errors.NewUsecaseError(http.StatusUnauthorized, "invalid client")
+
+// クライアントに許可していない grant_type が指定された (RFC 6749 5.2 unauthorized_client)
+var ErrUnauthorizedClient = errors.NewUsecaseError(http.StatusBadRequest, "grant type is not allowed for this client")
+
+type GenerateTokenByCodeParams struct {
+	Code         string
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+}
+
 func (uc *AuthorizationUsecase) GenerateTokenByCode(
 	ctx context.Context,
-	code string,
+	p GenerateTokenByCodeParams,
 ) (domain.Token, domain.RefreshToken, error) {
-	c, err := uc.codeRepo.FindAuthorizationCode(ctx, code)
+	clientID, err := uuid.Parse(p.ClientID)
+	if err != nil {
+		return nil, nil, ErrInvalidClient
+	}
+	client, err := uc.authenticateClient(ctx, clientID, p.ClientSecret, domain.GrantTypeAuthorizationCode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c, err := uc.codeRepo.FindAuthorizationCode(ctx, p.Code)
 	if err != nil {
 		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
@@ -105,38 +259,78 @@ func (uc *AuthorizationUsecase) GenerateTokenByCode(
 		return nil, nil, errors.NewUsecaseError(http.StatusForbidden, "code not found")
 	}
 
+	// 使用済みのコードが再度提示された場合は、そのコードから発行したトークンを全て失効させる (RFC 6749 4.1.2)
+	if c.IsRevoked() {
+		return nil, nil, uc.revokeReplayedCode(ctx, p.Code)
+	}
+
 	if c.IsExpired(time.Now()) {
 		return nil, nil, errors.NewUsecaseError(http.StatusForbidden, "code has expired")
 	}
 
+	// コードを発行したクライアントと redirect_uri が一致しなければ交換させない
+	if !c.IsIssuedTo(clientID) {
+		return nil, nil, errors.NewUsecaseError(http.StatusForbidden, "code was not issued to this client")
+	}
+
+	if !c.IsRedirectURIMatch(p.RedirectURI) {
+		return nil, nil, errors.NewUsecaseError(http.StatusForbidden, "redirect uri does not match")
+	}
+
+	if err := uc.checkUserActive(ctx, c.GetUserID()); err != nil {
+		return nil, nil, err
+	}
+
 	atoken, err := uc.tokenService.StoreNewToken(
 		ctx,
 		c.GetClientID(),
 		c.GetUserID(),
 		c.GetScope(),
+		p.Code,
+		client.GetAccessTokenExpiresMin(),
 	)
 	if err != nil {
 		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
 
-	rtoken, err := uc.tokenService.StoreNewRefreshToken(ctx, atoken.GetAccessToken())
+	rtoken, err := uc.tokenService.StoreNewRefreshToken(ctx, atoken.GetAccessToken(), client.GetRefreshTokenExpiresDay())
 	if err != nil {
 		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
 
-	// revoke code
-	if err := uc.codeRepo.RevokeCode(ctx, code); err != nil {
+	// トークンを保存した後にコードを使用済みにする。
+	// 同時に交換された場合は後から来た方が失敗し、両方のトークンを失効させる
+	redeemed, err := uc.codeRepo.RedeemCode(ctx, p.Code)
+	if err != nil {
 		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
+	if !redeemed {
+		return nil, nil, uc.revokeReplayedCode(ctx, p.Code)
+	}
 
 	return atoken, rtoken, nil
 }
 
+func (uc *AuthorizationUsecase) revokeReplayedCode(ctx context.Context, code string) error {
+	if err := uc.tokenService.RevokeTokensByAuthorizationCode(ctx, code); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return errors.NewUsecaseError(http.StatusForbidden, "code has already been used")
+}
+
+type GenerateTokenByRefreshTokenParams struct {
+	RefreshToken string
+	Scope        string
+	// ClientID を省略した場合は、リフレッシュトークンを発行したクライアントとして扱う
+	ClientID     string
+	ClientSecret string
+}
+
 func (uc *AuthorizationUsecase) GenerateTokenByRefreshToken(
 	ctx context.Context,
-	refreshToken string,
+	p GenerateTokenByRefreshTokenParams,
 ) (domain.Token, domain.RefreshToken, error) {
-	tkn, err := uc.tokenService.FindTokenByRefreshToken(ctx, refreshToken, time.Now())
+	tkn, err := uc.tokenService.FindTokenByRefreshToken(ctx, p.RefreshToken, time.Now())
 	if err != nil {
 		if serviceErr, ok := err.(*errors.ServiceError); ok {
 			return nil, nil, errors.NewUsecaseError(serviceErr.Code, serviceErr.Error())
@@ -144,17 +338,48 @@ func (uc *AuthorizationUsecase) GenerateTokenByRefreshToken(
 		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
 
+	// 別のクライアントに発行したリフレッシュトークンは使わせない
+	if p.ClientID != "" && p.ClientID != tkn.GetClientID().String() {
+		return nil, nil, ErrInvalidClient
+	}
+	client, err := uc.authenticateClient(ctx, tkn.GetClientID(), p.ClientSecret, domain.GrantTypeRefreshToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := uc.checkUserActive(ctx, tkn.GetUserID()); err != nil {
+		return nil, nil, err
+	}
+
+	granted, err := uc.grantedScope(ctx, tkn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// スコープは縮小のみ許可し、省略時は許可済みのスコープをそのまま使う (RFC 6749 6)
+	requested, err := domain.ParseScope(p.Scope)
+	if err != nil {
+		return nil, nil, ErrInvalidScope
+	}
+	if requested.IsEmpty() {
+		requested = granted
+	}
+	if !requested.IsSubsetOf(granted) {
+		return nil, nil, ErrInvalidScope
+	}
+
 	atoken, err := uc.tokenService.StoreNewToken(
 		ctx,
 		tkn.GetClientID(),
 		tkn.GetUserID(),
-		tkn.GetScope(),
+		requested.String(),
+		tkn.GetAuthorizationCode(),
+		client.GetAccessTokenExpiresMin(),
 	)
 	if err != nil {
 		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
 
-	rtoken, err := uc.tokenService.StoreNewRefreshToken(ctx, atoken.GetAccessToken())
+	rtoken, err := uc.tokenService.StoreNewRefreshToken(ctx, atoken.GetAccessToken(), client.GetRefreshTokenExpiresDay())
 	if err != nil {
 		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
@@ -163,9 +388,58 @@ func (uc *AuthorizationUsecase) GenerateTokenByRefreshToken(
 		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
 
-	if err := uc.tokenService.RevokeRefreshToken(ctx, refreshToken); err != nil {
+	if err := uc.tokenService.RevokeRefreshToken(ctx, p.RefreshToken); err != nil {
 		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
 	}
 
 	return atoken, rtoken, nil
 }
+
+// authenticateClient はトークンエンドポイントのクライアントを認証する。
+// 無効にしたクライアントは存在しないクライアントと同じく扱う
+func (uc *AuthorizationUsecase) authenticateClient(ctx context.Context, clientID uuid.UUID, secret, grantType string) (domain.Client, error) {
+	client, err := uc.clientRepo.FindClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if client.IsNotFound() || client.IsDisabled() || !client.VerifySecret(secret) {
+		return nil, ErrInvalidClient
+	}
+	if !client.IsGrantTypeAllowed(grantType) {
+		return nil, ErrUnauthorizedClient
+	}
+	return client, nil
+}
+
+// checkUserActive は無効にしたユーザーや削除したユーザーにトークンを発行しないよう確認する
+func (uc *AuthorizationUsecase) checkUserActive(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if user.IsNotFound() || user.IsDisabled() {
+		return ErrUserDisabled
+	}
+	return nil
+}
+
+// grantedScope はリソースオーナーが当初許可したスコープを返す。
+// リフレッシュで縮小されたスコープを再び広げられるよう、発行元の認可コードのスコープを基準にする
+func (uc *AuthorizationUsecase) grantedScope(ctx context.Context, tkn domain.Token) (domain.ScopeSet, error) {
+	scope := tkn.GetScope()
+	if code := tkn.GetAuthorizationCode(); code != "" {
+		c, err := uc.codeRepo.FindAuthorizationCode(ctx, code)
+		if err != nil {
+			return domain.ScopeSet{}, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+		if c != nil {
+			scope = c.GetScope()
+		}
+	}
+
+	granted, err := domain.ParseScope(scope)
+	if err != nil {
+		return domain.ScopeSet{}, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return granted, nil
+}
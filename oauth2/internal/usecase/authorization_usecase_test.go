@@ -14,21 +14,144 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestConsent_Success(t *testing.T) {
-	ctx := context.Background()
-	mockClientRepo := &domain.ClientRepositoryMock{
+var authorizationCodeParams = GenerateAuthorizationCodeParams{
+	UserID:      uuid.NewString(),
+	ClientID:    uuid.NewString(),
+	RedirectURI: "https://example.com/callback",
+	Scope:       "read",
+	Expires:     120,
+}
+
+var tokenByCodeParams = GenerateTokenByCodeParams{
+	Code:        "code",
+	ClientID:    uuid.NewString(),
+	RedirectURI: "https://example.com/callback",
+}
+
+var consentParams = ConsentParams{
+	UserID:   uuid.New(),
+	ClientID: uuid.New(),
+	Scope:    "read write profile",
+}
+
+func newConsentScopeRepo() *domain.ScopeRepositoryMock {
+	return &domain.ScopeRepositoryMock{
+		FindScopesByNamesFunc: func(ctx context.Context, names []string) ([]domain.Scope, error) {
+			return []domain.Scope{
+				domain.NewScope(domain.ScopeParams{Name: "profile", RequiresConsent: false}),
+				domain.NewScope(domain.ScopeParams{Name: "read", RequiresConsent: true}),
+				domain.NewScope(domain.ScopeParams{Name: "write", RequiresConsent: true}),
+			}, nil
+		},
+	}
+}
+
+func newConsentClientRepo(firstParty bool) *domain.ClientRepositoryMock {
+	return &domain.ClientRepositoryMock{
 		FindClientByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) (domain.Client, error) {
 			return &domain.ClientMock{
 				IsNotFoundFunc: func() bool {
 					return false
 				},
+				IsFirstPartyFunc: func() bool {
+					return firstParty
+				},
 			}, nil
 		},
 	}
+}
+
+// newTokenClientRepo は既定の grant_type を持つ公開クライアントを返す
+func newTokenClientRepo() *domain.ClientRepositoryMock {
+	return &domain.ClientRepositoryMock{
+		FindClientByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) (domain.Client, error) {
+			return domain.NewClient(domain.ClientParams{ID: clientID}), nil
+		},
+	}
+}
+
+// newTokenUserRepo は有効なユーザーを返す
+func newTokenUserRepo() *domain.UserRepositoryMock {
+	return &domain.UserRepositoryMock{
+		FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return domain.NewUser(domain.UserParams{ID: id}), nil
+		},
+	}
+}
+
+func TestConsent_Success(t *testing.T) {
+	ctx := context.Background()
+	mockConsentService := &domainservice.ConsentServiceMock{
+		FindConsentFunc: func(ctx context.Context, userID, clientID uuid.UUID) (domain.Consent, error) {
+			return nil, nil
+		},
+	}
+
+	uc := NewAuthorizationUsecase(newConsentClientRepo(false), nil, newConsentScopeRepo(), nil, nil, mockConsentService)
+	res, err := uc.Consent(ctx, consentParams)
+	require.NoError(t, err)
+	assert.True(t, res.Required)
+	assert.Equal(t, []string{"read", "write"}, domain.ScopeSetOf(res.Scopes).Names())
+	assert.Equal(t, consentParams.UserID, mockConsentService.FindConsentCalls()[0].UserID)
+	assert.Equal(t, consentParams.ClientID, mockConsentService.FindConsentCalls()[0].ClientID)
+}
+
+func TestConsent_AlreadyGranted(t *testing.T) {
+	ctx := context.Background()
+	mockConsentService := &domainservice.ConsentServiceMock{
+		FindConsentFunc: func(ctx context.Context, userID, clientID uuid.UUID) (domain.Consent, error) {
+			return domain.NewConsent(domain.ConsentParams{UserID: userID, ClientID: clientID, Scope: "read write"})
+		},
+	}
+
+	uc := NewAuthorizationUsecase(newConsentClientRepo(false), nil, newConsentScopeRepo(), nil, nil, mockConsentService)
+	res, err := uc.Consent(ctx, consentParams)
+	require.NoError(t, err)
+	assert.False(t, res.Required)
+	assert.Empty(t, res.Scopes)
+}
+
+func TestConsent_ScopeExpansion(t *testing.T) {
+	ctx := context.Background()
+	mockConsentService := &domainservice.ConsentServiceMock{
+		FindConsentFunc: func(ctx context.Context, userID, clientID uuid.UUID) (domain.Consent, error) {
+			return domain.NewConsent(domain.ConsentParams{UserID: userID, ClientID: clientID, Scope: "read"})
+		},
+	}
 
-	uc := NewAuthorizationUsecase(mockClientRepo, nil, nil)
-	_, err := uc.Consent(ctx, uuid.New())
+	uc := NewAuthorizationUsecase(newConsentClientRepo(false), nil, newConsentScopeRepo(), nil, nil, mockConsentService)
+	res, err := uc.Consent(ctx, consentParams)
 	require.NoError(t, err)
+	assert.True(t, res.Required)
+	assert.Equal(t, []string{"write"}, domain.ScopeSetOf(res.Scopes).Names())
+}
+
+func TestConsent_FirstParty(t *testing.T) {
+	ctx := context.Background()
+	mockScopeRepo := newConsentScopeRepo()
+	mockConsentService := &domainservice.ConsentServiceMock{}
+
+	uc := NewAuthorizationUsecase(newConsentClientRepo(true), nil, mockScopeRepo, nil, nil, mockConsentService)
+	res, err := uc.Consent(ctx, consentParams)
+	require.NoError(t, err)
+	assert.False(t, res.Required)
+	assert.Empty(t, mockScopeRepo.FindScopesByNamesCalls())
+	assert.Empty(t, mockConsentService.FindConsentCalls())
+}
+
+func TestConsent_FindConsentError(t *testing.T) {
+	ctx := context.Background()
+	mockConsentService := &domainservice.ConsentServiceMock{
+		FindConsentFunc: func(ctx context.Context, userID, clientID uuid.UUID) (domain.Consent, error) {
+			return nil, errors.New("FindConsent error")
+		},
+	}
+
+	uc := NewAuthorizationUsecase(newConsentClientRepo(false), nil, newConsentScopeRepo(), nil, nil, mockConsentService)
+	_, err := uc.Consent(ctx, consentParams)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "FindConsent error", err.(*errors.UsecaseError).Message)
 }
 
 func TestConsent_FindClientError(t *testing.T) {
@@ -39,8 +162,8 @@ func TestConsent_FindClientError(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(mockClientRepo, nil, nil)
-	_, err := uc.Consent(ctx, uuid.New())
+	uc := NewAuthorizationUsecase(mockClientRepo, nil, nil, nil, nil, nil)
+	_, err := uc.Consent(ctx, consentParams)
 	require.Error(t, err)
 	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
 	assert.Equal(t, "FindClientByClientID error", err.(*errors.UsecaseError).Message)
@@ -58,13 +181,136 @@ func TestConsent_ClientNotFound(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(mockClientRepo, nil, nil)
-	_, err := uc.Consent(ctx, uuid.New())
+	uc := NewAuthorizationUsecase(mockClientRepo, nil, nil, nil, nil, nil)
+	_, err := uc.Consent(ctx, consentParams)
 	require.Error(t, err)
 	assert.Equal(t, http.StatusBadRequest, err.(*errors.UsecaseError).Code)
 	assert.Equal(t, "client not found", err.(*errors.UsecaseError).Message)
 }
 
+func newGrantConsentService(granted string) *domainservice.ConsentServiceMock {
+	return &domainservice.ConsentServiceMock{
+		FindConsentFunc: func(ctx context.Context, userID, clientID uuid.UUID) (domain.Consent, error) {
+			if granted == "" {
+				return nil, nil
+			}
+			return domain.NewConsent(domain.ConsentParams{UserID: userID, ClientID: clientID, Scope: granted})
+		},
+		GrantConsentFunc: func(ctx context.Context, userID, clientID uuid.UUID, scopes domain.ScopeSet) (domain.Consent, error) {
+			return nil, nil
+		},
+	}
+}
+
+func TestGrantConsent_Success(t *testing.T) {
+	ctx := context.Background()
+	mockConsentService := newGrantConsentService("")
+
+	p := GrantConsentParams{
+		UserID:   uuid.NewString(),
+		ClientID: uuid.NewString(),
+		Scope:    "write read profile",
+		Approved: []string{"read", "write"},
+	}
+	uc := NewAuthorizationUsecase(newConsentClientRepo(false), nil, newConsentScopeRepo(), nil, nil, mockConsentService)
+	granted, err := uc.GrantConsent(ctx, p)
+	require.NoError(t, err)
+	assert.Equal(t, "profile read write", granted.String())
+
+	call := mockConsentService.GrantConsentCalls()[0]
+	assert.Equal(t, p.UserID, call.UserID.String())
+	assert.Equal(t, p.ClientID, call.ClientID.String())
+	assert.Equal(t, "profile read write", call.Scopes.String())
+}
+
+func TestGrantConsent_PartiallyApproved(t *testing.T) {
+	ctx := context.Background()
+	mockConsentService := newGrantConsentService("")
+
+	p := GrantConsentParams{
+		UserID:   uuid.NewString(),
+		ClientID: uuid.NewString(),
+		Scope:    "read write profile",
+		Approved: []string{"read"},
+	}
+	uc := NewAuthorizationUsecase(newConsentClientRepo(false), nil, newConsentScopeRepo(), nil, nil, mockConsentService)
+	granted, err := uc.GrantConsent(ctx, p)
+	require.NoError(t, err)
+	assert.Equal(t, "profile read", granted.String())
+	assert.Equal(t, "profile read", mockConsentService.GrantConsentCalls()[0].Scopes.String())
+}
+
+func TestGrantConsent_KeepsPreviouslyGrantedScopes(t *testing.T) {
+	ctx := context.Background()
+	mockConsentService := newGrantConsentService("write")
+
+	p := GrantConsentParams{
+		UserID:   uuid.NewString(),
+		ClientID: uuid.NewString(),
+		Scope:    "read write",
+	}
+	uc := NewAuthorizationUsecase(newConsentClientRepo(false), nil, newConsentScopeRepo(), nil, nil, mockConsentService)
+	granted, err := uc.GrantConsent(ctx, p)
+	require.NoError(t, err)
+	assert.Equal(t, "write", granted.String())
+}
+
+func TestGrantConsent_NothingApproved(t *testing.T) {
+	ctx := context.Background()
+	mockConsentService := newGrantConsentService("")
+
+	p := GrantConsentParams{
+		UserID:   uuid.NewString(),
+		ClientID: uuid.NewString(),
+		Scope:    "read write",
+	}
+	uc := NewAuthorizationUsecase(newConsentClientRepo(false), nil, newConsentScopeRepo(), nil, nil, mockConsentService)
+	granted, err := uc.GrantConsent(ctx, p)
+	require.NoError(t, err)
+	assert.True(t, granted.IsEmpty())
+	assert.Empty(t, mockConsentService.GrantConsentCalls())
+}
+
+func TestGrantConsent_IgnoresUnrequestedScopes(t *testing.T) {
+	ctx := context.Background()
+	mockConsentService := newGrantConsentService("")
+
+	p := GrantConsentParams{
+		UserID:   uuid.NewString(),
+		ClientID: uuid.NewString(),
+		Scope:    "read",
+		Approved: []string{"read", "admin"},
+	}
+	uc := NewAuthorizationUsecase(newConsentClientRepo(false), nil, newConsentScopeRepo(), nil, nil, mockConsentService)
+	granted, err := uc.GrantConsent(ctx, p)
+	require.NoError(t, err)
+	assert.Equal(t, "read", granted.String())
+}
+
+func TestGrantConsent_InvalidUserID(t *testing.T) {
+	ctx := context.Background()
+
+	uc := NewAuthorizationUsecase(nil, nil, nil, nil, nil, &domainservice.ConsentServiceMock{})
+	_, err := uc.GrantConsent(ctx, GrantConsentParams{UserID: "invalid", ClientID: uuid.NewString()})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "invalid user id", err.(*errors.UsecaseError).Message)
+}
+
+func TestGrantConsent_Error(t *testing.T) {
+	ctx := context.Background()
+	mockConsentService := newGrantConsentService("")
+	mockConsentService.GrantConsentFunc = func(ctx context.Context, userID, clientID uuid.UUID, scopes domain.ScopeSet) (domain.Consent, error) {
+		return nil, errors.New("GrantConsent error")
+	}
+
+	uc := NewAuthorizationUsecase(newConsentClientRepo(false), nil, newConsentScopeRepo(), nil, nil, mockConsentService)
+	_, err := uc.GrantConsent(ctx, GrantConsentParams{UserID: uuid.NewString(), ClientID: uuid.NewString(), Scope: "read", Approved: []string{"read"}})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "GrantConsent error", err.(*errors.UsecaseError).Message)
+}
+
 func TestGenerateAuthorizationCode_Success(t *testing.T) {
 	ctx := context.Background()
 	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
@@ -76,8 +322,8 @@ func TestGenerateAuthorizationCode_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, mockCodeRepo, nil)
-	_, err := uc.GenerateAuthorizationCode(ctx, GenerateAuthorizationCodeParams{})
+	uc := NewAuthorizationUsecase(nil, mockCodeRepo, nil, nil, nil, nil)
+	_, err := uc.GenerateAuthorizationCode(ctx, authorizationCodeParams)
 	require.NoError(t, err)
 }
 
@@ -89,8 +335,8 @@ func TestGenerateAuthorizationCode_StoreAuthorizationCodeError(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, mockCodeRepo, nil)
-	_, err := uc.GenerateAuthorizationCode(ctx, GenerateAuthorizationCodeParams{})
+	uc := NewAuthorizationUsecase(nil, mockCodeRepo, nil, nil, nil, nil)
+	_, err := uc.GenerateAuthorizationCode(ctx, authorizationCodeParams)
 	require.Error(t, err)
 	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
 	assert.Equal(t, "StoreAuthorizationCode error", err.(*errors.UsecaseError).Message)
@@ -107,8 +353,8 @@ func TestGenerateAuthorizationCode_FindAuthorizationCodeError(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, mockCodeRepo, nil)
-	_, err := uc.GenerateAuthorizationCode(ctx, GenerateAuthorizationCodeParams{})
+	uc := NewAuthorizationUsecase(nil, mockCodeRepo, nil, nil, nil, nil)
+	_, err := uc.GenerateAuthorizationCode(ctx, authorizationCodeParams)
 	require.Error(t, err)
 	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
 	assert.Equal(t, "FindAuthorizationCode error", err.(*errors.UsecaseError).Message)
@@ -125,20 +371,61 @@ func TestGenerateAuthorizationCode_FindAuthorizationCodeNil(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, mockCodeRepo, nil)
-	_, err := uc.GenerateAuthorizationCode(ctx, GenerateAuthorizationCodeParams{})
+	uc := NewAuthorizationUsecase(nil, mockCodeRepo, nil, nil, nil, nil)
+	_, err := uc.GenerateAuthorizationCode(ctx, authorizationCodeParams)
 	require.Error(t, err)
 	assert.Equal(t, http.StatusBadRequest, err.(*errors.UsecaseError).Code)
 }
 
+func TestGenerateAuthorizationCode_BindsClientAndUser(t *testing.T) {
+	ctx := context.Background()
+	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
+		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
+			return &domain.AuthorizationCodeMock{}, nil
+		},
+		StoreAuthorizationCodeFunc: func(ctx context.Context, storeAuthorizationCodeParams domain.StoreAuthorizationCodeParams) (string, error) {
+			return "code", nil
+		},
+	}
+
+	uc := NewAuthorizationUsecase(nil, mockCodeRepo, nil, nil, nil, nil)
+	_, err := uc.GenerateAuthorizationCode(ctx, authorizationCodeParams)
+	require.NoError(t, err)
+
+	stored := mockCodeRepo.StoreAuthorizationCodeCalls()[0].P
+	assert.Equal(t, authorizationCodeParams.ClientID, stored.ClientID.String())
+	assert.Equal(t, authorizationCodeParams.UserID, stored.UserID.String())
+	assert.Equal(t, authorizationCodeParams.RedirectURI, stored.RedirectURI)
+}
+
+func TestGenerateAuthorizationCode_InvalidClientID(t *testing.T) {
+	ctx := context.Background()
+	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{}
+
+	uc := NewAuthorizationUsecase(nil, mockCodeRepo, nil, nil, nil, nil)
+	_, err := uc.GenerateAuthorizationCode(ctx, GenerateAuthorizationCodeParams{UserID: uuid.NewString()})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*errors.UsecaseError).Code)
+	assert.Empty(t, mockCodeRepo.StoreAuthorizationCodeCalls())
+}
+
 func TestGenerateTokenByCode_Success(t *testing.T) {
 	ctx := context.Background()
 	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
 		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
 			return &domain.AuthorizationCodeMock{
+				IsRevokedFunc: func() bool {
+					return false
+				},
 				IsExpiredFunc: func(t time.Time) bool {
 					return false
 				},
+				IsIssuedToFunc: func(clientID uuid.UUID) bool {
+					return true
+				},
+				IsRedirectURIMatchFunc: func(redirectURI string) bool {
+					return true
+				},
 				GetClientIDFunc: func() uuid.UUID {
 					return uuid.New()
 				},
@@ -150,20 +437,20 @@ func TestGenerateTokenByCode_Success(t *testing.T) {
 				},
 			}, nil
 		},
-		RevokeCodeFunc: func(ctx context.Context, code string) error {
-			return nil
+		RedeemCodeFunc: func(ctx context.Context, code string) (bool, error) {
+			return true, nil
 		},
 	}
 
 	mockTokenService := &domainservice.TokenServiceMock{
-		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error) {
+		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
 			return &domain.TokenMock{
 				GetAccessTokenFunc: func() string {
 					return "access_token"
 				},
 			}, nil
 		},
-		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 			return &domain.RefreshTokenMock{
 				GetRefreshTokenFunc: func() string {
 					return "refresh_token"
@@ -172,8 +459,8 @@ func TestGenerateTokenByCode_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, mockCodeRepo, mockTokenService)
-	token, rtoken, err := uc.GenerateTokenByCode(ctx, "code")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	token, rtoken, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
 	require.NoError(t, err)
 	assert.Equal(t, "access_token", token.GetAccessToken())
 	assert.Equal(t, "refresh_token", rtoken.GetRefreshToken())
@@ -189,8 +476,8 @@ func TestGenerateTokenByCode_FindValidAuthorizationCodeError(t *testing.T) {
 
 	mockTokenService := &domainservice.TokenServiceMock{}
 
-	uc := NewAuthorizationUsecase(nil, mockCodeRepo, mockTokenService)
-	_, _, err := uc.GenerateTokenByCode(ctx, "code")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	_, _, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
 	require.Error(t, err)
 	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
 	assert.Equal(t, "FindValidAuthorizationCode error", err.(*errors.UsecaseError).Message)
@@ -206,8 +493,8 @@ func TestGenerateTokenByCode_CodeIsNil(t *testing.T) {
 
 	mockTokenService := &domainservice.TokenServiceMock{}
 
-	uc := NewAuthorizationUsecase(nil, mockCodeRepo, mockTokenService)
-	_, _, err := uc.GenerateTokenByCode(ctx, "code")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	_, _, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
 	require.Error(t, err)
 	assert.Equal(t, http.StatusForbidden, err.(*errors.UsecaseError).Code)
 	assert.Equal(t, "code not found", err.(*errors.UsecaseError).Message)
@@ -218,6 +505,9 @@ func TestGenerateTokenByCode_CodeIsExpired(t *testing.T) {
 	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
 		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
 			return &domain.AuthorizationCodeMock{
+				IsRevokedFunc: func() bool {
+					return false
+				},
 				IsExpiredFunc: func(t time.Time) bool {
 					return true
 				},
@@ -227,8 +517,8 @@ func TestGenerateTokenByCode_CodeIsExpired(t *testing.T) {
 
 	mockTokenService := &domainservice.TokenServiceMock{}
 
-	uc := NewAuthorizationUsecase(nil, mockCodeRepo, mockTokenService)
-	_, _, err := uc.GenerateTokenByCode(ctx, "code")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	_, _, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
 	require.Error(t, err)
 	assert.Equal(t, http.StatusForbidden, err.(*errors.UsecaseError).Code)
 	assert.Equal(t, "code has expired", err.(*errors.UsecaseError).Message)
@@ -239,9 +529,18 @@ func TestGenerateTokenByCode_StoreTokenError(t *testing.T) {
 	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
 		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
 			return &domain.AuthorizationCodeMock{
+				IsRevokedFunc: func() bool {
+					return false
+				},
 				IsExpiredFunc: func(t time.Time) bool {
 					return false
 				},
+				IsIssuedToFunc: func(clientID uuid.UUID) bool {
+					return true
+				},
+				IsRedirectURIMatchFunc: func(redirectURI string) bool {
+					return true
+				},
 				GetClientIDFunc: func() uuid.UUID {
 					return uuid.New()
 				},
@@ -253,19 +552,19 @@ func TestGenerateTokenByCode_StoreTokenError(t *testing.T) {
 				},
 			}, nil
 		},
-		RevokeCodeFunc: func(ctx context.Context, code string) error {
-			return nil
+		RedeemCodeFunc: func(ctx context.Context, code string) (bool, error) {
+			return true, nil
 		},
 	}
 
 	mockTokenService := &domainservice.TokenServiceMock{
-		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error) {
+		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
 			return nil, errors.New("StoreNewToken error")
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, mockCodeRepo, mockTokenService)
-	_, _, err := uc.GenerateTokenByCode(ctx, "code")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	_, _, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
 	require.Error(t, err)
 	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
 	assert.Equal(t, "StoreNewToken error", err.(*errors.UsecaseError).Message)
@@ -276,9 +575,18 @@ func TestGenerateTokenByCode_StoreRefreshTokenError(t *testing.T) {
 	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
 		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
 			return &domain.AuthorizationCodeMock{
+				IsRevokedFunc: func() bool {
+					return false
+				},
 				IsExpiredFunc: func(t time.Time) bool {
 					return false
 				},
+				IsIssuedToFunc: func(clientID uuid.UUID) bool {
+					return true
+				},
+				IsRedirectURIMatchFunc: func(redirectURI string) bool {
+					return true
+				},
 				GetClientIDFunc: func() uuid.UUID {
 					return uuid.New()
 				},
@@ -293,33 +601,42 @@ func TestGenerateTokenByCode_StoreRefreshTokenError(t *testing.T) {
 	}
 
 	mockTokenService := &domainservice.TokenServiceMock{
-		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error) {
+		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
 			return &domain.TokenMock{
 				GetAccessTokenFunc: func() string {
 					return "access_token"
 				},
 			}, nil
 		},
-		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 			return nil, errors.New("StoreNewRefreshToken error")
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, mockCodeRepo, mockTokenService)
-	_, _, err := uc.GenerateTokenByCode(ctx, "code")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	_, _, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
 	require.Error(t, err)
 	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
 	assert.Equal(t, "StoreNewRefreshToken error", err.(*errors.UsecaseError).Message)
 }
 
-func TestGenerateTokenByCode_RevokeCodeError(t *testing.T) {
+func TestGenerateTokenByCode_RedeemCodeError(t *testing.T) {
 	ctx := context.Background()
 	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
 		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
 			return &domain.AuthorizationCodeMock{
+				IsRevokedFunc: func() bool {
+					return false
+				},
 				IsExpiredFunc: func(t time.Time) bool {
 					return false
 				},
+				IsIssuedToFunc: func(clientID uuid.UUID) bool {
+					return true
+				},
+				IsRedirectURIMatchFunc: func(redirectURI string) bool {
+					return true
+				},
 				GetClientIDFunc: func() uuid.UUID {
 					return uuid.New()
 				},
@@ -331,29 +648,213 @@ func TestGenerateTokenByCode_RevokeCodeError(t *testing.T) {
 				},
 			}, nil
 		},
-		RevokeCodeFunc: func(ctx context.Context, code string) error {
-			return errors.New("RevokeCode error")
+		RedeemCodeFunc: func(ctx context.Context, code string) (bool, error) {
+			return false, errors.New("RedeemCode error")
 		},
 	}
 
 	mockTokenService := &domainservice.TokenServiceMock{
-		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error) {
+		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
 			return &domain.TokenMock{
 				GetAccessTokenFunc: func() string {
 					return "access_token"
 				},
 			}, nil
 		},
-		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 			return &domain.RefreshTokenMock{}, nil
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, mockCodeRepo, mockTokenService)
-	_, _, err := uc.GenerateTokenByCode(ctx, "code")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	_, _, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
 	require.Error(t, err)
 	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
-	assert.Equal(t, "RevokeCode error", err.(*errors.UsecaseError).Message)
+	assert.Equal(t, "RedeemCode error", err.(*errors.UsecaseError).Message)
+}
+
+func TestGenerateTokenByCode_InvalidClientID(t *testing.T) {
+	ctx := context.Background()
+	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{}
+
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), &domainservice.TokenServiceMock{}, nil)
+	_, _, err := uc.GenerateTokenByCode(ctx, GenerateTokenByCodeParams{Code: "code"})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.(*errors.UsecaseError).Code)
+	assert.Empty(t, mockCodeRepo.FindAuthorizationCodeCalls())
+}
+
+func TestGenerateTokenByCode_ClientMismatch(t *testing.T) {
+	ctx := context.Background()
+	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
+		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
+			return &domain.AuthorizationCodeMock{
+				IsRevokedFunc: func() bool {
+					return false
+				},
+				IsExpiredFunc: func(t time.Time) bool {
+					return false
+				},
+				IsIssuedToFunc: func(clientID uuid.UUID) bool {
+					return false
+				},
+			}, nil
+		},
+	}
+
+	mockTokenService := &domainservice.TokenServiceMock{}
+
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	_, _, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "code was not issued to this client", err.(*errors.UsecaseError).Message)
+	assert.Empty(t, mockTokenService.StoreNewTokenCalls())
+	assert.Empty(t, mockCodeRepo.RedeemCodeCalls())
+}
+
+func TestGenerateTokenByCode_RedirectURIMismatch(t *testing.T) {
+	ctx := context.Background()
+	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
+		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
+			return &domain.AuthorizationCodeMock{
+				IsRevokedFunc: func() bool {
+					return false
+				},
+				IsExpiredFunc: func(t time.Time) bool {
+					return false
+				},
+				IsIssuedToFunc: func(clientID uuid.UUID) bool {
+					return true
+				},
+				IsRedirectURIMatchFunc: func(redirectURI string) bool {
+					return false
+				},
+			}, nil
+		},
+	}
+
+	mockTokenService := &domainservice.TokenServiceMock{}
+
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	_, _, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "redirect uri does not match", err.(*errors.UsecaseError).Message)
+	assert.Empty(t, mockTokenService.StoreNewTokenCalls())
+}
+
+func TestGenerateTokenByCode_CodeIsReplayed(t *testing.T) {
+	ctx := context.Background()
+	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
+		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
+			return &domain.AuthorizationCodeMock{
+				IsRevokedFunc: func() bool {
+					return true
+				},
+			}, nil
+		},
+	}
+
+	mockTokenService := &domainservice.TokenServiceMock{
+		RevokeTokensByAuthorizationCodeFunc: func(ctx context.Context, code string) error {
+			return nil
+		},
+	}
+
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	_, _, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "code has already been used", err.(*errors.UsecaseError).Message)
+	require.Len(t, mockTokenService.RevokeTokensByAuthorizationCodeCalls(), 1)
+	assert.Equal(t, "code", mockTokenService.RevokeTokensByAuthorizationCodeCalls()[0].Code)
+	assert.Empty(t, mockTokenService.StoreNewTokenCalls())
+}
+
+func TestGenerateTokenByCode_ConcurrentRedemption(t *testing.T) {
+	ctx := context.Background()
+	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
+		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
+			return &domain.AuthorizationCodeMock{
+				IsRevokedFunc: func() bool {
+					return false
+				},
+				IsExpiredFunc: func(t time.Time) bool {
+					return false
+				},
+				IsIssuedToFunc: func(clientID uuid.UUID) bool {
+					return true
+				},
+				IsRedirectURIMatchFunc: func(redirectURI string) bool {
+					return true
+				},
+				GetClientIDFunc: func() uuid.UUID {
+					return uuid.New()
+				},
+				GetUserIDFunc: func() uuid.UUID {
+					return uuid.New()
+				},
+				GetScopeFunc: func() string {
+					return "scope"
+				},
+			}, nil
+		},
+		// 別のリクエストが先にコードを使用済みにした
+		RedeemCodeFunc: func(ctx context.Context, code string) (bool, error) {
+			return false, nil
+		},
+	}
+
+	mockTokenService := &domainservice.TokenServiceMock{
+		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
+			return &domain.TokenMock{
+				GetAccessTokenFunc: func() string {
+					return "access_token"
+				},
+			}, nil
+		},
+		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
+			return &domain.RefreshTokenMock{}, nil
+		},
+		RevokeTokensByAuthorizationCodeFunc: func(ctx context.Context, code string) error {
+			return nil
+		},
+	}
+
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	token, rtoken, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
+	require.Error(t, err)
+	assert.Nil(t, token)
+	assert.Nil(t, rtoken)
+	assert.Equal(t, http.StatusForbidden, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "code", mockTokenService.StoreNewTokenCalls()[0].AuthorizationCode)
+	require.Len(t, mockTokenService.RevokeTokensByAuthorizationCodeCalls(), 1)
+}
+
+func TestGenerateTokenByCode_RevokeReplayedTokensError(t *testing.T) {
+	ctx := context.Background()
+	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
+		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
+			return &domain.AuthorizationCodeMock{
+				IsRevokedFunc: func() bool {
+					return true
+				},
+			}, nil
+		},
+	}
+
+	mockTokenService := &domainservice.TokenServiceMock{
+		RevokeTokensByAuthorizationCodeFunc: func(ctx context.Context, code string) error {
+			return errors.New("RevokeTokensByAuthorizationCode error")
+		},
+	}
+
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+	_, _, err := uc.GenerateTokenByCode(ctx, tokenByCodeParams)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, "RevokeTokensByAuthorizationCode error", err.(*errors.UsecaseError).Message)
 }
 
 func TestGenerateTokenByRefreshToken_Success(t *testing.T) {
@@ -373,16 +874,19 @@ func TestGenerateTokenByRefreshToken_Success(t *testing.T) {
 				GetScopeFunc: func() string {
 					return "scope"
 				},
+				GetAuthorizationCodeFunc: func() string {
+					return ""
+				},
 			}, nil
 		},
-		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error) {
+		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
 			return &domain.TokenMock{
 				GetAccessTokenFunc: func() string {
 					return "new_access_token"
 				},
 			}, nil
 		},
-		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 			return &domain.RefreshTokenMock{
 				GetRefreshTokenFunc: func() string {
 					return "new_refresh_token"
@@ -397,8 +901,8 @@ func TestGenerateTokenByRefreshToken_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, nil, mockTokenService)
-	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, "refresh_token")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), nil, nil, newTokenUserRepo(), mockTokenService, nil)
+	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, GenerateTokenByRefreshTokenParams{RefreshToken: "refresh_token"})
 	require.NoError(t, err)
 	assert.Equal(t, "new_access_token", token.GetAccessToken())
 	assert.Equal(t, "new_refresh_token", rtoken.GetRefreshToken())
@@ -410,14 +914,14 @@ func TestGenerateTokenByRefreshToken_FindTokenAndRefreshTokenByRefreshTokenError
 		FindTokenByRefreshTokenFunc: func(ctx context.Context, refreshToken string, now time.Time) (domain.Token, error) {
 			return nil, errors.NewServiceErrorError(errors.ErrCodeInternalServer, "FindTokenAndRefreshTokenByRefreshToken error")
 		},
-		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error) {
+		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
 			return &domain.TokenMock{
 				GetAccessTokenFunc: func() string {
 					return "new_access_token"
 				},
 			}, nil
 		},
-		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 			return &domain.RefreshTokenMock{
 				GetRefreshTokenFunc: func() string {
 					return "new_refresh_token"
@@ -432,8 +936,8 @@ func TestGenerateTokenByRefreshToken_FindTokenAndRefreshTokenByRefreshTokenError
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, nil, mockTokenService)
-	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, "refresh_token")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), nil, nil, newTokenUserRepo(), mockTokenService, nil)
+	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, GenerateTokenByRefreshTokenParams{RefreshToken: "refresh_token"})
 	require.Error(t, err)
 	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
 	assert.Contains(t, err.(*errors.UsecaseError).Message, "FindTokenAndRefreshTokenByRefreshToken error")
@@ -458,12 +962,15 @@ func TestGenerateTokenByRefreshToken_StoreNewTokenError(t *testing.T) {
 				GetScopeFunc: func() string {
 					return "scope"
 				},
+				GetAuthorizationCodeFunc: func() string {
+					return ""
+				},
 			}, nil
 		},
-		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error) {
+		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
 			return nil, errors.NewServiceErrorError(errors.ErrCodeInternalServer, "StoreNewToken error")
 		},
-		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 			return &domain.RefreshTokenMock{
 				GetRefreshTokenFunc: func() string {
 					return "new_refresh_token"
@@ -478,8 +985,8 @@ func TestGenerateTokenByRefreshToken_StoreNewTokenError(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, nil, mockTokenService)
-	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, "refresh_token")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), nil, nil, newTokenUserRepo(), mockTokenService, nil)
+	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, GenerateTokenByRefreshTokenParams{RefreshToken: "refresh_token"})
 	require.Error(t, err)
 	assert.Equal(t, http.StatusInternalServerError, err.(*errors.UsecaseError).Code)
 	assert.Contains(t, err.(*errors.UsecaseError).Message, "StoreNewToken error")
@@ -504,16 +1011,19 @@ func TestGenerateTokenByRefreshToken_StoreNewRefreshTokenError(t *testing.T) {
 				GetScopeFunc: func() string {
 					return "scope"
 				},
+				GetAuthorizationCodeFunc: func() string {
+					return ""
+				},
 			}, nil
 		},
-		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error) {
+		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
 			return &domain.TokenMock{
 				GetAccessTokenFunc: func() string {
 					return "new_access_token"
 				},
 			}, nil
 		},
-		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 			return nil, errors.NewServiceErrorError(errors.ErrCodeInternalServer, "StoreNewRefreshToken error")
 		},
 		RevokeTokenFunc: func(ctx context.Context, accessToken string) error {
@@ -524,8 +1034,8 @@ func TestGenerateTokenByRefreshToken_StoreNewRefreshTokenError(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, nil, mockTokenService)
-	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, "refresh_token")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), nil, nil, newTokenUserRepo(), mockTokenService, nil)
+	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, GenerateTokenByRefreshTokenParams{RefreshToken: "refresh_token"})
 	require.Error(t, err)
 	assert.Contains(t, err.(*errors.UsecaseError).Message, "StoreNewRefreshToken error")
 	assert.Nil(t, token)
@@ -549,16 +1059,19 @@ func TestGenerateTokenByRefreshToken_RevokeTokenError(t *testing.T) {
 				GetScopeFunc: func() string {
 					return "scope"
 				},
+				GetAuthorizationCodeFunc: func() string {
+					return ""
+				},
 			}, nil
 		},
-		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error) {
+		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
 			return &domain.TokenMock{
 				GetAccessTokenFunc: func() string {
 					return "new_access_token"
 				},
 			}, nil
 		},
-		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 			return &domain.RefreshTokenMock{
 				GetRefreshTokenFunc: func() string {
 					return "new_refresh_token"
@@ -573,8 +1086,8 @@ func TestGenerateTokenByRefreshToken_RevokeTokenError(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, nil, mockTokenService)
-	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, "refresh_token")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), nil, nil, newTokenUserRepo(), mockTokenService, nil)
+	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, GenerateTokenByRefreshTokenParams{RefreshToken: "refresh_token"})
 	require.Error(t, err)
 	assert.Contains(t, err.(*errors.UsecaseError).Message, "RevokeToken error")
 	assert.Nil(t, token)
@@ -598,16 +1111,19 @@ func TestGenerateTokenByRefreshToken_RevokeRefreshTokenError(t *testing.T) {
 				GetScopeFunc: func() string {
 					return "scope"
 				},
+				GetAuthorizationCodeFunc: func() string {
+					return ""
+				},
 			}, nil
 		},
-		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope string) (domain.Token, error) {
+		StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
 			return &domain.TokenMock{
 				GetAccessTokenFunc: func() string {
 					return "new_access_token"
 				},
 			}, nil
 		},
-		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string) (domain.RefreshToken, error) {
+		StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
 			return &domain.RefreshTokenMock{
 				GetRefreshTokenFunc: func() string {
 					return "new_refresh_token"
@@ -622,10 +1138,197 @@ func TestGenerateTokenByRefreshToken_RevokeRefreshTokenError(t *testing.T) {
 		},
 	}
 
-	uc := NewAuthorizationUsecase(nil, nil, mockTokenService)
-	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, "refresh_token")
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), nil, nil, newTokenUserRepo(), mockTokenService, nil)
+	token, rtoken, err := uc.GenerateTokenByRefreshToken(ctx, GenerateTokenByRefreshTokenParams{RefreshToken: "refresh_token"})
 	require.Error(t, err)
 	assert.Contains(t, err.(*errors.UsecaseError).Message, "RevokeRefreshToken error")
 	assert.Nil(t, token)
 	assert.Nil(t, rtoken)
 }
+
+func TestGenerateTokenByRefreshToken_Scope(t *testing.T) {
+	ctx := context.Background()
+	newTokenService := func() *domainservice.TokenServiceMock {
+		return &domainservice.TokenServiceMock{
+			FindTokenByRefreshTokenFunc: func(ctx context.Context, refreshToken string, now time.Time) (domain.Token, error) {
+				return &domain.TokenMock{
+					GetAccessTokenFunc: func() string {
+						return "access_token"
+					},
+					GetClientIDFunc: func() uuid.UUID {
+						return uuid.New()
+					},
+					GetUserIDFunc: func() uuid.UUID {
+						return uuid.New()
+					},
+					// 前回のリフレッシュで read に縮小済み
+					GetScopeFunc: func() string {
+						return "read"
+					},
+					GetAuthorizationCodeFunc: func() string {
+						return "code"
+					},
+				}, nil
+			},
+			StoreNewTokenFunc: func(ctx context.Context, clientID, UserID uuid.UUID, scope, authorizationCode string, expiresMin int) (domain.Token, error) {
+				return &domain.TokenMock{
+					GetAccessTokenFunc: func() string {
+						return "new_access_token"
+					},
+				}, nil
+			},
+			StoreNewRefreshTokenFunc: func(ctx context.Context, accessToken string, expiresDay int) (domain.RefreshToken, error) {
+				return &domain.RefreshTokenMock{}, nil
+			},
+			RevokeTokenFunc: func(ctx context.Context, accessToken string) error {
+				return nil
+			},
+			RevokeRefreshTokenFunc: func(ctx context.Context, refreshToken string) error {
+				return nil
+			},
+		}
+	}
+	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
+		FindAuthorizationCodeFunc: func(ctx context.Context, s string) (domain.AuthorizationCode, error) {
+			return &domain.AuthorizationCodeMock{
+				GetScopeFunc: func() string {
+					return "read write"
+				},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name      string
+		scope     string
+		wantScope string
+		wantErr   error
+	}{
+		{name: "omitted scope keeps the granted scope", scope: "", wantScope: "read write"},
+		{name: "narrowed scope", scope: "write", wantScope: "write"},
+		{name: "widened scope", scope: "read admin", wantErr: ErrInvalidScope},
+		{name: "malformed scope", scope: `"read"`, wantErr: ErrInvalidScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTokenService := newTokenService()
+			uc := NewAuthorizationUsecase(newTokenClientRepo(), mockCodeRepo, nil, newTokenUserRepo(), mockTokenService, nil)
+			_, _, err := uc.GenerateTokenByRefreshToken(ctx, GenerateTokenByRefreshTokenParams{RefreshToken: "refresh_token", Scope: tt.scope})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, mockTokenService.StoreNewTokenCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantScope, mockTokenService.StoreNewTokenCalls()[0].Scope)
+		})
+	}
+}
+
+func TestGenerateTokenByCode_ClientAuthentication(t *testing.T) {
+	ctx := context.Background()
+	confidential, secret, err := domain.RegisterClient(domain.ClientParams{
+		Name:         "app",
+		RedirectURIs: []string{"https://example.com/callback"},
+		GrantTypes:   []string{domain.GrantTypeAuthorizationCode},
+	}, true)
+	require.NoError(t, err)
+	disabled := domain.NewClient(domain.ClientParams{ID: uuid.New()})
+	disabled.Disable(time.Now())
+	clients := map[uuid.UUID]domain.Client{confidential.GetID(): confidential, disabled.GetID(): disabled}
+	mockClientRepo := &domain.ClientRepositoryMock{
+		FindClientByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) (domain.Client, error) {
+			if c, ok := clients[clientID]; ok {
+				return c, nil
+			}
+			return domain.NewClient(domain.ClientParams{}), nil
+		},
+	}
+	mockCodeRepo := &domain.AuthorizationCodeRepositoryMock{
+		FindAuthorizationCodeFunc: func(ctx context.Context, code string) (domain.AuthorizationCode, error) {
+			return nil, nil
+		},
+	}
+	uc := NewAuthorizationUsecase(mockClientRepo, mockCodeRepo, nil, nil, nil, nil)
+
+	tests := []struct {
+		name     string
+		clientID uuid.UUID
+		secret   string
+		code     int
+	}{
+		{"wrong secret", confidential.GetID(), "wrong", http.StatusUnauthorized},
+		{"missing secret", confidential.GetID(), "", http.StatusUnauthorized},
+		{"disabled client", disabled.GetID(), "", http.StatusUnauthorized},
+		{"unknown client", uuid.New(), "", http.StatusUnauthorized},
+		// 認証できた後はコードの確認に進む
+		{"valid secret", confidential.GetID(), secret, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := uc.GenerateTokenByCode(ctx, GenerateTokenByCodeParams{
+				Code:         "code",
+				ClientID:     tt.clientID.String(),
+				ClientSecret: tt.secret,
+			})
+			require.Error(t, err)
+			assert.Equal(t, tt.code, err.(*errors.UsecaseError).Code)
+		})
+	}
+}
+
+func TestGenerateTokenByRefreshToken_GrantTypeNotAllowed(t *testing.T) {
+	ctx := context.Background()
+	clientID := uuid.New()
+	mockClientRepo := &domain.ClientRepositoryMock{
+		FindClientByClientIDFunc: func(ctx context.Context, id uuid.UUID) (domain.Client, error) {
+			return domain.NewClient(domain.ClientParams{ID: id, GrantTypes: []string{domain.GrantTypeAuthorizationCode}}), nil
+		},
+	}
+	mockTokenService := &domainservice.TokenServiceMock{
+		FindTokenByRefreshTokenFunc: func(ctx context.Context, refreshToken string, now time.Time) (domain.Token, error) {
+			return &domain.TokenMock{
+				GetClientIDFunc: func() uuid.UUID {
+					return clientID
+				},
+			}, nil
+		},
+	}
+	uc := NewAuthorizationUsecase(mockClientRepo, nil, nil, newTokenUserRepo(), mockTokenService, nil)
+
+	_, _, err := uc.GenerateTokenByRefreshToken(ctx, GenerateTokenByRefreshTokenParams{RefreshToken: "refresh_token"})
+	assert.ErrorIs(t, err, ErrUnauthorizedClient)
+
+	// 別のクライアントに発行したリフレッシュトークンは使えない
+	_, _, err = uc.GenerateTokenByRefreshToken(ctx, GenerateTokenByRefreshTokenParams{RefreshToken: "refresh_token", ClientID: uuid.NewString()})
+	assert.ErrorIs(t, err, ErrInvalidClient)
+}
+
+func TestGenerateTokenByRefreshToken_UserDisabled(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	disabledAt := time.Now()
+	mockUserRepo := &domain.UserRepositoryMock{
+		FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return domain.NewUser(domain.UserParams{ID: id, DisabledAt: &disabledAt}), nil
+		},
+	}
+	mockTokenService := &domainservice.TokenServiceMock{
+		FindTokenByRefreshTokenFunc: func(ctx context.Context, refreshToken string, now time.Time) (domain.Token, error) {
+			return &domain.TokenMock{
+				GetClientIDFunc: func() uuid.UUID {
+					return uuid.New()
+				},
+				GetUserIDFunc: func() uuid.UUID {
+					return userID
+				},
+			}, nil
+		},
+	}
+	uc := NewAuthorizationUsecase(newTokenClientRepo(), nil, nil, mockUserRepo, mockTokenService, nil)
+
+	_, _, err := uc.GenerateTokenByRefreshToken(ctx, GenerateTokenByRefreshTokenParams{RefreshToken: "refresh_token"})
+	assert.ErrorIs(t, err, ErrUserDisabled)
+	assert.Empty(t, mockTokenService.StoreNewTokenCalls())
+}
@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+var (
+	ErrClientNotFound     = errors.NewUsecaseError(http.StatusNotFound, "client not found")
+	ErrUnknownScope       = errors.NewUsecaseError(http.StatusBadRequest, "scope is not registered")
+	ErrPublicClientSecret = errors.NewUsecaseError(http.StatusBadRequest, "public client has no secret")
+)
+
+func NewClientAdminUsecase(
+	clientRepo domain.ClientRepository,
+	scopeRepo domain.ScopeRepository,
+	tokenRepo domain.TokenRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+) IClientAdminUsecase {
+	return &ClientAdminUsecase{
+		clientRepo:       clientRepo,
+		scopeRepo:        scopeRepo,
+		tokenRepo:        tokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+type IClientAdminUsecase interface {
+	CreateClient(ctx context.Context, p CreateClientParams) (domain.Client, string, error)
+	ListClients(ctx context.Context, offset, limit int) ([]domain.Client, bool, error)
+	GetClient(ctx context.Context, clientID uuid.UUID) (domain.Client, error)
+	UpdateClient(ctx context.Context, clientID uuid.UUID, p UpdateClientParams) (domain.Client, error)
+	RotateClientSecret(ctx context.Context, clientID uuid.UUID) (string, error)
+	DisableClient(ctx context.Context, clientID uuid.UUID) error
+	DeleteClient(ctx context.Context, clientID uuid.UUID) error
+}
+
+type ClientAdminUsecase struct {
+	clientRepo       domain.ClientRepository
+	scopeRepo        domain.ScopeRepository
+	tokenRepo        domain.TokenRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+}
+
+type CreateClientParams struct {
+	Name          string
+	RedirectURIs  []string
+	AllowedScopes []string
+	GrantTypes    []string
+	FirstParty    bool
+	// Public が true の場合はシークレットを発行しない
+	Public                 bool
+	AccessTokenExpiresMin  int
+	RefreshTokenExpiresDay int
+}
+
+// UpdateClientParams は nil の項目を変更しない
+type UpdateClientParams struct {
+	Name                   *string
+	RedirectURIs           []string
+	AllowedScopes          []string
+	GrantTypes             []string
+	AccessTokenExpiresMin  *int
+	RefreshTokenExpiresDay *int
+}
+
+// CreateClient はクライアントを登録する。シークレットは保存せず、戻り値でこの時だけ返す
+func (uc *ClientAdminUsecase) CreateClient(ctx context.Context, p CreateClientParams) (domain.Client, string, error) {
+	if err := uc.checkScopes(ctx, p.AllowedScopes); err != nil {
+		return nil, "", err
+	}
+
+	c, secret, err := domain.RegisterClient(domain.ClientParams{
+		Name:                   p.Name,
+		RedirectURIs:           p.RedirectURIs,
+		AllowedScopes:          p.AllowedScopes,
+		GrantTypes:             p.GrantTypes,
+		FirstParty:             p.FirstParty,
+		AccessTokenExpiresMin:  p.AccessTokenExpiresMin,
+		RefreshTokenExpiresDay: p.RefreshTokenExpiresDay,
+	}, !p.Public)
+	if err != nil {
+		return nil, "", clientValidationError(err)
+	}
+
+	if err := uc.clientRepo.CreateClient(ctx, c); err != nil {
+		return nil, "", errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return c, secret, nil
+}
+
+// ListClients は作成順に offset 件目から limit 件を返す。続きがあるかも返す
+func (uc *ClientAdminUsecase) ListClients(ctx context.Context, offset, limit int) ([]domain.Client, bool, error) {
+	// 1件多く取得して続きの有無を判定する
+	clients, err := uc.clientRepo.FindClients(ctx, offset, limit+1)
+	if err != nil {
+		return nil, false, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if len(clients) > limit {
+		return clients[:limit], true, nil
+	}
+	return clients, false, nil
+}
+
+func (uc *ClientAdminUsecase) GetClient(ctx context.Context, clientID uuid.UUID) (domain.Client, error) {
+	c, err := uc.clientRepo.FindClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if c.IsNotFound() {
+		return nil, ErrClientNotFound
+	}
+	return c, nil
+}
+
+func (uc *ClientAdminUsecase) UpdateClient(ctx context.Context, clientID uuid.UUID, p UpdateClientParams) (domain.Client, error) {
+	c, err := uc.GetClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Name != nil {
+		c.SetName(*p.Name)
+	}
+	if p.RedirectURIs != nil {
+		if err := c.SetRedirectURIs(p.RedirectURIs); err != nil {
+			return nil, clientValidationError(err)
+		}
+	}
+	if p.AllowedScopes != nil {
+		if err := uc.checkScopes(ctx, p.AllowedScopes); err != nil {
+			return nil, err
+		}
+		c.SetAllowedScopes(p.AllowedScopes)
+	}
+	if p.GrantTypes != nil {
+		if err := c.SetGrantTypes(p.GrantTypes); err != nil {
+			return nil, clientValidationError(err)
+		}
+	}
+	if p.AccessTokenExpiresMin != nil || p.RefreshTokenExpiresDay != nil {
+		accessMin, refreshDay := c.GetAccessTokenExpiresMin(), c.GetRefreshTokenExpiresDay()
+		if p.AccessTokenExpiresMin != nil {
+			accessMin = *p.AccessTokenExpiresMin
+		}
+		if p.RefreshTokenExpiresDay != nil {
+			refreshDay = *p.RefreshTokenExpiresDay
+		}
+		if err := c.SetTokenLifetimes(accessMin, refreshDay); err != nil {
+			return nil, clientValidationError(err)
+		}
+	}
+	if err := c.Validate(); err != nil {
+		return nil, clientValidationError(err)
+	}
+
+	if err := uc.clientRepo.UpdateClient(ctx, c); err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return c, nil
+}
+
+// RotateClientSecret は新しいシークレットを発行する。以前のシークレットはすぐに使えなくなり、発行済みのトークンも全て失効させる
+func (uc *ClientAdminUsecase) RotateClientSecret(ctx context.Context, clientID uuid.UUID) (string, error) {
+	c, err := uc.GetClient(ctx, clientID)
+	if err != nil {
+		return "", err
+	}
+	if !c.IsConfidential() {
+		return "", ErrPublicClientSecret
+	}
+
+	secret, err := c.RotateSecret()
+	if err != nil {
+		return "", errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.clientRepo.UpdateClient(ctx, c); err != nil {
+		return "", errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	// 漏れた秘密鍵で取得されたトークンも使えなくする
+	if err := uc.revokeTokens(ctx, clientID); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// DisableClient はクライアントを無効にし、発行済みのトークンを全て失効させる
+func (uc *ClientAdminUsecase) DisableClient(ctx context.Context, clientID uuid.UUID) error {
+	c, err := uc.GetClient(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	if !c.IsDisabled() {
+		c.Disable(time.Now())
+		if err := uc.clientRepo.UpdateClient(ctx, c); err != nil {
+			return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	// 失効させる途中で失敗した場合も、再度呼び出せば残りを失効させられる
+	return uc.revokeTokens(ctx, clientID)
+}
+
+// revokeTokens はクライアントに発行したアクセストークンとリフレッシュトークンを全て失効させる
+func (uc *ClientAdminUsecase) revokeTokens(ctx context.Context, clientID uuid.UUID) error {
+	if err := uc.tokenRepo.RevokeTokensByClientID(ctx, clientID); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.refreshTokenRepo.RevokeRefreshTokensByClientID(ctx, clientID); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// DeleteClient はクライアントと、クライアントに発行した認可コードとトークンを削除する
+func (uc *ClientAdminUsecase) DeleteClient(ctx context.Context, clientID uuid.UUID) error {
+	if _, err := uc.GetClient(ctx, clientID); err != nil {
+		return err
+	}
+	if err := uc.clientRepo.DeleteClient(ctx, clientID); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// checkScopes は登録されていないスコープを許可しようとしていないかを確認する
+func (uc *ClientAdminUsecase) checkScopes(ctx context.Context, names []string) error {
+	requested := domain.NewScopeSet(names...)
+	if requested.Len() == 0 {
+		return nil
+	}
+	scopes, err := uc.scopeRepo.FindScopesByNames(ctx, requested.Names())
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if len(scopes) != requested.Len() {
+		return ErrUnknownScope
+	}
+	return nil
+}
+
+// clientValidationError はクライアントの項目の検証エラーを 400 にする。
+// RegisterClient はシークレットの生成にも失敗しうるため、検証エラー以外は 500 にする
+func clientValidationError(err error) error {
+	for _, target := range []error{
+		domain.ErrClientNameRequired,
+		domain.ErrClientNameTooLong,
+		domain.ErrClientGrantTypeUnsupported,
+		domain.ErrClientGrantTypeRequired,
+		domain.ErrClientRedirectURIRequired,
+		domain.ErrClientTokenLifetimeInvalid,
+		domain.ErrRedirectURIInvalid,
+		domain.ErrRedirectURINotAbsolute,
+		domain.ErrRedirectURIFragment,
+		domain.ErrRedirectURIWildcard,
+		domain.ErrRedirectURIUserinfo,
+		domain.ErrRedirectURIPathTraversal,
+		domain.ErrRedirectURIOpenRedirect,
+		domain.ErrRedirectURIInsecureScheme,
+		domain.ErrRedirectURIUnsupportedScheme,
+	} {
+		if errors.Is(err, target) {
+			return errors.WrapUsecaseError(http.StatusBadRequest, err)
+		}
+	}
+	return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+}
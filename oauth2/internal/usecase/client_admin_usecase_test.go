@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clientAdminFixture はメモリ上のクライアントと失効させた記録を持つ
+type clientAdminFixture struct {
+	clients          map[uuid.UUID]domain.Client
+	tokenRepo        *domain.TokenRepositoryMock
+	refreshTokenRepo *domain.RefreshTokenRepositoryMock
+	uc               IClientAdminUsecase
+}
+
+func newClientAdminFixture() *clientAdminFixture {
+	f := &clientAdminFixture{clients: map[uuid.UUID]domain.Client{}}
+	clientRepo := &domain.ClientRepositoryMock{
+		FindClientByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) (domain.Client, error) {
+			if c, ok := f.clients[clientID]; ok {
+				return c, nil
+			}
+			return domain.NewClient(domain.ClientParams{}), nil
+		},
+		FindClientsFunc: func(ctx context.Context, offset, limit int) ([]domain.Client, error) {
+			all := make([]domain.Client, 0, len(f.clients))
+			for _, c := range f.clients {
+				all = append(all, c)
+			}
+			if offset >= len(all) {
+				return nil, nil
+			}
+			return all[offset:min(offset+limit, len(all))], nil
+		},
+		CreateClientFunc: func(ctx context.Context, c domain.Client) error {
+			f.clients[c.GetID()] = c
+			return nil
+		},
+		UpdateClientFunc: func(ctx context.Context, c domain.Client) error {
+			f.clients[c.GetID()] = c
+			return nil
+		},
+		DeleteClientFunc: func(ctx context.Context, clientID uuid.UUID) error {
+			delete(f.clients, clientID)
+			return nil
+		},
+	}
+	scopeRepo := &domain.ScopeRepositoryMock{
+		FindScopesByNamesFunc: func(ctx context.Context, names []string) ([]domain.Scope, error) {
+			var scopes []domain.Scope
+			for _, n := range names {
+				if n == "read" || n == "write" {
+					scopes = append(scopes, domain.NewScope(domain.ScopeParams{Name: n}))
+				}
+			}
+			return scopes, nil
+		},
+	}
+	f.tokenRepo = &domain.TokenRepositoryMock{
+		RevokeTokensByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) error {
+			return nil
+		},
+	}
+	f.refreshTokenRepo = &domain.RefreshTokenRepositoryMock{
+		RevokeRefreshTokensByClientIDFunc: func(ctx context.Context, clientID uuid.UUID) error {
+			return nil
+		},
+	}
+	f.uc = NewClientAdminUsecase(clientRepo, scopeRepo, f.tokenRepo, f.refreshTokenRepo)
+	return f
+}
+
+var createClientParams = CreateClientParams{
+	Name:          "app",
+	RedirectURIs:  []string{"https://example.com/callback"},
+	AllowedScopes: []string{"read"},
+}
+
+func TestClientAdmin_CreateClient(t *testing.T) {
+	ctx := context.Background()
+	f := newClientAdminFixture()
+
+	c, secret, err := f.uc.CreateClient(ctx, createClientParams)
+	require.NoError(t, err)
+	require.NotEmpty(t, secret)
+	assert.True(t, c.IsConfidential())
+	assert.True(t, c.VerifySecret(secret))
+	assert.NotEqual(t, secret, c.GetSecretHash())
+	assert.Equal(t, domain.DefaultGrantTypes, c.GetGrantTypes())
+
+	p := createClientParams
+	p.Public = true
+	c, secret, err = f.uc.CreateClient(ctx, p)
+	require.NoError(t, err)
+	assert.Empty(t, secret)
+	assert.False(t, c.IsConfidential())
+}
+
+func TestClientAdmin_CreateClientInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *CreateClientParams)
+		want   error
+	}{
+		{"no name", func(p *CreateClientParams) { p.Name = "" }, domain.ErrClientNameRequired},
+		{"no redirect uri", func(p *CreateClientParams) { p.RedirectURIs = nil }, domain.ErrClientRedirectURIRequired},
+		{"invalid redirect uri", func(p *CreateClientParams) { p.RedirectURIs = []string{"http://example.com/cb"} }, domain.ErrRedirectURIInsecureScheme},
+		{"unsupported grant type", func(p *CreateClientParams) { p.GrantTypes = []string{"password"} }, domain.ErrClientGrantTypeUnsupported},
+		{"without authorization code", func(p *CreateClientParams) { p.GrantTypes = []string{domain.GrantTypeRefreshToken} }, domain.ErrClientGrantTypeRequired},
+		{"lifetime out of range", func(p *CreateClientParams) { p.AccessTokenExpiresMin = domain.MaxAccessTokenExpiresMin + 1 }, domain.ErrClientTokenLifetimeInvalid},
+		{"unknown scope", func(p *CreateClientParams) { p.AllowedScopes = []string{"read", "admin"} }, ErrUnknownScope},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newClientAdminFixture()
+			p := createClientParams
+			tt.modify(&p)
+
+			_, _, err := f.uc.CreateClient(context.Background(), p)
+			require.Error(t, err)
+			assert.Equal(t, http.StatusBadRequest, err.(*errors.UsecaseError).Code)
+			assert.ErrorIs(t, err, tt.want)
+			assert.Empty(t, f.clients)
+		})
+	}
+}
+
+func TestClientAdmin_ListClients(t *testing.T) {
+	ctx := context.Background()
+	f := newClientAdminFixture()
+	for range 3 {
+		_, _, err := f.uc.CreateClient(ctx, createClientParams)
+		require.NoError(t, err)
+	}
+
+	clients, more, err := f.uc.ListClients(ctx, 0, 2)
+	require.NoError(t, err)
+	assert.Len(t, clients, 2)
+	assert.True(t, more)
+
+	clients, more, err = f.uc.ListClients(ctx, 2, 2)
+	require.NoError(t, err)
+	assert.Len(t, clients, 1)
+	assert.False(t, more)
+}
+
+func TestClientAdmin_UpdateClient(t *testing.T) {
+	ctx := context.Background()
+	f := newClientAdminFixture()
+	c, _, err := f.uc.CreateClient(ctx, createClientParams)
+	require.NoError(t, err)
+
+	accessMin := 30
+	updated, err := f.uc.UpdateClient(ctx, c.GetID(), UpdateClientParams{
+		RedirectURIs:          []string{"https://example.com/a", "https://example.com/b"},
+		AllowedScopes:         []string{"read", "write"},
+		GrantTypes:            []string{domain.GrantTypeAuthorizationCode},
+		AccessTokenExpiresMin: &accessMin,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "app", updated.GetName())
+	assert.Len(t, updated.GetRedirectURIs(), 2)
+	assert.Equal(t, []string{"read", "write"}, updated.GetAllowedScopes().Names())
+	assert.False(t, updated.IsGrantTypeAllowed(domain.GrantTypeRefreshToken))
+	assert.Equal(t, 30, updated.GetAccessTokenExpiresMin())
+	assert.Equal(t, 0, updated.GetRefreshTokenExpiresDay())
+
+	empty := ""
+	_, err = f.uc.UpdateClient(ctx, c.GetID(), UpdateClientParams{Name: &empty})
+	assert.Equal(t, http.StatusBadRequest, err.(*errors.UsecaseError).Code)
+
+	_, err = f.uc.UpdateClient(ctx, uuid.New(), UpdateClientParams{})
+	assert.ErrorIs(t, err, ErrClientNotFound)
+}
+
+func TestClientAdmin_RotateClientSecret(t *testing.T) {
+	ctx := context.Background()
+	f := newClientAdminFixture()
+	c, oldSecret, err := f.uc.CreateClient(ctx, createClientParams)
+	require.NoError(t, err)
+
+	newSecret, err := f.uc.RotateClientSecret(ctx, c.GetID())
+	require.NoError(t, err)
+	stored := f.clients[c.GetID()]
+	assert.True(t, stored.VerifySecret(newSecret))
+	assert.False(t, stored.VerifySecret(oldSecret))
+	// 以前のシークレットで取得されたトークンも失効させる
+	require.Len(t, f.tokenRepo.RevokeTokensByClientIDCalls(), 1)
+	assert.Equal(t, c.GetID(), f.tokenRepo.RevokeTokensByClientIDCalls()[0].ClientID)
+	require.Len(t, f.refreshTokenRepo.RevokeRefreshTokensByClientIDCalls(), 1)
+
+	p := createClientParams
+	p.Public = true
+	public, _, err := f.uc.CreateClient(ctx, p)
+	require.NoError(t, err)
+	_, err = f.uc.RotateClientSecret(ctx, public.GetID())
+	assert.ErrorIs(t, err, ErrPublicClientSecret)
+}
+
+func TestClientAdmin_DisableClient(t *testing.T) {
+	ctx := context.Background()
+	f := newClientAdminFixture()
+	c, _, err := f.uc.CreateClient(ctx, createClientParams)
+	require.NoError(t, err)
+
+	require.NoError(t, f.uc.DisableClient(ctx, c.GetID()))
+	assert.True(t, f.clients[c.GetID()].IsDisabled())
+	require.Len(t, f.tokenRepo.RevokeTokensByClientIDCalls(), 1)
+	assert.Equal(t, c.GetID(), f.tokenRepo.RevokeTokensByClientIDCalls()[0].ClientID)
+	require.Len(t, f.refreshTokenRepo.RevokeRefreshTokensByClientIDCalls(), 1)
+
+	// 無効にした日時は変えない
+	disabledAt := f.clients[c.GetID()].GetDisabledAt()
+	require.NoError(t, f.uc.DisableClient(ctx, c.GetID()))
+	assert.Equal(t, disabledAt, f.clients[c.GetID()].GetDisabledAt())
+}
+
+func TestClientAdmin_DeleteClient(t *testing.T) {
+	ctx := context.Background()
+	f := newClientAdminFixture()
+	c, _, err := f.uc.CreateClient(ctx, createClientParams)
+	require.NoError(t, err)
+
+	require.NoError(t, f.uc.DeleteClient(ctx, c.GetID()))
+	assert.Empty(t, f.clients)
+
+	err = f.uc.DeleteClient(ctx, c.GetID())
+	assert.ErrorIs(t, err, ErrClientNotFound)
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+// ErrCredentialNotFound は検証器がそのメールアドレスの利用者を扱っていないことを表す。次の検証器に任せる
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// ディレクトリで認証できたが、サインインを許可するグループに所属していない
+var ErrDirectoryGroupRequired = errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "you are not allowed to sign in", signinURI)
+
+// 管理者がパスワードの再設定を求めたユーザーが、再設定前のパスワードでサインインしようとした
+var ErrPasswordResetRequired = errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "your password must be reset; use the link sent to your email", forgotPasswordURI)
+
+// CredentialVerifier はメールアドレスとパスワードを確認し、ローカルのユーザーを返す。
+// 利用者を扱っていない場合は ErrCredentialNotFound、パスワードが誤っている場合は ErrUserOrPasswordNotMatch を返す
+type CredentialVerifier interface {
+	VerifyCredential(ctx context.Context, email, password string) (domain.User, error)
+}
+
+// NewPasswordVerifier はローカルのユーザーのパスワードのハッシュと照合する検証器を返す
+func NewPasswordVerifier(userRepo domain.UserRepository, hasher domain.PasswordHasher) CredentialVerifier {
+	return &passwordVerifier{
+		userRepo: userRepo,
+		hasher:   hasher,
+	}
+}
+
+type passwordVerifier struct {
+	userRepo domain.UserRepository
+	hasher   domain.PasswordHasher
+}
+
+func (v *passwordVerifier) VerifyCredential(ctx context.Context, email, password string) (domain.User, error) {
+	user, err := v.userRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	// パスワードを持たないユーザーはディレクトリや上流のプロバイダで認証する
+	if user.IsNotFound() || user.GetPassword() == "" {
+		return nil, ErrCredentialNotFound
+	}
+
+	// パスワードを比較して認証
+	if !user.IsPasswordMatch(v.hasher, password) {
+		return nil, ErrUserOrPasswordNotMatch
+	}
+
+	// 管理者が再設定を求めたパスワードは、正しくても使わせない
+	if user.IsPasswordResetRequired() {
+		return nil, ErrPasswordResetRequired
+	}
+
+	// 古いアルゴリズムやパラメータのハッシュは、平文のパスワードが分かるこの時点で作り直す。
+	// パスワード自体は変わらないため、規則を満たさなくても作り直す
+	if v.hasher.NeedsRehash(user.GetPassword()) {
+		newHash, err := v.hasher.Hash(password)
+		if err != nil {
+			return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+		// 同時にパスワードが変更された場合は、そちらを優先する
+		if _, err := v.userRepo.UpdatePasswordHash(ctx, user.GetID(), user.GetPassword(), newHash); err != nil {
+			return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return user, nil
+}
+
+type DirectoryVerifierParams struct {
+	// Name は紐づけを保存するときのプロバイダ名
+	Name      string
+	Directory domain.Directory
+	// RequiredGroups が空でない場合は、いずれかのグループに所属する利用者のみサインインさせる
+	RequiredGroups []string
+	// LinkByEmail が true の場合は、メールアドレスが一致する既存のユーザーに紐づける
+	LinkByEmail bool
+}
+
+// NewDirectoryVerifier は LDAP などのディレクトリで認証する検証器を返す。
+// 初めてサインインした利用者はローカルのユーザーを作り、以降は名前とメールアドレスをディレクトリに合わせる
+func NewDirectoryVerifier(
+	userRepo domain.UserRepository,
+	identityRepo domain.FederatedIdentityRepository,
+	p DirectoryVerifierParams,
+) CredentialVerifier {
+	return &directoryVerifier{
+		userRepo:  userRepo,
+		linker:    identityLinker{userRepo: userRepo, identityRepo: identityRepo},
+		name:      p.Name,
+		directory: p.Directory,
+		groups:    p.RequiredGroups,
+		linkEmail: p.LinkByEmail,
+	}
+}
+
+type directoryVerifier struct {
+	userRepo  domain.UserRepository
+	linker    identityLinker
+	name      string
+	directory domain.Directory
+	groups    []string
+	linkEmail bool
+}
+
+func (v *directoryVerifier) VerifyCredential(ctx context.Context, email, password string) (domain.User, error) {
+	entry, err := v.directory.Authenticate(ctx, email, password)
+	switch {
+	case errors.Is(err, domain.ErrDirectoryUserNotFound):
+		return nil, ErrCredentialNotFound
+	case errors.Is(err, domain.ErrDirectoryInvalidCredentials):
+		return nil, ErrUserOrPasswordNotMatch
+	case err != nil:
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	if !entry.IsMemberOfAny(v.groups) {
+		return nil, ErrDirectoryGroupRequired
+	}
+
+	// ディレクトリのメールアドレスは管理者が登録したものとして確認済みとする
+	user, err := v.linker.signin(ctx, externalIdentity{
+		Provider:      v.name,
+		Subject:       entry.ID,
+		Email:         entry.Email,
+		EmailVerified: true,
+		Name:          entry.Name,
+	}, v.linkEmail)
+	if err != nil {
+		return nil, err
+	}
+	return v.sync(ctx, user, entry)
+}
+
+// sync はディレクトリで変更された名前とメールアドレスをローカルのユーザーに反映する
+func (v *directoryVerifier) sync(ctx context.Context, user domain.User, entry *domain.DirectoryEntry) (domain.User, error) {
+	name := displayName(entry.Name, entry.Email)
+	email := entry.Email
+	if email == "" {
+		email = user.GetEmail()
+	}
+	if name == user.GetName() && email == user.GetEmail() {
+		return user, nil
+	}
+
+	// ディレクトリのメールアドレスは確認済みとして扱うため、確認した日時は変えない
+	if err := v.userRepo.UpdateProfile(ctx, user.GetID(), name, email, user.GetEmailVerifiedAt()); err != nil {
+		if errors.Is(err, domain.ErrUserEmailAlreadyExists) {
+			return nil, ErrFederatedEmailInUse
+		}
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return v.linker.findUser(ctx, user.GetID())
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// directoryFixture はディレクトリのモックとメモリ上のユーザーと紐づけを持つ
+type directoryFixture struct {
+	entries    map[string]domain.DirectoryEntry
+	users      map[uuid.UUID]domain.User
+	identities map[string]domain.FederatedIdentity
+	userRepo   *domain.UserRepositoryMock
+	directory  *domain.DirectoryMock
+}
+
+func newDirectoryFixture() *directoryFixture {
+	f := &directoryFixture{
+		entries:    map[string]domain.DirectoryEntry{},
+		users:      map[uuid.UUID]domain.User{},
+		identities: map[string]domain.FederatedIdentity{},
+	}
+	f.directory = &domain.DirectoryMock{
+		AuthenticateFunc: func(ctx context.Context, login, password string) (*domain.DirectoryEntry, error) {
+			e, ok := f.entries[login]
+			if !ok {
+				return nil, domain.ErrDirectoryUserNotFound
+			}
+			if password != "directory-secret" {
+				return nil, domain.ErrDirectoryInvalidCredentials
+			}
+			return &e, nil
+		},
+	}
+	f.userRepo = &domain.UserRepositoryMock{
+		FindUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
+			for _, u := range f.users {
+				if u.GetEmail() == email {
+					return u, nil
+				}
+			}
+			return domain.NewUser(domain.UserParams{}), nil
+		},
+		FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			if u, ok := f.users[id]; ok {
+				return u, nil
+			}
+			return domain.NewUser(domain.UserParams{}), nil
+		},
+		CreateUserFunc: func(ctx context.Context, u domain.User) (domain.User, error) {
+			created := domain.NewUser(domain.UserParams{ID: uuid.New(), Name: u.GetName(), Email: u.GetEmail()})
+			f.users[created.GetID()] = created
+			return created, nil
+		},
+		VerifyEmailFunc: func(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+			u := f.users[id]
+			f.users[id] = domain.NewUser(domain.UserParams{ID: id, Name: u.GetName(), Email: u.GetEmail(), EmailVerifiedAt: &verifiedAt})
+			return nil
+		},
+		UpdateProfileFunc: func(ctx context.Context, id uuid.UUID, name, email string, emailVerifiedAt *time.Time) error {
+			u := f.users[id]
+			f.users[id] = domain.NewUser(domain.UserParams{ID: id, Name: name, Email: email, Password: u.GetPassword(), EmailVerifiedAt: emailVerifiedAt})
+			return nil
+		},
+	}
+	return f
+}
+
+func (f *directoryFixture) usecase(t *testing.T, p DirectoryVerifierParams) IAuthenticationUsecase {
+	t.Helper()
+	identityRepo := &domain.FederatedIdentityRepositoryMock{
+		FindFederatedIdentityFunc: func(ctx context.Context, provider, subject string) (domain.FederatedIdentity, error) {
+			return f.identities[provider+"/"+subject], nil
+		},
+		StoreFederatedIdentityFunc: func(ctx context.Context, fi domain.FederatedIdentity) error {
+			f.identities[fi.GetProvider()+"/"+fi.GetSubject()] = fi
+			return nil
+		},
+		TouchFederatedIdentityFunc: func(ctx context.Context, provider, subject, email string, usedAt time.Time) error {
+			return nil
+		},
+	}
+	p.Name = "ldap"
+	p.Directory = f.directory
+	verifier := NewDirectoryVerifier(f.userRepo, identityRepo, p)
+	return NewAuthenticationUsecase(f.userRepo, nil, nil, newTestHasher(t), newTestPolicy(), verifier)
+}
+
+func (f *directoryFixture) addLocalUser(t *testing.T, email, password string) domain.User {
+	t.Helper()
+	var hash string
+	if password != "" {
+		var err error
+		hash, err = newTestHasher(t).Hash(password)
+		require.NoError(t, err)
+	}
+	u := domain.NewUser(domain.UserParams{ID: uuid.New(), Name: "Local", Email: email, Password: hash})
+	f.users[u.GetID()] = u
+	return u
+}
+
+func TestDirectoryVerifier_ProvisionAndSync(t *testing.T) {
+	ctx := context.Background()
+	f := newDirectoryFixture()
+	f.entries["alice@example.com"] = domain.DirectoryEntry{ID: "uuid-1", Name: "Alice", Email: "alice@example.com", Groups: []string{"staff"}}
+	uc := f.usecase(t, DirectoryVerifierParams{})
+
+	user, err := uc.AuthenticateUser(ctx, "alice@example.com", "directory-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", user.GetName())
+	assert.True(t, user.IsEmailVerified())
+	assert.Empty(t, user.GetPassword())
+	require.Contains(t, f.identities, "ldap/uuid-1")
+
+	// ディレクトリで変更された名前とメールアドレスを反映する
+	f.entries["alice@example.com"] = domain.DirectoryEntry{ID: "uuid-1", Name: "Alice Liddell", Email: "alice@corp.example.com"}
+	again, err := uc.AuthenticateUser(ctx, "alice@example.com", "directory-secret")
+	require.NoError(t, err)
+	assert.Equal(t, user.GetID(), again.GetID())
+	assert.Equal(t, "Alice Liddell", again.GetName())
+	assert.Equal(t, "alice@corp.example.com", again.GetEmail())
+	assert.Len(t, f.userRepo.CreateUserCalls(), 1)
+
+	// 変更がなければ更新しない
+	_, err = uc.AuthenticateUser(ctx, "alice@example.com", "directory-secret")
+	require.NoError(t, err)
+	assert.Len(t, f.userRepo.UpdateProfileCalls(), 1)
+}
+
+func TestDirectoryVerifier_LocalPasswordFirst(t *testing.T) {
+	ctx := context.Background()
+	f := newDirectoryFixture()
+	f.addLocalUser(t, "alice@example.com", "local-password")
+	f.entries["alice@example.com"] = domain.DirectoryEntry{ID: "uuid-1", Email: "alice@example.com"}
+	uc := f.usecase(t, DirectoryVerifierParams{})
+
+	_, err := uc.AuthenticateUser(ctx, "alice@example.com", "local-password")
+	require.NoError(t, err)
+
+	// パスワードを持つローカルのユーザーはディレクトリのパスワードでサインインできない
+	_, err = uc.AuthenticateUser(ctx, "alice@example.com", "directory-secret")
+	assert.ErrorIs(t, err, ErrUserOrPasswordNotMatch)
+	assert.Empty(t, f.directory.AuthenticateCalls())
+}
+
+func TestDirectoryVerifier_Rejected(t *testing.T) {
+	ctx := context.Background()
+	f := newDirectoryFixture()
+	f.entries["alice@example.com"] = domain.DirectoryEntry{ID: "uuid-1", Email: "alice@example.com", Groups: []string{"Sales"}}
+	uc := f.usecase(t, DirectoryVerifierParams{RequiredGroups: []string{"engineering", "sales"}})
+
+	_, err := uc.AuthenticateUser(ctx, "alice@example.com", "wrong")
+	assert.ErrorIs(t, err, ErrUserOrPasswordNotMatch)
+
+	// どの検証器も扱っていない利用者
+	_, err = uc.AuthenticateUser(ctx, "bob@example.com", "directory-secret")
+	assert.ErrorIs(t, err, ErrUserOrPasswordNotMatch)
+
+	// グループの名前は大文字と小文字を区別しない
+	_, err = uc.AuthenticateUser(ctx, "alice@example.com", "directory-secret")
+	require.NoError(t, err)
+
+	f.entries["alice@example.com"] = domain.DirectoryEntry{ID: "uuid-1", Email: "alice@example.com", Groups: []string{"guests"}}
+	_, err = uc.AuthenticateUser(ctx, "alice@example.com", "directory-secret")
+	assert.ErrorIs(t, err, ErrDirectoryGroupRequired)
+}
+
+func TestDirectoryVerifier_ExistingEmail(t *testing.T) {
+	ctx := context.Background()
+	f := newDirectoryFixture()
+	// 上流のプロバイダで作られた、パスワードを持たないユーザー
+	existing := f.addLocalUser(t, "alice@example.com", "")
+	f.entries["alice@example.com"] = domain.DirectoryEntry{ID: "uuid-1", Name: "Alice", Email: "alice@example.com"}
+
+	_, err := f.usecase(t, DirectoryVerifierParams{}).AuthenticateUser(ctx, "alice@example.com", "directory-secret")
+	assert.ErrorIs(t, err, ErrFederatedEmailInUse)
+	assert.Empty(t, f.identities)
+
+	user, err := f.usecase(t, DirectoryVerifierParams{LinkByEmail: true}).AuthenticateUser(ctx, "alice@example.com", "directory-secret")
+	require.NoError(t, err)
+	assert.Equal(t, existing.GetID(), user.GetID())
+	assert.Equal(t, "Alice", user.GetName())
+	assert.Empty(t, f.userRepo.CreateUserCalls())
+}
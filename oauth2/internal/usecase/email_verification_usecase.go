@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewEmailVerificationUsecase(
+	userRepo domain.UserRepository,
+	tokenRepo domain.EmailVerificationTokenRepository,
+	mailSender domain.MailSender,
+	cfg *config.Config,
+) IEmailVerificationUsecase {
+	return &EmailVerificationUsecase{
+		userRepo:   userRepo,
+		tokenRepo:  tokenRepo,
+		mailSender: mailSender,
+		config:     cfg,
+		policy: domain.EmailVerificationPolicy{
+			RequireForSignin: cfg.EmailVerificationSignin,
+			RequiredScopes:   domain.NewScopeSet(cfg.EmailVerificationScopes...),
+		},
+	}
+}
+
+type IEmailVerificationUsecase interface {
+	SendVerificationEmail(ctx context.Context, userID string) error
+	VerifyEmail(ctx context.Context, token string) error
+	CheckSignin(ctx context.Context, user domain.User) error
+	CheckScopes(ctx context.Context, userID, scope string) error
+}
+
+type EmailVerificationUsecase struct {
+	userRepo   domain.UserRepository
+	tokenRepo  domain.EmailVerificationTokenRepository
+	mailSender domain.MailSender
+	config     *config.Config
+	policy     domain.EmailVerificationPolicy
+}
+
+// SendVerificationEmail は確認用のリンクをメールで送る。短時間に繰り返し送ることはできない
+func (uc *EmailVerificationUsecase) SendVerificationEmail(ctx context.Context, userID string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+
+	user, err := uc.userRepo.FindUserByID(ctx, id)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if user.IsNotFound() {
+		return errors.NewUsecaseError(http.StatusBadRequest, "user not found")
+	}
+	if user.IsEmailVerified() {
+		return errors.NewUsecaseError(http.StatusBadRequest, "email is already verified")
+	}
+
+	now := time.Now()
+	interval := time.Duration(uc.config.EmailVerificationInterval) * time.Second
+	sent, err := uc.tokenRepo.CountEmailVerificationTokensSince(ctx, id, now.Add(-interval))
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if sent > 0 {
+		return errors.NewUsecaseError(http.StatusTooManyRequests, "verification email was sent recently")
+	}
+
+	expires := time.Duration(uc.config.EmailVerificationExpires) * time.Second
+	t, token, err := domain.IssueEmailVerificationToken(id, expires)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.tokenRepo.StoreEmailVerificationToken(ctx, t); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	link := fmt.Sprintf("%s/client/verify-email/confirm?token=%s", uc.config.BaseURL, url.QueryEscape(token))
+	if err := uc.mailSender.Send(ctx, domain.Mail{
+		To:      user.GetEmail(),
+		Subject: "Verify your email address",
+		Body:    fmt.Sprintf("Open the link below to verify your email address.\n\n%s\n\nThe link expires at %s.", link, t.GetExpiresAt().Format(time.RFC3339)),
+	}); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	return nil
+}
+
+// VerifyEmail はリンクのトークンを検証し、メールアドレスを確認済みにする。トークンは一度しか使えない
+func (uc *EmailVerificationUsecase) VerifyEmail(ctx context.Context, token string) error {
+	hash := domain.HashOpaqueToken(token)
+
+	t, err := uc.tokenRepo.FindEmailVerificationToken(ctx, hash)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if t == nil {
+		return errors.NewUsecaseError(http.StatusBadRequest, "invalid verification token")
+	}
+	if t.IsUsed() {
+		return errors.NewUsecaseError(http.StatusBadRequest, "verification token has already been used")
+	}
+	if t.IsExpired(time.Now()) {
+		return errors.NewUsecaseError(http.StatusBadRequest, "verification token has expired")
+	}
+
+	consumed, err := uc.tokenRepo.ConsumeEmailVerificationToken(ctx, hash)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if !consumed {
+		return errors.NewUsecaseError(http.StatusBadRequest, "verification token has already been used")
+	}
+
+	if err := uc.userRepo.VerifyEmail(ctx, t.GetUserID(), time.Now()); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	return nil
+}
+
+// CheckSignin は確認していないユーザーのサインインを制限する
+func (uc *EmailVerificationUsecase) CheckSignin(_ context.Context, user domain.User) error {
+	if uc.policy.BlocksSignin(user) {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// CheckScopes は確認していないユーザーに制限されたスコープが要求されていないかを確認する
+func (uc *EmailVerificationUsecase) CheckScopes(ctx context.Context, userID, scope string) error {
+	if uc.policy.RequiredScopes.IsEmpty() {
+		return nil
+	}
+
+	requested, err := domain.ParseScope(scope)
+	if err != nil {
+		return ErrInvalidScope
+	}
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+
+	user, err := uc.userRepo.FindUserByID(ctx, id)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if user.IsNotFound() {
+		return errors.NewUsecaseError(http.StatusBadRequest, "user not found")
+	}
+
+	if !uc.policy.BlockedScopes(user, requested).IsEmpty() {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
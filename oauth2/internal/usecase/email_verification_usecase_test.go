@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEmailVerificationConfig() *config.Config {
+	return &config.Config{
+		BaseURL:                   "https://auth.example.com",
+		EmailVerificationExpires:  3600,
+		EmailVerificationInterval: 60,
+		EmailVerificationScopes:   []string{"write"},
+	}
+}
+
+func newVerificationUserRepo(userID uuid.UUID, verifiedAt *time.Time) *domain.UserRepositoryMock {
+	return &domain.UserRepositoryMock{
+		FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return domain.NewUser(domain.UserParams{
+				ID:              userID,
+				Email:           "user@example.com",
+				EmailVerifiedAt: verifiedAt,
+			}), nil
+		},
+		VerifyEmailFunc: func(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+			return nil
+		},
+	}
+}
+
+func TestSendVerificationEmail_Success(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUserRepo := newVerificationUserRepo(userID, nil)
+	mockTokenRepo := &domain.EmailVerificationTokenRepositoryMock{
+		CountEmailVerificationTokensSinceFunc: func(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+			return 0, nil
+		},
+		StoreEmailVerificationTokenFunc: func(ctx context.Context, t domain.EmailVerificationToken) error {
+			return nil
+		},
+	}
+	mockSender := &domain.MailSenderMock{
+		SendFunc: func(ctx context.Context, m domain.Mail) error {
+			return nil
+		},
+	}
+
+	uc := NewEmailVerificationUsecase(mockUserRepo, mockTokenRepo, mockSender, newEmailVerificationConfig())
+	err := uc.SendVerificationEmail(ctx, userID.String())
+	require.NoError(t, err)
+
+	require.Len(t, mockSender.SendCalls(), 1)
+	m := mockSender.SendCalls()[0].M
+	assert.Equal(t, "user@example.com", m.To)
+
+	// メール本文のリンクに含まれるトークンのハッシュが保存されている
+	prefix := "https://auth.example.com/client/verify-email/confirm?token="
+	start := strings.Index(m.Body, prefix)
+	require.GreaterOrEqual(t, start, 0)
+	rest := m.Body[start+len(prefix):]
+	token, err := url.QueryUnescape(strings.Fields(rest)[0])
+	require.NoError(t, err)
+
+	require.Len(t, mockTokenRepo.StoreEmailVerificationTokenCalls(), 1)
+	stored := mockTokenRepo.StoreEmailVerificationTokenCalls()[0].T
+	assert.Equal(t, domain.HashOpaqueToken(token), stored.GetTokenHash())
+	assert.NotEqual(t, token, stored.GetTokenHash())
+	assert.Equal(t, userID, stored.GetUserID())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), stored.GetExpiresAt(), time.Minute)
+}
+
+func TestSendVerificationEmail_RateLimited(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockTokenRepo := &domain.EmailVerificationTokenRepositoryMock{
+		CountEmailVerificationTokensSinceFunc: func(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+			return 1, nil
+		},
+	}
+	mockSender := &domain.MailSenderMock{}
+
+	uc := NewEmailVerificationUsecase(newVerificationUserRepo(userID, nil), mockTokenRepo, mockSender, newEmailVerificationConfig())
+	err := uc.SendVerificationEmail(ctx, userID.String())
+
+	var usecaseErr *errors.UsecaseError
+	require.ErrorAs(t, err, &usecaseErr)
+	assert.Equal(t, http.StatusTooManyRequests, usecaseErr.Code)
+	assert.WithinDuration(t, time.Now().Add(-time.Minute), mockTokenRepo.CountEmailVerificationTokensSinceCalls()[0].Since, 5*time.Second)
+	assert.Empty(t, mockSender.SendCalls())
+}
+
+func TestSendVerificationEmail_AlreadyVerified(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	verifiedAt := time.Now()
+
+	mockTokenRepo := &domain.EmailVerificationTokenRepositoryMock{}
+	mockSender := &domain.MailSenderMock{}
+
+	uc := NewEmailVerificationUsecase(newVerificationUserRepo(userID, &verifiedAt), mockTokenRepo, mockSender, newEmailVerificationConfig())
+	err := uc.SendVerificationEmail(ctx, userID.String())
+
+	var usecaseErr *errors.UsecaseError
+	require.ErrorAs(t, err, &usecaseErr)
+	assert.Equal(t, http.StatusBadRequest, usecaseErr.Code)
+	assert.Empty(t, mockTokenRepo.StoreEmailVerificationTokenCalls())
+	assert.Empty(t, mockSender.SendCalls())
+}
+
+func newStoredVerificationToken(userID uuid.UUID, token string, expiresAt time.Time, usedAt *time.Time) domain.EmailVerificationToken {
+	return domain.NewEmailVerificationToken(domain.EmailVerificationTokenParams{
+		TokenHash: domain.HashOpaqueToken(token),
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		UsedAt:    usedAt,
+	})
+}
+
+func TestVerifyEmail_Success(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUserRepo := newVerificationUserRepo(userID, nil)
+	mockTokenRepo := &domain.EmailVerificationTokenRepositoryMock{
+		FindEmailVerificationTokenFunc: func(ctx context.Context, tokenHash string) (domain.EmailVerificationToken, error) {
+			return newStoredVerificationToken(userID, "token", time.Now().Add(time.Hour), nil), nil
+		},
+		ConsumeEmailVerificationTokenFunc: func(ctx context.Context, tokenHash string) (bool, error) {
+			return true, nil
+		},
+	}
+
+	uc := NewEmailVerificationUsecase(mockUserRepo, mockTokenRepo, &domain.MailSenderMock{}, newEmailVerificationConfig())
+	err := uc.VerifyEmail(ctx, "token")
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.HashOpaqueToken("token"), mockTokenRepo.FindEmailVerificationTokenCalls()[0].TokenHash)
+	assert.Equal(t, domain.HashOpaqueToken("token"), mockTokenRepo.ConsumeEmailVerificationTokenCalls()[0].TokenHash)
+	require.Len(t, mockUserRepo.VerifyEmailCalls(), 1)
+	assert.Equal(t, userID, mockUserRepo.VerifyEmailCalls()[0].ID)
+}
+
+func TestVerifyEmail_InvalidToken(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := newVerificationUserRepo(uuid.New(), nil)
+	mockTokenRepo := &domain.EmailVerificationTokenRepositoryMock{
+		FindEmailVerificationTokenFunc: func(ctx context.Context, tokenHash string) (domain.EmailVerificationToken, error) {
+			return nil, nil
+		},
+	}
+
+	uc := NewEmailVerificationUsecase(mockUserRepo, mockTokenRepo, &domain.MailSenderMock{}, newEmailVerificationConfig())
+	err := uc.VerifyEmail(ctx, "unknown")
+
+	var usecaseErr *errors.UsecaseError
+	require.ErrorAs(t, err, &usecaseErr)
+	assert.Equal(t, http.StatusBadRequest, usecaseErr.Code)
+	assert.Empty(t, mockUserRepo.VerifyEmailCalls())
+}
+
+func TestVerifyEmail_UsedToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	usedAt := time.Now().Add(-time.Minute)
+
+	mockUserRepo := newVerificationUserRepo(userID, nil)
+	mockTokenRepo := &domain.EmailVerificationTokenRepositoryMock{
+		FindEmailVerificationTokenFunc: func(ctx context.Context, tokenHash string) (domain.EmailVerificationToken, error) {
+			return newStoredVerificationToken(userID, "token", time.Now().Add(time.Hour), &usedAt), nil
+		},
+	}
+
+	uc := NewEmailVerificationUsecase(mockUserRepo, mockTokenRepo, &domain.MailSenderMock{}, newEmailVerificationConfig())
+	err := uc.VerifyEmail(ctx, "token")
+
+	var usecaseErr *errors.UsecaseError
+	require.ErrorAs(t, err, &usecaseErr)
+	assert.Equal(t, http.StatusBadRequest, usecaseErr.Code)
+	assert.Empty(t, mockTokenRepo.ConsumeEmailVerificationTokenCalls())
+	assert.Empty(t, mockUserRepo.VerifyEmailCalls())
+}
+
+func TestVerifyEmail_ExpiredToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUserRepo := newVerificationUserRepo(userID, nil)
+	mockTokenRepo := &domain.EmailVerificationTokenRepositoryMock{
+		FindEmailVerificationTokenFunc: func(ctx context.Context, tokenHash string) (domain.EmailVerificationToken, error) {
+			return newStoredVerificationToken(userID, "token", time.Now().Add(-time.Second), nil), nil
+		},
+	}
+
+	uc := NewEmailVerificationUsecase(mockUserRepo, mockTokenRepo, &domain.MailSenderMock{}, newEmailVerificationConfig())
+	err := uc.VerifyEmail(ctx, "token")
+
+	var usecaseErr *errors.UsecaseError
+	require.ErrorAs(t, err, &usecaseErr)
+	assert.Equal(t, http.StatusBadRequest, usecaseErr.Code)
+	assert.Empty(t, mockTokenRepo.ConsumeEmailVerificationTokenCalls())
+	assert.Empty(t, mockUserRepo.VerifyEmailCalls())
+}
+
+func TestVerifyEmail_ConsumedConcurrently(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUserRepo := newVerificationUserRepo(userID, nil)
+	mockTokenRepo := &domain.EmailVerificationTokenRepositoryMock{
+		FindEmailVerificationTokenFunc: func(ctx context.Context, tokenHash string) (domain.EmailVerificationToken, error) {
+			return newStoredVerificationToken(userID, "token", time.Now().Add(time.Hour), nil), nil
+		},
+		ConsumeEmailVerificationTokenFunc: func(ctx context.Context, tokenHash string) (bool, error) {
+			return false, nil
+		},
+	}
+
+	uc := NewEmailVerificationUsecase(mockUserRepo, mockTokenRepo, &domain.MailSenderMock{}, newEmailVerificationConfig())
+	err := uc.VerifyEmail(ctx, "token")
+
+	var usecaseErr *errors.UsecaseError
+	require.ErrorAs(t, err, &usecaseErr)
+	assert.Equal(t, http.StatusBadRequest, usecaseErr.Code)
+	assert.Empty(t, mockUserRepo.VerifyEmailCalls())
+}
+
+func TestCheckSignin(t *testing.T) {
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	unverified := domain.NewUser(domain.UserParams{ID: uuid.New()})
+	verified := domain.NewUser(domain.UserParams{ID: uuid.New(), EmailVerifiedAt: &verifiedAt})
+
+	cfg := newEmailVerificationConfig()
+	uc := NewEmailVerificationUsecase(&domain.UserRepositoryMock{}, &domain.EmailVerificationTokenRepositoryMock{}, &domain.MailSenderMock{}, cfg)
+	assert.NoError(t, uc.CheckSignin(ctx, unverified))
+
+	cfg.EmailVerificationSignin = true
+	uc = NewEmailVerificationUsecase(&domain.UserRepositoryMock{}, &domain.EmailVerificationTokenRepositoryMock{}, &domain.MailSenderMock{}, cfg)
+	assert.ErrorIs(t, uc.CheckSignin(ctx, unverified), ErrEmailNotVerified)
+	assert.NoError(t, uc.CheckSignin(ctx, verified))
+}
+
+func TestCheckScopes(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	verifiedAt := time.Now()
+
+	uc := NewEmailVerificationUsecase(newVerificationUserRepo(userID, nil), &domain.EmailVerificationTokenRepositoryMock{}, &domain.MailSenderMock{}, newEmailVerificationConfig())
+	assert.NoError(t, uc.CheckScopes(ctx, userID.String(), "read"))
+	assert.ErrorIs(t, uc.CheckScopes(ctx, userID.String(), "read write"), ErrEmailNotVerified)
+
+	uc = NewEmailVerificationUsecase(newVerificationUserRepo(userID, &verifiedAt), &domain.EmailVerificationTokenRepositoryMock{}, &domain.MailSenderMock{}, newEmailVerificationConfig())
+	assert.NoError(t, uc.CheckScopes(ctx, userID.String(), "read write"))
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"net/http"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+// OAuth 2.0 のエラーコードとしてクライアントに返すエラー (RFC 6749 4.1.2.1, 5.2)
+var (
+	ErrInvalidScope = errors.NewUsecaseError(http.StatusBadRequest, "invalid_scope")
+)
+
+// サインインに失敗した。ユーザーの有無やロックの状態が分からないよう、理由によらず同じエラーを返す
+var ErrUserOrPasswordNotMatch = errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "user or password not match", "client/signin")
+
+// メールアドレスを確認していないユーザーに対する制限 (EmailVerificationPolicy)
+var ErrEmailNotVerified = errors.NewUsecaseError(http.StatusForbidden, "email is not verified")
+
+// 管理者が無効にしたユーザーのトークンは発行も更新もしない
+var ErrUserDisabled = errors.NewUsecaseError(http.StatusForbidden, "user is disabled")
+
+// 二要素認証のコードが一致しない
+var ErrInvalidMFACode = errors.NewUsecaseError(http.StatusBadRequest, "invalid authentication code")
+
+// パスキーの応答を検証できない。理由はクライアントに伝えない
+var ErrInvalidPasskey = errors.NewUsecaseError(http.StatusBadRequest, "passkey verification failed")
+
+// passwordPolicyError は規則を満たさないパスワードを入力画面に戻し、それ以外のエラーは 500 にする。
+// 画面で理由を翻訳できるよう、元のエラーを残す
+func passwordPolicyError(err error, redirectURI string) error {
+	var policyErr *domain.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		return errors.WrapUsecaseErrorWithRedirectURI(http.StatusFound, policyErr, redirectURI)
+	}
+	return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+}
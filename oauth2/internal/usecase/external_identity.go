@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+// 紐づけていない既存のユーザーと同じメールアドレスが返された。乗っ取りを防ぐため自動では紐づけない
+var ErrFederatedEmailInUse = errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "an account with this email address already exists; sign in with your password", signinURI)
+
+// 上流が確認していないメールアドレスが返された。他人のアドレスでアカウントを作ったり紐づけたりできないよう断る
+var ErrFederatedEmailUnverified = errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "the identity provider has not verified your email address", signinURI)
+
+// externalIdentity は上流のプロバイダやディレクトリで認証できた利用者
+type externalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// identityLinker は外部の利用者をローカルのユーザーに紐づける
+type identityLinker struct {
+	userRepo     domain.UserRepository
+	identityRepo domain.FederatedIdentityRepository
+}
+
+// signin は紐づけたユーザーを返す。紐づけがない場合は、linkByEmail が true であれば
+// メールアドレスの一致する既存のユーザーに紐づけ、いなければ新しいユーザーを作る
+func (l identityLinker) signin(ctx context.Context, ext externalIdentity, linkByEmail bool) (domain.User, error) {
+	now := time.Now()
+	identity, err := l.identityRepo.FindFederatedIdentity(ctx, ext.Provider, ext.Subject)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if identity != nil {
+		return l.signinLinkedUser(ctx, identity, ext, now)
+	}
+
+	user, err := l.linkOrProvision(ctx, ext, linkByEmail)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.identityRepo.StoreFederatedIdentity(ctx, domain.NewFederatedIdentity(domain.FederatedIdentityParams{
+		Provider:   ext.Provider,
+		Subject:    ext.Subject,
+		UserID:     user.GetID(),
+		Email:      ext.Email,
+		CreatedAt:  now,
+		LastUsedAt: &now,
+	})); err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return user, nil
+}
+
+func (l identityLinker) signinLinkedUser(ctx context.Context, identity domain.FederatedIdentity, ext externalIdentity, now time.Time) (domain.User, error) {
+	user, err := l.userRepo.FindUserByID(ctx, identity.GetUserID())
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if user.IsNotFound() || user.IsDisabled() {
+		return nil, ErrFederationFailed
+	}
+	if err := l.identityRepo.TouchFederatedIdentity(ctx, identity.GetProvider(), identity.GetSubject(), ext.Email, now); err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return user, nil
+}
+
+// linkOrProvision は初めてサインインした外部の利用者のローカルのユーザーを決める
+func (l identityLinker) linkOrProvision(ctx context.Context, ext externalIdentity, linkByEmail bool) (domain.User, error) {
+	if ext.Email == "" {
+		return nil, errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "the identity provider did not return an email address", signinURI)
+	}
+	if !ext.EmailVerified {
+		return nil, ErrFederatedEmailUnverified
+	}
+
+	existing, err := l.userRepo.FindUserByEmail(ctx, ext.Email)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if !existing.IsNotFound() {
+		if existing.IsDisabled() {
+			return nil, ErrFederationFailed
+		}
+		if linkByEmail {
+			return existing, nil
+		}
+		return nil, ErrFederatedEmailInUse
+	}
+
+	// パスワードを持たないユーザーとして作る。パスワードでサインインするには再設定が必要になる
+	created, err := l.userRepo.CreateUser(ctx, domain.NewUser(domain.UserParams{
+		Name:  displayName(ext.Name, ext.Email),
+		Email: ext.Email,
+	}))
+	if err != nil {
+		if errors.Is(err, domain.ErrUserEmailAlreadyExists) {
+			return nil, ErrFederatedEmailInUse
+		}
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := l.userRepo.VerifyEmail(ctx, created.GetID(), time.Now()); err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return l.findUser(ctx, created.GetID())
+}
+
+func (l identityLinker) findUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
+	user, err := l.userRepo.FindUserByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return user, nil
+}
+
+// displayName は名前がない場合にメールアドレスのローカル部を使う
+func displayName(name, email string) string {
+	if name != "" {
+		return name
+	}
+	local, _, _ := strings.Cut(email, "@")
+	return local
+}
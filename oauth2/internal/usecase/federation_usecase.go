@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/oidc"
+)
+
+const signinURI = "/client/signin"
+
+// 上流のプロバイダでのサインインに失敗した。詳細は利用者に伝えない
+var ErrFederationFailed = errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "federated sign-in failed", signinURI)
+
+// FederationProvider は「〜でサインイン」に使う上流の OpenID プロバイダ
+type FederationProvider struct {
+	Name        string
+	DisplayName string
+	// LinkByEmail が true の場合は、確認済みのメールアドレスが一致する既存のユーザーに紐づける
+	LinkByEmail bool
+	Client      *oidc.Client
+}
+
+func NewFederationUsecase(
+	userRepo domain.UserRepository,
+	identityRepo domain.FederatedIdentityRepository,
+	providers []FederationProvider,
+) IFederationUsecase {
+	return &FederationUsecase{
+		linker:    identityLinker{userRepo: userRepo, identityRepo: identityRepo},
+		providers: providers,
+	}
+}
+
+type IFederationUsecase interface {
+	Providers() []FederationProviderSummary
+	BeginSignin(ctx context.Context, provider string) (*FederationRequest, error)
+	FinishSignin(ctx context.Context, p FinishFederationSigninParams) (domain.User, error)
+}
+
+type FederationUsecase struct {
+	linker    identityLinker
+	providers []FederationProvider
+}
+
+// FederationProviderSummary はサインイン画面のボタンに表示するプロバイダ
+type FederationProviderSummary struct {
+	Name        string
+	DisplayName string
+}
+
+// FederationRequest は上流への認可リクエスト。コールバックで照合するためセッションに保存する
+type FederationRequest struct {
+	Provider     string
+	State        string
+	Nonce        string
+	CodeVerifier string
+	AuthURL      string
+}
+
+type FinishFederationSigninParams struct {
+	Request FederationRequest
+	// State, Code, Error はコールバックのクエリ
+	State string
+	Code  string
+	Error string
+}
+
+func (uc *FederationUsecase) Providers() []FederationProviderSummary {
+	summaries := make([]FederationProviderSummary, 0, len(uc.providers))
+	for _, p := range uc.providers {
+		summaries = append(summaries, FederationProviderSummary{Name: p.Name, DisplayName: p.DisplayName})
+	}
+	return summaries
+}
+
+// BeginSignin は state, nonce, PKCE のコード検証値を作り、上流の認可リクエストのURLを返す
+func (uc *FederationUsecase) BeginSignin(ctx context.Context, name string) (*FederationRequest, error) {
+	provider, ok := uc.provider(name)
+	if !ok {
+		return nil, errors.NewUsecaseError(http.StatusNotFound, "identity provider not found")
+	}
+
+	req := &FederationRequest{Provider: name}
+	var err error
+	if req.State, err = oidc.RandomValue(); err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if req.Nonce, err = oidc.RandomValue(); err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if req.CodeVerifier, err = oidc.NewCodeVerifier(); err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	req.AuthURL, err = provider.Client.AuthCodeURL(ctx, req.State, req.Nonce, oidc.CodeChallengeS256(req.CodeVerifier))
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return req, nil
+}
+
+// FinishSignin は認可コードを交換して ID トークンを検証し、紐づけたユーザーを返す。
+// 紐づけがない場合は、メールアドレスの一致する既存のユーザーに紐づけるか、新しいユーザーを作る
+func (uc *FederationUsecase) FinishSignin(ctx context.Context, p FinishFederationSigninParams) (domain.User, error) {
+	provider, ok := uc.provider(p.Request.Provider)
+	if !ok {
+		return nil, ErrFederationFailed
+	}
+	if p.State == "" || subtle.ConstantTimeCompare([]byte(p.State), []byte(p.Request.State)) != 1 {
+		return nil, ErrFederationFailed
+	}
+	// 利用者が拒否した場合など
+	if p.Error != "" || p.Code == "" {
+		return nil, ErrFederationFailed
+	}
+
+	token, err := provider.Client.Exchange(ctx, p.Code, p.Request.CodeVerifier)
+	if err != nil {
+		return nil, federationError(err)
+	}
+	now := time.Now()
+	claims, err := provider.Client.VerifyIDToken(ctx, token.IDToken, p.Request.Nonce, now)
+	if err != nil {
+		return nil, federationError(err)
+	}
+
+	return uc.linker.signin(ctx, externalIdentity{
+		Provider:      provider.Name,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, provider.LinkByEmail)
+}
+
+func (uc *FederationUsecase) provider(name string) (FederationProvider, bool) {
+	for _, p := range uc.providers {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return FederationProvider{}, false
+}
+
+// federationError はプロバイダが拒否した応答をサインイン画面に戻し、通信の失敗などは 500 にする
+func federationError(err error) error {
+	if errors.Is(err, oidc.ErrInvalidIDToken) || errors.Is(err, oidc.ErrTokenExchange) {
+		return ErrFederationFailed
+	}
+	return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+}
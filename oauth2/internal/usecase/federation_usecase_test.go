@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/oidc"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// federationFixture はモックのプロバイダとメモリ上のユーザーと紐づけを持つ
+type federationFixture struct {
+	provider   *oidctest.Provider
+	users      map[uuid.UUID]domain.User
+	identities map[string]domain.FederatedIdentity
+	userRepo   *domain.UserRepositoryMock
+	uc         IFederationUsecase
+}
+
+func newFederationFixture(t *testing.T, linkByEmail bool) *federationFixture {
+	t.Helper()
+	f := &federationFixture{
+		provider:   oidctest.New("client-1", "secret"),
+		users:      map[uuid.UUID]domain.User{},
+		identities: map[string]domain.FederatedIdentity{},
+	}
+	t.Cleanup(f.provider.Close)
+
+	f.userRepo = &domain.UserRepositoryMock{
+		FindUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
+			for _, u := range f.users {
+				if u.GetEmail() == email {
+					return u, nil
+				}
+			}
+			return domain.NewUser(domain.UserParams{}), nil
+		},
+		FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			if u, ok := f.users[id]; ok {
+				return u, nil
+			}
+			return domain.NewUser(domain.UserParams{}), nil
+		},
+		CreateUserFunc: func(ctx context.Context, u domain.User) (domain.User, error) {
+			created := domain.NewUser(domain.UserParams{ID: uuid.New(), Name: u.GetName(), Email: u.GetEmail(), Password: u.GetPassword()})
+			f.users[created.GetID()] = created
+			return created, nil
+		},
+		VerifyEmailFunc: func(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+			u := f.users[id]
+			f.users[id] = domain.NewUser(domain.UserParams{ID: id, Name: u.GetName(), Email: u.GetEmail(), EmailVerifiedAt: &verifiedAt})
+			return nil
+		},
+	}
+	identityRepo := &domain.FederatedIdentityRepositoryMock{
+		FindFederatedIdentityFunc: func(ctx context.Context, provider, subject string) (domain.FederatedIdentity, error) {
+			return f.identities[provider+"/"+subject], nil
+		},
+		StoreFederatedIdentityFunc: func(ctx context.Context, fi domain.FederatedIdentity) error {
+			f.identities[fi.GetProvider()+"/"+fi.GetSubject()] = fi
+			return nil
+		},
+		TouchFederatedIdentityFunc: func(ctx context.Context, provider, subject, email string, usedAt time.Time) error {
+			return nil
+		},
+	}
+
+	f.uc = NewFederationUsecase(f.userRepo, identityRepo, []FederationProvider{{
+		Name:        "corp",
+		DisplayName: "Corp",
+		LinkByEmail: linkByEmail,
+		Client: oidc.NewClient(oidc.Config{
+			Issuer:       f.provider.Issuer(),
+			ClientID:     "client-1",
+			ClientSecret: "secret",
+			RedirectURL:  "https://auth.example.com/client/signin/federation/corp/callback",
+		}),
+	}})
+	return f
+}
+
+// signin はブラウザの代わりにプロバイダで承認し、コールバックを処理する
+func (f *federationFixture) signin(t *testing.T, user oidctest.User) (domain.User, error) {
+	t.Helper()
+	ctx := context.Background()
+	f.provider.Login(user)
+
+	req, err := f.uc.BeginSignin(ctx, "corp")
+	require.NoError(t, err)
+	callback, err := f.provider.Authorize(req.AuthURL)
+	require.NoError(t, err)
+
+	return f.uc.FinishSignin(ctx, FinishFederationSigninParams{
+		Request: *req,
+		State:   callback.Query().Get("state"),
+		Code:    callback.Query().Get("code"),
+		Error:   callback.Query().Get("error"),
+	})
+}
+
+func TestFederation_ProvisionUser(t *testing.T) {
+	f := newFederationFixture(t, false)
+
+	user, err := f.signin(t, oidctest.User{Subject: "sub-1", Email: "alice@example.com", EmailVerified: true, Name: "Alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", user.GetEmail())
+	assert.Equal(t, "Alice", user.GetName())
+	assert.True(t, user.IsEmailVerified())
+	// パスワードではサインインできない
+	assert.Empty(t, user.GetPassword())
+	require.Contains(t, f.identities, "corp/sub-1")
+	assert.Equal(t, user.GetID(), f.identities["corp/sub-1"].GetUserID())
+
+	// 2回目以降は sub で同じユーザーを返す。メールアドレスが変わっても変わらない
+	again, err := f.signin(t, oidctest.User{Subject: "sub-1", Email: "alice@new.example.com", EmailVerified: true})
+	require.NoError(t, err)
+	assert.Equal(t, user.GetID(), again.GetID())
+	assert.Len(t, f.userRepo.CreateUserCalls(), 1)
+}
+
+func TestFederation_ProvisionUnverifiedEmail(t *testing.T) {
+	f := newFederationFixture(t, false)
+
+	_, err := f.signin(t, oidctest.User{Subject: "sub-1", Email: "bob@example.com"})
+	assert.ErrorIs(t, err, ErrFederatedEmailUnverified)
+	assert.Empty(t, f.userRepo.CreateUserCalls())
+	assert.Empty(t, f.identities)
+}
+
+func TestFederation_ExistingEmail(t *testing.T) {
+	f := newFederationFixture(t, false)
+	existing := domain.NewUser(domain.UserParams{ID: uuid.New(), Email: "alice@example.com"})
+	f.users[existing.GetID()] = existing
+
+	// 紐づけを許可していないプロバイダでは既存のユーザーに紐づけない
+	_, err := f.signin(t, oidctest.User{Subject: "sub-1", Email: "alice@example.com", EmailVerified: true})
+	assert.ErrorIs(t, err, ErrFederatedEmailInUse)
+	assert.Empty(t, f.identities)
+}
+
+func TestFederation_LinkByEmail(t *testing.T) {
+	f := newFederationFixture(t, true)
+	existing := domain.NewUser(domain.UserParams{ID: uuid.New(), Email: "alice@example.com"})
+	f.users[existing.GetID()] = existing
+
+	// 確認していないメールアドレスでは紐づけない
+	_, err := f.signin(t, oidctest.User{Subject: "sub-1", Email: "alice@example.com"})
+	assert.ErrorIs(t, err, ErrFederatedEmailUnverified)
+	assert.Empty(t, f.identities)
+
+	user, err := f.signin(t, oidctest.User{Subject: "sub-1", Email: "alice@example.com", EmailVerified: true})
+	require.NoError(t, err)
+	assert.Equal(t, existing.GetID(), user.GetID())
+	assert.Empty(t, f.userRepo.CreateUserCalls())
+}
+
+func TestFederation_StateMismatch(t *testing.T) {
+	ctx := context.Background()
+	f := newFederationFixture(t, false)
+	f.provider.Login(oidctest.User{Subject: "sub-1", Email: "alice@example.com"})
+
+	req, err := f.uc.BeginSignin(ctx, "corp")
+	require.NoError(t, err)
+	callback, err := f.provider.Authorize(req.AuthURL)
+	require.NoError(t, err)
+
+	_, err = f.uc.FinishSignin(ctx, FinishFederationSigninParams{
+		Request: *req,
+		State:   "forged",
+		Code:    callback.Query().Get("code"),
+	})
+	assert.ErrorIs(t, err, ErrFederationFailed)
+	assert.Empty(t, f.identities)
+}
+
+func TestFederation_InvalidIDToken(t *testing.T) {
+	f := newFederationFixture(t, false)
+	// 別のリクエストの nonce を持つ ID トークンは受け付けない
+	f.provider.Claims = func(c jwt.MapClaims) { c["nonce"] = "other" }
+
+	_, err := f.signin(t, oidctest.User{Subject: "sub-1", Email: "alice@example.com"})
+	assert.ErrorIs(t, err, ErrFederationFailed)
+}
+
+func TestFederation_MissingEmail(t *testing.T) {
+	f := newFederationFixture(t, false)
+
+	_, err := f.signin(t, oidctest.User{Subject: "sub-1"})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusFound, err.(*errors.UsecaseError).Code)
+	assert.Empty(t, f.userRepo.CreateUserCalls())
+}
+
+func TestFederation_UnknownProvider(t *testing.T) {
+	f := newFederationFixture(t, false)
+
+	_, err := f.uc.BeginSignin(context.Background(), "other")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.(*errors.UsecaseError).Code)
+	assert.Equal(t, []FederationProviderSummary{{Name: "corp", DisplayName: "Corp"}}, f.uc.Providers())
+}
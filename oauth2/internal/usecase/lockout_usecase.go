@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+// maxDelayShift は待ち時間を倍にする回数の上限。桁あふれを防ぐ
+const maxDelayShift = 20
+
+func NewLockoutUsecase(
+	store domain.LoginAttemptStore,
+	eventRepo domain.LockoutEventRepository,
+	cfg *config.Config,
+) ILockoutUsecase {
+	return &LockoutUsecase{
+		store:     store,
+		eventRepo: eventRepo,
+		config:    cfg,
+	}
+}
+
+type ILockoutUsecase interface {
+	Check(ctx context.Context, email, ip string) error
+	RecordFailure(ctx context.Context, email, ip string) error
+	RecordSuccess(ctx context.Context, email string) error
+	Unlock(ctx context.Context, p UnlockParams) error
+	Events(ctx context.Context, limit int) ([]domain.LockoutEvent, error)
+}
+
+type LockoutUsecase struct {
+	store     domain.LoginAttemptStore
+	eventRepo domain.LockoutEventRepository
+	config    *config.Config
+}
+
+// UnlockParams はロックを解除する対象。どちらか一方を指定する
+type UnlockParams struct {
+	Email string
+	IP    string
+}
+
+// Check はサインインを試してよいかを確認する。
+// 制限中はパスワードを確認せずに、パスワードを誤った場合と同じエラーを返す
+func (uc *LockoutUsecase) Check(ctx context.Context, email, ip string) error {
+	now := time.Now()
+	for _, key := range []string{accountKey(email), ipKey(ip)} {
+		until, err := uc.store.BlockedUntil(ctx, key)
+		if err != nil {
+			return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+		if now.Before(until) {
+			return ErrUserOrPasswordNotMatch
+		}
+	}
+	return nil
+}
+
+// RecordFailure はアカウントと接続元の失敗回数を数える。
+// 登録されていないメールアドレスも同じように数え、ロックの有無からユーザーの有無が分からないようにする
+func (uc *LockoutUsecase) RecordFailure(ctx context.Context, email, ip string) error {
+	now := time.Now()
+	window := time.Duration(uc.config.LoginFailureWindow) * time.Second
+	lockUntil := now.Add(time.Duration(uc.config.LoginLockoutDuration) * time.Second)
+
+	n, err := uc.store.IncrFailures(ctx, accountKey(email), window)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	switch {
+	case n >= int64(uc.config.LoginLockoutThreshold):
+		if err := uc.lock(ctx, accountKey(email), domain.LockoutEvent{
+			SubjectType: domain.LockoutSubjectAccount,
+			Subject:     email,
+			IP:          ip,
+			LockedUntil: &lockUntil,
+		}); err != nil {
+			return err
+		}
+	case n > int64(uc.config.LoginDelayThreshold):
+		// 失敗するたびに次の試行までの待ち時間を倍にする
+		if err := uc.block(ctx, accountKey(email), now.Add(uc.delay(n))); err != nil {
+			return err
+		}
+	}
+
+	// 接続元は複数の利用者が共有している場合があるため、待ち時間は設けずにロックのみ行う
+	n, err = uc.store.IncrFailures(ctx, ipKey(ip), window)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if n >= int64(uc.config.LoginIPLockoutThreshold) {
+		if err := uc.lock(ctx, ipKey(ip), domain.LockoutEvent{
+			SubjectType: domain.LockoutSubjectIP,
+			Subject:     ip,
+			IP:          ip,
+			LockedUntil: &lockUntil,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordSuccess はアカウントの失敗回数を消す。
+// 接続元の回数は、正しいパスワードを知っている攻撃者が回数を戻せないよう残す
+func (uc *LockoutUsecase) RecordSuccess(ctx context.Context, email string) error {
+	if err := uc.store.ResetFailures(ctx, accountKey(email)); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// Unlock は管理者がロックと失敗回数を消す
+func (uc *LockoutUsecase) Unlock(ctx context.Context, p UnlockParams) error {
+	subjectType, subject, key := domain.LockoutSubjectAccount, p.Email, accountKey(p.Email)
+	switch {
+	case p.Email != "" && p.IP != "":
+		return errors.NewUsecaseError(http.StatusBadRequest, "specify either email or ip")
+	case p.IP != "":
+		subjectType, subject, key = domain.LockoutSubjectIP, p.IP, ipKey(p.IP)
+	case p.Email == "":
+		return errors.NewUsecaseError(http.StatusBadRequest, "specify either email or ip")
+	}
+
+	if err := uc.store.Unblock(ctx, key); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.store.ResetFailures(ctx, key); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.eventRepo.StoreLockoutEvent(ctx, domain.LockoutEvent{
+		ID:          uuid.New(),
+		Kind:        domain.LockoutEventUnlocked,
+		SubjectType: subjectType,
+		Subject:     subject,
+		CreatedAt:   time.Now(),
+	}); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+func (uc *LockoutUsecase) Events(ctx context.Context, limit int) ([]domain.LockoutEvent, error) {
+	events, err := uc.eventRepo.FindRecentLockoutEvents(ctx, limit)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return events, nil
+}
+
+// lock は一定時間サインインを受け付けないようにし、管理者が確認できるよう記録する
+func (uc *LockoutUsecase) lock(ctx context.Context, key string, e domain.LockoutEvent) error {
+	if err := uc.block(ctx, key, *e.LockedUntil); err != nil {
+		return err
+	}
+	e.ID = uuid.New()
+	e.Kind = domain.LockoutEventLocked
+	e.CreatedAt = time.Now()
+	if err := uc.eventRepo.StoreLockoutEvent(ctx, e); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// block は制限を設定する。既により長い制限がかかっている場合は短くしない
+func (uc *LockoutUsecase) block(ctx context.Context, key string, until time.Time) error {
+	current, err := uc.store.BlockedUntil(ctx, key)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if !until.After(current) {
+		return nil
+	}
+	if err := uc.store.Block(ctx, key, until); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// delay は n 回目の失敗の後の待ち時間を返す
+func (uc *LockoutUsecase) delay(n int64) time.Duration {
+	shift := min(n-int64(uc.config.LoginDelayThreshold)-1, maxDelayShift)
+	d := time.Duration(uc.config.LoginDelayBase) * time.Second << shift
+	return min(d, time.Duration(uc.config.LoginDelayMax)*time.Second)
+}
+
+// accountKey はメールアドレスをそのままキーに残さないようハッシュにする
+func accountKey(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return domain.LockoutSubjectAccount + ":" + hex.EncodeToString(sum[:])
+}
+
+func ipKey(ip string) string {
+	return domain.LockoutSubjectIP + ":" + ip
+}
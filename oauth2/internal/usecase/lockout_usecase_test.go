@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLockoutConfig() *config.Config {
+	return &config.Config{
+		LoginFailureWindow:      900,
+		LoginDelayThreshold:     3,
+		LoginDelayBase:          1,
+		LoginDelayMax:           4,
+		LoginLockoutThreshold:   10,
+		LoginIPLockoutThreshold: 20,
+		LoginLockoutDuration:    900,
+	}
+}
+
+// newLoginAttemptStore は失敗回数と制限をメモリ上に保持するストアのモックを返す
+func newLoginAttemptStore() *domain.LoginAttemptStoreMock {
+	failures := map[string]int64{}
+	blocked := map[string]time.Time{}
+	return &domain.LoginAttemptStoreMock{
+		IncrFailuresFunc: func(ctx context.Context, key string, window time.Duration) (int64, error) {
+			failures[key]++
+			return failures[key], nil
+		},
+		ResetFailuresFunc: func(ctx context.Context, key string) error {
+			delete(failures, key)
+			return nil
+		},
+		BlockFunc: func(ctx context.Context, key string, until time.Time) error {
+			blocked[key] = until
+			return nil
+		},
+		BlockedUntilFunc: func(ctx context.Context, key string) (time.Time, error) {
+			return blocked[key], nil
+		},
+		UnblockFunc: func(ctx context.Context, key string) error {
+			delete(blocked, key)
+			return nil
+		},
+	}
+}
+
+func newLockoutEventRepo() *domain.LockoutEventRepositoryMock {
+	return &domain.LockoutEventRepositoryMock{
+		StoreLockoutEventFunc: func(ctx context.Context, e domain.LockoutEvent) error {
+			return nil
+		},
+	}
+}
+
+func TestLockout_DelayGrowsExponentially(t *testing.T) {
+	ctx := context.Background()
+	store := newLoginAttemptStore()
+	uc := NewLockoutUsecase(store, newLockoutEventRepo(), newLockoutConfig())
+
+	for range 3 {
+		require.NoError(t, uc.RecordFailure(ctx, "user@example.com", "192.0.2.1"))
+	}
+	// しきい値までは待たせない
+	assert.Empty(t, store.BlockCalls())
+	require.NoError(t, uc.Check(ctx, "user@example.com", "192.0.2.1"))
+
+	var delays []time.Duration
+	for range 4 {
+		start := time.Now()
+		require.NoError(t, uc.RecordFailure(ctx, "user@example.com", "192.0.2.1"))
+		calls := store.BlockCalls()
+		delays = append(delays, calls[len(calls)-1].Until.Sub(start).Round(time.Second))
+	}
+	// 1秒から倍になり、上限で止まる
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second}, delays)
+
+	err := uc.Check(ctx, "user@example.com", "192.0.2.1")
+	assert.ErrorIs(t, err, ErrUserOrPasswordNotMatch)
+}
+
+func TestLockout_LocksAccount(t *testing.T) {
+	ctx := context.Background()
+	eventRepo := newLockoutEventRepo()
+	uc := NewLockoutUsecase(newLoginAttemptStore(), eventRepo, newLockoutConfig())
+
+	for range 10 {
+		require.NoError(t, uc.RecordFailure(ctx, "user@example.com", "192.0.2.1"))
+	}
+
+	// 別の接続元からもサインインできず、パスワードを誤った場合と区別できない
+	err := uc.Check(ctx, "USER@example.com", "198.51.100.1")
+	assert.ErrorIs(t, err, ErrUserOrPasswordNotMatch)
+	require.NoError(t, uc.Check(ctx, "other@example.com", "192.0.2.1"))
+
+	require.Len(t, eventRepo.StoreLockoutEventCalls(), 1)
+	event := eventRepo.StoreLockoutEventCalls()[0].E
+	assert.Equal(t, domain.LockoutEventLocked, event.Kind)
+	assert.Equal(t, domain.LockoutSubjectAccount, event.SubjectType)
+	assert.Equal(t, "user@example.com", event.Subject)
+	assert.Equal(t, "192.0.2.1", event.IP)
+	assert.WithinDuration(t, time.Now().Add(900*time.Second), *event.LockedUntil, time.Second)
+}
+
+func TestLockout_LocksIP(t *testing.T) {
+	ctx := context.Background()
+	eventRepo := newLockoutEventRepo()
+	uc := NewLockoutUsecase(newLoginAttemptStore(), eventRepo, newLockoutConfig())
+
+	// アカウントごとの回数に達しないよう、異なるメールアドレスを試す
+	for i := range 20 {
+		require.NoError(t, uc.RecordFailure(ctx, string(rune('a'+i))+"@example.com", "192.0.2.1"))
+	}
+
+	err := uc.Check(ctx, "user@example.com", "192.0.2.1")
+	assert.ErrorIs(t, err, ErrUserOrPasswordNotMatch)
+	require.NoError(t, uc.Check(ctx, "user@example.com", "198.51.100.1"))
+
+	event := eventRepo.StoreLockoutEventCalls()[0].E
+	assert.Equal(t, domain.LockoutSubjectIP, event.SubjectType)
+	assert.Equal(t, "192.0.2.1", event.Subject)
+}
+
+func TestLockout_RecordSuccessResetsAccount(t *testing.T) {
+	ctx := context.Background()
+	store := newLoginAttemptStore()
+	uc := NewLockoutUsecase(store, newLockoutEventRepo(), newLockoutConfig())
+
+	for range 3 {
+		require.NoError(t, uc.RecordFailure(ctx, "user@example.com", "192.0.2.1"))
+	}
+	require.NoError(t, uc.RecordSuccess(ctx, "user@example.com"))
+
+	// 成功するとアカウントの回数は数え直す
+	require.NoError(t, uc.RecordFailure(ctx, "user@example.com", "192.0.2.1"))
+	assert.Empty(t, store.BlockCalls())
+}
+
+func TestLockout_Unlock(t *testing.T) {
+	ctx := context.Background()
+	eventRepo := newLockoutEventRepo()
+	uc := NewLockoutUsecase(newLoginAttemptStore(), eventRepo, newLockoutConfig())
+
+	for range 10 {
+		require.NoError(t, uc.RecordFailure(ctx, "user@example.com", "192.0.2.1"))
+	}
+	require.NoError(t, uc.Unlock(ctx, UnlockParams{Email: "user@example.com"}))
+	require.NoError(t, uc.Check(ctx, "user@example.com", "192.0.2.1"))
+
+	// 解除した後は最初から数える
+	require.NoError(t, uc.RecordFailure(ctx, "user@example.com", "192.0.2.1"))
+	require.NoError(t, uc.Check(ctx, "user@example.com", "192.0.2.1"))
+
+	calls := eventRepo.StoreLockoutEventCalls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, domain.LockoutEventUnlocked, calls[1].E.Kind)
+	assert.Nil(t, calls[1].E.LockedUntil)
+}
+
+func TestLockout_UnlockRequiresSubject(t *testing.T) {
+	uc := NewLockoutUsecase(newLoginAttemptStore(), newLockoutEventRepo(), newLockoutConfig())
+
+	err := uc.Unlock(context.Background(), UnlockParams{})
+	require.Error(t, err)
+
+	err = uc.Unlock(context.Background(), UnlockParams{Email: "user@example.com", IP: "192.0.2.1"})
+	require.Error(t, err)
+}
@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewMFAUsecase(
+	userRepo domain.UserRepository,
+	totpRepo domain.TOTPCredentialRepository,
+	recoveryCodeRepo domain.RecoveryCodeRepository,
+	cfg *config.Config,
+) IMFAUsecase {
+	return &MFAUsecase{
+		userRepo:         userRepo,
+		totpRepo:         totpRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		config:           cfg,
+	}
+}
+
+type IMFAUsecase interface {
+	IsEnabled(ctx context.Context, userID string) (bool, error)
+	Status(ctx context.Context, userID string) (*MFAStatus, error)
+	BeginEnrollment(ctx context.Context, userID string) (*TOTPEnrollment, error)
+	ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error)
+	Disable(ctx context.Context, userID, code string) error
+	VerifyTOTP(ctx context.Context, userID, code string) error
+	VerifyRecoveryCode(ctx context.Context, userID, code string) error
+}
+
+type MFAUsecase struct {
+	userRepo         domain.UserRepository
+	totpRepo         domain.TOTPCredentialRepository
+	recoveryCodeRepo domain.RecoveryCodeRepository
+	config           *config.Config
+}
+
+type MFAStatus struct {
+	Enabled               bool
+	RemainingRecoveryCode int
+}
+
+// TOTPEnrollment は認証アプリに登録する内容
+type TOTPEnrollment struct {
+	Secret string
+	KeyURI string
+}
+
+// IsEnabled はサインインに二要素目が必要かを判定する
+func (uc *MFAUsecase) IsEnabled(ctx context.Context, userID string) (bool, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return false, errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+	c, err := uc.totpRepo.FindTOTPCredential(ctx, id)
+	if err != nil {
+		return false, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return c != nil && c.IsConfirmed(), nil
+}
+
+func (uc *MFAUsecase) Status(ctx context.Context, userID string) (*MFAStatus, error) {
+	enabled, err := uc.IsEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return &MFAStatus{}, nil
+	}
+	n, err := uc.recoveryCodeRepo.CountUnusedRecoveryCodes(ctx, uuid.MustParse(userID))
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return &MFAStatus{Enabled: true, RemainingRecoveryCode: n}, nil
+}
+
+// BeginEnrollment は確認前のシークレットを発行する。登録途中のシークレットがあればそれを返す
+func (uc *MFAUsecase) BeginEnrollment(ctx context.Context, userID string) (*TOTPEnrollment, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+	user, err := uc.userRepo.FindUserByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if user.IsNotFound() {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "user not found")
+	}
+
+	c, err := uc.totpRepo.FindTOTPCredential(ctx, id)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if c != nil && c.IsConfirmed() {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "two-factor authentication is already enabled")
+	}
+	if c == nil {
+		c, err = domain.GenerateTOTPCredential(id)
+		if err != nil {
+			return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+		if err := uc.totpRepo.StoreTOTPCredential(ctx, c); err != nil {
+			return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return &TOTPEnrollment{
+		Secret: c.GetSecret(),
+		KeyURI: c.KeyURI(uc.config.MFAIssuer, user.GetEmail()),
+	}, nil
+}
+
+// ConfirmEnrollment は認証アプリのコードを確認して登録を完了し、リカバリーコードを発行する
+func (uc *MFAUsecase) ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+	c, err := uc.totpRepo.FindTOTPCredential(ctx, id)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if c == nil {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "two-factor authentication enrollment has not started")
+	}
+	if c.IsConfirmed() {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "two-factor authentication is already enabled")
+	}
+
+	now := time.Now()
+	step, ok := c.Match(code, now)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	c.Confirm(now)
+	if err := uc.totpRepo.StoreTOTPCredential(ctx, c); err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	// 確認に使ったコードをサインインで使えないようにする
+	if _, err := uc.totpRepo.UseTOTPStep(ctx, id, step); err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	return uc.issueRecoveryCodes(ctx, id)
+}
+
+// RegenerateRecoveryCodes はリカバリーコードを発行し直す。以前のコードは使えなくなる
+func (uc *MFAUsecase) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	if err := uc.VerifyTOTP(ctx, userID, code); err != nil {
+		return nil, err
+	}
+	return uc.issueRecoveryCodes(ctx, uuid.MustParse(userID))
+}
+
+// Disable は二要素認証を解除する。本人の操作であることを確かめるため現在のコードを要求する
+func (uc *MFAUsecase) Disable(ctx context.Context, userID, code string) error {
+	if err := uc.VerifyTOTP(ctx, userID, code); err != nil {
+		return err
+	}
+	id := uuid.MustParse(userID)
+	if err := uc.recoveryCodeRepo.DeleteRecoveryCodes(ctx, id); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.totpRepo.DeleteTOTPCredential(ctx, id); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// VerifyTOTP は認証アプリのコードを検証する。一度使ったコードは受け付けない
+func (uc *MFAUsecase) VerifyTOTP(ctx context.Context, userID, code string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+	c, err := uc.totpRepo.FindTOTPCredential(ctx, id)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if c == nil || !c.IsConfirmed() {
+		return errors.NewUsecaseError(http.StatusBadRequest, "two-factor authentication is not enabled")
+	}
+
+	step, ok := c.Match(code, time.Now())
+	if !ok {
+		return ErrInvalidMFACode
+	}
+	used, err := uc.totpRepo.UseTOTPStep(ctx, id, step)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if !used {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// VerifyRecoveryCode は認証アプリを使えない場合にリカバリーコードで検証する。コードは一度しか使えない
+func (uc *MFAUsecase) VerifyRecoveryCode(ctx context.Context, userID, code string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+	used, err := uc.recoveryCodeRepo.UseRecoveryCode(ctx, id, domain.HashRecoveryCode(code))
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if !used {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (uc *MFAUsecase) issueRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes, hashes := domain.GenerateRecoveryCodes()
+	if err := uc.recoveryCodeRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return codes, nil
+}
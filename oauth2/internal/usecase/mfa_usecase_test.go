@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMFAConfig() *config.Config {
+	return &config.Config{MFAIssuer: "go-oauth2"}
+}
+
+func newMFAUserRepo(userID uuid.UUID) *domain.UserRepositoryMock {
+	return &domain.UserRepositoryMock{
+		FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return domain.NewUser(domain.UserParams{ID: userID, Email: "user@example.com"}), nil
+		},
+	}
+}
+
+// newTOTPRepo は credential を保存先として扱うリポジトリのモックを返す
+func newTOTPRepo(credential domain.TOTPCredential) *domain.TOTPCredentialRepositoryMock {
+	return &domain.TOTPCredentialRepositoryMock{
+		FindTOTPCredentialFunc: func(ctx context.Context, userID uuid.UUID) (domain.TOTPCredential, error) {
+			return credential, nil
+		},
+		StoreTOTPCredentialFunc: func(ctx context.Context, c domain.TOTPCredential) error {
+			return nil
+		},
+		UseTOTPStepFunc: func(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+			return true, nil
+		},
+		DeleteTOTPCredentialFunc: func(ctx context.Context, userID uuid.UUID) error {
+			return nil
+		},
+	}
+}
+
+func newRecoveryCodeRepo() *domain.RecoveryCodeRepositoryMock {
+	return &domain.RecoveryCodeRepositoryMock{
+		ReplaceRecoveryCodesFunc: func(ctx context.Context, userID uuid.UUID, hashes []string) error {
+			return nil
+		},
+		DeleteRecoveryCodesFunc: func(ctx context.Context, userID uuid.UUID) error {
+			return nil
+		},
+	}
+}
+
+func newConfirmedTOTPCredential(t *testing.T, userID uuid.UUID) domain.TOTPCredential {
+	t.Helper()
+	c, err := domain.GenerateTOTPCredential(userID)
+	require.NoError(t, err)
+	c.Confirm(time.Now())
+	return c
+}
+
+func currentTOTPCode(t *testing.T, c domain.TOTPCredential) string {
+	t.Helper()
+	code, err := totp.Code(c.GetSecret(), totp.Step(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestBeginEnrollment_New(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockTOTPRepo := newTOTPRepo(nil)
+
+	uc := NewMFAUsecase(newMFAUserRepo(userID), mockTOTPRepo, newRecoveryCodeRepo(), newMFAConfig())
+	enrollment, err := uc.BeginEnrollment(ctx, userID.String())
+	require.NoError(t, err)
+
+	require.Len(t, mockTOTPRepo.StoreTOTPCredentialCalls(), 1)
+	stored := mockTOTPRepo.StoreTOTPCredentialCalls()[0].C
+	assert.False(t, stored.IsConfirmed())
+	assert.Equal(t, stored.GetSecret(), enrollment.Secret)
+
+	u, err := url.Parse(enrollment.KeyURI)
+	require.NoError(t, err)
+	assert.Equal(t, "/go-oauth2:user@example.com", u.Path)
+	assert.Equal(t, enrollment.Secret, u.Query().Get("secret"))
+}
+
+func TestBeginEnrollment_ReusePending(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pending, err := domain.GenerateTOTPCredential(userID)
+	require.NoError(t, err)
+
+	mockTOTPRepo := newTOTPRepo(pending)
+
+	uc := NewMFAUsecase(newMFAUserRepo(userID), mockTOTPRepo, newRecoveryCodeRepo(), newMFAConfig())
+	enrollment, err := uc.BeginEnrollment(ctx, userID.String())
+	require.NoError(t, err)
+
+	assert.Equal(t, pending.GetSecret(), enrollment.Secret)
+	assert.Empty(t, mockTOTPRepo.StoreTOTPCredentialCalls())
+}
+
+func TestBeginEnrollment_AlreadyEnabled(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	uc := NewMFAUsecase(newMFAUserRepo(userID), newTOTPRepo(newConfirmedTOTPCredential(t, userID)), newRecoveryCodeRepo(), newMFAConfig())
+	_, err := uc.BeginEnrollment(ctx, userID.String())
+
+	var usecaseErr *errors.UsecaseError
+	require.ErrorAs(t, err, &usecaseErr)
+	assert.Equal(t, http.StatusBadRequest, usecaseErr.Code)
+}
+
+func TestConfirmEnrollment_Success(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pending, err := domain.GenerateTOTPCredential(userID)
+	require.NoError(t, err)
+
+	mockTOTPRepo := newTOTPRepo(pending)
+	mockRecoveryCodeRepo := newRecoveryCodeRepo()
+
+	uc := NewMFAUsecase(newMFAUserRepo(userID), mockTOTPRepo, mockRecoveryCodeRepo, newMFAConfig())
+	codes, err := uc.ConfirmEnrollment(ctx, userID.String(), currentTOTPCode(t, pending))
+	require.NoError(t, err)
+
+	assert.True(t, mockTOTPRepo.StoreTOTPCredentialCalls()[0].C.IsConfirmed())
+	assert.Equal(t, totp.Step(time.Now()), mockTOTPRepo.UseTOTPStepCalls()[0].Step)
+
+	require.Len(t, codes, domain.RecoveryCodeCount)
+	hashes := mockRecoveryCodeRepo.ReplaceRecoveryCodesCalls()[0].Hashes
+	require.Len(t, hashes, domain.RecoveryCodeCount)
+	for i, code := range codes {
+		assert.Equal(t, domain.HashRecoveryCode(code), hashes[i])
+	}
+}
+
+func TestConfirmEnrollment_InvalidCode(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pending, err := domain.GenerateTOTPCredential(userID)
+	require.NoError(t, err)
+
+	mockTOTPRepo := newTOTPRepo(pending)
+	mockRecoveryCodeRepo := newRecoveryCodeRepo()
+
+	uc := NewMFAUsecase(newMFAUserRepo(userID), mockTOTPRepo, mockRecoveryCodeRepo, newMFAConfig())
+	_, err = uc.ConfirmEnrollment(ctx, userID.String(), "abcdef")
+
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+	assert.Empty(t, mockTOTPRepo.StoreTOTPCredentialCalls())
+	assert.Empty(t, mockRecoveryCodeRepo.ReplaceRecoveryCodesCalls())
+}
+
+func TestVerifyTOTP_Success(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	c := newConfirmedTOTPCredential(t, userID)
+
+	mockTOTPRepo := newTOTPRepo(c)
+
+	uc := NewMFAUsecase(newMFAUserRepo(userID), mockTOTPRepo, newRecoveryCodeRepo(), newMFAConfig())
+	err := uc.VerifyTOTP(ctx, userID.String(), currentTOTPCode(t, c))
+	require.NoError(t, err)
+
+	assert.Equal(t, userID, mockTOTPRepo.UseTOTPStepCalls()[0].UserID)
+}
+
+func TestVerifyTOTP_Replayed(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	c := newConfirmedTOTPCredential(t, userID)
+
+	// 同じコードが先に使われていた場合は記録に失敗する
+	mockTOTPRepo := newTOTPRepo(c)
+	mockTOTPRepo.UseTOTPStepFunc = func(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+		return false, nil
+	}
+
+	uc := NewMFAUsecase(newMFAUserRepo(userID), mockTOTPRepo, newRecoveryCodeRepo(), newMFAConfig())
+	err := uc.VerifyTOTP(ctx, userID.String(), currentTOTPCode(t, c))
+
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+}
+
+func TestVerifyTOTP_NotEnabled(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pending, err := domain.GenerateTOTPCredential(userID)
+	require.NoError(t, err)
+
+	uc := NewMFAUsecase(newMFAUserRepo(userID), newTOTPRepo(pending), newRecoveryCodeRepo(), newMFAConfig())
+	err = uc.VerifyTOTP(ctx, userID.String(), currentTOTPCode(t, pending))
+
+	var usecaseErr *errors.UsecaseError
+	require.ErrorAs(t, err, &usecaseErr)
+	assert.Equal(t, http.StatusBadRequest, usecaseErr.Code)
+	assert.NotErrorIs(t, err, ErrInvalidMFACode)
+}
+
+func TestVerifyRecoveryCode(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockRecoveryCodeRepo := &domain.RecoveryCodeRepositoryMock{
+		UseRecoveryCodeFunc: func(ctx context.Context, userID uuid.UUID, hash string) (bool, error) {
+			return hash == domain.HashRecoveryCode("abcde-fghij"), nil
+		},
+	}
+
+	uc := NewMFAUsecase(newMFAUserRepo(userID), newTOTPRepo(nil), mockRecoveryCodeRepo, newMFAConfig())
+	require.NoError(t, uc.VerifyRecoveryCode(ctx, userID.String(), "ABCDE FGHIJ"))
+	assert.ErrorIs(t, uc.VerifyRecoveryCode(ctx, userID.String(), "zzzzz-zzzzz"), ErrInvalidMFACode)
+}
+
+func TestDisable(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	c := newConfirmedTOTPCredential(t, userID)
+
+	mockTOTPRepo := newTOTPRepo(c)
+	mockRecoveryCodeRepo := newRecoveryCodeRepo()
+
+	uc := NewMFAUsecase(newMFAUserRepo(userID), mockTOTPRepo, mockRecoveryCodeRepo, newMFAConfig())
+
+	assert.ErrorIs(t, uc.Disable(ctx, userID.String(), "000000"), ErrInvalidMFACode)
+	assert.Empty(t, mockTOTPRepo.DeleteTOTPCredentialCalls())
+
+	require.NoError(t, uc.Disable(ctx, userID.String(), currentTOTPCode(t, c)))
+	assert.Equal(t, userID, mockTOTPRepo.DeleteTOTPCredentialCalls()[0].UserID)
+	assert.Equal(t, userID, mockRecoveryCodeRepo.DeleteRecoveryCodesCalls()[0].UserID)
+}
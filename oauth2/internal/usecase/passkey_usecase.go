@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+func NewPasskeyUsecase(
+	userRepo domain.UserRepository,
+	passkeyRepo domain.PasskeyRepository,
+	wa *webauthn.WebAuthn,
+) IPasskeyUsecase {
+	return &PasskeyUsecase{
+		userRepo:    userRepo,
+		passkeyRepo: passkeyRepo,
+		webauthn:    wa,
+	}
+}
+
+type IPasskeyUsecase interface {
+	Passkeys(ctx context.Context, userID string) ([]PasskeySummary, error)
+	BeginRegistration(ctx context.Context, userID string) (*protocol.CredentialCreation, *webauthn.SessionData, error)
+	FinishRegistration(ctx context.Context, p FinishPasskeyRegistrationParams) (domain.Passkey, error)
+	BeginLogin(ctx context.Context) (*protocol.CredentialAssertion, *webauthn.SessionData, error)
+	FinishLogin(ctx context.Context, session webauthn.SessionData, body io.Reader) (domain.User, error)
+	DeletePasskey(ctx context.Context, userID, credentialID string) error
+}
+
+type PasskeyUsecase struct {
+	userRepo    domain.UserRepository
+	passkeyRepo domain.PasskeyRepository
+	webauthn    *webauthn.WebAuthn
+}
+
+type FinishPasskeyRegistrationParams struct {
+	UserID  string
+	Name    string
+	Session webauthn.SessionData
+	// Body は navigator.credentials.create の結果のJSON
+	Body io.Reader
+}
+
+// PasskeySummary は設定画面に表示するパスキー。ID は URL で扱えるよう Base64URL で表す
+type PasskeySummary struct {
+	ID         string
+	Name       string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+func (uc *PasskeyUsecase) Passkeys(ctx context.Context, userID string) ([]PasskeySummary, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+	passkeys, err := uc.passkeyRepo.FindPasskeysByUserID(ctx, id)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	summaries := make([]PasskeySummary, 0, len(passkeys))
+	for _, p := range passkeys {
+		summaries = append(summaries, PasskeySummary{
+			ID:         base64.RawURLEncoding.EncodeToString(p.GetCredentialID()),
+			Name:       p.GetName(),
+			CreatedAt:  p.GetCreatedAt(),
+			LastUsedAt: p.GetLastUsedAt(),
+		})
+	}
+	return summaries, nil
+}
+
+// BeginRegistration は登録のオプションを発行する。返したセッションは FinishRegistration まで保存しておく
+func (uc *PasskeyUsecase) BeginRegistration(ctx context.Context, userID string) (*protocol.CredentialCreation, *webauthn.SessionData, error) {
+	user, err := uc.findPasskeyUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 登録済みの認証器で重ねて登録しないよう除外する
+	creation, session, err := uc.webauthn.BeginRegistration(user,
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementRequired,
+			UserVerification: protocol.VerificationRequired,
+		}),
+		webauthn.WithExclusions(webauthn.Credentials(user.WebAuthnCredentials()).CredentialDescriptors()),
+	)
+	if err != nil {
+		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return creation, session, nil
+}
+
+// FinishRegistration は認証器の応答を検証してパスキーを保存する
+func (uc *PasskeyUsecase) FinishRegistration(ctx context.Context, p FinishPasskeyRegistrationParams) (domain.Passkey, error) {
+	user, err := uc.findPasskeyUser(ctx, p.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(p.Body)
+	if err != nil {
+		return nil, ErrInvalidPasskey
+	}
+	credential, err := uc.webauthn.CreateCredential(user, p.Session, parsed)
+	if err != nil {
+		return nil, ErrInvalidPasskey
+	}
+
+	transports := make([]string, 0, len(credential.Transport))
+	for _, t := range credential.Transport {
+		transports = append(transports, string(t))
+	}
+	passkey := domain.NewPasskey(domain.PasskeyParams{
+		CredentialID:    credential.ID,
+		UserID:          user.GetID(),
+		Name:            p.Name,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		Transports:      transports,
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+		CreatedAt:       time.Now(),
+	})
+	if err := uc.passkeyRepo.StorePasskey(ctx, passkey); err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return passkey, nil
+}
+
+// BeginLogin はユーザーを指定しないサインインのオプションを発行する。認証器が保持するパスキーから利用者が選ぶ
+func (uc *PasskeyUsecase) BeginLogin(ctx context.Context) (*protocol.CredentialAssertion, *webauthn.SessionData, error) {
+	assertion, session, err := uc.webauthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return assertion, session, nil
+}
+
+// FinishLogin は認証器の署名を検証し、パスキーの持ち主を返す。
+// 署名カウンタが戻っている場合は認証器が複製された可能性があるため拒否する
+func (uc *PasskeyUsecase) FinishLogin(ctx context.Context, session webauthn.SessionData, body io.Reader) (domain.User, error) {
+	parsed, err := protocol.ParseCredentialRequestResponseBody(body)
+	if err != nil {
+		return nil, ErrInvalidPasskey
+	}
+
+	// ユーザーハンドルとパスキーの持ち主が一致することはライブラリが検証する
+	handler := func(rawID, userHandle []byte) (webauthn.User, error) {
+		passkey, err := uc.passkeyRepo.FindPasskey(ctx, rawID)
+		if err != nil {
+			return nil, err
+		}
+		if passkey == nil {
+			return nil, errors.New("unknown credential")
+		}
+		return uc.findPasskeyUser(ctx, passkey.GetUserID().String())
+	}
+
+	user, credential, err := uc.webauthn.ValidatePasskeyLogin(handler, session, parsed)
+	if err != nil {
+		return nil, ErrInvalidPasskey
+	}
+	if credential.Authenticator.CloneWarning {
+		return nil, ErrInvalidPasskey
+	}
+
+	// 同じ応答が同時に送られても一方しか成功しないよう、条件付きで記録する
+	updated, err := uc.passkeyRepo.UpdatePasskeySignCount(ctx, credential.ID, credential.Authenticator.SignCount, time.Now())
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if !updated {
+		return nil, ErrInvalidPasskey
+	}
+
+	return user.(*passkeyUser).User, nil
+}
+
+func (uc *PasskeyUsecase) DeletePasskey(ctx context.Context, userID, credentialID string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+	credID, err := base64.RawURLEncoding.DecodeString(credentialID)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusBadRequest, "invalid credential id")
+	}
+	if err := uc.passkeyRepo.DeletePasskey(ctx, id, credID); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+func (uc *PasskeyUsecase) findPasskeyUser(ctx context.Context, userID string) (*passkeyUser, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "invalid user id")
+	}
+	user, err := uc.userRepo.FindUserByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	// 無効なユーザーはパスキーでもサインインさせない
+	if user.IsNotFound() || user.IsDisabled() {
+		return nil, errors.NewUsecaseError(http.StatusBadRequest, "user not found")
+	}
+	passkeys, err := uc.passkeyRepo.FindPasskeysByUserID(ctx, id)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return &passkeyUser{User: user, passkeys: passkeys}, nil
+}
+
+// passkeyUser はユーザーを webauthn.User として扱う。ユーザーハンドルにはユーザーIDのバイト列を使う
+type passkeyUser struct {
+	domain.User
+	passkeys []domain.Passkey
+}
+
+func (u *passkeyUser) WebAuthnID() []byte {
+	id := u.GetID()
+	return id[:]
+}
+
+func (u *passkeyUser) WebAuthnName() string {
+	return u.GetEmail()
+}
+
+func (u *passkeyUser) WebAuthnDisplayName() string {
+	return u.GetName()
+}
+
+func (u *passkeyUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.passkeys))
+	for _, p := range u.passkeys {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(p.GetTransports()))
+		for _, t := range p.GetTransports() {
+			transports = append(transports, protocol.AuthenticatorTransport(t))
+		}
+		credentials = append(credentials, webauthn.Credential{
+			ID:              p.GetCredentialID(),
+			PublicKey:       p.GetPublicKey(),
+			AttestationType: p.GetAttestationType(),
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				UserPresent:    true,
+				UserVerified:   true,
+				BackupEligible: p.IsBackupEligible(),
+				BackupState:    p.IsBackupState(),
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    p.GetAAGUID(),
+				SignCount: p.GetSignCount(),
+			},
+		})
+	}
+	return credentials
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/softauthn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const passkeyOrigin = "http://localhost:8080"
+
+func newWebAuthn(t *testing.T) *webauthn.WebAuthn {
+	t.Helper()
+	wa, err := webauthn.New(&webauthn.Config{
+		RPID:          "localhost",
+		RPDisplayName: "go-oauth2",
+		RPOrigins:     []string{passkeyOrigin},
+	})
+	require.NoError(t, err)
+	return wa
+}
+
+// newPasskeyRepo は保存したパスキーをメモリ上に保持するリポジトリのモックを返す
+func newPasskeyRepo(passkeys ...domain.Passkey) *domain.PasskeyRepositoryMock {
+	repo := &domain.PasskeyRepositoryMock{}
+	repo.FindPasskeysByUserIDFunc = func(ctx context.Context, userID uuid.UUID) ([]domain.Passkey, error) {
+		var found []domain.Passkey
+		for _, p := range passkeys {
+			if p.GetUserID() == userID {
+				found = append(found, p)
+			}
+		}
+		return found, nil
+	}
+	repo.FindPasskeyFunc = func(ctx context.Context, credentialID []byte) (domain.Passkey, error) {
+		for _, p := range passkeys {
+			if bytes.Equal(p.GetCredentialID(), credentialID) {
+				return p, nil
+			}
+		}
+		return nil, nil
+	}
+	repo.StorePasskeyFunc = func(ctx context.Context, p domain.Passkey) error {
+		passkeys = append(passkeys, p)
+		return nil
+	}
+	repo.UpdatePasskeySignCountFunc = func(ctx context.Context, credentialID []byte, count uint32, usedAt time.Time) (bool, error) {
+		return true, nil
+	}
+	return repo
+}
+
+// registerPasskey はソフトウェア認証器で登録のセレモニーを行う
+func registerPasskey(t *testing.T, uc IPasskeyUsecase, auth *softauthn.Authenticator, userID uuid.UUID) domain.Passkey {
+	t.Helper()
+	ctx := context.Background()
+
+	creation, session, err := uc.BeginRegistration(ctx, userID.String())
+	require.NoError(t, err)
+	options, err := json.Marshal(creation)
+	require.NoError(t, err)
+
+	body, err := auth.Register(options)
+	require.NoError(t, err)
+
+	passkey, err := uc.FinishRegistration(ctx, FinishPasskeyRegistrationParams{
+		UserID:  userID.String(),
+		Name:    "MacBook",
+		Session: *session,
+		Body:    bytes.NewReader(body),
+	})
+	require.NoError(t, err)
+	return passkey
+}
+
+// loginWithPasskey はソフトウェア認証器で認証のセレモニーを行う
+func loginWithPasskey(t *testing.T, uc IPasskeyUsecase, auth *softauthn.Authenticator) (domain.User, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	assertion, session, err := uc.BeginLogin(ctx)
+	require.NoError(t, err)
+	options, err := json.Marshal(assertion)
+	require.NoError(t, err)
+
+	body, err := auth.Login(options)
+	require.NoError(t, err)
+
+	return uc.FinishLogin(ctx, *session, bytes.NewReader(body))
+}
+
+func TestPasskey_RegisterAndLogin(t *testing.T) {
+	userID := uuid.New()
+	auth := softauthn.New(passkeyOrigin)
+	auth.BackupEligible = true
+
+	mockPasskeyRepo := newPasskeyRepo()
+	uc := NewPasskeyUsecase(newMFAUserRepo(userID), mockPasskeyRepo, newWebAuthn(t))
+
+	passkey := registerPasskey(t, uc, auth, userID)
+	assert.Equal(t, userID, passkey.GetUserID())
+	assert.Equal(t, "MacBook", passkey.GetName())
+	assert.True(t, passkey.IsBackupEligible())
+	assert.Equal(t, []string{"internal"}, passkey.GetTransports())
+	require.Len(t, mockPasskeyRepo.StorePasskeyCalls(), 1)
+
+	user, err := loginWithPasskey(t, uc, auth)
+	require.NoError(t, err)
+	assert.Equal(t, userID, user.GetID())
+
+	summaries, err := uc.Passkeys(context.Background(), userID.String())
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(passkey.GetCredentialID()), summaries[0].ID)
+
+	update := mockPasskeyRepo.UpdatePasskeySignCountCalls()[0]
+	assert.Equal(t, passkey.GetCredentialID(), update.CredentialID)
+	assert.Equal(t, uint32(1), update.Count)
+}
+
+func TestPasskey_BeginRegistrationExcludesRegistered(t *testing.T) {
+	userID := uuid.New()
+	auth := softauthn.New(passkeyOrigin)
+
+	uc := NewPasskeyUsecase(newMFAUserRepo(userID), newPasskeyRepo(), newWebAuthn(t))
+	registerPasskey(t, uc, auth, userID)
+
+	creation, _, err := uc.BeginRegistration(context.Background(), userID.String())
+	require.NoError(t, err)
+	require.Len(t, creation.Response.CredentialExcludeList, 1)
+
+	// 同じ認証器では重ねて登録できない
+	options, err := json.Marshal(creation)
+	require.NoError(t, err)
+	_, err = auth.Register(options)
+	assert.ErrorIs(t, err, softauthn.ErrExcluded)
+}
+
+func TestPasskey_FinishRegistrationWrongOrigin(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	auth := softauthn.New("https://evil.example.com")
+
+	mockPasskeyRepo := newPasskeyRepo()
+	uc := NewPasskeyUsecase(newMFAUserRepo(userID), mockPasskeyRepo, newWebAuthn(t))
+
+	creation, session, err := uc.BeginRegistration(ctx, userID.String())
+	require.NoError(t, err)
+	options, err := json.Marshal(creation)
+	require.NoError(t, err)
+	body, err := auth.Register(options)
+	require.NoError(t, err)
+
+	_, err = uc.FinishRegistration(ctx, FinishPasskeyRegistrationParams{
+		UserID:  userID.String(),
+		Session: *session,
+		Body:    bytes.NewReader(body),
+	})
+	assert.ErrorIs(t, err, ErrInvalidPasskey)
+	assert.Empty(t, mockPasskeyRepo.StorePasskeyCalls())
+}
+
+func TestPasskey_LoginSignCountRollback(t *testing.T) {
+	userID := uuid.New()
+	auth := softauthn.New(passkeyOrigin)
+
+	mockPasskeyRepo := newPasskeyRepo()
+	uc := NewPasskeyUsecase(newMFAUserRepo(userID), mockPasskeyRepo, newWebAuthn(t))
+	registered := registerPasskey(t, uc, auth, userID)
+
+	// 複製された認証器が先に使われ、保存済みのカウンタの方が進んでいる
+	cloned := domain.NewPasskey(domain.PasskeyParams{
+		CredentialID:    registered.GetCredentialID(),
+		UserID:          userID,
+		PublicKey:       registered.GetPublicKey(),
+		AttestationType: registered.GetAttestationType(),
+		SignCount:       5,
+	})
+	mockPasskeyRepo = newPasskeyRepo(cloned)
+	uc = NewPasskeyUsecase(newMFAUserRepo(userID), mockPasskeyRepo, newWebAuthn(t))
+
+	_, err := loginWithPasskey(t, uc, auth)
+	assert.ErrorIs(t, err, ErrInvalidPasskey)
+	assert.Empty(t, mockPasskeyRepo.UpdatePasskeySignCountCalls())
+}
+
+func TestPasskey_LoginUsedConcurrently(t *testing.T) {
+	userID := uuid.New()
+	auth := softauthn.New(passkeyOrigin)
+
+	mockPasskeyRepo := newPasskeyRepo()
+	uc := NewPasskeyUsecase(newMFAUserRepo(userID), mockPasskeyRepo, newWebAuthn(t))
+	registerPasskey(t, uc, auth, userID)
+
+	// 同じカウンタの応答が先に記録された
+	mockPasskeyRepo.UpdatePasskeySignCountFunc = func(ctx context.Context, credentialID []byte, count uint32, usedAt time.Time) (bool, error) {
+		return false, nil
+	}
+
+	_, err := loginWithPasskey(t, uc, auth)
+	assert.ErrorIs(t, err, ErrInvalidPasskey)
+}
+
+func TestPasskey_LoginChallengeMismatch(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	auth := softauthn.New(passkeyOrigin)
+
+	mockPasskeyRepo := newPasskeyRepo()
+	uc := NewPasskeyUsecase(newMFAUserRepo(userID), mockPasskeyRepo, newWebAuthn(t))
+	registerPasskey(t, uc, auth, userID)
+
+	assertion, _, err := uc.BeginLogin(ctx)
+	require.NoError(t, err)
+	options, err := json.Marshal(assertion)
+	require.NoError(t, err)
+	body, err := auth.Login(options)
+	require.NoError(t, err)
+
+	// 別のセレモニーのセッションでは検証できない
+	_, other, err := uc.BeginLogin(ctx)
+	require.NoError(t, err)
+	_, err = uc.FinishLogin(ctx, *other, bytes.NewReader(body))
+	assert.ErrorIs(t, err, ErrInvalidPasskey)
+	assert.Empty(t, mockPasskeyRepo.UpdatePasskeySignCountCalls())
+}
+
+func TestPasskey_LoginUnknownCredential(t *testing.T) {
+	userID := uuid.New()
+	auth := softauthn.New(passkeyOrigin)
+
+	uc := NewPasskeyUsecase(newMFAUserRepo(userID), newPasskeyRepo(), newWebAuthn(t))
+	registerPasskey(t, uc, auth, userID)
+
+	// 削除されたパスキーではサインインできない
+	uc = NewPasskeyUsecase(newMFAUserRepo(userID), newPasskeyRepo(), newWebAuthn(t))
+	_, err := loginWithPasskey(t, uc, auth)
+	assert.ErrorIs(t, err, ErrInvalidPasskey)
+}
+
+func TestPasskey_DeletePasskey(t *testing.T) {
+	userID := uuid.New()
+	credentialID := []byte("credential-id")
+
+	mockPasskeyRepo := &domain.PasskeyRepositoryMock{
+		DeletePasskeyFunc: func(ctx context.Context, userID uuid.UUID, credentialID []byte) error {
+			return nil
+		},
+	}
+	uc := NewPasskeyUsecase(newMFAUserRepo(userID), mockPasskeyRepo, newWebAuthn(t))
+
+	err := uc.DeletePasskey(context.Background(), userID.String(), base64.RawURLEncoding.EncodeToString(credentialID))
+	require.NoError(t, err)
+
+	// 他のユーザーのパスキーを削除できないよう、ユーザーIDを条件に含める
+	call := mockPasskeyRepo.DeletePasskeyCalls()[0]
+	assert.Equal(t, userID, call.UserID)
+	assert.Equal(t, credentialID, call.CredentialID)
+}
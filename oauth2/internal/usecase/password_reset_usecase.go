@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+const forgotPasswordURI = "/client/forgot-password"
+
+func NewPasswordResetUsecase(
+	userRepo domain.UserRepository,
+	tokenRepo domain.PasswordResetTokenRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	sessionRevoker domain.SessionRevoker,
+	mailSender domain.MailSender,
+	hasher domain.PasswordHasher,
+	policy domain.PasswordPolicy,
+	cfg *config.Config,
+	logger *slog.Logger,
+) IPasswordResetUsecase {
+	return &PasswordResetUsecase{
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		sessionRevoker:   sessionRevoker,
+		mailSender:       mailSender,
+		hasher:           hasher,
+		policy:           policy,
+		config:           cfg,
+		logger:           logger,
+	}
+}
+
+type IPasswordResetUsecase interface {
+	RequestPasswordReset(ctx context.Context, email string) error
+	ForcePasswordReset(ctx context.Context, userID uuid.UUID) error
+	ValidateResetToken(ctx context.Context, token string) error
+	ResetPassword(ctx context.Context, p ResetPasswordParams) error
+}
+
+type PasswordResetUsecase struct {
+	userRepo         domain.UserRepository
+	tokenRepo        domain.PasswordResetTokenRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	sessionRevoker   domain.SessionRevoker
+	mailSender       domain.MailSender
+	hasher           domain.PasswordHasher
+	policy           domain.PasswordPolicy
+	config           *config.Config
+	logger           *slog.Logger
+	// wg は応答の後に続けている再設定の依頼の処理
+	wg sync.WaitGroup
+}
+
+type ResetPasswordParams struct {
+	Token    string
+	Password string
+}
+
+// RequestPasswordReset は再設定用のリンクをメールで送る。
+// 登録の有無が分からないよう、ユーザーの検索から送信までを応答の後に行い、応答の内容にも時間にも差が出ないようにする。
+// 失敗はログに記録するだけで、常に成功として扱う
+func (uc *PasswordResetUsecase) RequestPasswordReset(ctx context.Context, email string) error {
+	// 応答を返した後にリクエストのコンテキストがキャンセルされても続ける
+	ctx = context.WithoutCancel(ctx)
+	uc.wg.Go(func() {
+		if err := uc.requestPasswordReset(ctx, email); err != nil {
+			uc.logger.ErrorContext(ctx, "password reset request failed", errors.LogStackTrace(err))
+		}
+	})
+	return nil
+}
+
+// requestPasswordReset はユーザーが存在しない場合や短時間に繰り返した場合は何もしない
+func (uc *PasswordResetUsecase) requestPasswordReset(ctx context.Context, email string) error {
+	user, err := uc.userRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if user.IsNotFound() {
+		return nil
+	}
+
+	now := time.Now()
+	interval := time.Duration(uc.config.PasswordResetInterval) * time.Second
+	sent, err := uc.tokenRepo.CountPasswordResetTokensSince(ctx, user.GetID(), now.Add(-interval))
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if sent > 0 {
+		return nil
+	}
+
+	return uc.sendResetLink(ctx, user, "If you did not request this, you can ignore this email.")
+}
+
+// ForcePasswordReset は管理者の操作で今のパスワードを使えなくし、再設定用のリンクを送る。
+// 発行済みのリフレッシュトークンとSSOセッションも全て失効させる
+func (uc *PasswordResetUsecase) ForcePasswordReset(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if user.IsNotFound() {
+		return ErrUserNotFound
+	}
+
+	if err := uc.userRepo.RequirePasswordReset(ctx, user.GetID()); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.refreshTokenRepo.RevokeRefreshTokensByUserID(ctx, user.GetID()); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.sessionRevoker.RevokeUserSessions(ctx, user.GetID(), time.Now()); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	// 利用者が依頼したものではないため、送信間隔の制限はかけない
+	return uc.sendResetLink(ctx, user, "An administrator has asked you to set a new password. You cannot sign in with your current password until you do.")
+}
+
+func (uc *PasswordResetUsecase) sendResetLink(ctx context.Context, user domain.User, note string) error {
+	expires := time.Duration(uc.config.PasswordResetExpires) * time.Second
+	t, token, err := domain.IssuePasswordResetToken(user.GetID(), expires)
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.tokenRepo.StorePasswordResetToken(ctx, t); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	link := fmt.Sprintf("%s/client/reset-password?token=%s", uc.config.BaseURL, url.QueryEscape(token))
+	if err := uc.mailSender.Send(ctx, domain.Mail{
+		To:      user.GetEmail(),
+		Subject: "Reset your password",
+		Body:    fmt.Sprintf("Open the link below to set a new password.\n\n%s\n\nThe link expires at %s. %s", link, t.GetExpiresAt().Format(time.RFC3339), note),
+	}); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	return nil
+}
+
+// ValidateResetToken は再設定画面を表示する前にトークンが使えるかを確認する
+func (uc *PasswordResetUsecase) ValidateResetToken(ctx context.Context, token string) error {
+	_, err := uc.findValidToken(ctx, token)
+	return err
+}
+
+// ResetPassword はパスワードを変更し、発行済みのリフレッシュトークンとSSOセッションを全て失効させる
+func (uc *PasswordResetUsecase) ResetPassword(ctx context.Context, p ResetPasswordParams) error {
+	t, err := uc.findValidToken(ctx, p.Token)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.FindUserByID(ctx, t.GetUserID())
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if user.IsNotFound() {
+		return errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "invalid password reset token", forgotPasswordURI)
+	}
+
+	// リンクを使い切る前に検証し、規則を満たさない場合は同じリンクで入力し直せるようにする
+	if err := uc.policy.Validate(p.Password, user.GetEmail()); err != nil {
+		return passwordPolicyError(err, "/client/reset-password?token="+url.QueryEscape(p.Token))
+	}
+
+	consumed, err := uc.tokenRepo.ConsumePasswordResetToken(ctx, t.GetTokenHash())
+	if err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if !consumed {
+		return errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "password reset token has already been used", forgotPasswordURI)
+	}
+
+	if err := user.SetPassword(uc.policy, uc.hasher, p.Password); err != nil {
+		return passwordPolicyError(err, forgotPasswordURI)
+	}
+	if err := uc.userRepo.UpdatePassword(ctx, user); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	// 同時に発行された他のリンクも使えないようにする
+	if err := uc.tokenRepo.ConsumePasswordResetTokensByUserID(ctx, user.GetID()); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.refreshTokenRepo.RevokeRefreshTokensByUserID(ctx, user.GetID()); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.sessionRevoker.RevokeUserSessions(ctx, user.GetID(), time.Now()); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	return nil
+}
+
+func (uc *PasswordResetUsecase) findValidToken(ctx context.Context, token string) (domain.PasswordResetToken, error) {
+	t, err := uc.tokenRepo.FindPasswordResetToken(ctx, domain.HashOpaqueToken(token))
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if t == nil {
+		return nil, errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "invalid password reset token", forgotPasswordURI)
+	}
+	if t.IsUsed() {
+		return nil, errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "password reset token has already been used", forgotPasswordURI)
+	}
+	if t.IsExpired(time.Now()) {
+		return nil, errors.NewUsecaseErrorWithRedirectURI(http.StatusFound, "password reset token has expired", forgotPasswordURI)
+	}
+	return t, nil
+}
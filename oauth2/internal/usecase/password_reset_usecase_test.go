@@ -0,0 +1,352 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/config"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPasswordResetConfig() *config.Config {
+	return &config.Config{
+		BaseURL:               "https://auth.example.com",
+		PasswordResetExpires:  1800,
+		PasswordResetInterval: 60,
+	}
+}
+
+func newPasswordResetUserRepo(userID uuid.UUID) *domain.UserRepositoryMock {
+	u := domain.NewUser(domain.UserParams{ID: userID, Email: "user@example.com"})
+	return &domain.UserRepositoryMock{
+		FindUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
+			if email != "user@example.com" {
+				return domain.NewUser(domain.UserParams{}), nil
+			}
+			return u, nil
+		},
+		FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return u, nil
+		},
+		UpdatePasswordFunc: func(ctx context.Context, u domain.User) error {
+			return nil
+		},
+	}
+}
+
+func newPasswordResetTokenRepo(userID uuid.UUID, token string, expiresAt time.Time, usedAt *time.Time) *domain.PasswordResetTokenRepositoryMock {
+	return &domain.PasswordResetTokenRepositoryMock{
+		FindPasswordResetTokenFunc: func(ctx context.Context, tokenHash string) (domain.PasswordResetToken, error) {
+			if tokenHash != domain.HashOpaqueToken(token) {
+				return nil, nil
+			}
+			return domain.NewPasswordResetToken(domain.PasswordResetTokenParams{
+				TokenHash: tokenHash,
+				UserID:    userID,
+				ExpiresAt: expiresAt,
+				UsedAt:    usedAt,
+			}), nil
+		},
+		ConsumePasswordResetTokenFunc: func(ctx context.Context, tokenHash string) (bool, error) {
+			return true, nil
+		},
+		ConsumePasswordResetTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID) error {
+			return nil
+		},
+	}
+}
+
+func TestRequestPasswordReset_Success(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockTokenRepo := &domain.PasswordResetTokenRepositoryMock{
+		CountPasswordResetTokensSinceFunc: func(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+			return 0, nil
+		},
+		StorePasswordResetTokenFunc: func(ctx context.Context, t domain.PasswordResetToken) error {
+			return nil
+		},
+	}
+	mockSender := &domain.MailSenderMock{
+		SendFunc: func(ctx context.Context, m domain.Mail) error {
+			return nil
+		},
+	}
+
+	uc := NewPasswordResetUsecase(newPasswordResetUserRepo(userID), mockTokenRepo, nil, nil, mockSender, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+	err := uc.RequestPasswordReset(ctx, "user@example.com")
+	require.NoError(t, err)
+	waitPasswordResetRequests(uc)
+
+	require.Len(t, mockSender.SendCalls(), 1)
+	m := mockSender.SendCalls()[0].M
+	assert.Equal(t, "user@example.com", m.To)
+
+	// メール本文のリンクに含まれるトークンのハッシュが保存されている
+	prefix := "https://auth.example.com/client/reset-password?token="
+	start := strings.Index(m.Body, prefix)
+	require.GreaterOrEqual(t, start, 0)
+	token, err := url.QueryUnescape(strings.Fields(m.Body[start+len(prefix):])[0])
+	require.NoError(t, err)
+
+	stored := mockTokenRepo.StorePasswordResetTokenCalls()[0].T
+	assert.Equal(t, domain.HashOpaqueToken(token), stored.GetTokenHash())
+	assert.Equal(t, userID, stored.GetUserID())
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), stored.GetExpiresAt(), time.Minute)
+}
+
+func TestRequestPasswordReset_UnknownEmail(t *testing.T) {
+	ctx := context.Background()
+
+	mockTokenRepo := &domain.PasswordResetTokenRepositoryMock{}
+	mockSender := &domain.MailSenderMock{}
+
+	uc := NewPasswordResetUsecase(newPasswordResetUserRepo(uuid.New()), mockTokenRepo, nil, nil, mockSender, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+	err := uc.RequestPasswordReset(ctx, "unknown@example.com")
+	waitPasswordResetRequests(uc)
+
+	// 登録されていない場合も同じ結果を返す
+	require.NoError(t, err)
+	assert.Empty(t, mockTokenRepo.StorePasswordResetTokenCalls())
+	assert.Empty(t, mockSender.SendCalls())
+}
+
+func TestRequestPasswordReset_RateLimited(t *testing.T) {
+	ctx := context.Background()
+
+	mockTokenRepo := &domain.PasswordResetTokenRepositoryMock{
+		CountPasswordResetTokensSinceFunc: func(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+			return 1, nil
+		},
+	}
+	mockSender := &domain.MailSenderMock{}
+
+	uc := NewPasswordResetUsecase(newPasswordResetUserRepo(uuid.New()), mockTokenRepo, nil, nil, mockSender, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+	err := uc.RequestPasswordReset(ctx, "user@example.com")
+	waitPasswordResetRequests(uc)
+
+	require.NoError(t, err)
+	assert.Empty(t, mockTokenRepo.StorePasswordResetTokenCalls())
+	assert.Empty(t, mockSender.SendCalls())
+}
+
+func TestRequestPasswordReset_ReturnsBeforeSending(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockTokenRepo := &domain.PasswordResetTokenRepositoryMock{
+		CountPasswordResetTokensSinceFunc: func(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+			return 0, nil
+		},
+		StorePasswordResetTokenFunc: func(ctx context.Context, t domain.PasswordResetToken) error {
+			return nil
+		},
+	}
+	release := make(chan struct{})
+	mockSender := &domain.MailSenderMock{
+		SendFunc: func(ctx context.Context, m domain.Mail) error {
+			<-release
+			return ctx.Err()
+		},
+	}
+
+	uc := NewPasswordResetUsecase(newPasswordResetUserRepo(uuid.New()), mockTokenRepo, nil, nil, mockSender, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+
+	// 送信が終わるのを待たずに応答するため、登録されている場合も応答時間から分からない
+	require.NoError(t, uc.RequestPasswordReset(ctx, "user@example.com"))
+
+	// 応答の後にリクエストが終わっても送信は続ける
+	cancel()
+	close(release)
+	waitPasswordResetRequests(uc)
+	require.Len(t, mockSender.SendCalls(), 1)
+	assert.NoError(t, mockSender.SendCalls()[0].Ctx.Err())
+}
+
+// waitPasswordResetRequests は応答の後に続けている処理が終わるのを待つ
+func waitPasswordResetRequests(uc IPasswordResetUsecase) {
+	uc.(*PasswordResetUsecase).wg.Wait()
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUserRepo := newPasswordResetUserRepo(userID)
+	mockTokenRepo := newPasswordResetTokenRepo(userID, "token", time.Now().Add(time.Hour), nil)
+	mockRefreshTokenRepo := &domain.RefreshTokenRepositoryMock{
+		RevokeRefreshTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID) error {
+			return nil
+		},
+	}
+	mockRevoker := &domain.SessionRevokerMock{
+		RevokeUserSessionsFunc: func(ctx context.Context, userID uuid.UUID, at time.Time) error {
+			return nil
+		},
+	}
+
+	uc := NewPasswordResetUsecase(mockUserRepo, mockTokenRepo, mockRefreshTokenRepo, mockRevoker, &domain.MailSenderMock{}, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+	err := uc.ResetPassword(ctx, ResetPasswordParams{Token: "token", Password: "new-password"})
+	require.NoError(t, err)
+
+	require.Len(t, mockUserRepo.UpdatePasswordCalls(), 1)
+	assert.True(t, mockUserRepo.UpdatePasswordCalls()[0].U.IsPasswordMatch(newTestHasher(t), "new-password"))
+	assert.Equal(t, domain.HashOpaqueToken("token"), mockTokenRepo.ConsumePasswordResetTokenCalls()[0].TokenHash)
+	assert.Equal(t, userID, mockTokenRepo.ConsumePasswordResetTokensByUserIDCalls()[0].UserID)
+	assert.Equal(t, userID, mockRefreshTokenRepo.RevokeRefreshTokensByUserIDCalls()[0].UserID)
+	assert.Equal(t, userID, mockRevoker.RevokeUserSessionsCalls()[0].UserID)
+}
+
+func assertPasswordResetTokenRejected(t *testing.T, err error) {
+	t.Helper()
+	var usecaseErr *errors.UsecaseError
+	require.ErrorAs(t, err, &usecaseErr)
+	assert.Equal(t, http.StatusFound, usecaseErr.Code)
+	assert.Equal(t, "/client/forgot-password", usecaseErr.RedirectURI)
+}
+
+func TestResetPassword_InvalidToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUserRepo := newPasswordResetUserRepo(userID)
+	mockTokenRepo := newPasswordResetTokenRepo(userID, "token", time.Now().Add(time.Hour), nil)
+
+	uc := NewPasswordResetUsecase(mockUserRepo, mockTokenRepo, nil, nil, &domain.MailSenderMock{}, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+	err := uc.ResetPassword(ctx, ResetPasswordParams{Token: "other", Password: "new-password"})
+
+	assertPasswordResetTokenRejected(t, err)
+	assert.Empty(t, mockUserRepo.UpdatePasswordCalls())
+}
+
+func TestResetPassword_UsedToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	usedAt := time.Now().Add(-time.Minute)
+
+	mockUserRepo := newPasswordResetUserRepo(userID)
+	mockTokenRepo := newPasswordResetTokenRepo(userID, "token", time.Now().Add(time.Hour), &usedAt)
+
+	uc := NewPasswordResetUsecase(mockUserRepo, mockTokenRepo, nil, nil, &domain.MailSenderMock{}, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+	err := uc.ResetPassword(ctx, ResetPasswordParams{Token: "token", Password: "new-password"})
+
+	assertPasswordResetTokenRejected(t, err)
+	assert.Empty(t, mockTokenRepo.ConsumePasswordResetTokenCalls())
+	assert.Empty(t, mockUserRepo.UpdatePasswordCalls())
+}
+
+func TestResetPassword_ExpiredToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUserRepo := newPasswordResetUserRepo(userID)
+	mockTokenRepo := newPasswordResetTokenRepo(userID, "token", time.Now().Add(-time.Second), nil)
+
+	uc := NewPasswordResetUsecase(mockUserRepo, mockTokenRepo, nil, nil, &domain.MailSenderMock{}, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+	err := uc.ResetPassword(ctx, ResetPasswordParams{Token: "token", Password: "new-password"})
+
+	assertPasswordResetTokenRejected(t, err)
+	assert.Empty(t, mockUserRepo.UpdatePasswordCalls())
+}
+
+func TestResetPassword_ConsumedConcurrently(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUserRepo := newPasswordResetUserRepo(userID)
+	mockTokenRepo := newPasswordResetTokenRepo(userID, "token", time.Now().Add(time.Hour), nil)
+	mockTokenRepo.ConsumePasswordResetTokenFunc = func(ctx context.Context, tokenHash string) (bool, error) {
+		return false, nil
+	}
+
+	uc := NewPasswordResetUsecase(mockUserRepo, mockTokenRepo, nil, nil, &domain.MailSenderMock{}, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+	err := uc.ResetPassword(ctx, ResetPasswordParams{Token: "token", Password: "new-password"})
+
+	assertPasswordResetTokenRejected(t, err)
+	assert.Empty(t, mockUserRepo.UpdatePasswordCalls())
+}
+
+func TestResetPassword_PasswordPolicy(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUserRepo := newPasswordResetUserRepo(userID)
+	mockTokenRepo := newPasswordResetTokenRepo(userID, "token", time.Now().Add(time.Hour), nil)
+
+	uc := NewPasswordResetUsecase(mockUserRepo, mockTokenRepo, nil, nil, &domain.MailSenderMock{}, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+	err := uc.ResetPassword(ctx, ResetPasswordParams{Token: "token", Password: "short"})
+
+	var usecaseErr *errors.UsecaseError
+	require.ErrorAs(t, err, &usecaseErr)
+	assert.Equal(t, http.StatusFound, usecaseErr.Code)
+	// リンクは使い切らず、同じリンクで入力し直せる
+	assert.Equal(t, "/client/reset-password?token=token", usecaseErr.RedirectURI)
+	var policyErr *domain.PasswordPolicyError
+	require.ErrorAs(t, err, &policyErr)
+	assert.Equal(t, domain.PasswordViolationTooShort, policyErr.Violation)
+	assert.Empty(t, mockTokenRepo.ConsumePasswordResetTokenCalls())
+	assert.Empty(t, mockUserRepo.UpdatePasswordCalls())
+}
+
+func TestValidateResetToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockTokenRepo := newPasswordResetTokenRepo(userID, "token", time.Now().Add(time.Hour), nil)
+	uc := NewPasswordResetUsecase(newPasswordResetUserRepo(userID), mockTokenRepo, nil, nil, &domain.MailSenderMock{}, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+
+	require.NoError(t, uc.ValidateResetToken(ctx, "token"))
+	assertPasswordResetTokenRejected(t, uc.ValidateResetToken(ctx, "other"))
+	assert.Empty(t, mockTokenRepo.ConsumePasswordResetTokenCalls())
+}
+
+func TestForcePasswordReset(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUserRepo := newPasswordResetUserRepo(userID)
+	mockUserRepo.RequirePasswordResetFunc = func(ctx context.Context, id uuid.UUID) error {
+		return nil
+	}
+	mockTokenRepo := &domain.PasswordResetTokenRepositoryMock{
+		StorePasswordResetTokenFunc: func(ctx context.Context, t domain.PasswordResetToken) error {
+			return nil
+		},
+	}
+	mockRefreshTokenRepo := &domain.RefreshTokenRepositoryMock{
+		RevokeRefreshTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID) error {
+			return nil
+		},
+	}
+	mockRevoker := &domain.SessionRevokerMock{
+		RevokeUserSessionsFunc: func(ctx context.Context, userID uuid.UUID, at time.Time) error {
+			return nil
+		},
+	}
+	mockSender := &domain.MailSenderMock{
+		SendFunc: func(ctx context.Context, m domain.Mail) error {
+			return nil
+		},
+	}
+
+	uc := NewPasswordResetUsecase(mockUserRepo, mockTokenRepo, mockRefreshTokenRepo, mockRevoker, mockSender, newTestHasher(t), newTestPolicy(), newPasswordResetConfig(), slog.New(slog.DiscardHandler))
+	require.NoError(t, uc.ForcePasswordReset(ctx, userID))
+
+	require.Len(t, mockUserRepo.RequirePasswordResetCalls(), 1)
+	assert.Equal(t, userID, mockUserRepo.RequirePasswordResetCalls()[0].ID)
+	require.Len(t, mockRefreshTokenRepo.RevokeRefreshTokensByUserIDCalls(), 1)
+	require.Len(t, mockRevoker.RevokeUserSessionsCalls(), 1)
+	// 送信間隔を確認せずにリンクを送る
+	assert.Empty(t, mockTokenRepo.CountPasswordResetTokensSinceCalls())
+	require.Len(t, mockSender.SendCalls(), 1)
+	assert.Contains(t, mockSender.SendCalls()[0].M.Body, "https://auth.example.com/client/reset-password?token=")
+}
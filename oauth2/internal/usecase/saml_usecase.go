@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/oidc"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/saml"
+)
+
+const (
+	// samlProviderPrefix は紐づけの provider に付ける接頭辞。OpenID プロバイダの名前と区別する
+	samlProviderPrefix = "saml:"
+	// samlLoginTTL は ACS で受け取ってからサインインを終えるまでの猶予
+	samlLoginTTL = 5 * time.Minute
+)
+
+// IdP から受け取った応答を受け付けられない。ACS への POST にはセッションがないため、サインイン画面には戻さない
+var ErrInvalidSAMLResponse = errors.NewUsecaseError(http.StatusBadRequest, "invalid SAML response")
+
+// SAMLServiceProviderParams は全ての接続で共有する SP の設定
+type SAMLServiceProviderParams struct {
+	// BaseURL から接続ごとの entity ID と ACS のURLを作る
+	BaseURL string
+	// Key と Certificate を指定した場合は、認証リクエストに署名し、暗号化したアサーションを受け付ける
+	Key         *rsa.PrivateKey
+	Certificate *x509.Certificate
+}
+
+func NewSAMLUsecase(
+	userRepo domain.UserRepository,
+	identityRepo domain.FederatedIdentityRepository,
+	connectionRepo domain.SAMLConnectionRepository,
+	loginStore domain.SAMLLoginStore,
+	params SAMLServiceProviderParams,
+) ISAMLUsecase {
+	return &SAMLUsecase{
+		linker:         identityLinker{userRepo: userRepo, identityRepo: identityRepo},
+		connectionRepo: connectionRepo,
+		loginStore:     loginStore,
+		params:         params,
+	}
+}
+
+type ISAMLUsecase interface {
+	Connections(ctx context.Context) ([]FederationProviderSummary, error)
+	Metadata(ctx context.Context, connection string) ([]byte, error)
+	BeginSignin(ctx context.Context, connection string) (*SAMLRequest, error)
+	ConsumeResponse(ctx context.Context, connection, samlResponse string) (string, error)
+	FinishSignin(ctx context.Context, req SAMLRequest, token string) (domain.User, error)
+}
+
+type SAMLUsecase struct {
+	linker         identityLinker
+	connectionRepo domain.SAMLConnectionRepository
+	loginStore     domain.SAMLLoginStore
+	params         SAMLServiceProviderParams
+}
+
+// SAMLRequest は IdP に送った認証リクエスト。応答と照合するためセッションに保存する
+type SAMLRequest struct {
+	Connection string
+	RequestID  string
+	URL        string
+}
+
+// Connections はサインイン画面のボタンに表示する接続を返す
+func (uc *SAMLUsecase) Connections(ctx context.Context) ([]FederationProviderSummary, error) {
+	connections, err := uc.connectionRepo.FindSAMLConnections(ctx)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	summaries := make([]FederationProviderSummary, 0, len(connections))
+	for _, c := range connections {
+		summaries = append(summaries, FederationProviderSummary{Name: c.GetName(), DisplayName: c.GetDisplayName()})
+	}
+	return summaries, nil
+}
+
+// Metadata は IdP に登録する SP のメタデータを返す
+func (uc *SAMLUsecase) Metadata(ctx context.Context, name string) ([]byte, error) {
+	_, sp, err := uc.serviceProvider(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	md, err := sp.Metadata()
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return md, nil
+}
+
+// BeginSignin は認証リクエストを作り、IdP にリダイレクトするURLを返す
+func (uc *SAMLUsecase) BeginSignin(ctx context.Context, name string) (*SAMLRequest, error) {
+	_, sp, err := uc.serviceProvider(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	req, err := sp.AuthnRequest()
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return &SAMLRequest{Connection: name, RequestID: req.ID, URL: req.URL}, nil
+}
+
+// ConsumeResponse は ACS に POST された応答を検証し、サインインを終えるためのトークンを返す。
+// 一度受け付けたアサーションは有効期間が過ぎるまで再び受け付けない
+func (uc *SAMLUsecase) ConsumeResponse(ctx context.Context, name, samlResponse string) (string, error) {
+	conn, sp, err := uc.serviceProvider(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	assertion, err := sp.ParseResponse(samlResponse)
+	if err != nil {
+		if errors.Is(err, saml.ErrInvalidResponse) {
+			return "", ErrInvalidSAMLResponse
+		}
+		return "", errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+
+	first, err := uc.loginStore.MarkAssertionUsed(ctx, name, assertion.ID, assertion.ExpiresAt)
+	if err != nil {
+		return "", errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if !first {
+		return "", ErrInvalidSAMLResponse
+	}
+
+	token, err := oidc.RandomValue()
+	if err != nil {
+		return "", errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.loginStore.SaveLogin(ctx, token, domain.SAMLLogin{
+		Connection: name,
+		RequestID:  assertion.RequestID,
+		NameID:     assertion.NameID,
+		Email:      samlEmail(conn, assertion),
+		Name:       assertion.Attribute(conn.GetNameAttribute()),
+	}, samlLoginTTL); err != nil {
+		return "", errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return token, nil
+}
+
+// FinishSignin は ACS で受け取ったサインインが、このセッションで送った認証リクエストへの応答であることを確かめ、
+// NameID に紐づけたユーザーを返す。紐づけがない場合の扱いは OpenID プロバイダと同じ
+func (uc *SAMLUsecase) FinishSignin(ctx context.Context, req SAMLRequest, token string) (domain.User, error) {
+	if token == "" {
+		return nil, ErrFederationFailed
+	}
+	login, err := uc.loginStore.TakeLogin(ctx, token)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if login == nil || login.Connection != req.Connection ||
+		subtle.ConstantTimeCompare([]byte(login.RequestID), []byte(req.RequestID)) != 1 {
+		return nil, ErrFederationFailed
+	}
+
+	conn, err := uc.connectionRepo.FindSAMLConnectionByName(ctx, req.Connection)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if conn == nil {
+		return nil, ErrFederationFailed
+	}
+
+	// 接続は管理者が登録した企業の IdP に限るため、返されたメールアドレスは確認済みとして扱う
+	return uc.linker.signin(ctx, externalIdentity{
+		Provider:      samlProviderPrefix + conn.GetName(),
+		Subject:       login.NameID,
+		Email:         login.Email,
+		EmailVerified: true,
+		Name:          login.Name,
+	}, conn.IsLinkByEmail())
+}
+
+func (uc *SAMLUsecase) serviceProvider(ctx context.Context, name string) (domain.SAMLConnection, *saml.ServiceProvider, error) {
+	conn, err := uc.connectionRepo.FindSAMLConnectionByName(ctx, name)
+	if err != nil {
+		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if conn == nil {
+		return nil, nil, errors.NewUsecaseError(http.StatusNotFound, "SAML connection not found")
+	}
+
+	base := uc.params.BaseURL + "/saml/" + url.PathEscape(conn.GetName())
+	sp, err := saml.NewServiceProvider(saml.Config{
+		EntityID:    base + "/metadata",
+		ACSURL:      base + "/acs",
+		IdPMetadata: []byte(conn.GetIdPMetadata()),
+		Key:         uc.params.Key,
+		Certificate: uc.params.Certificate,
+	})
+	if err != nil {
+		return nil, nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return conn, sp, nil
+}
+
+// samlEmail は属性を指定していない場合、メールアドレス形式の NameID を使う
+func samlEmail(conn domain.SAMLConnection, a *saml.Assertion) string {
+	if attr := conn.GetEmailAttribute(); attr != "" {
+		return a.Attribute(attr)
+	}
+	if a.NameIDFormat == saml.EmailAddressNameIDFormat {
+		return a.NameID
+	}
+	return ""
+}
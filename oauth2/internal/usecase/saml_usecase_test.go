@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/saml"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/saml/samltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// samlFixture はテスト用の IdP とメモリ上のユーザー、紐づけ、アサーションの記録を持つ
+type samlFixture struct {
+	idp        *samltest.IdP
+	users      map[uuid.UUID]domain.User
+	identities map[string]domain.FederatedIdentity
+	used       map[string]bool
+	logins     map[string]domain.SAMLLogin
+	userRepo   *domain.UserRepositoryMock
+	uc         ISAMLUsecase
+}
+
+func newSAMLFixture(t *testing.T, emailAttribute string, linkByEmail bool) *samlFixture {
+	t.Helper()
+	f := &samlFixture{
+		idp:        samltest.New(),
+		users:      map[uuid.UUID]domain.User{},
+		identities: map[string]domain.FederatedIdentity{},
+		used:       map[string]bool{},
+		logins:     map[string]domain.SAMLLogin{},
+	}
+
+	f.userRepo = &domain.UserRepositoryMock{
+		FindUserByEmailFunc: func(ctx context.Context, email string) (domain.User, error) {
+			for _, u := range f.users {
+				if u.GetEmail() == email {
+					return u, nil
+				}
+			}
+			return domain.NewUser(domain.UserParams{}), nil
+		},
+		FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			if u, ok := f.users[id]; ok {
+				return u, nil
+			}
+			return domain.NewUser(domain.UserParams{}), nil
+		},
+		CreateUserFunc: func(ctx context.Context, u domain.User) (domain.User, error) {
+			created := domain.NewUser(domain.UserParams{ID: uuid.New(), Name: u.GetName(), Email: u.GetEmail()})
+			f.users[created.GetID()] = created
+			return created, nil
+		},
+		VerifyEmailFunc: func(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+			u := f.users[id]
+			f.users[id] = domain.NewUser(domain.UserParams{ID: id, Name: u.GetName(), Email: u.GetEmail(), EmailVerifiedAt: &verifiedAt})
+			return nil
+		},
+	}
+	identityRepo := &domain.FederatedIdentityRepositoryMock{
+		FindFederatedIdentityFunc: func(ctx context.Context, provider, subject string) (domain.FederatedIdentity, error) {
+			return f.identities[provider+"/"+subject], nil
+		},
+		StoreFederatedIdentityFunc: func(ctx context.Context, fi domain.FederatedIdentity) error {
+			f.identities[fi.GetProvider()+"/"+fi.GetSubject()] = fi
+			return nil
+		},
+		TouchFederatedIdentityFunc: func(ctx context.Context, provider, subject, email string, usedAt time.Time) error {
+			return nil
+		},
+	}
+	conn := domain.NewSAMLConnection(domain.SAMLConnectionParams{
+		ID:             uuid.New(),
+		Name:           "acme",
+		DisplayName:    "Acme",
+		IdPMetadata:    string(f.idp.Metadata()),
+		EmailAttribute: emailAttribute,
+		NameAttribute:  "cn",
+		LinkByEmail:    linkByEmail,
+	})
+	connectionRepo := &domain.SAMLConnectionRepositoryMock{
+		FindSAMLConnectionByNameFunc: func(ctx context.Context, name string) (domain.SAMLConnection, error) {
+			if name == conn.GetName() {
+				return conn, nil
+			}
+			return nil, nil
+		},
+		FindSAMLConnectionsFunc: func(ctx context.Context) ([]domain.SAMLConnection, error) {
+			return []domain.SAMLConnection{conn}, nil
+		},
+	}
+	loginStore := &domain.SAMLLoginStoreMock{
+		MarkAssertionUsedFunc: func(ctx context.Context, connection, assertionID string, until time.Time) (bool, error) {
+			key := connection + "/" + assertionID
+			if f.used[key] {
+				return false, nil
+			}
+			f.used[key] = true
+			return true, nil
+		},
+		SaveLoginFunc: func(ctx context.Context, token string, login domain.SAMLLogin, ttl time.Duration) error {
+			f.logins[token] = login
+			return nil
+		},
+		TakeLoginFunc: func(ctx context.Context, token string) (*domain.SAMLLogin, error) {
+			login, ok := f.logins[token]
+			if !ok {
+				return nil, nil
+			}
+			delete(f.logins, token)
+			return &login, nil
+		},
+	}
+
+	f.uc = NewSAMLUsecase(f.userRepo, identityRepo, connectionRepo, loginStore, SAMLServiceProviderParams{
+		BaseURL: "https://auth.example.com",
+	})
+	md, err := f.uc.Metadata(context.Background(), "acme")
+	require.NoError(t, err)
+	require.NoError(t, f.idp.RegisterServiceProvider(md))
+	return f
+}
+
+// respond はブラウザの代わりに IdP で認証し、ACS に POST する応答を返す
+func (f *samlFixture) respond(t *testing.T, user samltest.User) (*SAMLRequest, string) {
+	t.Helper()
+	f.idp.Login(user)
+	req, err := f.uc.BeginSignin(context.Background(), "acme")
+	require.NoError(t, err)
+	resp, err := f.idp.Respond(req.URL)
+	require.NoError(t, err)
+	return req, resp
+}
+
+func (f *samlFixture) signin(t *testing.T, user samltest.User) (domain.User, error) {
+	t.Helper()
+	ctx := context.Background()
+	req, resp := f.respond(t, user)
+	token, err := f.uc.ConsumeResponse(ctx, "acme", resp)
+	if err != nil {
+		return nil, err
+	}
+	return f.uc.FinishSignin(ctx, *req, token)
+}
+
+func TestSAML_ProvisionUser(t *testing.T) {
+	f := newSAMLFixture(t, "mail", false)
+
+	user, err := f.signin(t, samltest.User{NameID: "u-1", Email: "alice@example.com", Name: "Alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", user.GetEmail())
+	assert.Equal(t, "Alice", user.GetName())
+	assert.True(t, user.IsEmailVerified())
+	require.Contains(t, f.identities, "saml:acme/u-1")
+
+	// 2回目以降は NameID で同じユーザーを返す
+	again, err := f.signin(t, samltest.User{NameID: "u-1", Email: "alice@new.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, user.GetID(), again.GetID())
+	assert.Len(t, f.userRepo.CreateUserCalls(), 1)
+}
+
+func TestSAML_EmailNameID(t *testing.T) {
+	f := newSAMLFixture(t, "", false)
+
+	user, err := f.signin(t, samltest.User{NameID: "bob@example.com", NameIDFormat: saml.EmailAddressNameIDFormat})
+	require.NoError(t, err)
+	assert.Equal(t, "bob@example.com", user.GetEmail())
+	assert.Equal(t, "bob", user.GetName())
+
+	// メールアドレスを得られない場合は作らない
+	_, err = f.signin(t, samltest.User{NameID: "u-2"})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusFound, err.(*errors.UsecaseError).Code)
+}
+
+func TestSAML_LinkByEmail(t *testing.T) {
+	existing := domain.NewUser(domain.UserParams{ID: uuid.New(), Email: "alice@example.com"})
+
+	f := newSAMLFixture(t, "mail", false)
+	f.users[existing.GetID()] = existing
+	_, err := f.signin(t, samltest.User{NameID: "u-1", Email: "alice@example.com"})
+	assert.ErrorIs(t, err, ErrFederatedEmailInUse)
+
+	f = newSAMLFixture(t, "mail", true)
+	f.users[existing.GetID()] = existing
+	user, err := f.signin(t, samltest.User{NameID: "u-1", Email: "alice@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, existing.GetID(), user.GetID())
+}
+
+func TestSAML_Replay(t *testing.T) {
+	ctx := context.Background()
+	f := newSAMLFixture(t, "mail", false)
+	req, resp := f.respond(t, samltest.User{NameID: "u-1", Email: "alice@example.com"})
+
+	token, err := f.uc.ConsumeResponse(ctx, "acme", resp)
+	require.NoError(t, err)
+	_, err = f.uc.ConsumeResponse(ctx, "acme", resp)
+	assert.ErrorIs(t, err, ErrInvalidSAMLResponse)
+
+	_, err = f.uc.FinishSignin(ctx, *req, token)
+	require.NoError(t, err)
+	// トークンも一度しか使えない
+	_, err = f.uc.FinishSignin(ctx, *req, token)
+	assert.ErrorIs(t, err, ErrFederationFailed)
+}
+
+func TestSAML_OtherSessionRequest(t *testing.T) {
+	ctx := context.Background()
+	f := newSAMLFixture(t, "mail", false)
+	_, resp := f.respond(t, samltest.User{NameID: "u-1", Email: "alice@example.com"})
+	token, err := f.uc.ConsumeResponse(ctx, "acme", resp)
+	require.NoError(t, err)
+
+	// 別のセッションで始めた認証リクエストでは、受け取ったサインインを使えない
+	other, err := f.uc.BeginSignin(ctx, "acme")
+	require.NoError(t, err)
+	_, err = f.uc.FinishSignin(ctx, *other, token)
+	assert.ErrorIs(t, err, ErrFederationFailed)
+	assert.Empty(t, f.identities)
+}
+
+func TestSAML_InvalidSignature(t *testing.T) {
+	f := newSAMLFixture(t, "mail", false)
+	// 登録したメタデータにない鍵で署名した応答は受け付けない
+	f.idp.RotateKey()
+
+	_, err := f.signin(t, samltest.User{NameID: "u-1", Email: "alice@example.com"})
+	assert.ErrorIs(t, err, ErrInvalidSAMLResponse)
+	assert.Empty(t, f.logins)
+}
+
+func TestSAML_UnknownConnection(t *testing.T) {
+	f := newSAMLFixture(t, "mail", false)
+
+	_, err := f.uc.BeginSignin(context.Background(), "other")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.(*errors.UsecaseError).Code)
+
+	connections, err := f.uc.Connections(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []FederationProviderSummary{{Name: "acme", DisplayName: "Acme"}}, connections)
+}
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+)
+
+var (
+	ErrUserNotFound   = errors.NewUsecaseError(http.StatusNotFound, "user not found")
+	ErrUserEmailInUse = errors.NewUsecaseError(http.StatusConflict, domain.ErrUserEmailAlreadyExists.Error())
+)
+
+func NewUserAdminUsecase(
+	userRepo domain.UserRepository,
+	tokenRepo domain.TokenRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	sessionRevoker domain.SessionRevoker,
+	hasher domain.PasswordHasher,
+	policy domain.PasswordPolicy,
+) IUserAdminUsecase {
+	return &UserAdminUsecase{
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		sessionRevoker:   sessionRevoker,
+		hasher:           hasher,
+		policy:           policy,
+	}
+}
+
+type IUserAdminUsecase interface {
+	SearchUsers(ctx context.Context, query string, offset, limit int) ([]domain.User, bool, error)
+	GetUser(ctx context.Context, userID uuid.UUID) (domain.User, error)
+	CreateUser(ctx context.Context, p CreateUserParams) (domain.User, error)
+	UpdateUser(ctx context.Context, userID uuid.UUID, p UpdateUserParams) (domain.User, error)
+	DisableUser(ctx context.Context, userID uuid.UUID) error
+	EnableUser(ctx context.Context, userID uuid.UUID) error
+	DeleteUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type UserAdminUsecase struct {
+	userRepo         domain.UserRepository
+	tokenRepo        domain.TokenRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	sessionRevoker   domain.SessionRevoker
+	hasher           domain.PasswordHasher
+	policy           domain.PasswordPolicy
+}
+
+type CreateUserParams struct {
+	Name  string
+	Email string
+	// Password が空の場合はパスワードを持たないユーザーを作る。パスワードでサインインするには再設定が必要になる
+	Password      string
+	EmailVerified bool
+	// IsAdmin が true の場合は管理用のAPIを呼び出せるユーザーとして作る
+	IsAdmin bool
+}
+
+// UpdateUserParams は nil の項目を変更しない
+type UpdateUserParams struct {
+	Name    *string
+	Email   *string
+	IsAdmin *bool
+}
+
+// SearchUsers はメールアドレスか名前に query を含むユーザーを返す。続きがあるかも返す
+func (uc *UserAdminUsecase) SearchUsers(ctx context.Context, query string, offset, limit int) ([]domain.User, bool, error) {
+	// 1件多く取得して続きの有無を判定する
+	users, err := uc.userRepo.SearchUsers(ctx, query, offset, limit+1)
+	if err != nil {
+		return nil, false, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if len(users) > limit {
+		return users[:limit], true, nil
+	}
+	return users, false, nil
+}
+
+func (uc *UserAdminUsecase) GetUser(ctx context.Context, userID uuid.UUID) (domain.User, error) {
+	user, err := uc.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if user.IsNotFound() {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (uc *UserAdminUsecase) CreateUser(ctx context.Context, p CreateUserParams) (domain.User, error) {
+	user := domain.NewUser(domain.UserParams{
+		Name:    p.Name,
+		Email:   p.Email,
+		IsAdmin: p.IsAdmin,
+	})
+	if p.Password != "" {
+		if err := user.SetPassword(uc.policy, uc.hasher, p.Password); err != nil {
+			var policyErr *domain.PasswordPolicyError
+			if errors.As(err, &policyErr) {
+				return nil, errors.WrapUsecaseError(http.StatusBadRequest, policyErr)
+			}
+			return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	created, err := uc.userRepo.CreateUser(ctx, user)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserEmailAlreadyExists) {
+			return nil, ErrUserEmailInUse
+		}
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if p.EmailVerified {
+		if err := uc.userRepo.VerifyEmail(ctx, created.GetID(), time.Now()); err != nil {
+			return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return uc.GetUser(ctx, created.GetID())
+}
+
+func (uc *UserAdminUsecase) UpdateUser(ctx context.Context, userID uuid.UUID, p UpdateUserParams) (domain.User, error) {
+	user, err := uc.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, email := user.GetName(), user.GetEmail()
+	if p.Name != nil {
+		name = *p.Name
+	}
+	if p.Email != nil {
+		email = *p.Email
+	}
+	// 新しいメールアドレスは本人が確認するまで未確認として扱う
+	verifiedAt := user.GetEmailVerifiedAt()
+	if email != user.GetEmail() {
+		verifiedAt = nil
+	}
+	if err := uc.userRepo.UpdateProfile(ctx, userID, name, email, verifiedAt); err != nil {
+		if errors.Is(err, domain.ErrUserEmailAlreadyExists) {
+			return nil, ErrUserEmailInUse
+		}
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	// 外した場合は発行済みの admin スコープのアクセストークンも次の呼び出しから拒否される
+	if p.IsAdmin != nil && *p.IsAdmin != user.IsAdmin() {
+		if err := uc.userRepo.UpdateAdmin(ctx, userID, *p.IsAdmin); err != nil {
+			return nil, errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return uc.GetUser(ctx, userID)
+}
+
+// DisableUser はユーザーを無効にし、発行済みのトークンとSSOセッションを全て失効させる
+func (uc *UserAdminUsecase) DisableUser(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !user.IsDisabled() {
+		if err := uc.userRepo.UpdateDisabledAt(ctx, userID, &now); err != nil {
+			return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	// 失効させる途中で失敗した場合も、再度呼び出せば残りを失効させられる
+	return uc.revokeAll(ctx, userID, now)
+}
+
+// EnableUser は無効にしたユーザーを有効に戻す。失効させたトークンとセッションは戻らない
+func (uc *UserAdminUsecase) EnableUser(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsDisabled() {
+		return nil
+	}
+	if err := uc.userRepo.UpdateDisabledAt(ctx, userID, nil); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// DeleteUser はSSOセッションを失効させ、ユーザーと、ユーザーに発行した認可コードとトークンを削除する
+func (uc *UserAdminUsecase) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	if _, err := uc.GetUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := uc.sessionRevoker.RevokeUserSessions(ctx, userID, time.Now()); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.userRepo.DeleteUser(ctx, userID); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+func (uc *UserAdminUsecase) revokeAll(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	if err := uc.tokenRepo.RevokeTokensByUserID(ctx, userID); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.refreshTokenRepo.RevokeRefreshTokensByUserID(ctx, userID); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	if err := uc.sessionRevoker.RevokeUserSessions(ctx, userID, now); err != nil {
+		return errors.NewUsecaseError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
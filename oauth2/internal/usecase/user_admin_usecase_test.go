@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sntkn/go-oauth2/oauth2/domain"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userAdminFixture はメモリ上のユーザーと失効させた記録を持つ
+type userAdminFixture struct {
+	users            map[uuid.UUID]domain.UserParams
+	tokenRepo        *domain.TokenRepositoryMock
+	refreshTokenRepo *domain.RefreshTokenRepositoryMock
+	revoker          *domain.SessionRevokerMock
+	uc               IUserAdminUsecase
+}
+
+func newUserAdminFixture(t *testing.T) *userAdminFixture {
+	t.Helper()
+	f := &userAdminFixture{users: map[uuid.UUID]domain.UserParams{}}
+	emailInUse := func(email string, except uuid.UUID) bool {
+		for id, u := range f.users {
+			if u.Email == email && id != except {
+				return true
+			}
+		}
+		return false
+	}
+	userRepo := &domain.UserRepositoryMock{
+		FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			if p, ok := f.users[id]; ok {
+				return domain.NewUser(p), nil
+			}
+			return domain.NewUser(domain.UserParams{}), nil
+		},
+		CreateUserFunc: func(ctx context.Context, u domain.User) (domain.User, error) {
+			if emailInUse(u.GetEmail(), uuid.Nil) {
+				return nil, domain.ErrUserEmailAlreadyExists
+			}
+			p := domain.UserParams{ID: uuid.New(), Name: u.GetName(), Email: u.GetEmail(), Password: u.GetPassword(), IsAdmin: u.IsAdmin()}
+			f.users[p.ID] = p
+			return domain.NewUser(p), nil
+		},
+		VerifyEmailFunc: func(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+			p := f.users[id]
+			p.EmailVerifiedAt = &verifiedAt
+			f.users[id] = p
+			return nil
+		},
+		UpdateProfileFunc: func(ctx context.Context, id uuid.UUID, name, email string, emailVerifiedAt *time.Time) error {
+			p, ok := f.users[id]
+			if !ok {
+				return domain.ErrUserNotFound
+			}
+			if emailInUse(email, id) {
+				return domain.ErrUserEmailAlreadyExists
+			}
+			p.Name, p.Email, p.EmailVerifiedAt = name, email, emailVerifiedAt
+			f.users[id] = p
+			return nil
+		},
+		UpdateAdminFunc: func(ctx context.Context, id uuid.UUID, isAdmin bool) error {
+			p := f.users[id]
+			p.IsAdmin = isAdmin
+			f.users[id] = p
+			return nil
+		},
+		SearchUsersFunc: func(ctx context.Context, query string, offset, limit int) ([]domain.User, error) {
+			var users []domain.User
+			for _, p := range f.users {
+				users = append(users, domain.NewUser(p))
+			}
+			if offset >= len(users) {
+				return nil, nil
+			}
+			return users[offset:min(offset+limit, len(users))], nil
+		},
+		UpdateDisabledAtFunc: func(ctx context.Context, id uuid.UUID, disabledAt *time.Time) error {
+			p := f.users[id]
+			p.DisabledAt = disabledAt
+			f.users[id] = p
+			return nil
+		},
+		DeleteUserFunc: func(ctx context.Context, id uuid.UUID) error {
+			delete(f.users, id)
+			return nil
+		},
+	}
+	f.tokenRepo = &domain.TokenRepositoryMock{
+		RevokeTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID) error {
+			return nil
+		},
+	}
+	f.refreshTokenRepo = &domain.RefreshTokenRepositoryMock{
+		RevokeRefreshTokensByUserIDFunc: func(ctx context.Context, userID uuid.UUID) error {
+			return nil
+		},
+	}
+	f.revoker = &domain.SessionRevokerMock{
+		RevokeUserSessionsFunc: func(ctx context.Context, userID uuid.UUID, at time.Time) error {
+			return nil
+		},
+	}
+	f.uc = NewUserAdminUsecase(userRepo, f.tokenRepo, f.refreshTokenRepo, f.revoker, newTestHasher(t), newTestPolicy())
+	return f
+}
+
+func TestUserAdmin_CreateUser(t *testing.T) {
+	ctx := context.Background()
+	f := newUserAdminFixture(t)
+
+	user, err := f.uc.CreateUser(ctx, CreateUserParams{Name: "Alice", Email: "alice@example.com", Password: "correct horse", EmailVerified: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", user.GetName())
+	assert.True(t, user.IsEmailVerified())
+	assert.True(t, user.IsPasswordMatch(newTestHasher(t), "correct horse"))
+
+	// パスワードを省略した場合はパスワードを持たないユーザーを作る
+	user, err = f.uc.CreateUser(ctx, CreateUserParams{Name: "Bob", Email: "bob@example.com"})
+	require.NoError(t, err)
+	assert.Empty(t, user.GetPassword())
+	assert.False(t, user.IsEmailVerified())
+	assert.False(t, user.IsAdmin())
+
+	user, err = f.uc.CreateUser(ctx, CreateUserParams{Name: "Dave", Email: "dave@example.com", IsAdmin: true})
+	require.NoError(t, err)
+	assert.True(t, user.IsAdmin())
+
+	_, err = f.uc.CreateUser(ctx, CreateUserParams{Name: "Alice", Email: "alice@example.com"})
+	assert.ErrorIs(t, err, ErrUserEmailInUse)
+
+	_, err = f.uc.CreateUser(ctx, CreateUserParams{Name: "Carol", Email: "carol@example.com", Password: "short"})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*errors.UsecaseError).Code)
+	var policyErr *domain.PasswordPolicyError
+	assert.ErrorAs(t, err, &policyErr)
+}
+
+func TestUserAdmin_SearchUsers(t *testing.T) {
+	ctx := context.Background()
+	f := newUserAdminFixture(t)
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		_, err := f.uc.CreateUser(ctx, CreateUserParams{Name: email, Email: email})
+		require.NoError(t, err)
+	}
+
+	users, more, err := f.uc.SearchUsers(ctx, "example", 0, 2)
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.True(t, more)
+
+	users, more, err = f.uc.SearchUsers(ctx, "example", 2, 2)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.False(t, more)
+}
+
+func TestUserAdmin_UpdateUser(t *testing.T) {
+	ctx := context.Background()
+	f := newUserAdminFixture(t)
+	alice, err := f.uc.CreateUser(ctx, CreateUserParams{Name: "Alice", Email: "alice@example.com", EmailVerified: true})
+	require.NoError(t, err)
+	_, err = f.uc.CreateUser(ctx, CreateUserParams{Name: "Bob", Email: "bob@example.com"})
+	require.NoError(t, err)
+
+	name := "Alice Smith"
+	updated, err := f.uc.UpdateUser(ctx, alice.GetID(), UpdateUserParams{Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Smith", updated.GetName())
+	assert.Equal(t, "alice@example.com", updated.GetEmail())
+	assert.False(t, updated.IsAdmin())
+	// メールアドレスを変えない場合は確認済みのまま
+	assert.True(t, updated.IsEmailVerified())
+
+	isAdmin := true
+	updated, err = f.uc.UpdateUser(ctx, alice.GetID(), UpdateUserParams{IsAdmin: &isAdmin})
+	require.NoError(t, err)
+	assert.True(t, updated.IsAdmin())
+	assert.Equal(t, "Alice Smith", updated.GetName())
+
+	email := "bob@example.com"
+	_, err = f.uc.UpdateUser(ctx, alice.GetID(), UpdateUserParams{Email: &email})
+	assert.ErrorIs(t, err, ErrUserEmailInUse)
+
+	// 新しいメールアドレスは確認するまで未確認に戻す
+	email = "alice@new.example.com"
+	updated, err = f.uc.UpdateUser(ctx, alice.GetID(), UpdateUserParams{Email: &email})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@new.example.com", updated.GetEmail())
+	assert.False(t, updated.IsEmailVerified())
+
+	_, err = f.uc.UpdateUser(ctx, uuid.New(), UpdateUserParams{Name: &name})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestUserAdmin_UpdateUser_DeletedMeanwhile(t *testing.T) {
+	userID := uuid.New()
+	userRepo := &domain.UserRepositoryMock{
+		FindUserByIDFunc: func(ctx context.Context, id uuid.UUID) (domain.User, error) {
+			return domain.NewUser(domain.UserParams{ID: id, Name: "Alice", Email: "alice@example.com"}), nil
+		},
+		UpdateProfileFunc: func(ctx context.Context, id uuid.UUID, name, email string, emailVerifiedAt *time.Time) error {
+			return domain.ErrUserNotFound
+		},
+	}
+	uc := NewUserAdminUsecase(userRepo, nil, nil, nil, nil, nil)
+
+	// 確認した後に削除されたユーザーはメールアドレスの重複ではなく見つからないものとして返す
+	name := "Alice Smith"
+	_, err := uc.UpdateUser(context.Background(), userID, UpdateUserParams{Name: &name})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestUserAdmin_DisableAndEnableUser(t *testing.T) {
+	ctx := context.Background()
+	f := newUserAdminFixture(t)
+	user, err := f.uc.CreateUser(ctx, CreateUserParams{Name: "Alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+
+	require.NoError(t, f.uc.DisableUser(ctx, user.GetID()))
+	disabled, err := f.uc.GetUser(ctx, user.GetID())
+	require.NoError(t, err)
+	assert.True(t, disabled.IsDisabled())
+	require.Len(t, f.tokenRepo.RevokeTokensByUserIDCalls(), 1)
+	assert.Equal(t, user.GetID(), f.tokenRepo.RevokeTokensByUserIDCalls()[0].UserID)
+	require.Len(t, f.refreshTokenRepo.RevokeRefreshTokensByUserIDCalls(), 1)
+	require.Len(t, f.revoker.RevokeUserSessionsCalls(), 1)
+
+	require.NoError(t, f.uc.EnableUser(ctx, user.GetID()))
+	enabled, err := f.uc.GetUser(ctx, user.GetID())
+	require.NoError(t, err)
+	assert.False(t, enabled.IsDisabled())
+
+	assert.ErrorIs(t, f.uc.DisableUser(ctx, uuid.New()), ErrUserNotFound)
+}
+
+func TestUserAdmin_DeleteUser(t *testing.T) {
+	ctx := context.Background()
+	f := newUserAdminFixture(t)
+	user, err := f.uc.CreateUser(ctx, CreateUserParams{Name: "Alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+
+	require.NoError(t, f.uc.DeleteUser(ctx, user.GetID()))
+	assert.Empty(t, f.users)
+	require.Len(t, f.revoker.RevokeUserSessionsCalls(), 1)
+
+	assert.ErrorIs(t, f.uc.DeleteUser(ctx, user.GetID()), ErrUserNotFound)
+}
@@ -1,28 +0,0 @@
-package main
-
-import (
-	"crypto/ed25519"
-	"crypto/rand"
-	"encoding/base64"
-	"fmt"
-)
-
-func main() {
-	publicKey, privateKey, err := generateEd25519KeyPair()
-	if err != nil {
-		panic(err)
-	}
-	publicKeyBase64 := base64.StdEncoding.EncodeToString(publicKey)
-	privateKeyBase64 := base64.StdEncoding.EncodeToString(privateKey)
-
-	fmt.Println("Public Key (Base64):", publicKeyBase64)
-	fmt.Println("Private Key (Base64):", privateKeyBase64)
-}
-
-func generateEd25519KeyPair() (ed25519.PublicKey, ed25519.PrivateKey, error) {
-	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
-	if err != nil {
-		return nil, nil, err
-	}
-	return publicKey, privateKey, nil
-}
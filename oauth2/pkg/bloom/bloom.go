@@ -0,0 +1,61 @@
+// Package bloom はメモリ上のブルームフィルタ。
+// 含まれていないことは確実に判定でき、含まれている判定は falsePositiveRate の確率で誤る
+package bloom
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+type Filter struct {
+	bits []uint64
+	m    uint64 // ビット数
+	k    uint64 // ハッシュ関数の数
+}
+
+// New は n 個の要素を追加したときに誤判定の確率が falsePositiveRate になる大きさのフィルタを返す
+func New(n int, falsePositiveRate float64) *Filter {
+	n = max(n, 1)
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.001
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	m = max(m, 64)
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	k = max(k, 1)
+	return &Filter{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+func (f *Filter) Add(data []byte) {
+	h1, h2 := hash(data)
+	for i := range f.k {
+		bit := (h1 + i*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// Test は data が追加されている可能性がある場合に true を返す
+func (f *Filter) Test(data []byte) bool {
+	h1, h2 := hash(data)
+	for i := range f.k {
+		bit := (h1 + i*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hash は128ビットのハッシュを2つに分け、k 個のハッシュ関数の代わりに使う (Kirsch-Mitzenmacher)
+func hash(data []byte) (uint64, uint64) {
+	h := fnv.New128a()
+	h.Write(data)
+	sum := h.Sum(nil)
+	var h1, h2 uint64
+	for i := range 8 {
+		h1 = h1<<8 | uint64(sum[i])
+		h2 = h2<<8 | uint64(sum[8+i])
+	}
+	// h2 が0だと全て同じビットになる
+	return h1, h2 | 1
+}
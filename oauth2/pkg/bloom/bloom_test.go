@@ -0,0 +1,34 @@
+package bloom
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	f := New(1000, 0.01)
+	for i := range 1000 {
+		f.Add([]byte("password" + strconv.Itoa(i)))
+	}
+
+	// 追加したものは必ず含まれる
+	for i := range 1000 {
+		assert.True(t, f.Test([]byte("password"+strconv.Itoa(i))))
+	}
+
+	falsePositives := 0
+	for i := range 10000 {
+		if f.Test([]byte("other" + strconv.Itoa(i))) {
+			falsePositives++
+		}
+	}
+	// 誤判定はおおよそ1%に収まる
+	assert.Less(t, falsePositives, 300)
+}
+
+func TestFilter_Empty(t *testing.T) {
+	f := New(0, 0)
+	assert.False(t, f.Test([]byte("password")))
+}
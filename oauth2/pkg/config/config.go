@@ -1,28 +1,116 @@
 package config
 
 import (
+	"encoding/json"
+	"reflect"
+
 	"github.com/caarlos0/env"
 )
 
 type Config struct {
-	DBHost                     string `env:"DBHost" envDefault:"localhost"`
-	DBPort                     int    `env:"DBPort" envDefault:"5432"`
-	DBUser                     string `env:"DBUser" envDefault:"app"`
-	DBPassword                 string `env:"DBPassword" envDefault:"pass"`
-	DBName                     string `env:"DBName" envDefault:"auth"`
-	AuthCodeExpires            int    `env:"AuthCodeExpires" envDefault:"120"`           // 秒を単位として指定
-	AuthTokenExpiresMin        int    `env:"AuthTokenExpiresMin" envDefault:"60"`        // 分を単位として指定
-	AuthRefreshTokenExpiresDay int    `env:"AuthRefreshTokenExpiresDay" envDefault:"30"` // 時間を単位として指定
-	SessionExpires             int    `env:"SessionExpires" envDefault:"3600"`
-	PrivateKey                 string `env:"PRIVATE_KEY"`
-	PublicKey                  string `env:"PUBLIC_KEY"`
+	DBHost                     string   `env:"DBHost" envDefault:"localhost"`
+	DBPort                     int      `env:"DBPort" envDefault:"5432"`
+	DBUser                     string   `env:"DBUser" envDefault:"app"`
+	DBPassword                 string   `env:"DBPassword" envDefault:"pass"`
+	DBName                     string   `env:"DBName" envDefault:"auth"`
+	AuthCodeExpires            int      `env:"AuthCodeExpires" envDefault:"120"`           // 秒を単位として指定
+	AuthTokenExpiresMin        int      `env:"AuthTokenExpiresMin" envDefault:"60"`        // 分を単位として指定
+	AuthRefreshTokenExpiresDay int      `env:"AuthRefreshTokenExpiresDay" envDefault:"30"` // 時間を単位として指定
+	SessionExpires             int      `env:"SessionExpires" envDefault:"3600"`
+	SessionCookieDomain        string   `env:"SessionCookieDomain" envDefault:""` // 空の場合はホスト限定のクッキーにする
+	SessionCookieSecure        bool     `env:"SessionCookieSecure" envDefault:"true"`
+	SessionCookieSameSite      string   `env:"SessionCookieSameSite" envDefault:"Lax"` // Lax, Strict, None のいずれか
+	SessionCookiePrefix        string   `env:"SessionCookiePrefix" envDefault:""`      // __Host- または __Secure-
+	ConsentExpiresDay          int      `env:"ConsentExpiresDay" envDefault:"180"`     // 日を単位として指定。0 の場合は無期限
+	BaseURL                    string   `env:"BaseURL" envDefault:"http://localhost:8080"`
+	MailSender                 string   `env:"MailSender" envDefault:"log"` // log または file
+	MailDir                    string   `env:"MailDir" envDefault:"tmp/mail"`
+	MailFrom                   string   `env:"MailFrom" envDefault:"no-reply@localhost"`
+	EmailVerificationExpires   int      `env:"EmailVerificationExpires" envDefault:"86400"` // 秒を単位として指定
+	EmailVerificationInterval  int      `env:"EmailVerificationInterval" envDefault:"60"`   // 再送できるまでの秒数
+	EmailVerificationSignin    bool     `env:"EmailVerificationSignin" envDefault:"false"`  // true の場合は確認するまでサインインさせない
+	EmailVerificationScopes    []string `env:"EmailVerificationScopes" envSeparator:","`    // 確認するまで許可しないスコープ
+	PasswordResetExpires       int      `env:"PasswordResetExpires" envDefault:"1800"`      // 秒を単位として指定
+	PasswordResetInterval      int      `env:"PasswordResetInterval" envDefault:"60"`       // 再送できるまでの秒数
+	MFAIssuer                  string   `env:"MFAIssuer" envDefault:"go-oauth2"`            // 認証アプリに表示する発行者名
+	MFAEncryptionKey           string   `env:"MFA_ENCRYPTION_KEY"`                          // TOTP のシークレットを暗号化する32バイトの鍵 (Base64)
+	PasskeyRPID                string   `env:"PasskeyRPID" envDefault:"localhost"`          // パスキーを紐づけるドメイン。変更すると登録済みのパスキーは使えなくなる
+	PasskeyRPName              string   `env:"PasskeyRPName" envDefault:"go-oauth2"`        // 認証器に表示するサービス名
+	PasskeyRPOrigins           []string `env:"PasskeyRPOrigins" envSeparator:","`           // パスキーの操作を受け付けるオリジン。空の場合は BaseURL のみ
+	LoginFailureWindow         int      `env:"LoginFailureWindow" envDefault:"900"`         // 失敗回数を数える秒数。最初の失敗から数える
+	LoginDelayThreshold        int      `env:"LoginDelayThreshold" envDefault:"3"`          // この回数を超えて失敗すると次の試行まで待たせる
+	LoginDelayBase             int      `env:"LoginDelayBase" envDefault:"1"`               // 最初の待ち時間 (秒)。失敗するたびに倍にする
+	LoginDelayMax              int      `env:"LoginDelayMax" envDefault:"60"`               // 待ち時間の上限 (秒)
+	LoginLockoutThreshold      int      `env:"LoginLockoutThreshold" envDefault:"10"`       // アカウントをロックする失敗回数
+	LoginIPLockoutThreshold    int      `env:"LoginIPLockoutThreshold" envDefault:"50"`     // 接続元をロックする失敗回数
+	LoginLockoutDuration       int      `env:"LoginLockoutDuration" envDefault:"900"`       // ロックする秒数
+	PasswordHashAlgorithm      string   `env:"PasswordHashAlgorithm" envDefault:"argon2id"` // argon2id または bcrypt。異なるハッシュはサインイン時に作り直す
+	PasswordArgon2Memory       int      `env:"PasswordArgon2Memory" envDefault:"19456"`     // KiB を単位として指定
+	PasswordArgon2Time         int      `env:"PasswordArgon2Time" envDefault:"2"`           // 反復回数
+	PasswordArgon2Parallelism  int      `env:"PasswordArgon2Parallelism" envDefault:"1"`    // 並列数
+	PasswordBcryptCost         int      `env:"PasswordBcryptCost" envDefault:"12"`          // bcrypt を使う場合のコスト
+	PasswordMinLength          int      `env:"PasswordMinLength" envDefault:"8"`            // 文字数で数える。上限は bcrypt に合わせて72バイト
+	PasswordBreachedList       string   `env:"PasswordBreachedList" envDefault:""`          // 漏洩したパスワードの一覧。prefix または bloom。空の場合は確認しない
+	PasswordBreachedPath       string   `env:"PasswordBreachedPath" envDefault:""`          // prefix はディレクトリ、bloom はファイルを指定する
+	RateLimitWindow            int      `env:"RateLimitWindow" envDefault:"60"`             // 回数を数える秒数。以下の上限は 0 で無効
+	RateLimitTokenPerClient    int      `env:"RateLimitTokenPerClient" envDefault:"60"`     // トークンエンドポイントのクライアントごとの上限
+	RateLimitTokenPerIP        int      `env:"RateLimitTokenPerIP" envDefault:"120"`        // トークンエンドポイントの接続元ごとの上限
+	RateLimitSigninPerIP       int      `env:"RateLimitSigninPerIP" envDefault:"30"`        // サインインの接続元ごとの上限
+	RateLimitSignupPerIP       int      `env:"RateLimitSignupPerIP" envDefault:"10"`        // 登録、メール送信、パスワード再設定の接続元ごとの上限
+	RateLimitAdminPerUser      int      `env:"RateLimitAdminPerUser" envDefault:"120"`      // 管理用APIのユーザーごとの上限
+	TrustedProxies             []string `env:"TrustedProxies" envSeparator:","`             // X-Forwarded-For を信頼するプロキシの IP または CIDR。空の場合は接続元のアドレスを使う
+	LDAPURL                    string   `env:"LDAPURL" envDefault:""`                       // ldap:// または ldaps://。空の場合は LDAP で認証しない
+	LDAPStartTLS               bool     `env:"LDAPStartTLS" envDefault:"false"`
+	LDAPBindDN                 string   `env:"LDAPBindDN" envDefault:""` // 利用者とグループを検索するアカウント。空の場合は匿名で検索する
+	LDAPBindPassword           string   `env:"LDAP_BIND_PASSWORD"`
+	LDAPBaseDN                 string   `env:"LDAPBaseDN" envDefault:""`
+	LDAPUserFilter             string   `env:"LDAPUserFilter" envDefault:"(&(objectClass=person)(mail={login}))"` // {login} を入力されたメールアドレスに置き換える
+	LDAPIDAttribute            string   `env:"LDAPIDAttribute" envDefault:"entryUUID"`                            // 利用者を識別する変わらない属性。Active Directory は objectGUID。空の場合は DN
+	LDAPNameAttribute          string   `env:"LDAPNameAttribute" envDefault:"cn"`
+	LDAPEmailAttribute         string   `env:"LDAPEmailAttribute" envDefault:"mail"`
+	LDAPGroupBaseDN            string   `env:"LDAPGroupBaseDN" envDefault:""`                                           // 空の場合はグループを検索しない
+	LDAPGroupFilter            string   `env:"LDAPGroupFilter" envDefault:"(&(objectClass=groupOfNames)(member={dn}))"` // {dn} を利用者の DN に置き換える
+	LDAPGroupNameAttribute     string   `env:"LDAPGroupNameAttribute" envDefault:"cn"`
+	LDAPRequiredGroups         []string `env:"LDAPRequiredGroups" envSeparator:","` // いずれかに所属する利用者のみサインインさせる。空の場合は制限しない
+	LDAPLinkByEmail            bool     `env:"LDAPLinkByEmail" envDefault:"false"`  // メールアドレスが一致するパスワードを持たない既存のユーザーに紐づける
+	LDAPTimeout                int      `env:"LDAPTimeout" envDefault:"5"`          // 秒を単位として指定
+	SAMLSPPrivateKey           string   `env:"SAML_SP_PRIVATE_KEY"`                 // PEM の RSA 秘密鍵。指定した場合は認証リクエストに署名する
+	SAMLSPCertificate          string   `env:"SAML_SP_CERTIFICATE"`                 // SAML_SP_PRIVATE_KEY の PEM の証明書。SP のメタデータに載せる
+	PrivateKey                 string   `env:"PRIVATE_KEY"`
+	PublicKey                  string   `env:"PUBLIC_KEY"`
+
+	// FederationProviders は上流の OpenID プロバイダ。JSON の配列で指定する
+	FederationProviders []FederationProvider `env:"FEDERATION_PROVIDERS"`
+}
+
+// FederationProvider は「〜でサインイン」に使う上流の OpenID プロバイダ
+type FederationProvider struct {
+	// Name はコールバックの URL に使う識別子。変更すると紐づけ済みの利用者はサインインできなくなる
+	Name         string   `json:"name"`
+	DisplayName  string   `json:"display_name"`
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	Scopes       []string `json:"scopes"`
+	// LinkByEmail が true の場合は、プロバイダが確認済みとしたメールアドレスが一致する既存のユーザーに紐づける
+	LinkByEmail bool `json:"link_by_email"`
 }
 
 func GetEnv() (*Config, error) {
 	cfg := &Config{}
-	if err := env.Parse(cfg); err != nil {
+	if err := env.ParseWithFuncs(cfg, env.CustomParsers{
+		reflect.TypeOf([]FederationProvider{}): parseFederationProviders,
+	}); err != nil {
 		return cfg, err
 	}
 
 	return cfg, nil
 }
+
+func parseFederationProviders(v string) (any, error) {
+	var providers []FederationProvider
+	if err := json.Unmarshal([]byte(v), &providers); err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
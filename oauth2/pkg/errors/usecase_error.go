@@ -6,12 +6,18 @@ type UsecaseError struct {
 	Code        int
 	Message     string
 	RedirectURI string
+	// Err は利用者に理由を伝えるために残しておく元のエラー
+	Err error
 }
 
 func (e *UsecaseError) Error() string {
 	return fmt.Sprintf("Code: %d, Message: %s", e.Code, e.Message)
 }
 
+func (e *UsecaseError) Unwrap() error {
+	return e.Err
+}
+
 func NewUsecaseError(code int, message string) *UsecaseError {
 	return &UsecaseError{
 		Code:    code,
@@ -26,3 +32,22 @@ func NewUsecaseErrorWithRedirectURI(code int, message, redirectURI string) *Usec
 		RedirectURI: redirectURI,
 	}
 }
+
+// WrapUsecaseErrorWithRedirectURI は err を残したままリダイレクトするエラーを作る
+func WrapUsecaseErrorWithRedirectURI(code int, err error, redirectURI string) *UsecaseError {
+	return &UsecaseError{
+		Code:        code,
+		Message:     err.Error(),
+		RedirectURI: redirectURI,
+		Err:         err,
+	}
+}
+
+// WrapUsecaseError は err を残したままエラーを作る
+func WrapUsecaseError(code int, err error) *UsecaseError {
+	return &UsecaseError{
+		Code:    code,
+		Message: err.Error(),
+		Err:     err,
+	}
+}
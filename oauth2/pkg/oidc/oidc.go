@@ -0,0 +1,338 @@
+// Package oidc は上流の OpenID プロバイダに対する Relying Party。
+// 認可コードフローを PKCE (RFC 7636) 付きで実行し、ID トークンをディスカバリと JWKS で検証する
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	// maxResponseBytes はプロバイダの応答として読み込む上限
+	maxResponseBytes = 1 << 20
+	// clockSkew はプロバイダとの時刻のずれとして許容する時間
+	clockSkew = time.Minute
+)
+
+// 署名に RSA と ECDSA のみを受け付ける。none や共有鍵のアルゴリズムは使わせない
+var signingMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+var (
+	ErrInvalidIDToken = errors.New("oidc: invalid id token")
+	ErrTokenExchange  = errors.New("oidc: token exchange failed")
+)
+
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// HTTPClient が nil の場合は http.DefaultClient を使う
+	HTTPClient *http.Client
+}
+
+// Client は1つのプロバイダに対するクライアント。
+// ディスカバリは最初に使うときに取得し、失敗した場合は次の呼び出しで取得し直す
+type Client struct {
+	config Config
+
+	mu       sync.Mutex
+	metadata *Metadata
+	keys     map[string]any
+}
+
+// Metadata はディスカバリで取得するプロバイダの情報 (OpenID Connect Discovery 3)
+type Metadata struct {
+	Issuer                        string   `json:"issuer"`
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	JWKSURI                       string   `json:"jwks_uri"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+}
+
+// Token はトークンエンドポイントの応答
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// IDToken は検証を終えた ID トークンのクレーム
+type IDToken struct {
+	Issuer        string   `json:"iss"`
+	Subject       string   `json:"sub"`
+	Audience      audience `json:"aud"`
+	AuthorizedBy  string   `json:"azp"`
+	ExpiresAt     int64    `json:"exp"`
+	IssuedAt      int64    `json:"iat"`
+	Nonce         string   `json:"nonce"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// Valid は署名の検証後に Client.VerifyIDToken で行うため、ここでは何もしない
+func (*IDToken) Valid() error {
+	return nil
+}
+
+// audience は aud が文字列と配列のどちらでも受け付ける
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multi []string
+	if err := json.Unmarshal(b, &multi); err != nil {
+		return err
+	}
+	*a = multi
+	return nil
+}
+
+func NewClient(cfg Config) *Client {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &Client{config: cfg}
+}
+
+// AuthCodeURL は利用者をリダイレクトする認可リクエストのURLを返す
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	m, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(m.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", c.config.ClientID)
+	q.Set("redirect_uri", c.config.RedirectURL)
+	q.Set("scope", strings.Join(c.scopes(), " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// Exchange は認可コードをトークンに交換する
+func (c *Client) Exchange(ctx context.Context, code, codeVerifier string) (*Token, error) {
+	m, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.config.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	// シークレットのないクライアントは client_id のみを送る (RFC 6749 2.3.1)
+	if c.config.ClientSecret == "" {
+		form.Set("client_id", c.config.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.config.ClientID), url.QueryEscape(c.config.ClientSecret))
+	}
+
+	resp, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Wrapf(ErrTokenExchange, "status %d: %s", resp.StatusCode, body)
+	}
+
+	var token Token
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if token.IDToken == "" {
+		return nil, errors.Wrap(ErrTokenExchange, "id_token is missing")
+	}
+	return &token, nil
+}
+
+// VerifyIDToken は署名とクレームを検証する (OpenID Connect Core 3.1.3.7)
+func (c *Client) VerifyIDToken(ctx context.Context, raw, nonce string, now time.Time) (*IDToken, error) {
+	m, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := jwt.Parser{ValidMethods: signingMethods}
+	token, err := parser.ParseWithClaims(raw, &IDToken{}, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return c.key(ctx, m, kid)
+	})
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidIDToken, err.Error())
+	}
+	claims := token.Claims.(*IDToken)
+
+	switch {
+	case claims.Issuer != m.Issuer:
+		return nil, errors.Wrap(ErrInvalidIDToken, "issuer does not match")
+	case !slices.Contains(claims.Audience, c.config.ClientID):
+		return nil, errors.Wrap(ErrInvalidIDToken, "audience does not match")
+	case len(claims.Audience) > 1 && claims.AuthorizedBy != c.config.ClientID:
+		return nil, errors.Wrap(ErrInvalidIDToken, "authorized party does not match")
+	case claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)):
+		return nil, errors.Wrap(ErrInvalidIDToken, "token is expired")
+	case claims.IssuedAt == 0 || time.Unix(claims.IssuedAt, 0).After(now.Add(clockSkew)):
+		return nil, errors.Wrap(ErrInvalidIDToken, "token is issued in the future")
+	case claims.Nonce != nonce:
+		return nil, errors.Wrap(ErrInvalidIDToken, "nonce does not match")
+	case claims.Subject == "":
+		return nil, errors.Wrap(ErrInvalidIDToken, "subject is missing")
+	}
+	return claims, nil
+}
+
+func (c *Client) scopes() []string {
+	if len(c.config.Scopes) == 0 {
+		return []string{"openid", "email", "profile"}
+	}
+	if !slices.Contains(c.config.Scopes, "openid") {
+		return append([]string{"openid"}, c.config.Scopes...)
+	}
+	return c.config.Scopes
+}
+
+func (c *Client) discover(ctx context.Context) (*Metadata, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.metadata != nil {
+		return c.metadata, nil
+	}
+
+	var m Metadata
+	if err := c.getJSON(ctx, strings.TrimSuffix(c.config.Issuer, "/")+discoveryPath, &m); err != nil {
+		return nil, err
+	}
+	// 応答の issuer は設定した issuer と一致しなければならない (OpenID Connect Discovery 4.3)
+	if m.Issuer != c.config.Issuer {
+		return nil, errors.Newf("oidc: issuer %q does not match %q", m.Issuer, c.config.Issuer)
+	}
+	if m.AuthorizationEndpoint == "" || m.TokenEndpoint == "" || m.JWKSURI == "" {
+		return nil, errors.New("oidc: discovery document is incomplete")
+	}
+	// PKCE に対応していると明示しているプロバイダのみ使う
+	if !slices.Contains(m.CodeChallengeMethodsSupported, "S256") {
+		return nil, errors.New("oidc: provider does not support PKCE with S256")
+	}
+	c.metadata = &m
+	return c.metadata, nil
+}
+
+// key は kid の公開鍵を返す。知らない kid の場合は鍵の更新に備えて JWKS を取得し直す
+func (c *Client) key(ctx context.Context, m *Metadata, kid string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key, ok := c.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	var set jsonWebKeySet
+	if err := c.getJSON(ctx, m.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+	keys, err := set.publicKeys()
+	if err != nil {
+		return nil, err
+	}
+	c.keys = keys
+
+	if key, ok := c.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, errors.Newf("oidc: no key for kid %q", kid)
+}
+
+// lookupKey は kid が省略された場合、鍵が1つだけであればそれを使う
+func (c *Client) lookupKey(kid string) (any, bool) {
+	if kid == "" && len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key, true
+		}
+	}
+	key, ok := c.keys[kid]
+	return key, ok
+}
+
+func (c *Client) getJSON(ctx context.Context, rawURL string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Newf("oidc: GET %s: status %d", rawURL, resp.StatusCode)
+	}
+	return errors.WithStack(json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(v))
+}
+
+// NewCodeVerifier は PKCE のコード検証値を返す (RFC 7636 4.1)
+func NewCodeVerifier() (string, error) {
+	return RandomValue()
+}
+
+// CodeChallengeS256 はコード検証値から S256 のチャレンジを作る (RFC 7636 4.2)
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// RandomValue は state や nonce に使う推測できない値を返す
+func RandomValue() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,152 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "https://auth.example.com/client/signin/federation/corp/callback"
+
+func newTestClient(p *oidctest.Provider) *Client {
+	return NewClient(Config{
+		Issuer:       p.Issuer(),
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		RedirectURL:  redirectURL,
+	})
+}
+
+// signin は認可リクエストからトークンの検証までを実行する
+func signin(t *testing.T, p *oidctest.Provider, c *Client) (*IDToken, error) {
+	t.Helper()
+	ctx := context.Background()
+	verifier, err := NewCodeVerifier()
+	require.NoError(t, err)
+
+	authURL, err := c.AuthCodeURL(ctx, "state-1", "nonce-1", CodeChallengeS256(verifier))
+	require.NoError(t, err)
+	callback, err := p.Authorize(authURL)
+	require.NoError(t, err)
+	require.Equal(t, "state-1", callback.Query().Get("state"))
+
+	token, err := c.Exchange(ctx, callback.Query().Get("code"), verifier)
+	require.NoError(t, err)
+	return c.VerifyIDToken(ctx, token.IDToken, "nonce-1", time.Now())
+}
+
+func TestClient_CodeFlow(t *testing.T) {
+	p := oidctest.New("client-1", "secret")
+	defer p.Close()
+	p.Login(oidctest.User{Subject: "user-1", Email: "user@example.com", EmailVerified: true, Name: "User"})
+	c := newTestClient(p)
+
+	authURL, err := c.AuthCodeURL(context.Background(), "state-1", "nonce-1", "challenge")
+	require.NoError(t, err)
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "openid email profile", u.Query().Get("scope"))
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	assert.Equal(t, redirectURL, u.Query().Get("redirect_uri"))
+
+	claims, err := signin(t, p, c)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, "user@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, "User", claims.Name)
+}
+
+func TestClient_ExchangeRejectsWrongVerifier(t *testing.T) {
+	ctx := context.Background()
+	p := oidctest.New("client-1", "")
+	defer p.Close()
+	c := newTestClient(p)
+
+	verifier, err := NewCodeVerifier()
+	require.NoError(t, err)
+	authURL, err := c.AuthCodeURL(ctx, "state", "nonce", CodeChallengeS256(verifier))
+	require.NoError(t, err)
+	callback, err := p.Authorize(authURL)
+	require.NoError(t, err)
+
+	_, err = c.Exchange(ctx, callback.Query().Get("code"), "other-verifier")
+	assert.ErrorIs(t, err, ErrTokenExchange)
+
+	// 認可コードは一度しか使えない
+	_, err = c.Exchange(ctx, callback.Query().Get("code"), verifier)
+	assert.ErrorIs(t, err, ErrTokenExchange)
+}
+
+func TestClient_VerifyIDTokenRejectsInvalidClaims(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims func(jwt.MapClaims)
+	}{
+		{name: "issuer", claims: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{name: "audience", claims: func(c jwt.MapClaims) { c["aud"] = "other-client" }},
+		{name: "authorized party", claims: func(c jwt.MapClaims) { c["aud"] = []string{"client-1", "other-client"} }},
+		{name: "expired", claims: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{name: "nonce", claims: func(c jwt.MapClaims) { c["nonce"] = "replayed" }},
+		{name: "subject", claims: func(c jwt.MapClaims) { c["sub"] = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := oidctest.New("client-1", "secret")
+			defer p.Close()
+			p.Login(oidctest.User{Subject: "user-1"})
+			p.Claims = tt.claims
+
+			_, err := signin(t, p, newTestClient(p))
+			assert.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+}
+
+func TestClient_MultipleAudiencesWithAuthorizedParty(t *testing.T) {
+	p := oidctest.New("client-1", "secret")
+	defer p.Close()
+	p.Login(oidctest.User{Subject: "user-1"})
+	p.Claims = func(c jwt.MapClaims) {
+		c["aud"] = []string{"client-1", "other-client"}
+		c["azp"] = "client-1"
+	}
+
+	_, err := signin(t, p, newTestClient(p))
+	assert.NoError(t, err)
+}
+
+func TestClient_KeyRotation(t *testing.T) {
+	p := oidctest.New("client-1", "secret")
+	defer p.Close()
+	p.Login(oidctest.User{Subject: "user-1"})
+	c := newTestClient(p)
+
+	_, err := signin(t, p, c)
+	require.NoError(t, err)
+
+	// 知らない kid の鍵は JWKS を取得し直して検証する
+	p.RotateKey()
+	_, err = signin(t, p, c)
+	assert.NoError(t, err)
+}
+
+func TestClient_DiscoveryIssuerMismatch(t *testing.T) {
+	p := oidctest.New("client-1", "secret")
+	defer p.Close()
+
+	c := NewClient(Config{Issuer: p.Issuer() + "/", ClientID: "client-1", RedirectURL: redirectURL})
+	_, err := c.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	assert.Error(t, err)
+}
+
+func TestCodeChallengeS256(t *testing.T) {
+	// RFC 7636 Appendix B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", CodeChallengeS256("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
@@ -0,0 +1,87 @@
+// Package ratelimit はスライディングウィンドウでリクエスト数を制限する。
+// 回数は valkey で数えるため、複数のサーバーで同じ制限を共有できる
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/valkey"
+)
+
+const keyPrefix = "rateLimit:"
+
+// Result は制限の判定結果。RateLimit-* ヘッダーに使う
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset は現在のウィンドウが終わるまでの時間
+	Reset time.Duration
+	// RetryAfter は拒否した場合に、次に受け付けられるまでの時間
+	RetryAfter time.Duration
+}
+
+type Limiter struct {
+	cli valkey.ClientIF
+}
+
+func New(cli valkey.ClientIF) *Limiter {
+	return &Limiter{cli: cli}
+}
+
+// Allow は key のリクエストを1回数え、window の間に limit 回を超えていないかを判定する。
+//
+// 固定のウィンドウごとに回数を数え、直前のウィンドウの回数を経過時間に応じて重みづけして足す。
+// 境界をまたいで短時間に集中したリクエストも、おおよそ limit 回までに抑えられる
+func (l *Limiter) Allow(ctx context.Context, key string, limit int, window time.Duration, now time.Time) (Result, error) {
+	index := now.UnixNano() / int64(window)
+	elapsed := time.Duration(now.UnixNano() - index*int64(window))
+
+	// 直前のウィンドウの回数を参照するため、2ウィンドウ分保持する
+	current, err := l.cli.Incr(ctx, windowKey(key, index), int64(math.Ceil((2 * window).Seconds())))
+	if err != nil {
+		return Result{}, err
+	}
+	v, err := l.cli.Get(ctx, windowKey(key, index-1))
+	if err != nil {
+		return Result{}, err
+	}
+	var previous int64
+	if v != "" {
+		if previous, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return Result{}, errors.WithStack(err)
+		}
+	}
+
+	weight := float64(window-elapsed) / float64(window)
+	estimated := float64(previous)*weight + float64(current)
+
+	res := Result{
+		Allowed:   estimated <= float64(limit),
+		Limit:     limit,
+		Remaining: max(limit-int(math.Ceil(estimated)), 0),
+		Reset:     window - elapsed,
+	}
+	if !res.Allowed {
+		res.RetryAfter = retryAfter(float64(limit), float64(previous), float64(current), window, elapsed)
+	}
+	return res, nil
+}
+
+// retryAfter は新しいリクエストがない場合に、推定の回数が limit まで下がる時間を求める
+func retryAfter(limit, previous, current float64, window, elapsed time.Duration) time.Duration {
+	w := float64(window)
+	// 現在のウィンドウだけで超えている場合は、次のウィンドウで重みが下がるのを待つ
+	if current > limit {
+		return window - elapsed + time.Duration(w*(1-limit/current))
+	}
+	return window - elapsed - time.Duration(w*(limit-current)/previous)
+}
+
+func windowKey(key string, index int64) string {
+	return keyPrefix + key + ":" + strconv.FormatInt(index, 10)
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sntkn/go-oauth2/oauth2/pkg/valkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClient は回数をメモリ上に保持する valkey クライアントのモックを返す
+func newClient() *valkey.ClientIFMock {
+	counts := map[string]int64{}
+	return &valkey.ClientIFMock{
+		IncrFunc: func(ctx context.Context, key string, expiration int64) (int64, error) {
+			counts[key]++
+			return counts[key], nil
+		},
+		GetFunc: func(ctx context.Context, key string) (string, error) {
+			n, ok := counts[key]
+			if !ok {
+				return "", nil
+			}
+			return strconv.FormatInt(n, 10), nil
+		},
+	}
+}
+
+func TestAllow(t *testing.T) {
+	ctx := context.Background()
+	l := New(newClient())
+	now := time.Unix(600, 0)
+
+	for i := range 3 {
+		res, err := l.Allow(ctx, "token:ip:192.0.2.1", 3, time.Minute, now)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 3, res.Limit)
+		assert.Equal(t, 2-i, res.Remaining)
+		assert.Equal(t, time.Minute, res.Reset)
+	}
+
+	res, err := l.Allow(ctx, "token:ip:192.0.2.1", 3, time.Minute, now.Add(15*time.Second))
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 45*time.Second, res.Reset)
+	// 4回目まで数えたため、次のウィンドウで直前の回数の重みが 3/4 に下がるまで待つ
+	assert.Equal(t, 60*time.Second, res.RetryAfter)
+
+	// 他のキーには影響しない
+	res, err = l.Allow(ctx, "token:ip:198.51.100.1", 3, time.Minute, now)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestAllow_SlidingWindow(t *testing.T) {
+	ctx := context.Background()
+	l := New(newClient())
+	start := time.Unix(600, 0)
+
+	for range 4 {
+		res, err := l.Allow(ctx, "signin:ip:192.0.2.1", 4, time.Minute, start)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+	}
+
+	// 次のウィンドウに入った直後は、直前のウィンドウの回数がほぼそのまま残る
+	res, err := l.Allow(ctx, "signin:ip:192.0.2.1", 4, time.Minute, start.Add(65*time.Second))
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	// 4 * (55-t)/60 + 1 が 4 まで下がるのは10秒後
+	assert.Equal(t, 10*time.Second, res.RetryAfter)
+
+	// 直前のウィンドウの重みが下がると受け付ける
+	res, err = l.Allow(ctx, "signin:ip:192.0.2.1", 4, time.Minute, start.Add(105*time.Second))
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining)
+}
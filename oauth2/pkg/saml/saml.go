@@ -0,0 +1,283 @@
+// Package saml は SAML 2.0 の Service Provider。
+// 認証リクエストは HTTP-Redirect、応答は HTTP-POST のバインディングで受け取り、
+// 署名、宛先、発行者、受信者、有効期間、audience を github.com/crewjam/saml で検証する
+package saml
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	crewjam "github.com/crewjam/saml"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+// maxResponseBytes は受け付ける応答の上限
+const maxResponseBytes = 1 << 20
+
+// EmailAddressNameIDFormat は NameID がメールアドレスであることを示す形式
+const EmailAddressNameIDFormat = string(crewjam.EmailAddressNameIDFormat)
+
+var (
+	ErrInvalidMetadata = errors.New("saml: invalid idp metadata")
+	ErrInvalidResponse = errors.New("saml: invalid response")
+)
+
+type Config struct {
+	// EntityID は SP の entity ID。メタデータのURLを使う
+	EntityID string
+	// ACSURL はアサーションを受け取るURL
+	ACSURL string
+	// IdPMetadata は IdP のメタデータの XML
+	IdPMetadata []byte
+	// Key と Certificate を指定した場合は、認証リクエストに署名し、暗号化したアサーションを受け付ける
+	Key         *rsa.PrivateKey
+	Certificate *x509.Certificate
+}
+
+// ServiceProvider は1つの IdP との接続
+type ServiceProvider struct {
+	sp *crewjam.ServiceProvider
+}
+
+// AuthnRequest は IdP に送る認証リクエスト
+type AuthnRequest struct {
+	// ID は応答の InResponseTo と照合する
+	ID string
+	// URL は IdP のシングルサインオンのURL。利用者をリダイレクトする
+	URL string
+}
+
+// Assertion は検証を終えたアサーション
+type Assertion struct {
+	ID     string
+	Issuer string
+	// RequestID は応答した認証リクエストの ID
+	RequestID    string
+	NameID       string
+	NameIDFormat string
+	// ExpiresAt を過ぎると同じアサーションは受け付けられない。再送の検出に使う
+	ExpiresAt  time.Time
+	Attributes map[string][]string
+}
+
+// Attribute は名前が一致する属性の最初の値を返す。属性の Name と FriendlyName のどちらでも探す
+func (a *Assertion) Attribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func NewServiceProvider(c Config) (*ServiceProvider, error) {
+	idp, err := parseIdPMetadata(c.IdPMetadata)
+	if err != nil {
+		return nil, err
+	}
+	acsURL, err := url.Parse(c.ACSURL)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	metadataURL, err := url.Parse(c.EntityID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	sp := &crewjam.ServiceProvider{
+		EntityID:          c.EntityID,
+		MetadataURL:       *metadataURL,
+		AcsURL:            *acsURL,
+		IDPMetadata:       idp,
+		AuthnNameIDFormat: crewjam.UnspecifiedNameIDFormat,
+	}
+	// audience を含まないアサーションも受け付けないようにする
+	sp.ValidateAudienceRestriction = func(a *crewjam.Assertion) error {
+		if a.Conditions == nil {
+			return errors.New("assertion has no conditions")
+		}
+		for _, r := range a.Conditions.AudienceRestrictions {
+			if r.Audience.Value == c.EntityID {
+				return nil
+			}
+		}
+		return errors.Newf("assertion audience does not contain %q", c.EntityID)
+	}
+	if c.Key != nil {
+		sp.Key = c.Key
+		sp.Certificate = c.Certificate
+		sp.SignatureMethod = dsig.RSASHA256SignatureMethod
+	}
+	return &ServiceProvider{sp: sp}, nil
+}
+
+// Metadata は IdP に登録する SP のメタデータを返す
+func (p *ServiceProvider) Metadata() ([]byte, error) {
+	md := p.sp.Metadata()
+	// アサーションは HTTP-POST でのみ受け取る
+	for i := range md.SPSSODescriptors {
+		descriptor := &md.SPSSODescriptors[i]
+		acs := descriptor.AssertionConsumerServices[:0]
+		for _, e := range descriptor.AssertionConsumerServices {
+			if e.Binding == crewjam.HTTPPostBinding {
+				acs = append(acs, e)
+			}
+		}
+		descriptor.AssertionConsumerServices = acs
+	}
+	b, err := xml.MarshalIndent(md, "", "  ")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+// AuthnRequest は認証リクエストを作り、IdP にリダイレクトするURLを返す
+func (p *ServiceProvider) AuthnRequest() (*AuthnRequest, error) {
+	ssoURL := p.sp.GetSSOBindingLocation(crewjam.HTTPRedirectBinding)
+	if ssoURL == "" {
+		return nil, errors.Wrap(ErrInvalidMetadata, "idp has no HTTP-Redirect single sign-on service")
+	}
+	req, err := p.sp.MakeAuthenticationRequest(ssoURL, crewjam.HTTPRedirectBinding, crewjam.HTTPPostBinding)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	u, err := req.Redirect("", p.sp)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &AuthnRequest{ID: req.ID, URL: u.String()}, nil
+}
+
+// ParseResponse は HTTP-POST で受け取った SAMLResponse を検証し、アサーションを返す。
+// 応答は SP が送った認証リクエストへのものに限る。どのリクエストへの応答かは呼び出し側が RequestID で照合する
+func (p *ServiceProvider) ParseResponse(samlResponse string) (*Assertion, error) {
+	if len(samlResponse) > maxResponseBytes {
+		return nil, errors.Wrap(ErrInvalidResponse, "response too large")
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(samlResponse), ""))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidResponse, err.Error())
+	}
+
+	// InResponseTo は応答とアサーションで一致している必要がある。IdP から始めたサインインは受け付けない
+	var envelope struct {
+		InResponseTo string `xml:",attr"`
+	}
+	if err := xml.NewDecoder(bytes.NewReader(raw)).Decode(&envelope); err != nil {
+		return nil, errors.Wrap(ErrInvalidResponse, err.Error())
+	}
+	if envelope.InResponseTo == "" {
+		return nil, errors.Wrap(ErrInvalidResponse, "unsolicited response")
+	}
+
+	a, err := p.sp.ParseXMLResponse(raw, []string{envelope.InResponseTo}, p.sp.AcsURL)
+	if err != nil {
+		var invalid *crewjam.InvalidResponseError
+		if errors.As(err, &invalid) {
+			return nil, errors.Wrap(ErrInvalidResponse, invalid.PrivateErr.Error())
+		}
+		return nil, errors.Wrap(ErrInvalidResponse, err.Error())
+	}
+	return newAssertion(a, envelope.InResponseTo)
+}
+
+func newAssertion(a *crewjam.Assertion, requestID string) (*Assertion, error) {
+	if a.ID == "" {
+		return nil, errors.Wrap(ErrInvalidResponse, "assertion has no ID")
+	}
+	if a.Subject == nil || a.Subject.NameID == nil || a.Subject.NameID.Value == "" {
+		return nil, errors.Wrap(ErrInvalidResponse, "assertion has no NameID")
+	}
+	// 一時的な NameID ではユーザーを紐づけられない
+	if a.Subject.NameID.Format == string(crewjam.TransientNameIDFormat) {
+		return nil, errors.Wrap(ErrInvalidResponse, "transient NameID is not supported")
+	}
+	// 受信者の検証は SubjectConfirmation ごとに行われるため、1つもない場合は受け付けない
+	bearer := false
+	for _, sc := range a.Subject.SubjectConfirmations {
+		if sc.Method == "urn:oasis:names:tc:SAML:2.0:cm:bearer" && sc.SubjectConfirmationData != nil {
+			bearer = true
+		}
+	}
+	if !bearer {
+		return nil, errors.Wrap(ErrInvalidResponse, "assertion has no bearer subject confirmation")
+	}
+
+	assertion := &Assertion{
+		ID:           a.ID,
+		Issuer:       a.Issuer.Value,
+		RequestID:    requestID,
+		NameID:       a.Subject.NameID.Value,
+		NameIDFormat: a.Subject.NameID.Format,
+		// 発行から MaxIssueDelay を過ぎたアサーションは検証で拒否される
+		ExpiresAt:  a.IssueInstant.Add(crewjam.MaxIssueDelay),
+		Attributes: map[string][]string{},
+	}
+	for _, statement := range a.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			values := make([]string, 0, len(attr.Values))
+			for _, v := range attr.Values {
+				values = append(values, v.Value)
+			}
+			assertion.Attributes[attr.Name] = append(assertion.Attributes[attr.Name], values...)
+			if attr.FriendlyName != "" && attr.FriendlyName != attr.Name {
+				assertion.Attributes[attr.FriendlyName] = append(assertion.Attributes[attr.FriendlyName], values...)
+			}
+		}
+	}
+	return assertion, nil
+}
+
+// parseIdPMetadata は EntityDescriptor か、IdP を1つだけ含む EntitiesDescriptor を読み込む
+func parseIdPMetadata(data []byte) (*crewjam.EntityDescriptor, error) {
+	var entity crewjam.EntityDescriptor
+	if err := xml.Unmarshal(data, &entity); err == nil {
+		if len(entity.IDPSSODescriptors) == 0 {
+			return nil, errors.Wrap(ErrInvalidMetadata, "no IDPSSODescriptor")
+		}
+		return &entity, nil
+	}
+
+	var entities crewjam.EntitiesDescriptor
+	if err := xml.Unmarshal(data, &entities); err != nil {
+		return nil, errors.Wrap(ErrInvalidMetadata, err.Error())
+	}
+	var found *crewjam.EntityDescriptor
+	for i, e := range entities.EntityDescriptors {
+		if len(e.IDPSSODescriptors) == 0 {
+			continue
+		}
+		if found != nil {
+			return nil, errors.Wrap(ErrInvalidMetadata, "multiple identity providers")
+		}
+		found = &entities.EntityDescriptors[i]
+	}
+	if found == nil {
+		return nil, errors.Wrap(ErrInvalidMetadata, "no IDPSSODescriptor")
+	}
+	return found, nil
+}
+
+// LoadKeyPair は PEM の RSA 秘密鍵と証明書を読み込む
+func LoadKeyPair(keyPEM, certPEM string) (*rsa.PrivateKey, *x509.Certificate, error) {
+	pair, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	key, ok := pair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("saml: private key must be RSA")
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	return key, cert, nil
+}
@@ -0,0 +1,243 @@
+package saml
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	crewjam "github.com/crewjam/saml"
+	"github.com/sntkn/go-oauth2/oauth2/pkg/saml/samltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	entityID = "https://auth.example.com/saml/corp/metadata"
+	acsURL   = "https://auth.example.com/saml/corp/acs"
+)
+
+func newTestServiceProvider(t *testing.T, idp *samltest.IdP, key *rsa.PrivateKey, cert *x509.Certificate) *ServiceProvider {
+	t.Helper()
+	sp, err := NewServiceProvider(Config{
+		EntityID:    entityID,
+		ACSURL:      acsURL,
+		IdPMetadata: idp.Metadata(),
+		Key:         key,
+		Certificate: cert,
+	})
+	require.NoError(t, err)
+	md, err := sp.Metadata()
+	require.NoError(t, err)
+	require.NoError(t, idp.RegisterServiceProvider(md))
+	return sp
+}
+
+// signin は認証リクエストから応答の検証までを実行する
+func signin(t *testing.T, idp *samltest.IdP, sp *ServiceProvider) (*Assertion, error) {
+	t.Helper()
+	req, err := sp.AuthnRequest()
+	require.NoError(t, err)
+	resp, err := idp.Respond(req.URL)
+	require.NoError(t, err)
+
+	a, err := sp.ParseResponse(resp)
+	if err == nil {
+		assert.Equal(t, req.ID, a.RequestID)
+	}
+	return a, err
+}
+
+func newKeyPairPEM(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "auth.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return string(keyPEM), string(certPEM)
+}
+
+func TestServiceProvider_Signin(t *testing.T) {
+	idp := samltest.New()
+	idp.Login(samltest.User{NameID: "user-1", Email: "user@example.com", Name: "User"})
+	sp := newTestServiceProvider(t, idp, nil, nil)
+
+	a, err := signin(t, idp, sp)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", a.NameID)
+	assert.Equal(t, idp.EntityID(), a.Issuer)
+	assert.NotEmpty(t, a.ID)
+	assert.WithinDuration(t, time.Now().Add(crewjam.MaxIssueDelay), a.ExpiresAt, 5*time.Second)
+	// 属性は Name と FriendlyName のどちらでも取り出せる
+	assert.Equal(t, "user@example.com", a.Attribute("mail"))
+	assert.Equal(t, "user@example.com", a.Attribute("urn:oid:0.9.2342.19200300.100.1.3"))
+	assert.Equal(t, "User", a.Attribute("cn"))
+	assert.Empty(t, a.Attribute("unknown"))
+}
+
+func TestServiceProvider_SignedRequestAndEncryptedAssertion(t *testing.T) {
+	keyPEM, certPEM := newKeyPairPEM(t)
+	key, cert, err := LoadKeyPair(keyPEM, certPEM)
+	require.NoError(t, err)
+
+	idp := samltest.New()
+	idp.Login(samltest.User{NameID: "user-1", Email: "user@example.com"})
+	sp := newTestServiceProvider(t, idp, key, cert)
+
+	req, err := sp.AuthnRequest()
+	require.NoError(t, err)
+	u, err := url.Parse(req.URL)
+	require.NoError(t, err)
+	assert.NotEmpty(t, u.Query().Get("Signature"))
+
+	// SP の証明書を登録した IdP はアサーションを暗号化する
+	resp, err := idp.Respond(req.URL)
+	require.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(resp)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), "EncryptedAssertion")
+
+	a, err := sp.ParseResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", a.NameID)
+}
+
+func TestServiceProvider_Metadata(t *testing.T) {
+	idp := samltest.New()
+	sp := newTestServiceProvider(t, idp, nil, nil)
+
+	md, err := sp.Metadata()
+	require.NoError(t, err)
+	assert.Contains(t, string(md), `entityID="`+entityID+`"`)
+	assert.Contains(t, string(md), `Location="`+acsURL+`"`)
+	// アサーションは HTTP-POST でのみ受け取る
+	assert.NotContains(t, string(md), crewjam.HTTPArtifactBinding)
+}
+
+func TestServiceProvider_RejectsInvalidAssertion(t *testing.T) {
+	tests := []struct {
+		name      string
+		assertion func(a *crewjam.Assertion)
+	}{
+		{name: "audience", assertion: func(a *crewjam.Assertion) {
+			a.Conditions.AudienceRestrictions[0].Audience.Value = "https://other.example.com/metadata"
+		}},
+		{name: "no audience", assertion: func(a *crewjam.Assertion) {
+			a.Conditions.AudienceRestrictions = nil
+		}},
+		{name: "recipient", assertion: func(a *crewjam.Assertion) {
+			a.Subject.SubjectConfirmations[0].SubjectConfirmationData.Recipient = "https://other.example.com/acs"
+		}},
+		{name: "no subject confirmation", assertion: func(a *crewjam.Assertion) {
+			a.Subject.SubjectConfirmations = nil
+		}},
+		{name: "expired", assertion: func(a *crewjam.Assertion) {
+			past := time.Now().Add(-time.Hour)
+			a.IssueInstant = past
+			a.Conditions.NotBefore = past
+			a.Conditions.NotOnOrAfter = past.Add(time.Minute)
+			a.Subject.SubjectConfirmations[0].SubjectConfirmationData.NotOnOrAfter = past.Add(time.Minute)
+		}},
+		{name: "not yet valid", assertion: func(a *crewjam.Assertion) {
+			a.Conditions.NotBefore = time.Now().Add(time.Hour)
+		}},
+		{name: "issuer", assertion: func(a *crewjam.Assertion) {
+			a.Issuer.Value = "https://evil.example.com/metadata"
+		}},
+		{name: "transient name id", assertion: func(a *crewjam.Assertion) {
+			a.Subject.NameID.Format = string(crewjam.TransientNameIDFormat)
+		}},
+		{name: "other request", assertion: func(a *crewjam.Assertion) {
+			a.Subject.SubjectConfirmations[0].SubjectConfirmationData.InResponseTo = "id-other"
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := samltest.New()
+			idp.Login(samltest.User{NameID: "user-1"})
+			idp.Assertion = tt.assertion
+			sp := newTestServiceProvider(t, idp, nil, nil)
+
+			_, err := signin(t, idp, sp)
+			assert.ErrorIs(t, err, ErrInvalidResponse)
+		})
+	}
+}
+
+func TestServiceProvider_RejectsInvalidSignature(t *testing.T) {
+	idp := samltest.New()
+	idp.Login(samltest.User{NameID: "user-1"})
+	sp := newTestServiceProvider(t, idp, nil, nil)
+
+	// メタデータにない鍵で署名した応答
+	idp.RotateKey()
+	_, err := signin(t, idp, sp)
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+}
+
+func TestServiceProvider_RejectsTamperedResponse(t *testing.T) {
+	idp := samltest.New()
+	idp.Login(samltest.User{NameID: "user-1"})
+	sp := newTestServiceProvider(t, idp, nil, nil)
+
+	req, err := sp.AuthnRequest()
+	require.NoError(t, err)
+	resp, err := idp.Respond(req.URL)
+	require.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(resp)
+	require.NoError(t, err)
+
+	tampered := strings.ReplaceAll(string(raw), ">user-1<", ">admin<")
+	require.NotEqual(t, string(raw), tampered)
+	_, err = sp.ParseResponse(base64.StdEncoding.EncodeToString([]byte(tampered)))
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+}
+
+func TestServiceProvider_RejectsUnsolicitedResponse(t *testing.T) {
+	idp := samltest.New()
+	idp.Login(samltest.User{NameID: "user-1"})
+	sp := newTestServiceProvider(t, idp, nil, nil)
+
+	req, err := sp.AuthnRequest()
+	require.NoError(t, err)
+	resp, err := idp.Respond(req.URL)
+	require.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(resp)
+	require.NoError(t, err)
+
+	// InResponseTo のない応答は IdP から始めたサインインとして拒否する
+	unsolicited := strings.Replace(string(raw), `InResponseTo="`+req.ID+`"`, "", 1)
+	require.NotEqual(t, string(raw), unsolicited)
+	_, err = sp.ParseResponse(base64.StdEncoding.EncodeToString([]byte(unsolicited)))
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+
+	_, err = sp.ParseResponse("not base64")
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+}
+
+func TestNewServiceProvider_InvalidMetadata(t *testing.T) {
+	_, err := NewServiceProvider(Config{EntityID: entityID, ACSURL: acsURL, IdPMetadata: []byte("<html></html>")})
+	assert.ErrorIs(t, err, ErrInvalidMetadata)
+
+	_, err = NewServiceProvider(Config{
+		EntityID:    entityID,
+		ACSURL:      acsURL,
+		IdPMetadata: []byte(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://sp.example.com"></EntityDescriptor>`),
+	})
+	assert.ErrorIs(t, err, ErrInvalidMetadata)
+}
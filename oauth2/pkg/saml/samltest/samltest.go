@@ -0,0 +1,179 @@
+// Package samltest はテストで使う SAML の IdP。
+// 画面やHTTPのサーバーは持たず、SP の認証リクエストのURLから Login で指定した利用者の署名済みの応答を作る
+package samltest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/xml"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/crewjam/saml"
+)
+
+const (
+	metadataURL = "https://idp.example.com/saml/metadata"
+	ssoURL      = "https://idp.example.com/saml/sso"
+)
+
+// User は認証リクエストに応答する利用者
+type User struct {
+	NameID string
+	// NameIDFormat が空の場合は persistent
+	NameIDFormat string
+	Email        string
+	Name         string
+}
+
+type IdP struct {
+	// Assertion は署名する前のアサーションを書き換える。異常なアサーションのテストに使う
+	Assertion func(a *saml.Assertion)
+
+	mu   sync.Mutex
+	user User
+	idp  *saml.IdentityProvider
+	sps  map[string]*saml.EntityDescriptor
+}
+
+func New() *IdP {
+	p := &IdP{sps: map[string]*saml.EntityDescriptor{}}
+	md, _ := url.Parse(metadataURL)
+	sso, _ := url.Parse(ssoURL)
+	p.idp = &saml.IdentityProvider{
+		MetadataURL:             *md,
+		SSOURL:                  *sso,
+		ServiceProviderProvider: serviceProviders{p},
+	}
+	p.RotateKey()
+	return p
+}
+
+// EntityID は IdP の entity ID を返す
+func (p *IdP) EntityID() string {
+	return metadataURL
+}
+
+// Metadata は SP に登録する IdP のメタデータを返す
+func (p *IdP) Metadata() []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b, err := xml.Marshal(p.idp.Metadata())
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// RegisterServiceProvider は SP のメタデータを登録する。登録した SP の認証リクエストにのみ応答する
+func (p *IdP) RegisterServiceProvider(metadata []byte) error {
+	var sp saml.EntityDescriptor
+	if err := xml.Unmarshal(metadata, &sp); err != nil {
+		return errors.WithStack(err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sps[sp.EntityID] = &sp
+	return nil
+}
+
+// Login は以降の認証リクエストに応答する利用者を設定する
+func (p *IdP) Login(u User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.user = u
+}
+
+// RotateKey は署名鍵と証明書を取り替える。取り替えた後の応答は、古いメタデータでは検証できない
+func (p *IdP) RotateKey() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.idp.Key = key
+	p.idp.Certificate = cert
+}
+
+// Respond はブラウザの代わりに認証リクエストを受け取り、ACS に POST する SAMLResponse を返す
+func (p *IdP) Respond(authnRequestURL string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	req, err := saml.NewIdpAuthnRequest(p.idp, httptest.NewRequest(http.MethodGet, authnRequestURL, nil))
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if err := req.Validate(); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	format := p.user.NameIDFormat
+	if format == "" {
+		format = string(saml.PersistentNameIDFormat)
+	}
+	if err := (saml.DefaultAssertionMaker{}).MakeAssertion(req, &saml.Session{
+		ID:             randomID(),
+		CreateTime:     req.Now,
+		ExpireTime:     req.Now.Add(time.Hour),
+		Index:          randomID(),
+		NameID:         p.user.NameID,
+		NameIDFormat:   format,
+		UserEmail:      p.user.Email,
+		UserCommonName: p.user.Name,
+	}); err != nil {
+		return "", errors.WithStack(err)
+	}
+	if p.Assertion != nil {
+		p.Assertion(req.Assertion)
+	}
+
+	form, err := req.PostBinding()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return form.SAMLResponse, nil
+}
+
+type serviceProviders struct {
+	p *IdP
+}
+
+// GetServiceProvider は Respond の中で呼ばれるため、ロックを取らない
+func (s serviceProviders) GetServiceProvider(_ *http.Request, id string) (*saml.EntityDescriptor, error) {
+	sp, ok := s.p.sps[id]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return sp, nil
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return "id-" + big.NewInt(0).SetBytes(b).Text(16)
+}
@@ -0,0 +1,64 @@
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+
+	"github.com/cockroachdb/errors"
+)
+
+const keyBytes = 32
+
+var (
+	ErrInvalidKey        = errors.New("secretbox key must be 32 bytes encoded in base64")
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+// Box は DB に保存する秘密の値を AES-256-GCM で暗号化する
+type Box struct {
+	aead cipher.AEAD
+}
+
+// New は Base64 でエンコードされた32バイトの鍵から Box を作る
+func New(keyBase64 string) (*Box, error) {
+	key, err := base64.StdEncoding.DecodeString(keyBase64)
+	if err != nil || len(key) != keyBytes {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &Box{aead: aead}, nil
+}
+
+// Seal は値を暗号化し、ノンスと暗号文をまとめて Base64 で返す
+func (b *Box) Seal(plaintext []byte) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.WithStack(err)
+	}
+	sealed := b.aead.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open は Seal で暗号化した値を復号する
+func (b *Box) Open(sealed string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < b.aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	nonce, ciphertext := data[:b.aead.NonceSize()], data[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
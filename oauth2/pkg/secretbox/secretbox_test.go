@@ -0,0 +1,55 @@
+package secretbox
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+
+func TestSealAndOpen(t *testing.T) {
+	b, err := New(testKey)
+	require.NoError(t, err)
+
+	sealed, err := b.Seal([]byte("secret"))
+	require.NoError(t, err)
+	assert.NotContains(t, sealed, "secret")
+
+	// ノンスが毎回変わるため同じ値でも暗号文は異なる
+	other, err := b.Seal([]byte("secret"))
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, other)
+
+	plaintext, err := b.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+}
+
+func TestOpen_Tampered(t *testing.T) {
+	b, err := New(testKey)
+	require.NoError(t, err)
+
+	sealed, err := b.Seal([]byte("secret"))
+	require.NoError(t, err)
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	require.NoError(t, err)
+	data[len(data)-1] ^= 1
+
+	_, err = b.Open(base64.StdEncoding.EncodeToString(data))
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	_, err = b.Open("short")
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func TestNew_InvalidKey(t *testing.T) {
+	_, err := New("")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = New(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
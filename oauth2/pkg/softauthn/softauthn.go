@@ -0,0 +1,243 @@
+// Package softauthn はテストでWebAuthnのセレモニーを実行するためのソフトウェア認証器。
+// ブラウザの navigator.credentials.create / get の代わりに、サーバーが返すオプションのJSONから応答のJSONを作る
+package softauthn
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+)
+
+// 認証器データのフラグ (WebAuthn 6.1)
+const (
+	flagUserPresent    = 0x01
+	flagUserVerified   = 0x04
+	flagBackupEligible = 0x08
+	flagBackupState    = 0x10
+	flagAttestedData   = 0x40
+)
+
+var (
+	ErrNoCredential = errors.New("softauthn: no credential for the relying party")
+	ErrExcluded     = errors.New("softauthn: credential already registered")
+)
+
+var b64 = base64.RawURLEncoding
+
+// Authenticator は ES256 の鍵を保持するパスキー。登録したクレデンシャルはメモリ上にのみ残る
+type Authenticator struct {
+	// Origin はクライアントデータに記録するオリジン
+	Origin string
+	// BackupEligible が true の場合は同期されるパスキーとして振る舞う
+	BackupEligible bool
+
+	credentials []*credential
+}
+
+type credential struct {
+	id         []byte
+	rpID       string
+	userHandle []byte
+	key        *ecdsa.PrivateKey
+	signCount  uint32
+}
+
+func New(origin string) *Authenticator {
+	return &Authenticator{Origin: origin}
+}
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// Register は登録のオプション ({"publicKey": ...}) を受け取り、新しいクレデンシャルの応答を返す
+func (a *Authenticator) Register(options []byte) ([]byte, error) {
+	var cc protocol.CredentialCreation
+	if err := json.Unmarshal(options, &cc); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	opts := cc.Response
+
+	userHandle, err := decodeUserHandle(opts.User.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ex := range opts.CredentialExcludeList {
+		if a.find(opts.RelyingParty.ID, ex.CredentialID) != nil {
+			return nil, ErrExcluded
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	cred := &credential{
+		id:         make([]byte, 16),
+		rpID:       opts.RelyingParty.ID,
+		userHandle: userHandle,
+		key:        key,
+	}
+	if _, err := rand.Read(cred.id); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	clientDataJSON, err := json.Marshal(clientData{
+		Type:      "webauthn.create",
+		Challenge: b64.EncodeToString(opts.Challenge),
+		Origin:    a.Origin,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	publicKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  int64(webauthncose.P256),
+		XCoord: key.X.FillBytes(make([]byte, 32)),
+		YCoord: key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var authData bytes.Buffer
+	authData.Write(a.authDataHeader(cred, flagAttestedData))
+	authData.Write(make([]byte, 16)) // AAGUID
+	_ = binary.Write(&authData, binary.BigEndian, uint16(len(cred.id)))
+	authData.Write(cred.id)
+	authData.Write(publicKey)
+
+	attestationObject, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData.Bytes(),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	a.credentials = append(a.credentials, cred)
+
+	return json.Marshal(map[string]any{
+		"id":                      b64.EncodeToString(cred.id),
+		"rawId":                   b64.EncodeToString(cred.id),
+		"type":                    "public-key",
+		"authenticatorAttachment": "platform",
+		"clientExtensionResults":  map[string]any{},
+		"response": map[string]any{
+			"clientDataJSON":    b64.EncodeToString(clientDataJSON),
+			"attestationObject": b64.EncodeToString(attestationObject),
+			"transports":        []string{"internal"},
+		},
+	})
+}
+
+// Login は認証のオプション ({"publicKey": ...}) を受け取り、署名した応答を返す。
+// allowCredentials が空の場合は、その RP に登録した最初のクレデンシャルを使う
+func (a *Authenticator) Login(options []byte) ([]byte, error) {
+	var ca protocol.CredentialAssertion
+	if err := json.Unmarshal(options, &ca); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	opts := ca.Response
+
+	var cred *credential
+	if len(opts.AllowedCredentials) == 0 {
+		cred = a.find(opts.RelyingPartyID, nil)
+	}
+	for _, allowed := range opts.AllowedCredentials {
+		if cred = a.find(opts.RelyingPartyID, allowed.CredentialID); cred != nil {
+			break
+		}
+	}
+	if cred == nil {
+		return nil, ErrNoCredential
+	}
+
+	clientDataJSON, err := json.Marshal(clientData{
+		Type:      "webauthn.get",
+		Challenge: b64.EncodeToString(opts.Challenge),
+		Origin:    a.Origin,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	cred.signCount++
+	authData := a.authDataHeader(cred, 0)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(bytes.Clone(authData), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, cred.key, digest[:])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return json.Marshal(map[string]any{
+		"id":                      b64.EncodeToString(cred.id),
+		"rawId":                   b64.EncodeToString(cred.id),
+		"type":                    "public-key",
+		"authenticatorAttachment": "platform",
+		"clientExtensionResults":  map[string]any{},
+		"response": map[string]any{
+			"clientDataJSON":    b64.EncodeToString(clientDataJSON),
+			"authenticatorData": b64.EncodeToString(authData),
+			"signature":         b64.EncodeToString(signature),
+			"userHandle":        b64.EncodeToString(cred.userHandle),
+		},
+	})
+}
+
+// authDataHeader は RP ID のハッシュ、フラグ、署名カウンタからなる認証器データの先頭部分を作る
+func (a *Authenticator) authDataHeader(cred *credential, flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(cred.rpID))
+	flags |= flagUserPresent | flagUserVerified
+	if a.BackupEligible {
+		flags |= flagBackupEligible | flagBackupState
+	}
+
+	b := make([]byte, 0, 37)
+	b = append(b, rpIDHash[:]...)
+	b = append(b, flags)
+	return binary.BigEndian.AppendUint32(b, cred.signCount)
+}
+
+// find は RP ID と一致するクレデンシャルを探す。id が nil の場合は最初に見つかったものを返す
+func (a *Authenticator) find(rpID string, id []byte) *credential {
+	for _, cred := range a.credentials {
+		if cred.rpID == rpID && (id == nil || bytes.Equal(cred.id, id)) {
+			return cred
+		}
+	}
+	return nil
+}
+
+// decodeUserHandle はJSONで Base64URL の文字列として渡されたユーザーハンドルをデコードする
+func decodeUserHandle(id any) ([]byte, error) {
+	s, ok := id.(string)
+	if !ok {
+		return nil, errors.Newf("softauthn: unexpected user id %v", id)
+	}
+	b, err := b64.DecodeString(s)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return b, nil
+}
@@ -12,6 +12,12 @@ type ClientIF interface {
 	Set(ctx context.Context, key string, value string, expiration int64) error
 	Get(ctx context.Context, key string) (string, error)
 	Del(ctx context.Context, key string) error
+	// Incr はキーの値を1増やして返す。キーを作成した時だけ有効期限を設定するため、期限は最初の加算から数える
+	Incr(ctx context.Context, key string, expiration int64) (int64, error)
+	// SetNX はキーがない場合にだけ値を設定する。設定した場合に true を返す
+	SetNX(ctx context.Context, key string, value string, expiration int64) (bool, error)
+	// GetDel はキーの値を返して削除する。キーがない場合は空文字を返す
+	GetDel(ctx context.Context, key string) (string, error)
 }
 
 type Options struct {
@@ -63,3 +69,45 @@ func (c *Client) Del(ctx context.Context, key string) error {
 	}
 	return nil
 }
+
+func (c *Client) Incr(ctx context.Context, key string, expiration int64) (int64, error) {
+	// 複数のサーバーから同時に加算されても回数を取りこぼさないよう、MULTI/EXEC でまとめて実行する
+	resps := c.cli.DoMulti(ctx,
+		c.cli.B().Multi().Build(),
+		c.cli.B().Incr().Key(key).Build(),
+		c.cli.B().Expire().Key(key).Seconds(expiration).Nx().Build(),
+		c.cli.B().Exec().Build(),
+	)
+	exec, err := resps[len(resps)-1].ToArray()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	n, err := exec[0].AsInt64()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return n, nil
+}
+
+func (c *Client) SetNX(ctx context.Context, key string, value string, expiration int64) (bool, error) {
+	// SET NX EX で設定と有効期限を1回で行い、同時に呼ばれても1つだけが成功する
+	err := c.cli.Do(ctx, c.cli.B().Set().Key(key).Value(value).Nx().ExSeconds(expiration).Build()).Error()
+	if err != nil {
+		if errors.Is(err, valkey.Nil) {
+			return false, nil
+		}
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+func (c *Client) GetDel(ctx context.Context, key string) (string, error) {
+	str, err := c.cli.Do(ctx, c.cli.B().Getdel().Key(key).Build()).ToString()
+	if err != nil {
+		if errors.Is(err, valkey.Nil) {
+			return "", nil
+		}
+		return "", errors.WithStack(err)
+	}
+	return str, nil
+}
@@ -24,9 +24,18 @@ var _ ClientIF = &ClientIFMock{}
 //			GetFunc: func(ctx context.Context, key string) (string, error) {
 //				panic("mock out the Get method")
 //			},
+//			GetDelFunc: func(ctx context.Context, key string) (string, error) {
+//				panic("mock out the GetDel method")
+//			},
+//			IncrFunc: func(ctx context.Context, key string, expiration int64) (int64, error) {
+//				panic("mock out the Incr method")
+//			},
 //			SetFunc: func(ctx context.Context, key string, value string, expiration int64) error {
 //				panic("mock out the Set method")
 //			},
+//			SetNXFunc: func(ctx context.Context, key string, value string, expiration int64) (bool, error) {
+//				panic("mock out the SetNX method")
+//			},
 //		}
 //
 //		// use mockedClientIF in code that requires ClientIF
@@ -40,9 +49,18 @@ type ClientIFMock struct {
 	// GetFunc mocks the Get method.
 	GetFunc func(ctx context.Context, key string) (string, error)
 
+	// GetDelFunc mocks the GetDel method.
+	GetDelFunc func(ctx context.Context, key string) (string, error)
+
+	// IncrFunc mocks the Incr method.
+	IncrFunc func(ctx context.Context, key string, expiration int64) (int64, error)
+
 	// SetFunc mocks the Set method.
 	SetFunc func(ctx context.Context, key string, value string, expiration int64) error
 
+	// SetNXFunc mocks the SetNX method.
+	SetNXFunc func(ctx context.Context, key string, value string, expiration int64) (bool, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Del holds details about calls to the Del method.
@@ -59,6 +77,22 @@ type ClientIFMock struct {
 			// Key is the key argument value.
 			Key string
 		}
+		// GetDel holds details about calls to the GetDel method.
+		GetDel []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// Incr holds details about calls to the Incr method.
+		Incr []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Expiration is the expiration argument value.
+			Expiration int64
+		}
 		// Set holds details about calls to the Set method.
 		Set []struct {
 			// Ctx is the ctx argument value.
@@ -70,10 +104,24 @@ type ClientIFMock struct {
 			// Expiration is the expiration argument value.
 			Expiration int64
 		}
+		// SetNX holds details about calls to the SetNX method.
+		SetNX []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Value is the value argument value.
+			Value string
+			// Expiration is the expiration argument value.
+			Expiration int64
+		}
 	}
-	lockDel sync.RWMutex
-	lockGet sync.RWMutex
-	lockSet sync.RWMutex
+	lockDel    sync.RWMutex
+	lockGet    sync.RWMutex
+	lockGetDel sync.RWMutex
+	lockIncr   sync.RWMutex
+	lockSet    sync.RWMutex
+	lockSetNX  sync.RWMutex
 }
 
 // Del calls DelFunc.
@@ -148,6 +196,82 @@ func (mock *ClientIFMock) GetCalls() []struct {
 	return calls
 }
 
+// GetDel calls GetDelFunc.
+func (mock *ClientIFMock) GetDel(ctx context.Context, key string) (string, error) {
+	if mock.GetDelFunc == nil {
+		panic("ClientIFMock.GetDelFunc: method is nil but ClientIF.GetDel was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockGetDel.Lock()
+	mock.calls.GetDel = append(mock.calls.GetDel, callInfo)
+	mock.lockGetDel.Unlock()
+	return mock.GetDelFunc(ctx, key)
+}
+
+// GetDelCalls gets all the calls that were made to GetDel.
+// Check the length with:
+//
+//	len(mockedClientIF.GetDelCalls())
+func (mock *ClientIFMock) GetDelCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockGetDel.RLock()
+	calls = mock.calls.GetDel
+	mock.lockGetDel.RUnlock()
+	return calls
+}
+
+// Incr calls IncrFunc.
+func (mock *ClientIFMock) Incr(ctx context.Context, key string, expiration int64) (int64, error) {
+	if mock.IncrFunc == nil {
+		panic("ClientIFMock.IncrFunc: method is nil but ClientIF.Incr was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Key        string
+		Expiration int64
+	}{
+		Ctx:        ctx,
+		Key:        key,
+		Expiration: expiration,
+	}
+	mock.lockIncr.Lock()
+	mock.calls.Incr = append(mock.calls.Incr, callInfo)
+	mock.lockIncr.Unlock()
+	return mock.IncrFunc(ctx, key, expiration)
+}
+
+// IncrCalls gets all the calls that were made to Incr.
+// Check the length with:
+//
+//	len(mockedClientIF.IncrCalls())
+func (mock *ClientIFMock) IncrCalls() []struct {
+	Ctx        context.Context
+	Key        string
+	Expiration int64
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Key        string
+		Expiration int64
+	}
+	mock.lockIncr.RLock()
+	calls = mock.calls.Incr
+	mock.lockIncr.RUnlock()
+	return calls
+}
+
 // Set calls SetFunc.
 func (mock *ClientIFMock) Set(ctx context.Context, key string, value string, expiration int64) error {
 	if mock.SetFunc == nil {
@@ -191,3 +315,47 @@ func (mock *ClientIFMock) SetCalls() []struct {
 	mock.lockSet.RUnlock()
 	return calls
 }
+
+// SetNX calls SetNXFunc.
+func (mock *ClientIFMock) SetNX(ctx context.Context, key string, value string, expiration int64) (bool, error) {
+	if mock.SetNXFunc == nil {
+		panic("ClientIFMock.SetNXFunc: method is nil but ClientIF.SetNX was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Key        string
+		Value      string
+		Expiration int64
+	}{
+		Ctx:        ctx,
+		Key:        key,
+		Value:      value,
+		Expiration: expiration,
+	}
+	mock.lockSetNX.Lock()
+	mock.calls.SetNX = append(mock.calls.SetNX, callInfo)
+	mock.lockSetNX.Unlock()
+	return mock.SetNXFunc(ctx, key, value, expiration)
+}
+
+// SetNXCalls gets all the calls that were made to SetNX.
+// Check the length with:
+//
+//	len(mockedClientIF.SetNXCalls())
+func (mock *ClientIFMock) SetNXCalls() []struct {
+	Ctx        context.Context
+	Key        string
+	Value      string
+	Expiration int64
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Key        string
+		Value      string
+		Expiration int64
+	}
+	mock.lockSetNX.RLock()
+	calls = mock.calls.SetNX
+	mock.lockSetNX.RUnlock()
+	return calls
+}
@@ -41,4 +41,16 @@ func TestKVSCli(t *testing.T) {
 	val, err = cli.Get(ctx, "testKey")
 	require.NoError(t, err)
 	assert.Empty(t, val)
+
+	// Test Incr
+	n, err := cli.Incr(ctx, "testCounter", 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	n, err = cli.Incr(ctx, "testCounter", 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	err = cli.Del(ctx, "testCounter")
+	require.NoError(t, err)
 }